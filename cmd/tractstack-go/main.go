@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"os"
 
@@ -8,7 +9,10 @@ import (
 )
 
 func main() {
-	if err := startup.Initialize(); err != nil {
+	skipSnapshot := flag.Bool("skip-snapshot", false, "disable loading and saving cache snapshots across restarts")
+	flag.Parse()
+
+	if err := startup.Initialize(*skipSnapshot); err != nil {
 		log.Fatalf("Application startup failed: %v", err)
 		os.Exit(1)
 	}
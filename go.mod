@@ -15,6 +15,7 @@ require (
 	github.com/resendlabs/resend-go v1.7.0
 	github.com/tursodatabase/libsql-client-go v0.0.0-20240902231107-85af5b9d094d
 	golang.org/x/crypto v0.31.0
+	golang.org/x/sync v0.10.0
 )
 
 require (
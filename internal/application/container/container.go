@@ -3,7 +3,6 @@ package container
 
 import (
 	"log/slog"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -11,8 +10,10 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/user"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/email"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/maintenance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/monitoring"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/templates"
@@ -34,6 +35,11 @@ type Container struct {
 	OrphanAnalysisService *services.OrphanAnalysisService
 	BeliefRegistryService *services.BeliefRegistryService
 	WarmingService        *services.WarmingService
+	ExternalIDService     *services.ExternalIDService
+	JSONLDService         *services.JSONLDService
+	AdminSessionService   *services.AdminSessionService
+	ContentImportService  *services.ContentImportService
+	PerfSnapshotService   *services.PerfSnapshotService
 
 	// Fragment Services
 	SessionBeliefService *services.SessionBeliefService
@@ -60,28 +66,34 @@ type Container struct {
 	LogBroadcaster         *logging.LogBroadcaster
 	Broadcaster            messaging.Broadcaster
 	SysOpBroadcaster       *messaging.SysOpBroadcaster
+	WebhookDispatcher      *messaging.WebhookDispatcher
 	SysOpService           *services.SysOpService
 
 	// Infrastructure Dependencies
-	TenantManager  *tenant.Manager
-	CacheManager   *manager.Manager
-	Logger         *logging.ChanneledLogger
-	PerfTracker    *performance.Tracker
-	EmailService   email.Service
-	LeadRepository user.LeadRepository
+	TenantManager        *tenant.Manager
+	CacheManager         *manager.Manager
+	MaintenanceScheduler *maintenance.Scheduler
+	CacheMonitor         *monitoring.CachePerformanceMonitor
+	Logger               *logging.ChanneledLogger
+	AuditLogger          *logging.AuditLogger
+	PerfTracker          *performance.Tracker
+	EmailService         email.Service
+	LeadRepository       user.LeadRepository
 }
 
 // NewContainer creates and wires all singleton services
 func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager) *Container {
 	// Initialize observability infrastructure
 	perfTracker := performance.NewTracker(performance.DefaultTrackerConfig())
+	cacheMonitor := monitoring.NewCachePerformanceMonitor(nil)
+	cacheManager.SetCacheMonitor(cacheMonitor)
 	emailService, err := email.NewService()
 	if err != nil {
 		panic("Failed to initialize email service: " + err.Error())
 	}
 
 	loggerConfig := logging.DefaultLoggerConfig()
-	loggerConfig.LogDirectory = filepath.Join(os.Getenv("HOME"), "t8k-go-server", "log")
+	loggerConfig.LogDirectory = filepath.Join(config.DataDir, "log")
 
 	switch strings.ToUpper(config.LogVerbosity) {
 	case "TRACE":
@@ -105,6 +117,11 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 	}
 	logger.Startup().Info("Channeled logger initialized successfully", "logDirectory", loggerConfig.LogDirectory)
 
+	auditLogger, err := logging.NewAuditLogger(loggerConfig.LogDirectory)
+	if err != nil {
+		panic("Failed to initialize audit logger: " + err.Error())
+	}
+
 	beliefEvaluationService := services.NewBeliefEvaluationService()
 	beliefBroadcastService := services.NewBeliefBroadcastService(cacheManager)
 	eventProcessingService := services.NewEventProcessingService(beliefBroadcastService, beliefEvaluationService, logger)
@@ -122,8 +139,10 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 		scrollTargetService,
 	)
 	contentMapService := services.NewContentMapService(logger, perfTracker)
-	authService := services.NewAuthService(logger, perfTracker)
-	sessionService := services.NewSessionService(beliefBroadcastService, logger, perfTracker)
+	webhookDispatcher := messaging.NewWebhookDispatcher(logger)
+	smtpSender := email.NewSMTPSender()
+	authService := services.NewAuthService(logger, perfTracker, webhookDispatcher, smtpSender)
+	sessionService := services.NewSessionService(beliefBroadcastService, logger, auditLogger, perfTracker)
 	dbService := services.NewDBService(logger, perfTracker)
 	configService := services.NewConfigService(logger, perfTracker)
 
@@ -133,7 +152,11 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 	// Create TailwindService after its dependencies
 	tailwindService := services.NewTailwindService(paneService, configService, logger, perfTracker)
 
+	beliefRegistryService := services.NewBeliefRegistryService(logger)
+	warmingService := services.NewWarmingService(logger, perfTracker, beliefEvaluationService, sessionBeliefService)
+
 	multiTenantService := services.NewMultiTenantService(tenantManager, emailService, logger, perfTracker)
+	multiTenantService.SetWarmingDependencies(warmingService, contentMapService, beliefRegistryService, cacheManager)
 	logBroadcaster := logging.GetBroadcaster()
 	broadcaster := messaging.NewSSEBroadcaster(logger)
 	sysOpService := services.NewSysOpService(
@@ -148,7 +171,7 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 
 	logger.Startup().Info("Dependency injection container services initialized")
 
-	return &Container{
+	c := &Container{
 		// Content Services
 		MenuService:           services.NewMenuService(logger, perfTracker, contentMapService),
 		PaneService:           paneService, // Use the variable created above
@@ -160,8 +183,12 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 		EpinetService:         services.NewEpinetService(logger, perfTracker, contentMapService),
 		ContentMapService:     contentMapService,
 		OrphanAnalysisService: services.NewOrphanAnalysisService(logger),
-		BeliefRegistryService: services.NewBeliefRegistryService(logger),
-		WarmingService:        services.NewWarmingService(logger, perfTracker, beliefEvaluationService, sessionBeliefService),
+		BeliefRegistryService: beliefRegistryService,
+		WarmingService:        warmingService,
+		ExternalIDService:     services.NewExternalIDService(logger, perfTracker),
+		JSONLDService:         services.NewJSONLDService(logger, perfTracker),
+		AdminSessionService:   services.NewAdminSessionService(logger, perfTracker),
+		PerfSnapshotService:   services.NewPerfSnapshotService(logger, perfTracker),
 
 		// Fragment Services
 		SessionBeliefService: sessionBeliefService,
@@ -189,12 +216,49 @@ func NewContainer(tenantManager *tenant.Manager, cacheManager *manager.Manager)
 		Broadcaster:            broadcaster,
 		SysOpService:           sysOpService,
 		SysOpBroadcaster:       sysOpBroadcaster,
+		WebhookDispatcher:      webhookDispatcher,
 
 		// Infrastructure
-		TenantManager: tenantManager,
-		CacheManager:  cacheManager,
-		Logger:        logger,
-		PerfTracker:   perfTracker,
-		EmailService:  emailService,
+		TenantManager:        tenantManager,
+		CacheManager:         cacheManager,
+		MaintenanceScheduler: maintenance.NewScheduler(tenantManager, cacheManager, logger),
+		CacheMonitor:         cacheMonitor,
+		Logger:               logger,
+		AuditLogger:          auditLogger,
+		PerfTracker:          perfTracker,
+		EmailService:         emailService,
 	}
+
+	// Built after the container so it can reuse the per-type content services
+	c.ContentImportService = services.NewContentImportService(
+		c.TractStackService,
+		c.MenuService,
+		c.BeliefService,
+		c.ResourceService,
+		c.PaneService,
+		c.StoryFragmentService,
+		logger,
+		perfTracker,
+	)
+
+	// Stale-while-revalidate refreshers: the cache manager serves stale data
+	// immediately and calls these in the background to rebuild it.
+	cacheManager.SetContentMapRefresher(func(tenantID string) error {
+		tenantCtx, err := tenantManager.NewContextFromID(tenantID)
+		if err != nil {
+			return err
+		}
+		defer tenantCtx.Close()
+		return c.ContentMapService.RefreshContentMap(tenantCtx, cacheManager)
+	})
+	cacheManager.SetOrphanAnalysisRefresher(func(tenantID string) error {
+		tenantCtx, err := tenantManager.NewContextFromID(tenantID)
+		if err != nil {
+			return err
+		}
+		defer tenantCtx.Close()
+		return c.OrphanAnalysisService.RecomputeOrphanAnalysis(tenantCtx, cacheManager)
+	})
+
+	return c
 }
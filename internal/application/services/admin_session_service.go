@@ -0,0 +1,270 @@
+// Package services provides admin bulk operations over sessions and beliefs
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+)
+
+// AdminSessionService performs bulk administrative operations against session
+// and belief state. Every operation reports how many items it affected and
+// notifies connected SSE clients so they can resynchronize.
+type AdminSessionService struct {
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewAdminSessionService creates a new admin session service singleton
+func NewAdminSessionService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *AdminSessionService {
+	return &AdminSessionService{
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// ForceExpireSessions removes cached sessions for a tenant. When fingerprintID
+// or leadID is provided only matching sessions are expired; otherwise all
+// sessions for the tenant are. Each expired session gets a resync event before
+// removal so connected clients know to re-establish state.
+func (s *AdminSessionService) ForceExpireSessions(tenantCtx *tenant.Context, broadcaster messaging.Broadcaster, fingerprintID, leadID string) (int, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("admin_force_expire_sessions", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	cacheManager := tenantCtx.CacheManager
+
+	var sessionIDs []string
+	switch {
+	case fingerprintID != "":
+		sessionIDs = cacheManager.GetSessionsByFingerprint(tenantCtx.TenantID, fingerprintID)
+	case leadID != "":
+		for _, fpID := range cacheManager.GetAllFingerprintIDs(tenantCtx.TenantID) {
+			state, exists := cacheManager.GetFingerprintState(tenantCtx.TenantID, fpID)
+			if !exists || state.LeadID == nil || *state.LeadID != leadID {
+				continue
+			}
+			sessionIDs = append(sessionIDs, cacheManager.GetSessionsByFingerprint(tenantCtx.TenantID, fpID)...)
+		}
+	default:
+		sessionIDs = cacheManager.GetAllSessionIDs(tenantCtx.TenantID)
+	}
+
+	registryIDs := cacheManager.GetAllStoryfragmentBeliefRegistryIDs(tenantCtx.TenantID)
+
+	expired := 0
+	for _, sessionID := range sessionIDs {
+		if _, exists := cacheManager.GetSession(tenantCtx.TenantID, sessionID); !exists {
+			continue
+		}
+		broadcaster.BroadcastSessionResync(tenantCtx.TenantID, sessionID)
+		for _, storyfragmentID := range registryIDs {
+			cacheManager.InvalidateSessionBeliefContext(tenantCtx.TenantID, sessionID, storyfragmentID)
+		}
+		cacheManager.RemoveSession(tenantCtx.TenantID, sessionID)
+		broadcaster.PurgeSessionEvents(tenantCtx.TenantID, sessionID)
+		expired++
+	}
+
+	s.logger.Auth().Info("Admin force-expire sessions completed",
+		"tenantId", tenantCtx.TenantID, "fingerprintId", fingerprintID, "leadId", leadID,
+		"expired", expired, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return expired, nil
+}
+
+// ResetBelief removes a belief slug from every cached fingerprint state and
+// deletes its rows from the heldbeliefs table — a bulk UNSET. Sessions of
+// affected fingerprints get their belief contexts invalidated and a resync
+// event. Returns the number of fingerprints that held the belief.
+func (s *AdminSessionService) ResetBelief(tenantCtx *tenant.Context, broadcaster messaging.Broadcaster, beliefSlug string) (int, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("admin_reset_belief", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	cacheManager := tenantCtx.CacheManager
+
+	beliefID, exists := cacheManager.GetContentBySlug(tenantCtx.TenantID, "belief:"+beliefSlug)
+	if !exists {
+		err := tenantCtx.Database.Conn.QueryRow("SELECT id FROM beliefs WHERE slug = ?", beliefSlug).Scan(&beliefID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, fmt.Errorf("belief not found: %s", beliefSlug)
+			}
+			return 0, fmt.Errorf("failed to query belief by slug: %w", err)
+		}
+	}
+
+	if _, err := tenantCtx.Database.Conn.Exec("DELETE FROM heldbeliefs WHERE belief_id = ?", beliefID); err != nil {
+		return 0, fmt.Errorf("failed to delete held beliefs: %w", err)
+	}
+
+	registryIDs := cacheManager.GetAllStoryfragmentBeliefRegistryIDs(tenantCtx.TenantID)
+
+	affected := 0
+	for _, fpID := range cacheManager.GetAllFingerprintIDs(tenantCtx.TenantID) {
+		state, exists := cacheManager.GetFingerprintState(tenantCtx.TenantID, fpID)
+		if !exists {
+			continue
+		}
+		if _, held := state.HeldBeliefs[beliefSlug]; !held {
+			continue
+		}
+		delete(state.HeldBeliefs, beliefSlug)
+		cacheManager.SetFingerprintState(tenantCtx.TenantID, state)
+		affected++
+
+		for _, sessionID := range cacheManager.GetSessionsByFingerprint(tenantCtx.TenantID, fpID) {
+			for _, storyfragmentID := range registryIDs {
+				cacheManager.InvalidateSessionBeliefContext(tenantCtx.TenantID, sessionID, storyfragmentID)
+			}
+			broadcaster.BroadcastSessionResync(tenantCtx.TenantID, sessionID)
+		}
+	}
+
+	s.logger.Auth().Info("Admin belief reset completed",
+		"tenantId", tenantCtx.TenantID, "beliefSlug", beliefSlug, "beliefId", beliefID,
+		"fingerprintsAffected", affected, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return affected, nil
+}
+
+// KnownFingerprintEntry describes one known fingerprint as seen by the
+// database alongside its cached classification, surfacing any mismatch.
+type KnownFingerprintEntry struct {
+	FingerprintID string  `json:"fingerprintId"`
+	LeadID        *string `json:"leadId,omitempty"`
+	CachedKnown   bool    `json:"cachedKnown"`
+}
+
+// ListKnownFingerprints returns every fingerprint with a lead linkage in the
+// database, annotated with whether the sessions cache also classifies it as
+// known. A cachedKnown of false on a listed entry is an analytics
+// misclassification candidate.
+func (s *AdminSessionService) ListKnownFingerprints(tenantCtx *tenant.Context) ([]KnownFingerprintEntry, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("admin_list_known_fingerprints", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	rows, err := tenantCtx.Database.Conn.Query("SELECT id, lead_id FROM fingerprints WHERE lead_id IS NOT NULL ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query known fingerprints: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []KnownFingerprintEntry
+	for rows.Next() {
+		var entry KnownFingerprintEntry
+		if err := rows.Scan(&entry.FingerprintID, &entry.LeadID); err != nil {
+			return nil, fmt.Errorf("failed to scan known fingerprint row: %w", err)
+		}
+		entry.CachedKnown = tenantCtx.CacheManager.IsKnownFingerprint(tenantCtx.TenantID, entry.FingerprintID)
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate known fingerprint rows: %w", err)
+	}
+
+	s.logger.Auth().Info("Admin known-fingerprint listing completed",
+		"tenantId", tenantCtx.TenantID, "count", len(entries), "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return entries, nil
+}
+
+// SetFingerprintKnown force-sets a fingerprint's known status, keeping the
+// database lead linkage and the sessions cache consistent. Marking a
+// fingerprint known requires the lead it should link to; marking it unknown
+// clears the linkage.
+func (s *AdminSessionService) SetFingerprintKnown(tenantCtx *tenant.Context, fingerprintID string, isKnown bool, leadID string) error {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("admin_set_fingerprint_known", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	var existing string
+	if err := tenantCtx.Database.Conn.QueryRow("SELECT id FROM fingerprints WHERE id = ?", fingerprintID).Scan(&existing); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("fingerprint not found: %s", fingerprintID)
+		}
+		return fmt.Errorf("failed to query fingerprint: %w", err)
+	}
+
+	if isKnown {
+		if leadID == "" {
+			return fmt.Errorf("leadId is required to mark a fingerprint known")
+		}
+		var leadExists string
+		if err := tenantCtx.Database.Conn.QueryRow("SELECT id FROM leads WHERE id = ?", leadID).Scan(&leadExists); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("lead not found: %s", leadID)
+			}
+			return fmt.Errorf("failed to query lead: %w", err)
+		}
+		if _, err := tenantCtx.Database.Conn.Exec("UPDATE fingerprints SET lead_id = ? WHERE id = ?", leadID, fingerprintID); err != nil {
+			return fmt.Errorf("failed to link fingerprint to lead: %w", err)
+		}
+	} else {
+		if _, err := tenantCtx.Database.Conn.Exec("UPDATE fingerprints SET lead_id = NULL WHERE id = ?", fingerprintID); err != nil {
+			return fmt.Errorf("failed to clear fingerprint lead linkage: %w", err)
+		}
+	}
+
+	cacheManager := tenantCtx.CacheManager
+	cacheManager.SetKnownFingerprint(tenantCtx.TenantID, fingerprintID, isKnown)
+	if state, exists := cacheManager.GetFingerprintState(tenantCtx.TenantID, fingerprintID); exists {
+		if isKnown {
+			state.LeadID = &leadID
+		} else {
+			state.LeadID = nil
+		}
+		cacheManager.SetFingerprintState(tenantCtx.TenantID, state)
+	}
+
+	s.logger.Auth().Info("Admin fingerprint known-status update completed",
+		"tenantId", tenantCtx.TenantID, "fingerprintId", fingerprintID, "isKnown", isKnown,
+		"leadId", leadID, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return nil
+}
+
+// BroadcastStoryfragmentRefresh pushes a panes_updated event for every pane of
+// a storyfragment to all connected sessions, forcing clients viewing it to
+// re-render. Returns the number of sessions that had live connections.
+func (s *AdminSessionService) BroadcastStoryfragmentRefresh(tenantCtx *tenant.Context, broadcaster messaging.Broadcaster, storyfragmentID string) (int, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("admin_broadcast_refresh", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
+	storyFragment, err := storyFragmentRepo.FindByID(tenantCtx.TenantID, storyfragmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load storyfragment %s: %w", storyfragmentID, err)
+	}
+	if storyFragment == nil {
+		return 0, fmt.Errorf("storyfragment not found: %s", storyfragmentID)
+	}
+
+	notified := 0
+	for _, sessionID := range tenantCtx.CacheManager.GetAllSessionIDs(tenantCtx.TenantID) {
+		if broadcaster.GetSessionConnectionCount(tenantCtx.TenantID, sessionID) == 0 {
+			continue
+		}
+		broadcaster.BroadcastToSpecificSession(tenantCtx.TenantID, sessionID, storyfragmentID, storyFragment.PaneIDs, nil)
+		notified++
+	}
+
+	s.logger.Auth().Info("Admin storyfragment refresh broadcast completed",
+		"tenantId", tenantCtx.TenantID, "storyfragmentId", storyfragmentID,
+		"sessionsNotified", notified, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return notified, nil
+}
@@ -23,6 +23,17 @@ type SankeyFilters struct {
 	SelectedUserID *string `json:"selectedUserID,omitempty"`
 	StartHour      *int    `json:"startHour,omitempty"`
 	EndHour        *int    `json:"endHour,omitempty"`
+	// Breakdown annotates every Sankey node and link with known vs anonymous
+	// visitor counts. Off by default to keep the payload small.
+	Breakdown bool `json:"breakdown,omitempty"`
+	// MinLinkValue drops links carrying fewer than this many visitors; zero
+	// keeps everything.
+	MinLinkValue int `json:"minLinkValue,omitempty"`
+	// ExcludeGateTypes hides nodes whose gate type matches (e.g. "belief").
+	ExcludeGateTypes []string `json:"excludeGateTypes,omitempty"`
+	// CollapseOthers aggregates filtered-out nodes into a single "Other" node
+	// per step instead of dropping them, so link totals still add up.
+	CollapseOthers bool `json:"collapseOthers,omitempty"`
 }
 
 type AnalyticsService struct {
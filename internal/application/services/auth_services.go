@@ -3,6 +3,7 @@ package services
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
 	"slices"
@@ -10,25 +11,32 @@ import (
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/user"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/email"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthService handles authentication workflows and JWT operations
 type AuthService struct {
-	logger      *logging.ChanneledLogger
-	perfTracker *performance.Tracker
+	logger            *logging.ChanneledLogger
+	perfTracker       *performance.Tracker
+	webhookDispatcher *messaging.WebhookDispatcher
+	smtpSender        *email.SMTPSender
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *AuthService {
+func NewAuthService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker, webhookDispatcher *messaging.WebhookDispatcher, smtpSender *email.SMTPSender) *AuthService {
 	return &AuthService{
-		logger:      logger,
-		perfTracker: perfTracker,
+		logger:            logger,
+		perfTracker:       perfTracker,
+		webhookDispatcher: webhookDispatcher,
+		smtpSender:        smtpSender,
 	}
 }
 
@@ -62,7 +70,7 @@ func (a *AuthService) DecodeProfileToken(tokenString string, tenantCtx *tenant.C
 		return &ProfileDecodeResult{Profile: nil, Valid: false}
 	}
 
-	claims, err := security.ValidateJWT(tokenString, tenantCtx.Config.JWTSecret)
+	claims, err := security.ValidateJWTWithFallback(tokenString, tenantCtx.Config.JWTSecret, tenantCtx.Config.PreviousJWTSecret)
 	if err != nil {
 		return &ProfileDecodeResult{Profile: nil, Valid: false}
 	}
@@ -134,6 +142,7 @@ func (a *AuthService) CreateLead(firstName, email, password, contactPersona, sho
 		ShortBio:       shortBio,
 		EncryptedCode:  encryptedCode,
 		EncryptedEmail: encryptedEmail,
+		LeadStatus:     user.LeadStatusActive,
 		CreatedAt:      time.Now().UTC(),
 		Changed:        time.Now().UTC(),
 	}
@@ -156,6 +165,21 @@ func (a *AuthService) CreateLead(firstName, email, password, contactPersona, sho
 		return nil, fmt.Errorf("token generation failed")
 	}
 
+	// Notify the tenant's webhook endpoint, if one is configured. Delivery
+	// is fully asynchronous so a slow or failing endpoint never delays the
+	// profile response.
+	if a.webhookDispatcher != nil && tenantCtx.Config.WebhookURL != "" {
+		payload := map[string]any{
+			"leadId":         newLead.ID,
+			"firstName":      newLead.FirstName,
+			"contactPersona": newLead.ContactPersona,
+			"createdAt":      newLead.CreatedAt,
+		}
+		if err := a.webhookDispatcher.Deliver(tenantCtx.TenantID, tenantCtx.Config.WebhookURL, "lead.created", payload); err != nil {
+			a.logger.Auth().Warn("Failed to queue lead.created webhook", "error", err, "leadId", newLead.ID)
+		}
+	}
+
 	return &CreateLeadResult{
 		Success:        true,
 		Profile:        profile,
@@ -165,6 +189,132 @@ func (a *AuthService) CreateLead(firstName, email, password, contactPersona, sho
 	}, nil
 }
 
+// RegisterResult holds the result of a lead registration or verification step
+type RegisterResult struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RegisterLead starts the magic-link verification flow for a new lead. It
+// stores a pending lead whose encrypted_code holds an AES-encrypted one-time
+// code and emails that code to the address being claimed. The code doubles as
+// the lead's codeword once verified, matching the CreateLead credential model.
+func (a *AuthService) RegisterLead(firstName, email string, tenantCtx *tenant.Context) (*RegisterResult, error) {
+	leadRepo := tenantCtx.LeadRepo()
+
+	existing, err := leadRepo.FindByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("lead lookup failed")
+	}
+	if existing != nil && existing.LeadStatus == user.LeadStatusActive {
+		return &RegisterResult{Success: false, Error: "email already registered"}, nil
+	}
+
+	code := security.GenerateULID()
+
+	encryptedEmail, err := security.Encrypt(email, tenantCtx.Config.AESKey)
+	if err != nil {
+		return nil, fmt.Errorf("email encryption failed")
+	}
+
+	encryptedCode, err := security.Encrypt(code, tenantCtx.Config.AESKey)
+	if err != nil {
+		return nil, fmt.Errorf("code encryption failed")
+	}
+
+	hashedCode, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("code hashing failed")
+	}
+
+	var leadID string
+	if existing != nil {
+		// A pending lead that never verified — typically because the first
+		// verification send failed — may register again. Overwriting the
+		// pending credentials and resending keeps a failed send from
+		// permanently blocking the address for its real owner.
+		existing.FirstName = firstName
+		existing.PasswordHash = string(hashedCode)
+		existing.EncryptedCode = encryptedCode
+		existing.EncryptedEmail = encryptedEmail
+		existing.Changed = time.Now().UTC()
+		if err := leadRepo.Update(existing); err != nil {
+			a.logger.Auth().Error("Failed to refresh pending lead", "error", err, "leadId", existing.ID)
+			return nil, fmt.Errorf("failed to register lead")
+		}
+		leadID = existing.ID
+	} else {
+		newLead := &user.Lead{
+			ID:             security.GenerateULID(),
+			FirstName:      firstName,
+			Email:          email,
+			PasswordHash:   string(hashedCode),
+			ContactPersona: "major", // registration form has no persona picker; default to major updates
+			EncryptedCode:  encryptedCode,
+			EncryptedEmail: encryptedEmail,
+			LeadStatus:     user.LeadStatusPending,
+			CreatedAt:      time.Now().UTC(),
+			Changed:        time.Now().UTC(),
+		}
+		if err := leadRepo.Store(newLead); err != nil {
+			a.logger.Auth().Error("Failed to store pending lead", "error", err, "leadId", newLead.ID)
+			return nil, fmt.Errorf("failed to register lead")
+		}
+		leadID = newLead.ID
+	}
+
+	if err := a.smtpSender.SendLeadVerificationEmail(email, firstName, code); err != nil {
+		a.logger.Auth().Error("Failed to send verification email", "error", err, "leadId", leadID)
+		return nil, fmt.Errorf("failed to send verification email")
+	}
+
+	a.logger.Auth().Info("Lead registered pending verification", "leadId", leadID, "tenantId", tenantCtx.TenantID)
+	return &RegisterResult{Success: true}, nil
+}
+
+// VerifyLead completes the magic-link flow: it decrypts the stored
+// verification code, compares it to the submitted one, and promotes the lead
+// from pending to active on a match.
+func (a *AuthService) VerifyLead(email, code string, tenantCtx *tenant.Context) (*RegisterResult, error) {
+	leadRepo := tenantCtx.LeadRepo()
+
+	lead, err := leadRepo.FindByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("lead lookup failed")
+	}
+	if lead == nil || lead.EncryptedCode == "" {
+		return &RegisterResult{Success: false, Error: "invalid email or code"}, nil
+	}
+
+	// Pending leads expire if never verified; a fresh registration issues a
+	// new code, and the purge job eventually removes abandoned rows.
+	if lead.LeadStatus == user.LeadStatusPending && config.PendingLeadTTL > 0 && time.Since(lead.Changed) > config.PendingLeadTTL {
+		return &RegisterResult{Success: false, Error: "verification code expired"}, nil
+	}
+
+	storedCode, err := security.DecryptWithFallback(lead.EncryptedCode, tenantCtx.Config.AESKey, tenantCtx.Config.PreviousAESKey)
+	if err != nil {
+		a.logger.Auth().Warn("Failed to decrypt verification code", "leadId", lead.ID, "tenantId", tenantCtx.TenantID)
+		return &RegisterResult{Success: false, Error: "invalid email or code"}, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(storedCode), []byte(code)) != 1 {
+		return &RegisterResult{Success: false, Error: "invalid email or code"}, nil
+	}
+
+	if lead.LeadStatus != user.LeadStatusActive {
+		lead.LeadStatus = user.LeadStatusActive
+		lead.Changed = time.Now().UTC()
+		if err := leadRepo.Update(lead); err != nil {
+			a.logger.Auth().Error("Failed to activate lead", "error", err, "leadId", lead.ID)
+			return nil, fmt.Errorf("failed to activate lead")
+		}
+	}
+
+	a.logger.Auth().Info("Lead verified and activated", "leadId", lead.ID, "tenantId", tenantCtx.TenantID)
+	return &RegisterResult{Success: true}, nil
+}
+
 // GenerateJWT creates a JWT token with given claims
 func (a *AuthService) GenerateJWT(claims jwt.MapClaims, jwtSecret string) (string, error) {
 	if _, ok := claims["iat"]; !ok {
@@ -196,7 +346,7 @@ func (a *AuthService) ValidateTokenWithRoles(tokenString string, tenantCtx *tena
 
 	token := strings.TrimPrefix(tokenString, "Bearer ")
 
-	claims, err := security.ValidateJWT(token, tenantCtx.Config.JWTSecret)
+	claims, err := security.ValidateJWTWithFallback(token, tenantCtx.Config.JWTSecret, tenantCtx.Config.PreviousJWTSecret)
 	if err != nil {
 		return false
 	}
@@ -245,7 +395,7 @@ func (a *AuthService) GetTokenInfo(tokenString string, tenantCtx *tenant.Context
 
 	token := strings.TrimPrefix(tokenString, "Bearer ")
 
-	claims, err := security.ValidateJWT(token, tenantCtx.Config.JWTSecret)
+	claims, err := security.ValidateJWTWithFallback(token, tenantCtx.Config.JWTSecret, tenantCtx.Config.PreviousJWTSecret)
 	if err != nil {
 		return &TokenInfo{Valid: false}
 	}
@@ -271,13 +421,13 @@ func (a *AuthService) GetTokenInfo(tokenString string, tenantCtx *tenant.Context
 // ValidateEncryptedCredentials validates profile credentials using encrypted data
 func (a *AuthService) ValidateEncryptedCredentials(encryptedEmail, encryptedCode string, tenantCtx *tenant.Context) *user.Profile {
 	leadRepo := tenantCtx.LeadRepo()
-	decryptedEmail, err := security.Decrypt(encryptedEmail, tenantCtx.Config.AESKey)
+	decryptedEmail, err := security.DecryptWithFallback(encryptedEmail, tenantCtx.Config.AESKey, tenantCtx.Config.PreviousAESKey)
 	if err != nil {
 		a.logger.Auth().Warn("Failed to decrypt email for credential validation", "tenantId", tenantCtx.TenantID)
 		return nil
 	}
 
-	decryptedCode, err := security.Decrypt(encryptedCode, tenantCtx.Config.AESKey)
+	decryptedCode, err := security.DecryptWithFallback(encryptedCode, tenantCtx.Config.AESKey, tenantCtx.Config.PreviousAESKey)
 	if err != nil {
 		a.logger.Auth().Warn("Failed to decrypt code for credential validation", "tenantId", tenantCtx.TenantID)
 		return nil
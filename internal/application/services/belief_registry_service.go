@@ -9,6 +9,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 // BeliefRegistryService handles storyfragment belief registry operations.
@@ -43,6 +44,7 @@ func (brs *BeliefRegistryService) BuildRegistryFromLoadedPanes(tenantCtx *tenant
 		RequiredBadges:     []string{},
 		PaneWidgetBeliefs:  make(map[string][]string),
 		AllWidgetBeliefs:   make(map[string]bool),
+		PaneEvalCosts:      make(map[string]int),
 		LastUpdated:        time.Now().UTC(),
 	}
 
@@ -60,6 +62,12 @@ func (brs *BeliefRegistryService) BuildRegistryFromLoadedPanes(tenantCtx *tenant
 			registry.PaneBeliefPayloads[paneID] = paneBeliefData
 			// Add these beliefs to the flat lookup map for quick checks.
 			brs.addToRequiredBeliefs(registry.RequiredBeliefs, paneBeliefData)
+
+			// Precompute the evaluation cost so render-time visibility checks
+			// have a known upper bound and expensive pages are visible to ops.
+			cost := brs.evaluationCost(paneBeliefData)
+			registry.PaneEvalCosts[paneID] = cost
+			registry.TotalEvalCost += cost
 		}
 
 		// Task 2: Scan the pane's node structure for interactive belief widgets.
@@ -74,6 +82,16 @@ func (brs *BeliefRegistryService) BuildRegistryFromLoadedPanes(tenantCtx *tenant
 		}
 	}
 
+	// Flag storyfragments whose per-render belief evaluation exceeds the
+	// configured complexity threshold so editors know the page is expensive.
+	if registry.TotalEvalCost > config.BeliefEvalComplexityThreshold {
+		registry.ComplexityFlagged = true
+		brs.logger.Content().Warn("Storyfragment exceeds belief evaluation complexity threshold",
+			"tenantId", tenantCtx.TenantID, "storyfragmentId", storyfragmentID,
+			"totalEvalCost", registry.TotalEvalCost, "threshold", config.BeliefEvalComplexityThreshold,
+			"gatedPanes", len(registry.PaneBeliefPayloads))
+	}
+
 	// Cache the newly built registry for future requests.
 	tenantCtx.CacheManager.SetStoryfragmentBeliefRegistry(tenantCtx.TenantID, registry)
 
@@ -183,6 +201,23 @@ func extractWidgetTypeFromCopy(copyText string) string {
 	return ""
 }
 
+// evaluationCost counts the belief comparisons a single pane contributes to
+// each visibility evaluation: one per required value plus the OR and cascade
+// lists, which are walked separately.
+func (brs *BeliefRegistryService) evaluationCost(data types.PaneBeliefData) int {
+	cost := 0
+	for _, values := range data.HeldBeliefs {
+		cost += len(values)
+	}
+	for _, values := range data.WithheldBeliefs {
+		cost += len(values)
+	}
+	cost += len(data.MatchAcross)
+	cost += len(data.LinkedBeliefs)
+	cost += len(data.HeldBadges)
+	return cost
+}
+
 // addToRequiredBeliefs populates the flat lookup map of all beliefs required by a pane.
 func (brs *BeliefRegistryService) addToRequiredBeliefs(required map[string]bool, data types.PaneBeliefData) {
 	for beliefSlug := range data.HeldBeliefs {
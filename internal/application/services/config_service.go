@@ -17,6 +17,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
 )
 
@@ -108,7 +109,7 @@ func (c *ConfigService) ValidateAdminPermissions(authHeader string, tenantCtx *t
 	}
 
 	// Validate JWT token
-	claims, err := security.ValidateJWT(token, tenantCtx.Config.JWTSecret)
+	claims, err := security.ValidateJWTWithFallback(token, tenantCtx.Config.JWTSecret, tenantCtx.Config.PreviousJWTSecret)
 	if err != nil {
 		return fmt.Errorf("invalid JWT token: %w", err)
 	}
@@ -135,7 +136,7 @@ func (c *ConfigService) ValidateEditorPermissions(authHeader string, tenantCtx *
 	}
 
 	// Validate JWT token
-	claims, err := security.ValidateJWT(token, tenantCtx.Config.JWTSecret)
+	claims, err := security.ValidateJWTWithFallback(token, tenantCtx.Config.JWTSecret, tenantCtx.Config.PreviousJWTSecret)
 	if err != nil {
 		return fmt.Errorf("invalid JWT token: %w", err)
 	}
@@ -244,8 +245,8 @@ func (c *ConfigService) TestTursoConnection(databaseURL, authToken string) error
 }
 
 // SaveBrandConfig saves brand configuration to disk
-func (c *ConfigService) SaveBrandConfig(tenantID string, config *types.BrandConfig) error {
-	configPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantID)
+func (c *ConfigService) SaveBrandConfig(tenantID string, brandConfig *types.BrandConfig) error {
+	configPath := filepath.Join(config.DataDir, "config", tenantID)
 
 	// Ensure config directory exists
 	if err := os.MkdirAll(configPath, 0755); err != nil {
@@ -253,12 +254,12 @@ func (c *ConfigService) SaveBrandConfig(tenantID string, config *types.BrandConf
 	}
 
 	// Save knownResources separately
-	if err := c.saveKnownResources(tenantID, config.KnownResources); err != nil {
+	if err := c.saveKnownResources(tenantID, brandConfig.KnownResources); err != nil {
 		return err
 	}
 
 	// Create copy without KnownResources for brand.json
-	brandConfigForFile := *config
+	brandConfigForFile := *brandConfig
 	brandConfigForFile.KnownResources = nil
 
 	// Write brand config
@@ -277,7 +278,7 @@ func (c *ConfigService) SaveBrandConfig(tenantID string, config *types.BrandConf
 
 // SaveAdvancedConfig saves advanced configuration to disk
 func (c *ConfigService) SaveAdvancedConfig(tenantCtx *tenant.Context) error {
-	configPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantCtx.Config.TenantID, "env.json")
+	configPath := filepath.Join(config.DataDir, "config", tenantCtx.Config.TenantID, "env.json")
 
 	// Ensure config directory exists
 	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
@@ -604,7 +605,7 @@ func (c *ConfigService) saveKnownResources(tenantID string, knownResources *type
 		return nil // Nothing to save
 	}
 
-	configPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantID)
+	configPath := filepath.Join(config.DataDir, "config", tenantID)
 
 	// Ensure config directory exists
 	if err := os.MkdirAll(configPath, 0755); err != nil {
@@ -4,9 +4,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/analytics"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 type HourlyActivity map[string]map[string]struct {
@@ -74,7 +76,7 @@ func (s *ContentAnalyticsService) GetHourlyNodeActivity(tenantCtx *tenant.Contex
 				}
 
 				currentData := hourNodeData[contentID]
-				currentData.Events[verb] += len(stepData.Visitors)
+				currentData.Events[verb] += analytics.ScaleCount(len(stepData.Visitors), config.AnalyticsSampleRate)
 
 				visitorSet := make(map[string]struct{})
 				for _, vID := range currentData.VisitorIDs {
@@ -105,6 +107,83 @@ func (s *ContentAnalyticsService) GetHourlyNodeActivity(tenantCtx *tenant.Contex
 	return hourlyActivity, nil
 }
 
+// ContentTimeSeriesPoint is one hour of activity for a single content node.
+type ContentTimeSeriesPoint struct {
+	HourKey        string         `json:"hourKey"`
+	Events         map[string]int `json:"events"`
+	UniqueVisitors int            `json:"uniqueVisitors"`
+}
+
+// GetContentTimeSeries walks the cached hourly epinet bins like
+// GetHourlyNodeActivity but filtered to a single content ID, returning an
+// hourly series of verb counts and unique visitor totals. visitorType
+// "known" or "anonymous" narrows the visitor sets; anything else counts all.
+// Content IDs with no recorded activity yield an empty series.
+func (s *ContentAnalyticsService) GetContentTimeSeries(tenantCtx *tenant.Context, epinetID, contentID string, startHour, endHour *int, visitorType string) ([]ContentTimeSeriesPoint, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_content_time_series", tenantCtx.TenantID)
+	defer marker.Complete()
+	var hourKeys []string
+	if startHour != nil && endHour != nil {
+		hourKeys = s.getHourKeysForCustomRange(*startHour, *endHour)
+	} else {
+		hourKeys = s.getHourKeysForTimeRange(168)
+	}
+
+	series := make([]ContentTimeSeriesPoint, 0)
+	for _, hourKey := range hourKeys {
+		bin, exists := tenantCtx.CacheManager.GetHourlyEpinetBin(tenantCtx.TenantID, epinetID, hourKey)
+		if !exists {
+			continue
+		}
+
+		events := make(map[string]int)
+		hourVisitors := make(map[string]struct{})
+
+		for nodeID, stepData := range bin.Data.Steps {
+			if len(stepData.Visitors) == 0 {
+				continue
+			}
+			originalNodeID := strings.ReplaceAll(nodeID, "_", "-")
+			parts := strings.Split(originalNodeID, "-")
+			if len(parts) < 3 || parts[len(parts)-1] != contentID {
+				continue
+			}
+			verb := parts[len(parts)-2]
+
+			matched := 0
+			for visitorID := range stepData.Visitors {
+				isKnown := stepData.KnownVisitors[visitorID]
+				if visitorType == "known" && !isKnown {
+					continue
+				}
+				if visitorType == "anonymous" && isKnown {
+					continue
+				}
+				matched++
+				hourVisitors[visitorID] = struct{}{}
+			}
+			if matched > 0 {
+				events[verb] += analytics.ScaleCount(matched, config.AnalyticsSampleRate)
+			}
+		}
+
+		if len(events) > 0 {
+			series = append(series, ContentTimeSeriesPoint{
+				HourKey:        hourKey,
+				Events:         events,
+				UniqueVisitors: len(hourVisitors),
+			})
+		}
+	}
+
+	s.logger.Analytics().Info("Successfully computed content time series", "tenantId", tenantCtx.TenantID, "epinetId", epinetID, "contentId", contentID, "hourCount", len(hourKeys), "activeHours", len(series), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for GetContentTimeSeries", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	return series, nil
+}
+
 func (s *ContentAnalyticsService) GetStoryfragmentAnalytics(tenantCtx *tenant.Context, epinetIDs []string, startHour, endHour int) ([]StoryfragmentAnalytics, error) {
 	start := time.Now()
 	marker := s.perfTracker.StartOperation("get_storyfragment_analytics", tenantCtx.TenantID)
@@ -136,7 +215,8 @@ func (s *ContentAnalyticsService) GetStoryfragmentAnalytics(tenantCtx *tenant.Co
 				if s.isStoryFragmentStep(stepID) {
 					contentID := s.extractContentIDFromNodeID(stepID)
 					if contentID != "" && storyFragmentTitles[contentID] != "" {
-						storyFragmentCounts[contentID] += len(stepData.Visitors)
+						// Scale sampled counts back up to estimated totals
+						storyFragmentCounts[contentID] += analytics.ScaleCount(len(stepData.Visitors), config.AnalyticsSampleRate)
 					}
 				}
 			}
@@ -0,0 +1,374 @@
+// Package services provides bulk content import orchestration
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+)
+
+// Import plan actions reported per node.
+const (
+	ImportActionCreate    = "create"
+	ImportActionOverwrite = "overwrite"
+	ImportActionSkip      = "skip"
+	ImportActionReject    = "reject"
+)
+
+// ImportPayload is the bulk content import request body. Node types are
+// applied in dependency order: tractstacks, menus, beliefs, resources,
+// panes, then storyfragments.
+type ImportPayload struct {
+	TractStacks    []*content.TractStackNode    `json:"tractStacks,omitempty"`
+	Menus          []*content.MenuNode          `json:"menus,omitempty"`
+	Beliefs        []*content.BeliefNode        `json:"beliefs,omitempty"`
+	Resources      []*content.ResourceNode      `json:"resources,omitempty"`
+	Panes          []*content.PaneNode          `json:"panes,omitempty"`
+	StoryFragments []*content.StoryFragmentNode `json:"storyFragments,omitempty"`
+	Overwrite      bool                         `json:"overwrite,omitempty"`
+}
+
+// ImportPlanEntry describes what the import would do (or did) for one node.
+type ImportPlanEntry struct {
+	ID       string `json:"id"`
+	NodeType string `json:"nodeType"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug,omitempty"`
+	Action   string `json:"action"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ContentImportService validates and applies bulk content imports. The
+// dry-run and real import share the same validation path.
+type ContentImportService struct {
+	tractStackService    *TractStackService
+	menuService          *MenuService
+	beliefService        *BeliefService
+	resourceService      *ResourceService
+	paneService          *PaneService
+	storyFragmentService *StoryFragmentService
+	logger               *logging.ChanneledLogger
+	perfTracker          *performance.Tracker
+}
+
+// NewContentImportService creates a new content import service singleton
+func NewContentImportService(
+	tractStackService *TractStackService,
+	menuService *MenuService,
+	beliefService *BeliefService,
+	resourceService *ResourceService,
+	paneService *PaneService,
+	storyFragmentService *StoryFragmentService,
+	logger *logging.ChanneledLogger,
+	perfTracker *performance.Tracker,
+) *ContentImportService {
+	return &ContentImportService{
+		tractStackService:    tractStackService,
+		menuService:          menuService,
+		beliefService:        beliefService,
+		resourceService:      resourceService,
+		paneService:          paneService,
+		storyFragmentService: storyFragmentService,
+		logger:               logger,
+		perfTracker:          perfTracker,
+	}
+}
+
+// PlanImport validates the payload and returns the per-node plan without
+// writing anything.
+func (s *ContentImportService) PlanImport(tenantCtx *tenant.Context, payload *ImportPayload) ([]ImportPlanEntry, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("plan_content_import", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	plan := make([]ImportPlanEntry, 0)
+	payloadIDs := s.collectPayloadIDs(payload)
+	seenSlugs := make(map[string]string) // slug -> node ID within the payload
+
+	for _, node := range payload.TractStacks {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "TractStack", node.ID, node.Title, node.Slug, func() (bool, error) {
+			existing, err := s.tractStackService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, func() (string, error) {
+			existing, err := s.tractStackService.GetBySlug(tenantCtx, node.Slug)
+			if existing == nil {
+				return "", err
+			}
+			return existing.ID, err
+		}, nil))
+	}
+
+	for _, node := range payload.Menus {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "Menu", node.ID, node.Title, "", func() (bool, error) {
+			existing, err := s.menuService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, nil, nil))
+	}
+
+	for _, node := range payload.Beliefs {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "Belief", node.ID, node.Title, node.Slug, func() (bool, error) {
+			existing, err := s.beliefService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, func() (string, error) {
+			existing, err := s.beliefService.GetBySlug(tenantCtx, node.Slug)
+			if existing == nil {
+				return "", err
+			}
+			return existing.ID, err
+		}, nil))
+	}
+
+	for _, node := range payload.Resources {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "Resource", node.ID, node.Title, node.Slug, func() (bool, error) {
+			existing, err := s.resourceService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, func() (string, error) {
+			existing, err := s.resourceService.GetBySlug(tenantCtx, node.Slug)
+			if existing == nil {
+				return "", err
+			}
+			return existing.ID, err
+		}, nil))
+	}
+
+	for _, node := range payload.Panes {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "Pane", node.ID, node.Title, node.Slug, func() (bool, error) {
+			existing, err := s.paneService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, func() (string, error) {
+			existing, err := s.paneService.GetBySlug(tenantCtx, node.Slug)
+			if existing == nil {
+				return "", err
+			}
+			return existing.ID, err
+		}, nil))
+	}
+
+	for _, node := range payload.StoryFragments {
+		plan = append(plan, s.planNode(tenantCtx, payload, seenSlugs, "StoryFragment", node.ID, node.Title, node.Slug, func() (bool, error) {
+			existing, err := s.storyFragmentService.GetByID(tenantCtx, node.ID)
+			return existing != nil, err
+		}, func() (string, error) {
+			existing, err := s.storyFragmentService.GetBySlug(tenantCtx, node.Slug)
+			if existing == nil {
+				return "", err
+			}
+			return existing.ID, err
+		}, func() string {
+			return s.checkStoryFragmentReferences(tenantCtx, payloadIDs, node)
+		}))
+	}
+
+	s.logger.Content().Info("Planned content import", "tenantId", tenantCtx.TenantID, "nodes", len(plan), "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return plan, nil
+}
+
+// ApplyImport plans the payload, then writes every create/overwrite entry.
+// Rejected and skipped nodes are left untouched.
+func (s *ContentImportService) ApplyImport(tenantCtx *tenant.Context, payload *ImportPayload) ([]ImportPlanEntry, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("apply_content_import", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	plan, err := s.PlanImport(tenantCtx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make(map[string]string, len(plan))
+	for _, entry := range plan {
+		actions[entry.NodeType+":"+entry.ID] = entry.Action
+	}
+
+	apply := func(nodeType, id string, create func() error, update func() error) error {
+		switch actions[nodeType+":"+id] {
+		case ImportActionCreate:
+			return create()
+		case ImportActionOverwrite:
+			return update()
+		}
+		return nil
+	}
+
+	for _, node := range payload.TractStacks {
+		if err := apply("TractStack", node.ID,
+			func() error { return s.tractStackService.Create(tenantCtx, node) },
+			func() error { return s.tractStackService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import tractstack %s: %w", node.ID, err)
+		}
+	}
+	for _, node := range payload.Menus {
+		if err := apply("Menu", node.ID,
+			func() error { return s.menuService.Create(tenantCtx, node) },
+			func() error { return s.menuService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import menu %s: %w", node.ID, err)
+		}
+	}
+	for _, node := range payload.Beliefs {
+		if err := apply("Belief", node.ID,
+			func() error { return s.beliefService.Create(tenantCtx, node) },
+			func() error { return s.beliefService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import belief %s: %w", node.ID, err)
+		}
+	}
+	for _, node := range payload.Resources {
+		if err := apply("Resource", node.ID,
+			func() error { return s.resourceService.Create(tenantCtx, node) },
+			func() error { return s.resourceService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import resource %s: %w", node.ID, err)
+		}
+	}
+	for _, node := range payload.Panes {
+		if err := apply("Pane", node.ID,
+			func() error { return s.paneService.Create(tenantCtx, node) },
+			func() error { return s.paneService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import pane %s: %w", node.ID, err)
+		}
+	}
+	for _, node := range payload.StoryFragments {
+		if err := apply("StoryFragment", node.ID,
+			func() error { return s.storyFragmentService.Create(tenantCtx, node) },
+			func() error { return s.storyFragmentService.Update(tenantCtx, node) }); err != nil {
+			return plan, fmt.Errorf("failed to import storyfragment %s: %w", node.ID, err)
+		}
+	}
+
+	s.logger.Content().Info("Applied content import", "tenantId", tenantCtx.TenantID, "nodes", len(plan), "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return plan, nil
+}
+
+// planNode decides the action for one node. existsByID reports whether the
+// node ID already exists; slugOwner (optional) returns the ID owning the
+// slug; checkRefs (optional) returns a rejection reason for broken
+// references.
+func (s *ContentImportService) planNode(
+	tenantCtx *tenant.Context,
+	payload *ImportPayload,
+	seenSlugs map[string]string,
+	nodeType, id, title, slug string,
+	existsByID func() (bool, error),
+	slugOwner func() (string, error),
+	checkRefs func() string,
+) ImportPlanEntry {
+	entry := ImportPlanEntry{ID: id, NodeType: nodeType, Title: title, Slug: slug}
+
+	if id == "" || title == "" || (slugOwner != nil && slug == "") {
+		entry.Action = ImportActionReject
+		entry.Reason = "missing required field (id, title, or slug)"
+		return entry
+	}
+
+	if slug != "" {
+		if ownerID, dup := seenSlugs[slug]; dup && ownerID != id {
+			entry.Action = ImportActionReject
+			entry.Reason = fmt.Sprintf("slug duplicated within payload by node %s", ownerID)
+			return entry
+		}
+		seenSlugs[slug] = id
+	}
+
+	exists, err := existsByID()
+	if err != nil {
+		entry.Action = ImportActionReject
+		entry.Reason = fmt.Sprintf("lookup failed: %v", err)
+		return entry
+	}
+
+	if slugOwner != nil {
+		ownerID, err := slugOwner()
+		if err != nil {
+			entry.Action = ImportActionReject
+			entry.Reason = fmt.Sprintf("slug lookup failed: %v", err)
+			return entry
+		}
+		if ownerID != "" && ownerID != id {
+			entry.Action = ImportActionReject
+			entry.Reason = fmt.Sprintf("slug conflicts with existing node %s", ownerID)
+			return entry
+		}
+	}
+
+	if checkRefs != nil {
+		if reason := checkRefs(); reason != "" {
+			entry.Action = ImportActionReject
+			entry.Reason = reason
+			return entry
+		}
+	}
+
+	if exists {
+		if payload.Overwrite {
+			entry.Action = ImportActionOverwrite
+		} else {
+			entry.Action = ImportActionSkip
+			entry.Reason = "node exists and overwrite is disabled"
+		}
+		return entry
+	}
+
+	entry.Action = ImportActionCreate
+	return entry
+}
+
+// checkStoryFragmentReferences verifies the tractstack, menu, and pane IDs
+// a storyfragment points at exist already or arrive in the same payload.
+func (s *ContentImportService) checkStoryFragmentReferences(tenantCtx *tenant.Context, payloadIDs map[string]bool, node *content.StoryFragmentNode) string {
+	if node.TractStackID == "" {
+		return "storyfragment is missing tractStackId"
+	}
+	if !payloadIDs["TractStack:"+node.TractStackID] {
+		existing, err := s.tractStackService.GetByID(tenantCtx, node.TractStackID)
+		if err != nil || existing == nil {
+			return fmt.Sprintf("references missing tractstack %s", node.TractStackID)
+		}
+	}
+	if node.MenuID != nil && *node.MenuID != "" && !payloadIDs["Menu:"+*node.MenuID] {
+		existing, err := s.menuService.GetByID(tenantCtx, *node.MenuID)
+		if err != nil || existing == nil {
+			return fmt.Sprintf("references missing menu %s", *node.MenuID)
+		}
+	}
+	for _, paneID := range node.PaneIDs {
+		if payloadIDs["Pane:"+paneID] {
+			continue
+		}
+		existing, err := s.paneService.GetByID(tenantCtx, paneID)
+		if err != nil || existing == nil {
+			return fmt.Sprintf("references missing pane %s", paneID)
+		}
+	}
+	return ""
+}
+
+// collectPayloadIDs indexes payload node IDs as "<type>:<id>" for
+// reference-integrity checks against nodes arriving in the same import.
+func (s *ContentImportService) collectPayloadIDs(payload *ImportPayload) map[string]bool {
+	ids := make(map[string]bool)
+	for _, node := range payload.TractStacks {
+		ids["TractStack:"+node.ID] = true
+	}
+	for _, node := range payload.Menus {
+		ids["Menu:"+node.ID] = true
+	}
+	for _, node := range payload.Beliefs {
+		ids["Belief:"+node.ID] = true
+	}
+	for _, node := range payload.Resources {
+		ids["Resource:"+node.ID] = true
+	}
+	for _, node := range payload.Panes {
+		ids["Pane:"+node.ID] = true
+	}
+	for _, node := range payload.StoryFragments {
+		ids["StoryFragment:"+node.ID] = true
+	}
+	return ids
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newImportFixture builds a content import service wired to a tenant context
+// with the full content schema in an in-memory SQLite database.
+func newImportFixture(t *testing.T) (*ContentImportService, *tenant.Context, *sql.DB) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+
+	perfTracker := performance.NewTracker(nil)
+	importService := NewContentImportService(
+		NewTractStackService(logger, perfTracker, nil),
+		NewMenuService(logger, perfTracker, nil),
+		NewBeliefService(logger, perfTracker, nil),
+		NewResourceService(logger, perfTracker, nil),
+		NewPaneService(logger, perfTracker, nil),
+		NewStoryFragmentService(logger, perfTracker, nil, nil),
+		logger,
+		perfTracker,
+	)
+	return importService, tenantCtx, db
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		t.Fatalf("failed to count %s rows: %v", table, err)
+	}
+	return count
+}
+
+func planActionFor(plan []ImportPlanEntry, id string) (ImportPlanEntry, bool) {
+	for _, entry := range plan {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return ImportPlanEntry{}, false
+}
+
+func TestPlanImportClassifiesNodes(t *testing.T) {
+	importService, tenantCtx, db := newImportFixture(t)
+
+	if _, err := db.Exec(`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-db', 'Main', 'main')`); err != nil {
+		t.Fatalf("failed to seed tractstack: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO panes (id, title, slug, options_payload, pane_type) VALUES ('pane-db', 'Hero', 'hero', '{}', 'standard')`); err != nil {
+		t.Fatalf("failed to seed pane: %v", err)
+	}
+
+	plan, err := importService.PlanImport(tenantCtx, &ImportPayload{
+		TractStacks: []*content.TractStackNode{
+			{ID: "ts-new", Title: "Fresh", Slug: "fresh"},
+			{ID: "ts-db", Title: "Main", Slug: "main"},
+		},
+		Panes: []*content.PaneNode{
+			{ID: "pane-clash", Title: "Clash", Slug: "hero", OptionsPayload: map[string]any{}},
+		},
+		StoryFragments: []*content.StoryFragmentNode{
+			{ID: "sf-dangling", Title: "Orphan", Slug: "orphan", TractStackID: "ts-missing"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PlanImport failed: %v", err)
+	}
+
+	if entry, ok := planActionFor(plan, "ts-new"); !ok || entry.Action != ImportActionCreate {
+		t.Errorf("expected a new node to plan as create, got %+v", entry)
+	}
+	if entry, ok := planActionFor(plan, "ts-db"); !ok || entry.Action != ImportActionSkip {
+		t.Errorf("expected an existing node without overwrite to plan as skip, got %+v", entry)
+	}
+	if entry, ok := planActionFor(plan, "pane-clash"); !ok || entry.Action != ImportActionReject {
+		t.Errorf("expected a slug collision with another node to reject, got %+v", entry)
+	}
+	if entry, ok := planActionFor(plan, "sf-dangling"); !ok || entry.Action != ImportActionReject {
+		t.Errorf("expected a dangling tractstack reference to reject, got %+v", entry)
+	}
+}
+
+func TestPlanImportOverwriteMarksExistingNodes(t *testing.T) {
+	importService, tenantCtx, db := newImportFixture(t)
+
+	if _, err := db.Exec(`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-db', 'Main', 'main')`); err != nil {
+		t.Fatalf("failed to seed tractstack: %v", err)
+	}
+
+	plan, err := importService.PlanImport(tenantCtx, &ImportPayload{
+		TractStacks: []*content.TractStackNode{{ID: "ts-db", Title: "Renamed", Slug: "main"}},
+		Overwrite:   true,
+	})
+	if err != nil {
+		t.Fatalf("PlanImport failed: %v", err)
+	}
+	if entry, ok := planActionFor(plan, "ts-db"); !ok || entry.Action != ImportActionOverwrite {
+		t.Errorf("expected an existing node with overwrite to plan as overwrite, got %+v", entry)
+	}
+}
+
+func TestPlanImportRejectsDuplicateSlugsWithinPayload(t *testing.T) {
+	importService, tenantCtx, _ := newImportFixture(t)
+
+	plan, err := importService.PlanImport(tenantCtx, &ImportPayload{
+		TractStacks: []*content.TractStackNode{
+			{ID: "ts-a", Title: "A", Slug: "same"},
+			{ID: "ts-b", Title: "B", Slug: "same"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PlanImport failed: %v", err)
+	}
+	if entry, _ := planActionFor(plan, "ts-a"); entry.Action != ImportActionCreate {
+		t.Errorf("expected the first slug owner to plan as create, got %+v", entry)
+	}
+	if entry, _ := planActionFor(plan, "ts-b"); entry.Action != ImportActionReject {
+		t.Errorf("expected the duplicate slug to reject, got %+v", entry)
+	}
+}
+
+func TestPlanImportIsDryRun(t *testing.T) {
+	importService, tenantCtx, db := newImportFixture(t)
+
+	if _, err := db.Exec(`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-db', 'Main', 'main')`); err != nil {
+		t.Fatalf("failed to seed tractstack: %v", err)
+	}
+
+	// A plan mixing creates, overwrites, and conflicts must not touch the
+	// database — only ApplyImport writes.
+	if _, err := importService.PlanImport(tenantCtx, &ImportPayload{
+		TractStacks: []*content.TractStackNode{
+			{ID: "ts-new", Title: "Fresh", Slug: "fresh"},
+			{ID: "ts-db", Title: "Renamed", Slug: "main"},
+		},
+		Overwrite: true,
+	}); err != nil {
+		t.Fatalf("PlanImport failed: %v", err)
+	}
+
+	if got := countRows(t, db, "tractstacks"); got != 1 {
+		t.Errorf("expected the dry run to leave 1 tractstack row, found %d", got)
+	}
+	var title string
+	if err := db.QueryRow(`SELECT title FROM tractstacks WHERE id = 'ts-db'`).Scan(&title); err != nil {
+		t.Fatalf("failed to re-read seeded row: %v", err)
+	}
+	if title != "Main" {
+		t.Errorf("expected the dry run to leave the existing row untouched, title is %q", title)
+	}
+}
@@ -3,7 +3,9 @@ package services
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
@@ -156,20 +158,29 @@ func (cms *ContentMapService) GetContentMap(tenantCtx *tenant.Context, clientLas
 		}, false, nil
 	}
 
-	// Cache miss - build content map from database using bulk repository
-	bulkRepo := tenantCtx.BulkRepo()
-	contentMap, err := bulkRepo.BuildContentMap(tenantCtx.TenantID)
+	// Cache miss - build content map from database using bulk repository.
+	// Singleflight collapses concurrent misses into one build, which matters
+	// when eager warming is disabled and the first requests race to build.
+	built, err := cache.LoadOrShare(tenantCtx.TenantID+":content-map-build", func() (any, error) {
+		bulkRepo := tenantCtx.BulkRepo()
+		contentMap, err := bulkRepo.BuildContentMap(tenantCtx.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build content map: %w", err)
+		}
+
+		// Convert domain entities to cache types before storing
+		cacheItems := cms.convertToFullContentMapItems(contentMap)
+		cache.SetFullContentMap(tenantCtx.TenantID, cacheItems)
+		return contentMap, nil
+	})
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to build content map: %w", err)
+		return nil, false, err
 	}
+	contentMap := built.([]*content.ContentMapItem)
 
 	// Current timestamp for the response
 	timestamp := time.Now().Unix()
 
-	// Convert domain entities to cache types before storing
-	cacheItems := cms.convertToFullContentMapItems(contentMap)
-	cache.SetFullContentMap(tenantCtx.TenantID, cacheItems)
-
 	// Convert to response format with type-specific fields
 	convertedItems := make([]*content.ContentMapItem, len(contentMap))
 	for i, item := range contentMap {
@@ -334,3 +345,146 @@ func (cms *ContentMapService) RefreshContentMap(tenantCtx *tenant.Context, cache
 	marker.SetSuccess(true)
 	return nil
 }
+
+// UpdateContentMapEntry surgically patches one item in the cached content map
+// instead of forcing a full rebuild, falling back to RefreshContentMap when no
+// map is cached yet.
+func (cms *ContentMapService) UpdateContentMapEntry(tenantCtx *tenant.Context, cache interfaces.ContentCache, item types.FullContentMapItem) error {
+	if cache.UpdateContentMapItem(tenantCtx.TenantID, item) {
+		cms.logger.Content().Debug("Surgically updated content map item",
+			"tenantId", tenantCtx.TenantID, "id", item.ID, "type", item.Type)
+		return nil
+	}
+	return cms.RefreshContentMap(tenantCtx, cache)
+}
+
+// RemoveContentMapEntry surgically removes one item from the cached content
+// map instead of forcing a full rebuild, falling back to RefreshContentMap
+// when no map is cached yet.
+func (cms *ContentMapService) RemoveContentMapEntry(tenantCtx *tenant.Context, cache interfaces.ContentCache, id string) error {
+	if cache.RemoveContentMapItem(tenantCtx.TenantID, id) {
+		cms.logger.Content().Debug("Surgically removed content map item",
+			"tenantId", tenantCtx.TenantID, "id", id)
+		return nil
+	}
+	return cms.RefreshContentMap(tenantCtx, cache)
+}
+
+// GetPaneDependents returns the IDs of storyfragments that reference a pane.
+// The cached pane -> storyfragment reverse index answers when populated;
+// otherwise every storyfragment's PaneIDs are scanned via the repository.
+func (cms *ContentMapService) GetPaneDependents(tenantCtx *tenant.Context, paneID string) ([]string, error) {
+	start := time.Now()
+	marker := cms.perfTracker.StartOperation("get_pane_dependents", tenantCtx.TenantID)
+	defer marker.Complete()
+	if paneID == "" {
+		return nil, fmt.Errorf("pane ID cannot be empty")
+	}
+
+	dependents := tenantCtx.CacheManager.GetStoryFragmentIDsForPane(tenantCtx.TenantID, paneID)
+	if len(dependents) == 0 {
+		storyFragments, err := tenantCtx.StoryFragmentRepo().FindAll(tenantCtx.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan storyfragments for pane %s dependents: %w", paneID, err)
+		}
+		for _, storyFragment := range storyFragments {
+			if slices.Contains(storyFragment.PaneIDs, paneID) {
+				dependents = append(dependents, storyFragment.ID)
+			}
+		}
+	}
+
+	cms.logger.Content().Info("Successfully resolved pane dependents",
+		"tenantId", tenantCtx.TenantID, "paneId", paneID, "count", len(dependents), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	return dependents, nil
+}
+
+// SearchResult is one content map entry matched by Search.
+type SearchResult struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// searchResultLimit caps how many matches one search request returns.
+const searchResultLimit = 50
+
+// Search scans the cached content map for entries whose title (case
+// insensitive) or slug contains the query, optionally restricted to the given
+// node types. Results are ordered by relevance: exact slug match first, then
+// title prefix match, then matches anywhere.
+func (cms *ContentMapService) Search(tenantCtx *tenant.Context, query string, nodeTypes []string) ([]SearchResult, error) {
+	start := time.Now()
+	marker := cms.perfTracker.StartOperation("search_content_map", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if query == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+
+	cacheManager := tenantCtx.CacheManager
+	contentMap, found := cacheManager.GetFullContentMap(tenantCtx.TenantID)
+	if !found {
+		if err := cms.RefreshContentMap(tenantCtx, cacheManager); err != nil {
+			return nil, fmt.Errorf("failed to build content map for search: %w", err)
+		}
+		contentMap, _ = cacheManager.GetFullContentMap(tenantCtx.TenantID)
+	}
+
+	typeFilter := make(map[string]bool, len(nodeTypes))
+	for _, nodeType := range nodeTypes {
+		if trimmed := strings.TrimSpace(nodeType); trimmed != "" {
+			typeFilter[strings.ToLower(trimmed)] = true
+		}
+	}
+
+	loweredQuery := strings.ToLower(query)
+	type rankedResult struct {
+		SearchResult
+		rank int
+	}
+	var matches []rankedResult
+	for _, item := range contentMap {
+		if len(typeFilter) > 0 && !typeFilter[strings.ToLower(item.Type)] {
+			continue
+		}
+		titleMatch := strings.Contains(strings.ToLower(item.Title), loweredQuery)
+		slugMatch := strings.Contains(item.Slug, query)
+		if !titleMatch && !slugMatch {
+			continue
+		}
+		rank := 2
+		switch {
+		case item.Slug == query:
+			rank = 0
+		case strings.HasPrefix(strings.ToLower(item.Title), loweredQuery):
+			rank = 1
+		}
+		matches = append(matches, rankedResult{
+			SearchResult: SearchResult{ID: item.ID, Type: item.Type, Title: item.Title, Slug: item.Slug},
+			rank:         rank,
+		})
+	}
+
+	slices.SortStableFunc(matches, func(a, b rankedResult) int {
+		if a.rank != b.rank {
+			return a.rank - b.rank
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+
+	results := make([]SearchResult, 0, min(len(matches), searchResultLimit))
+	for i, match := range matches {
+		if i == searchResultLimit {
+			break
+		}
+		results = append(results, match.SearchResult)
+	}
+
+	cms.logger.Content().Info("Content map search completed",
+		"tenantId", tenantCtx.TenantID, "query", query, "matches", len(results), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	return results, nil
+}
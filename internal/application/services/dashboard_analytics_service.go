@@ -1,6 +1,7 @@
 package services
 
 import (
+	"sort"
 	"strings"
 	"time"
 
@@ -9,6 +10,13 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 )
 
+const (
+	// DefaultHotContentLimit is used when callers don't request a specific size.
+	DefaultHotContentLimit = 10
+	// MaxHotContentLimit bounds client-supplied hot-content limits.
+	MaxHotContentLimit = 100
+)
+
 type TimeRangeStats struct {
 	Daily   int `json:"daily"`
 	Weekly  int `json:"weekly"`
@@ -59,7 +67,13 @@ func NewDashboardAnalyticsService(logger *logging.ChanneledLogger, perfTracker *
 	}
 }
 
-func (s *DashboardAnalyticsService) ComputeDashboard(tenantCtx *tenant.Context, startHour, endHour int) (*DashboardAnalytics, error) {
+func (s *DashboardAnalyticsService) ComputeDashboard(tenantCtx *tenant.Context, startHour, endHour, hotContentLimit int) (*DashboardAnalytics, error) {
+	if hotContentLimit < 1 {
+		hotContentLimit = DefaultHotContentLimit
+	}
+	if hotContentLimit > MaxHotContentLimit {
+		hotContentLimit = MaxHotContentLimit
+	}
 	start := time.Now()
 	marker := s.perfTracker.StartOperation("compute_dashboard", tenantCtx.TenantID)
 	defer marker.Complete()
@@ -93,7 +107,7 @@ func (s *DashboardAnalyticsService) ComputeDashboard(tenantCtx *tenant.Context,
 	return &DashboardAnalytics{
 		Stats:            stats,
 		Line:             s.computeLineData(tenantCtx, epinets, hourKeys),
-		HotContent:       s.computeHotContent(tenantCtx, epinets, hourKeys),
+		HotContent:       s.computeHotContent(tenantCtx, epinets, hourKeys, hotContentLimit),
 		DailyKnown:       dailyKnown,
 		DailyAnonymous:   dailyAnonymous,
 		WeeklyKnown:      weeklyKnown,
@@ -200,7 +214,7 @@ func (s *DashboardAnalyticsService) computeLineData(tenantCtx *tenant.Context, e
 	return lineSeriesList
 }
 
-func (s *DashboardAnalyticsService) computeHotContent(tenantCtx *tenant.Context, epinets []EpinetConfig, hourKeys []string) []HotItem {
+func (s *DashboardAnalyticsService) computeHotContent(tenantCtx *tenant.Context, epinets []EpinetConfig, hourKeys []string, limit int) []HotItem {
 	contentCounts := make(map[string]int)
 
 	// ADD: Debug logging for input parameters
@@ -273,16 +287,17 @@ func (s *DashboardAnalyticsService) computeHotContent(tenantCtx *tenant.Context,
 		})
 	}
 
-	for i := 0; i < len(sortedContent)-1; i++ {
-		for j := i + 1; j < len(sortedContent); j++ {
-			if sortedContent[i].TotalEvents < sortedContent[j].TotalEvents {
-				sortedContent[i], sortedContent[j] = sortedContent[j], sortedContent[i]
-			}
+	// Sort by event count descending with ID as a deterministic tie-break so
+	// equal counts always render in the same order.
+	sort.Slice(sortedContent, func(i, j int) bool {
+		if sortedContent[i].TotalEvents != sortedContent[j].TotalEvents {
+			return sortedContent[i].TotalEvents > sortedContent[j].TotalEvents
 		}
-	}
+		return sortedContent[i].ID < sortedContent[j].ID
+	})
 
-	if len(sortedContent) > 10 {
-		sortedContent = sortedContent[:10]
+	if len(sortedContent) > limit {
+		sortedContent = sortedContent[:limit]
 	}
 
 	s.logger.Analytics().Debug("computeHotContent final result",
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newDBServiceFixture(t *testing.T) (*DBService, *tenant.Context, *sql.DB) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	tenantCtx := &tenant.Context{
+		TenantID: "t1",
+		Config:   &tenant.Config{TenantID: "t1"},
+		Database: &tenant.Database{Conn: db, TenantID: "t1"},
+		Logger:   logger,
+	}
+	return NewDBService(logger, performance.NewTracker(nil)), tenantCtx, db
+}
+
+func TestCheckStatusReportsConnectedOnHealthyConnection(t *testing.T) {
+	dbService, tenantCtx, _ := newDBServiceFixture(t)
+
+	result := dbService.CheckStatus(tenantCtx)
+	if result["connection"] != "connected" {
+		t.Errorf("expected a live connection to report connected, got %v", result)
+	}
+}
+
+func TestCheckStatusReportsDegradedOnClosedConnection(t *testing.T) {
+	dbService, tenantCtx, db := newDBServiceFixture(t)
+
+	// Simulate a mid-request connection drop: the handle is still cached on
+	// the tenant context but the underlying connection is gone.
+	if err := db.Close(); err != nil {
+		t.Fatalf("failed to close test database: %v", err)
+	}
+
+	result := dbService.CheckStatus(tenantCtx)
+	if result["status"] != "degraded" || result["connection"] != "degraded" {
+		t.Errorf("expected a closed connection to report degraded, got %v", result)
+	}
+}
+
+func TestCheckStatusReportsUnavailableWithoutConnection(t *testing.T) {
+	dbService, tenantCtx, _ := newDBServiceFixture(t)
+	tenantCtx.Database.Conn = nil
+
+	result := dbService.CheckStatus(tenantCtx)
+	if result["status"] != "error" || result["connection"] != "unavailable" {
+		t.Errorf("expected a missing connection to report unavailable, got %v", result)
+	}
+}
+
+func TestContextDBReturnsTypedErrorWithoutConnection(t *testing.T) {
+	_, tenantCtx, _ := newDBServiceFixture(t)
+
+	if _, err := tenantCtx.DB(); err != nil {
+		t.Errorf("expected a live context to expose its connection, got %v", err)
+	}
+
+	tenantCtx.Database.Conn = nil
+	if _, err := tenantCtx.DB(); !errors.Is(err, tenant.ErrDatabaseUnavailable) {
+		t.Errorf("expected ErrDatabaseUnavailable for a nil connection, got %v", err)
+	}
+
+	tenantCtx.Database = nil
+	if _, err := tenantCtx.DB(); !errors.Is(err, tenant.ErrDatabaseUnavailable) {
+		t.Errorf("expected ErrDatabaseUnavailable for a nil database, got %v", err)
+	}
+}
+
+func TestSessionErrorStringMapsDatabaseUnavailable(t *testing.T) {
+	wrapped := errors.Join(errors.New("query failed"), tenant.ErrDatabaseUnavailable)
+	if got := sessionErrorString(wrapped, "fallback"); got != "database unavailable" {
+		t.Errorf("expected the retryable error string for a dropped connection, got %q", got)
+	}
+	if got := sessionErrorString(errors.New("boom"), "fallback"); got != "fallback" {
+		t.Errorf("expected the fallback for unrelated errors, got %q", got)
+	}
+}
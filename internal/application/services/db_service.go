@@ -2,6 +2,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -33,15 +34,29 @@ func (d *DBService) CheckStatus(tenantCtx *tenant.Context) map[string]any {
 	}
 
 	// Simple connection test
-	if tenantCtx.Database == nil || tenantCtx.Database.Conn == nil {
+	conn, err := tenantCtx.DB()
+	if err != nil {
 		result["status"] = "error"
+		result["connection"] = "unavailable"
 		result["error"] = "no database connection"
 		return result
 	}
 
+	// Probe the connection itself so a dropped-but-cached handle reports as
+	// degraded rather than connected.
+	pingCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := conn.PingContext(pingCtx); err != nil {
+		result["status"] = "degraded"
+		result["connection"] = "degraded"
+		result["error"] = fmt.Sprintf("connection ping failed: %v", err)
+		return result
+	}
+	result["connection"] = "connected"
+
 	// Test with simple query
 	var testResult int
-	err := tenantCtx.Database.Conn.QueryRow("SELECT 1").Scan(&testResult)
+	err = conn.QueryRow("SELECT 1").Scan(&testResult)
 	if err != nil {
 		result["status"] = "error"
 		result["error"] = fmt.Sprintf("connection test failed: %v", err)
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,15 +13,24 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 )
 
+// VisitorBreakdown splits a visitor count into known (has a lead/profile)
+// and anonymous fingerprints. Only populated when the breakdown filter is on.
+type VisitorBreakdown struct {
+	Known     int `json:"known"`
+	Anonymous int `json:"anonymous"`
+}
+
 type SankeyNode struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Breakdown *VisitorBreakdown `json:"breakdown,omitempty"`
 }
 
 type SankeyLink struct {
-	Source int `json:"source"`
-	Target int `json:"target"`
-	Value  int `json:"value"`
+	Source    int               `json:"source"`
+	Target    int               `json:"target"`
+	Value     int               `json:"value"`
+	Breakdown *VisitorBreakdown `json:"breakdown,omitempty"`
 }
 
 type SankeyDiagram struct {
@@ -34,9 +46,12 @@ type ContentItem struct {
 }
 
 type potentialLink struct {
-	from  string
-	to    string
-	value int
+	from     string
+	to       string
+	fromStep int
+	toStep   int
+	value    int
+	visitors map[string]bool // retained only when a breakdown is requested
 }
 
 type EpinetAnalyticsService struct {
@@ -67,7 +82,12 @@ func (s *EpinetAnalyticsService) ComputeEpinetSankey(tenantCtx *tenant.Context,
 		return nil, err
 	}
 
+	stepWeights := s.getStepWeights(tenantCtx, epinetID)
+
+	withBreakdown := filters != nil && filters.Breakdown
+
 	stepUserSets := make(map[int]map[string]map[string]bool)
+	knownVisitors := make(map[string]bool)
 
 	for _, hourKey := range hourKeys {
 		bin, exists := tenantCtx.CacheManager.GetHourlyEpinetBin(tenantCtx.TenantID, epinetID, hourKey)
@@ -90,39 +110,54 @@ func (s *EpinetAnalyticsService) ComputeEpinetSankey(tenantCtx *tenant.Context,
 					continue
 				}
 				stepUserSets[stepIndex][originalNodeID][visitorID] = true
+				if withBreakdown && stepData.KnownVisitors[visitorID] {
+					knownVisitors[visitorID] = true
+				}
 			}
 		}
 	}
 
+	s.applyGateTypeExclusions(stepUserSets, filters)
+
 	var potentialLinks []potentialLink
 	var stepOrder []int
 	for stepIndex := range stepUserSets {
 		stepOrder = append(stepOrder, stepIndex)
 	}
-
-	for i := 0; i < len(stepOrder); i++ {
-		for j := i + 1; j < len(stepOrder); j++ {
-			sourceStep := stepOrder[i]
-			targetStep := stepOrder[j]
-
-			for sourceNode := range stepUserSets[sourceStep] {
-				for targetNode := range stepUserSets[targetStep] {
-					intersection := s.intersectVisitors(
-						stepUserSets[sourceStep][sourceNode],
-						stepUserSets[targetStep][targetNode],
-					)
-					if len(intersection) > 0 {
-						potentialLinks = append(potentialLinks, potentialLink{
-							from:  sourceNode,
-							to:    targetNode,
-							value: len(intersection),
-						})
+	sort.Ints(stepOrder)
+
+	// Link each step to the next one present, however many steps the epinet
+	// defines. Pairing consecutive steps (rather than every pair) keeps the
+	// diagram a flow: visitors who skipped a step simply don't intersect.
+	for i := 0; i+1 < len(stepOrder); i++ {
+		sourceStep := stepOrder[i]
+		targetStep := stepOrder[i+1]
+
+		for sourceNode := range stepUserSets[sourceStep] {
+			for targetNode := range stepUserSets[targetStep] {
+				intersection := s.intersectVisitors(
+					stepUserSets[sourceStep][sourceNode],
+					stepUserSets[targetStep][targetNode],
+				)
+				if len(intersection) > 0 {
+					plink := potentialLink{
+						from:     sourceNode,
+						to:       targetNode,
+						fromStep: sourceStep,
+						toStep:   targetStep,
+						value:    s.weightedLinkValue(len(intersection), stepWeights[targetStep]),
 					}
+					if withBreakdown {
+						plink.visitors = intersection
+					}
+					potentialLinks = append(potentialLinks, plink)
 				}
 			}
 		}
 	}
 
+	potentialLinks = s.applyMinLinkValue(potentialLinks, filters, withBreakdown)
+
 	nodeSet := make(map[string]bool)
 	for _, plink := range potentialLinks {
 		nodeSet[plink.from] = true
@@ -133,11 +168,17 @@ func (s *EpinetAnalyticsService) ComputeEpinetSankey(tenantCtx *tenant.Context,
 	finalNodeIndexMap := make(map[string]int)
 	for nodeID := range nodeSet {
 		title := nodeID
-		if item, exists := contentItems[s.extractContentIDFromNodeID(nodeID)]; exists {
+		if strings.HasPrefix(nodeID, otherNodePrefix) {
+			title = "Other"
+		} else if item, exists := contentItems[s.extractContentIDFromNodeID(nodeID)]; exists {
 			title = item.Title
 		}
+		node := SankeyNode{ID: nodeID, Name: title}
+		if withBreakdown {
+			node.Breakdown = s.visitorBreakdown(s.nodeVisitorSet(stepUserSets, nodeID), knownVisitors)
+		}
 		finalNodeIndexMap[nodeID] = len(finalNodes)
-		finalNodes = append(finalNodes, SankeyNode{ID: nodeID, Name: title})
+		finalNodes = append(finalNodes, node)
 	}
 
 	var finalLinks []SankeyLink
@@ -146,7 +187,11 @@ func (s *EpinetAnalyticsService) ComputeEpinetSankey(tenantCtx *tenant.Context,
 		targetIndex, targetExists := finalNodeIndexMap[plink.to]
 
 		if sourceExists && targetExists {
-			finalLinks = append(finalLinks, SankeyLink{Source: sourceIndex, Target: targetIndex, Value: plink.value})
+			link := SankeyLink{Source: sourceIndex, Target: targetIndex, Value: plink.value}
+			if withBreakdown {
+				link.Breakdown = s.visitorBreakdown(plink.visitors, knownVisitors)
+			}
+			finalLinks = append(finalLinks, link)
 		}
 	}
 
@@ -162,6 +207,264 @@ func (s *EpinetAnalyticsService) ComputeEpinetSankey(tenantCtx *tenant.Context,
 	}, nil
 }
 
+// otherNodePrefix marks the synthetic per-step aggregate nodes produced when
+// CollapseOthers folds filtered-out traffic together instead of dropping it.
+const otherNodePrefix = "other-"
+
+// nodeGateType extracts the gate type from a node ID. Node IDs are built by
+// the warming pass as gateType-qualified segments, so the first segment is
+// always the gate type ("belief", "identifyAs", "commitmentAction", ...).
+func (s *EpinetAnalyticsService) nodeGateType(nodeID string) string {
+	if idx := strings.Index(nodeID, "-"); idx > 0 {
+		return nodeID[:idx]
+	}
+	return nodeID
+}
+
+// applyGateTypeExclusions removes nodes whose gate type is excluded by the
+// filters. With CollapseOthers their visitors merge into a per-step "Other"
+// node so step totals are preserved; without it the traffic disappears.
+func (s *EpinetAnalyticsService) applyGateTypeExclusions(stepUserSets map[int]map[string]map[string]bool, filters *SankeyFilters) {
+	if filters == nil || len(filters.ExcludeGateTypes) == 0 {
+		return
+	}
+
+	excluded := make(map[string]bool, len(filters.ExcludeGateTypes))
+	for _, gateType := range filters.ExcludeGateTypes {
+		excluded[gateType] = true
+	}
+
+	for stepIndex, nodes := range stepUserSets {
+		for nodeID, visitors := range nodes {
+			if !excluded[s.nodeGateType(nodeID)] {
+				continue
+			}
+			delete(nodes, nodeID)
+			if filters.CollapseOthers {
+				otherID := fmt.Sprintf("%s%d", otherNodePrefix, stepIndex)
+				if nodes[otherID] == nil {
+					nodes[otherID] = make(map[string]bool)
+				}
+				for visitorID := range visitors {
+					nodes[otherID][visitorID] = true
+				}
+			}
+		}
+	}
+}
+
+// applyMinLinkValue enforces the MinLinkValue filter. Sub-threshold links are
+// dropped outright, or — with CollapseOthers — re-pointed at per-step "Other"
+// nodes and merged, so the sum of link values between any two steps is
+// unchanged while the diagram sheds its one-visitor noise.
+func (s *EpinetAnalyticsService) applyMinLinkValue(links []potentialLink, filters *SankeyFilters, withBreakdown bool) []potentialLink {
+	if filters == nil || filters.MinLinkValue <= 0 {
+		return links
+	}
+
+	if !filters.CollapseOthers {
+		var kept []potentialLink
+		for _, plink := range links {
+			if plink.value >= filters.MinLinkValue {
+				kept = append(kept, plink)
+			}
+		}
+		return kept
+	}
+
+	merged := make(map[string]*potentialLink)
+	var order []string
+	for _, plink := range links {
+		if plink.value < filters.MinLinkValue {
+			plink.from = fmt.Sprintf("%s%d", otherNodePrefix, plink.fromStep)
+			plink.to = fmt.Sprintf("%s%d", otherNodePrefix, plink.toStep)
+		}
+		key := plink.from + "|" + plink.to
+		if existing, ok := merged[key]; ok {
+			existing.value += plink.value
+			if withBreakdown {
+				for visitorID := range plink.visitors {
+					existing.visitors[visitorID] = true
+				}
+			}
+			continue
+		}
+		copied := plink
+		merged[key] = &copied
+		order = append(order, key)
+	}
+
+	collapsed := make([]potentialLink, 0, len(order))
+	for _, key := range order {
+		collapsed = append(collapsed, *merged[key])
+	}
+	return collapsed
+}
+
+// FunnelStep reports the unique visitors who reached one ordered step of an
+// epinet, plus retention relative to the preceding step.
+type FunnelStep struct {
+	StepIndex    int     `json:"stepIndex"`
+	Title        string  `json:"title"`
+	Visitors     int     `json:"visitors"`
+	RetentionPct float64 `json:"retentionPct"`
+	DropOffPct   float64 `json:"dropOffPct"`
+}
+
+type Funnel struct {
+	ID    string       `json:"id"`
+	Title string       `json:"title"`
+	Steps []FunnelStep `json:"steps"`
+}
+
+// ComputeFunnel collapses the Sankey aggregation into a step-by-step view:
+// unique visitors per ordered step, with retention measured against the set
+// of visitors who reached the previous step. It honors the same filters as
+// ComputeEpinetSankey.
+func (s *EpinetAnalyticsService) ComputeFunnel(tenantCtx *tenant.Context, epinetID string, filters *SankeyFilters) (*Funnel, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("compute_epinet_funnel", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	var hourKeys []string
+	if filters != nil && filters.StartHour != nil && filters.EndHour != nil {
+		hourKeys = s.getHourKeysForCustomRange(*filters.StartHour, *filters.EndHour)
+	} else {
+		hourKeys = s.getHourKeysForTimeRange(168)
+	}
+
+	// Union visitors across every node at a step; the funnel doesn't care
+	// which node within the step a visitor hit.
+	stepVisitors := make(map[int]map[string]bool)
+	for _, hourKey := range hourKeys {
+		bin, exists := tenantCtx.CacheManager.GetHourlyEpinetBin(tenantCtx.TenantID, epinetID, hourKey)
+		if !exists {
+			continue
+		}
+		for _, stepData := range bin.Data.Steps {
+			if stepVisitors[stepData.StepIndex] == nil {
+				stepVisitors[stepData.StepIndex] = make(map[string]bool)
+			}
+			for visitorID := range stepData.Visitors {
+				if !s.shouldIncludeVisitor(visitorID, filters, stepData) {
+					continue
+				}
+				stepVisitors[stepData.StepIndex][visitorID] = true
+			}
+		}
+	}
+
+	stepTitles := s.getStepTitles(tenantCtx, epinetID)
+
+	var stepOrder []int
+	for stepIndex := range stepVisitors {
+		stepOrder = append(stepOrder, stepIndex)
+	}
+	sort.Ints(stepOrder)
+
+	funnel := &Funnel{ID: epinetID, Title: "Conversion Funnel"}
+	for i, stepIndex := range stepOrder {
+		step := FunnelStep{
+			StepIndex:    stepIndex,
+			Title:        stepTitles[stepIndex],
+			Visitors:     len(stepVisitors[stepIndex]),
+			RetentionPct: 100,
+		}
+		if i > 0 {
+			previous := stepVisitors[stepOrder[i-1]]
+			retained := len(s.intersectVisitors(previous, stepVisitors[stepIndex]))
+			if len(previous) > 0 {
+				step.RetentionPct = roundPct(float64(retained) / float64(len(previous)) * 100)
+			} else {
+				step.RetentionPct = 0
+			}
+			step.DropOffPct = roundPct(100 - step.RetentionPct)
+		}
+		funnel.Steps = append(funnel.Steps, step)
+	}
+
+	s.logger.Analytics().Info("Successfully computed epinet funnel", "tenantId", tenantCtx.TenantID, "epinetId", epinetID, "stepCount", len(funnel.Steps), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for ComputeFunnel", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	return funnel, nil
+}
+
+// getStepTitles maps 1-based step indexes to their configured titles.
+func (s *EpinetAnalyticsService) getStepTitles(tenantCtx *tenant.Context, epinetID string) map[int]string {
+	titles := make(map[int]string)
+	epinet, err := tenantCtx.EpinetRepo().FindByID(tenantCtx.TenantID, epinetID)
+	if err != nil || epinet == nil {
+		return titles
+	}
+	for i, step := range epinet.Steps {
+		if step != nil {
+			titles[i+1] = step.Title
+		}
+	}
+	return titles
+}
+
+// roundPct keeps percentages to one decimal place for stable JSON output.
+func roundPct(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// getStepWeights maps 1-based step indexes to their configured weights.
+// Steps without a positive weight are omitted and count as 1 (unweighted).
+func (s *EpinetAnalyticsService) getStepWeights(tenantCtx *tenant.Context, epinetID string) map[int]float64 {
+	weights := make(map[int]float64)
+	epinet, err := tenantCtx.EpinetRepo().FindByID(tenantCtx.TenantID, epinetID)
+	if err != nil || epinet == nil {
+		return weights
+	}
+	for i, step := range epinet.Steps {
+		if step != nil && step.Weight != nil && *step.Weight > 0 {
+			weights[i+1] = *step.Weight
+		}
+	}
+	return weights
+}
+
+// weightedLinkValue scales a visitor count by the target step's weight,
+// keeping at least 1 so down-weighted links stay visible in the Sankey.
+func (s *EpinetAnalyticsService) weightedLinkValue(visitorCount int, weight float64) int {
+	if weight <= 0 || weight == 1 {
+		return visitorCount
+	}
+	value := int(math.Round(float64(visitorCount) * weight))
+	if value < 1 {
+		value = 1
+	}
+	return value
+}
+
+// nodeVisitorSet merges a node's visitor sets across every step it appears
+// in, so its breakdown covers all visits rather than a single step's.
+func (s *EpinetAnalyticsService) nodeVisitorSet(stepUserSets map[int]map[string]map[string]bool, nodeID string) map[string]bool {
+	visitors := make(map[string]bool)
+	for _, nodeSets := range stepUserSets {
+		for visitorID := range nodeSets[nodeID] {
+			visitors[visitorID] = true
+		}
+	}
+	return visitors
+}
+
+// visitorBreakdown splits a visitor set into known and anonymous counts; the
+// two always sum to the set size.
+func (s *EpinetAnalyticsService) visitorBreakdown(visitors, knownVisitors map[string]bool) *VisitorBreakdown {
+	breakdown := &VisitorBreakdown{}
+	for visitorID := range visitors {
+		if knownVisitors[visitorID] {
+			breakdown.Known++
+		} else {
+			breakdown.Anonymous++
+		}
+	}
+	return breakdown
+}
+
 func (s *EpinetAnalyticsService) intersectVisitors(set1, set2 map[string]bool) map[string]bool {
 	intersection := make(map[string]bool)
 	for visitor := range set1 {
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newEpinetAnalyticsFixture builds an epinet analytics service and a tenant
+// context backed by an empty in-memory database, so synthetic hourly bins in
+// the cache are the only analytics input.
+func newEpinetAnalyticsFixture(t *testing.T) (*EpinetAnalyticsService, *tenant.Context) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	return NewEpinetAnalyticsService(logger, performance.NewTracker(nil)), tenantCtx
+}
+
+// lastHourKey returns the bin key for one hour ago, safely inside every
+// default analytics range.
+func lastHourKey() string {
+	return time.Now().UTC().Add(-time.Hour).Format("2006-01-02-15")
+}
+
+// visitorSet builds a visitor ID set from a list.
+func visitorSet(ids ...string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// seedEpinetBin stores a synthetic hourly bin whose steps map node IDs to
+// visitor sets, with the given subset flagged as known visitors.
+func seedEpinetBin(tenantCtx *tenant.Context, epinetID, hourKey string, steps map[string]*types.HourlyEpinetStepData) {
+	tenantCtx.CacheManager.SetHourlyEpinetBin(tenantCtx.TenantID, epinetID, hourKey, &types.HourlyEpinetBin{
+		Data:       &types.HourlyEpinetData{Steps: steps},
+		ComputedAt: time.Now().UTC(),
+	})
+}
+
+func TestSankeyBreakdownSplitSumsToTotal(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	known := visitorSet("v1", "v2")
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate": {
+			StepIndex:     1,
+			Visitors:      visitorSet("v1", "v2", "v3", "v4"),
+			KnownVisitors: known,
+		},
+		"commitmentAction_read": {
+			StepIndex:     2,
+			Visitors:      visitorSet("v1", "v2", "v3"),
+			KnownVisitors: known,
+		},
+	})
+
+	diagram, err := service.ComputeEpinetSankey(tenantCtx, "ep1", &SankeyFilters{Breakdown: true})
+	if err != nil {
+		t.Fatalf("ComputeEpinetSankey failed: %v", err)
+	}
+	if len(diagram.Links) != 1 {
+		t.Fatalf("expected 1 link between the two steps, got %d", len(diagram.Links))
+	}
+
+	link := diagram.Links[0]
+	if link.Breakdown == nil {
+		t.Fatal("expected the link to carry a breakdown")
+	}
+	if link.Breakdown.Known+link.Breakdown.Anonymous != link.Value {
+		t.Errorf("expected the link split %d+%d to sum to its value %d", link.Breakdown.Known, link.Breakdown.Anonymous, link.Value)
+	}
+	if link.Breakdown.Known != 2 || link.Breakdown.Anonymous != 1 {
+		t.Errorf("expected a 2 known / 1 anonymous split on the step transition, got %+v", link.Breakdown)
+	}
+
+	for _, node := range diagram.Nodes {
+		if node.Breakdown == nil {
+			t.Fatalf("expected node %s to carry a breakdown", node.ID)
+		}
+		total := node.Breakdown.Known + node.Breakdown.Anonymous
+		switch node.ID {
+		case "belief-gate":
+			if node.Breakdown.Known != 2 || total != 4 {
+				t.Errorf("expected belief-gate to split 2 known of 4, got %+v", node.Breakdown)
+			}
+		case "commitmentAction-read":
+			if node.Breakdown.Known != 2 || total != 3 {
+				t.Errorf("expected commitmentAction-read to split 2 known of 3, got %+v", node.Breakdown)
+			}
+		default:
+			t.Errorf("unexpected node %s in the diagram", node.ID)
+		}
+	}
+}
+
+func TestSankeyBreakdownDefaultsOff(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate":           {StepIndex: 1, Visitors: visitorSet("v1", "v2")},
+		"commitmentAction_read": {StepIndex: 2, Visitors: visitorSet("v1")},
+	})
+
+	diagram, err := service.ComputeEpinetSankey(tenantCtx, "ep1", nil)
+	if err != nil {
+		t.Fatalf("ComputeEpinetSankey failed: %v", err)
+	}
+	for _, node := range diagram.Nodes {
+		if node.Breakdown != nil {
+			t.Errorf("expected no node breakdown without the filter, got %+v on %s", node.Breakdown, node.ID)
+		}
+	}
+	for _, link := range diagram.Links {
+		if link.Breakdown != nil {
+			t.Errorf("expected no link breakdown without the filter, got %+v", link.Breakdown)
+		}
+	}
+}
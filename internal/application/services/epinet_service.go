@@ -4,6 +4,7 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
@@ -99,6 +100,42 @@ func (s *EpinetService) GetByIDs(tenantCtx *tenant.Context, ids []string) ([]*co
 	return epinets, nil
 }
 
+// validateStepObjectReferences verifies that every step's object IDs point at
+// content that exists for the supported object types, collecting dangling IDs
+// into a single ErrMissingReferences error.
+func (s *EpinetService) validateStepObjectReferences(tenantCtx *tenant.Context, epinet *content.EpinetNode) error {
+	var missing []string
+	for _, step := range epinet.Steps {
+		if step == nil || step.ObjectType == nil || len(step.ObjectIDs) == 0 {
+			continue
+		}
+		for _, objectID := range step.ObjectIDs {
+			switch *step.ObjectType {
+			case "StoryFragment":
+				sf, err := tenantCtx.StoryFragmentRepo().FindByID(tenantCtx.TenantID, objectID)
+				if err != nil {
+					return fmt.Errorf("failed to verify storyfragment %s: %w", objectID, err)
+				}
+				if sf == nil {
+					missing = append(missing, "storyfragment:"+objectID)
+				}
+			case "Pane":
+				pane, err := tenantCtx.PaneRepo().FindByID(tenantCtx.TenantID, objectID)
+				if err != nil {
+					return fmt.Errorf("failed to verify pane %s: %w", objectID, err)
+				}
+				if pane == nil {
+					missing = append(missing, "pane:"+objectID)
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingReferences, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // Create creates a new epinet
 func (s *EpinetService) Create(tenantCtx *tenant.Context, epinet *content.EpinetNode) error {
 	start := time.Now()
@@ -113,6 +150,9 @@ func (s *EpinetService) Create(tenantCtx *tenant.Context, epinet *content.Epinet
 	if epinet.Title == "" {
 		return fmt.Errorf("epinet title cannot be empty")
 	}
+	if err := s.validateStepObjectReferences(tenantCtx, epinet); err != nil {
+		return err
+	}
 
 	epinetRepo := tenantCtx.EpinetRepo()
 	err := epinetRepo.Store(tenantCtx.TenantID, epinet)
@@ -160,6 +200,10 @@ func (s *EpinetService) Update(tenantCtx *tenant.Context, epinet *content.Epinet
 		return fmt.Errorf("epinet %s not found", epinet.ID)
 	}
 
+	if err := s.validateStepObjectReferences(tenantCtx, epinet); err != nil {
+		return err
+	}
+
 	err = epinetRepo.Update(tenantCtx.TenantID, epinet)
 	if err != nil {
 		return fmt.Errorf("failed to update epinet %s: %w", epinet.ID, err)
@@ -218,3 +262,119 @@ func (s *EpinetService) Delete(tenantCtx *tenant.Context, id string) error {
 
 	return nil
 }
+
+// EpinetExport is the portable representation of an epinet's configuration.
+// It carries everything needed to recreate the epinet except its ID, so a
+// funnel definition can be backed up or copied between tenants.
+type EpinetExport struct {
+	Title    string                `json:"title"`
+	Promoted bool                  `json:"promoted"`
+	Steps    []*content.EpinetStep `json:"steps"`
+}
+
+// epinetGateTypes enumerates the step gate types the analytics pipeline
+// understands; imports with anything else are rejected up front.
+var epinetGateTypes = map[string]bool{
+	"belief":           true,
+	"identifyAs":       true,
+	"commitmentAction": true,
+	"conversionAction": true,
+}
+
+// validateEpinetExport checks an export payload before it is turned into a
+// new epinet, mirroring the checks Create applies plus per-step gate types.
+func validateEpinetExport(export *EpinetExport) error {
+	if export == nil {
+		return fmt.Errorf("epinet export cannot be nil")
+	}
+	if export.Title == "" {
+		return fmt.Errorf("epinet title cannot be empty")
+	}
+	for i, step := range export.Steps {
+		if step == nil {
+			return fmt.Errorf("epinet step %d cannot be null", i)
+		}
+		if !epinetGateTypes[step.GateType] {
+			return fmt.Errorf("epinet step %d has unknown gateType %q", i, step.GateType)
+		}
+	}
+	return nil
+}
+
+// copyEpinetSteps deep-copies a step list so exports and imports never alias
+// cached nodes.
+func copyEpinetSteps(steps []*content.EpinetStep) []*content.EpinetStep {
+	copied := make([]*content.EpinetStep, 0, len(steps))
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		stepCopy := *step
+		stepCopy.Values = append([]string(nil), step.Values...)
+		stepCopy.ObjectIDs = append([]string(nil), step.ObjectIDs...)
+		copied = append(copied, &stepCopy)
+	}
+	return copied
+}
+
+// Export returns an epinet's full step configuration as a portable export.
+// Returns nil when the epinet does not exist.
+func (s *EpinetService) Export(tenantCtx *tenant.Context, id string) (*EpinetExport, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("export_epinet", tenantCtx.TenantID)
+	defer marker.Complete()
+	if id == "" {
+		return nil, fmt.Errorf("epinet ID cannot be empty")
+	}
+
+	epinetRepo := tenantCtx.EpinetRepo()
+	epinet, err := epinetRepo.FindByID(tenantCtx.TenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epinet %s for export: %w", id, err)
+	}
+	if epinet == nil {
+		return nil, nil
+	}
+
+	export := &EpinetExport{
+		Title:    epinet.Title,
+		Promoted: epinet.Promoted,
+		Steps:    copyEpinetSteps(epinet.Steps),
+	}
+
+	s.logger.Content().Info("Successfully exported epinet", "tenantId", tenantCtx.TenantID, "epinetId", id, "stepCount", len(export.Steps), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for ExportEpinet", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "epinetId", id)
+
+	return export, nil
+}
+
+// Import validates a portable export and creates a new epinet from it under a
+// freshly generated ID.
+func (s *EpinetService) Import(tenantCtx *tenant.Context, export *EpinetExport) (*content.EpinetNode, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("import_epinet", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if err := validateEpinetExport(export); err != nil {
+		return nil, fmt.Errorf("invalid epinet export: %w", err)
+	}
+
+	epinet := &content.EpinetNode{
+		ID:       security.GenerateULID(),
+		NodeType: "Epinet",
+		Title:    export.Title,
+		Promoted: export.Promoted,
+		Steps:    copyEpinetSteps(export.Steps),
+	}
+
+	if err := s.Create(tenantCtx, epinet); err != nil {
+		return nil, fmt.Errorf("failed to import epinet: %w", err)
+	}
+
+	s.logger.Content().Info("Successfully imported epinet", "tenantId", tenantCtx.TenantID, "epinetId", epinet.ID, "title", epinet.Title, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for ImportEpinet", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "epinetId", epinet.ID)
+
+	return epinet, nil
+}
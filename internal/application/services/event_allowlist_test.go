@@ -0,0 +1,77 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	domainEvents "github.com/AtRiskMedia/tractstack-go/internal/domain/events"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+)
+
+func newAllowlistFixture(t *testing.T, verbs, objects []string) (*EventProcessingService, *tenant.Context) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	tenantCtx := &tenant.Context{
+		TenantID: "t1",
+		Config: &tenant.Config{
+			TenantID:             "t1",
+			AllowedEventVerbs:    verbs,
+			AllowedBeliefObjects: objects,
+		},
+		Logger: logger,
+	}
+	return NewEventProcessingService(nil, nil, logger), tenantCtx
+}
+
+func TestDisallowedEventsAcceptsAllWithoutAllowlist(t *testing.T) {
+	service, tenantCtx := newAllowlistFixture(t, nil, nil)
+
+	events := []domainEvents.Event{
+		{ID: "pane-1", Type: "Pane", Verb: "ANYTHING_GOES"},
+		{ID: "b-1", Type: "Belief", Verb: "IDENTIFY_AS", Object: "Whoever"},
+	}
+	if rejected := service.DisallowedEvents(tenantCtx, events); rejected != nil {
+		t.Errorf("expected an unconfigured allowlist to accept everything, got %v", rejected)
+	}
+}
+
+func TestDisallowedEventsRejectsUnknownVerbs(t *testing.T) {
+	service, tenantCtx := newAllowlistFixture(t, []string{"PAGEVIEWED", "READ"}, nil)
+
+	events := []domainEvents.Event{
+		{ID: "pane-1", Type: "Pane", Verb: "READ"},
+		{ID: "pane-2", Type: "Pane", Verb: "JUNKVERB"},
+		{ID: "pane-3", Type: "Pane", Verb: "ANOTHERJUNK"},
+	}
+	rejected := service.DisallowedEvents(tenantCtx, events)
+	if len(rejected) != 2 {
+		t.Fatalf("expected the two junk verbs rejected, got %v", rejected)
+	}
+	for i, want := range []string{"JUNKVERB", "ANOTHERJUNK"} {
+		if !strings.Contains(rejected[i], want) {
+			t.Errorf("expected rejection %d to name %s, got %q", i, want, rejected[i])
+		}
+	}
+}
+
+func TestDisallowedEventsGatesBeliefObjects(t *testing.T) {
+	service, tenantCtx := newAllowlistFixture(t, []string{"IDENTIFY_AS"}, []string{"Member"})
+
+	events := []domainEvents.Event{
+		{ID: "b-1", Type: "Belief", Verb: "IDENTIFY_AS", Object: "Member"},
+		{ID: "b-2", Type: "Belief", Verb: "IDENTIFY_AS", Object: "Imposter"},
+	}
+	rejected := service.DisallowedEvents(tenantCtx, events)
+	if len(rejected) != 1 || !strings.Contains(rejected[0], "Imposter") {
+		t.Errorf("expected only the unrecognized belief object rejected, got %v", rejected)
+	}
+}
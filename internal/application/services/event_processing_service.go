@@ -13,6 +13,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 // EventProcessingService contains the business logic for handling events.
@@ -35,6 +36,32 @@ func NewEventProcessingService(
 	}
 }
 
+// DisallowedEvents checks incoming events against the tenant's ingestion
+// allowlist and returns a description of each event that should be rejected.
+// An unconfigured allowlist accepts everything, so existing tenants are
+// unaffected. ALLOWED_EVENT_VERBS gates every event's verb; when
+// ALLOWED_BELIEF_OBJECTS is also set, IDENTIFY_AS belief events must name a
+// recognized object.
+func (s *EventProcessingService) DisallowedEvents(tenantCtx *tenant.Context, events []domainEvents.Event) []string {
+	allowedVerbs := tenantCtx.Config.AllowedEventVerbs
+	allowedObjects := tenantCtx.Config.AllowedBeliefObjects
+	if len(allowedVerbs) == 0 && len(allowedObjects) == 0 {
+		return nil
+	}
+
+	var rejected []string
+	for _, event := range events {
+		if len(allowedVerbs) > 0 && !slices.Contains(allowedVerbs, event.Verb) {
+			rejected = append(rejected, fmt.Sprintf("verb %q", event.Verb))
+			continue
+		}
+		if len(allowedObjects) > 0 && event.Type == "Belief" && event.Verb == "IDENTIFY_AS" && !slices.Contains(allowedObjects, event.Object) {
+			rejected = append(rejected, fmt.Sprintf("belief object %q", event.Object))
+		}
+	}
+	return rejected
+}
+
 // ProcessEventsWithSSE is the main entry point for processing events with SSE broadcasting.
 func (s *EventProcessingService) ProcessEventsWithSSE(
 	tenantCtx *tenant.Context,
@@ -80,6 +107,11 @@ func (s *EventProcessingService) ProcessEventsWithSSE(
 				continue
 			}
 
+			if !analytics.SampledFingerprint(sessionData.FingerprintID, config.AnalyticsSampleRate) {
+				s.logger.Analytics().Debug("Pane event dropped by ingestion sampling", "fingerprintId", sessionData.FingerprintID, "sampleRate", config.AnalyticsSampleRate)
+				continue
+			}
+
 			durationMs, _ := strconv.Atoi(event.Object)
 
 			actionEvent := &analytics.ActionEvent{
@@ -112,6 +144,11 @@ func (s *EventProcessingService) ProcessEventsWithSSE(
 				continue
 			}
 
+			if !analytics.SampledFingerprint(sessionData.FingerprintID, config.AnalyticsSampleRate) {
+				s.logger.Analytics().Debug("Impression event dropped by ingestion sampling", "fingerprintId", sessionData.FingerprintID, "sampleRate", config.AnalyticsSampleRate)
+				continue
+			}
+
 			actionEvent := &analytics.ActionEvent{
 				ObjectID:      event.ID,
 				ObjectType:    event.Type,
@@ -144,6 +181,11 @@ func (s *EventProcessingService) ProcessEventsWithSSE(
 					continue
 				}
 
+				if !analytics.SampledFingerprint(sessionData.FingerprintID, config.AnalyticsSampleRate) {
+					s.logger.Analytics().Debug("StoryFragment event dropped by ingestion sampling", "fingerprintId", sessionData.FingerprintID, "sampleRate", config.AnalyticsSampleRate)
+					continue
+				}
+
 				actionEvent := &analytics.ActionEvent{
 					ObjectID:      event.ID,
 					ObjectType:    event.Type,
@@ -179,6 +221,106 @@ func (s *EventProcessingService) ProcessEventsWithSSE(
 	return nil
 }
 
+// BatchEventResult reports the outcome of one event in a batch, keyed by its
+// index in the submitted array so clients know exactly which events failed.
+type BatchEventResult struct {
+	Index   int    `json:"index"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ProcessEventBatchWithSSE processes a batch of events, validating and
+// applying each one independently so a bad event fails alone instead of
+// aborting the batch. Belief changes accumulated across the whole batch
+// trigger a single broadcast at the end, so connected clients see one
+// belief-diff per request no matter how many events it carried.
+func (s *EventProcessingService) ProcessEventBatchWithSSE(
+	tenantCtx *tenant.Context,
+	sessionID string,
+	storyfragmentID string,
+	events []domainEvents.Event,
+	currentPaneID string,
+	gotoPaneID string,
+	broadcaster messaging.Broadcaster,
+) []BatchEventResult {
+	results := make([]BatchEventResult, len(events))
+	var changedBeliefs []string
+	visibilitySnapshot := s.captureVisibilitySnapshot(tenantCtx, sessionID, events)
+
+	for i, event := range events {
+		results[i].Index = i
+
+		if rejected := s.DisallowedEvents(tenantCtx, []domainEvents.Event{event}); len(rejected) > 0 {
+			results[i].Error = "event not accepted: " + rejected[0]
+			continue
+		}
+
+		var err error
+		switch event.Type {
+		case "Belief":
+			var changed bool
+			changed, err = s.processBelief(tenantCtx, sessionID, event)
+			if err == nil && changed {
+				changedBeliefs = append(changedBeliefs, event.ID)
+			}
+		case "Pane", "Impression", "StoryFragment":
+			err = s.storeAnalyticsEvent(tenantCtx, sessionID, event)
+		default:
+			err = fmt.Errorf("unknown event type: %s", event.Type)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Success = true
+	}
+
+	if len(changedBeliefs) > 0 {
+		s.beliefBroadcaster.BroadcastBeliefChange(tenantCtx.TenantID, sessionID, storyfragmentID, changedBeliefs, visibilitySnapshot, currentPaneID, gotoPaneID, broadcaster)
+	}
+
+	return results
+}
+
+// storeAnalyticsEvent persists a Pane, Impression, or StoryFragment action
+// event. Sampling drops and non-analytics StoryFragment verbs are not errors.
+func (s *EventProcessingService) storeAnalyticsEvent(tenantCtx *tenant.Context, sessionID string, event domainEvents.Event) error {
+	if event.Type == "StoryFragment" && event.Verb != "PAGEVIEWED" && event.Verb != "ENTERED" {
+		return nil
+	}
+
+	sessionData, exists := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID)
+	if !exists {
+		return fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if !analytics.SampledFingerprint(sessionData.FingerprintID, config.AnalyticsSampleRate) {
+		s.logger.Analytics().Debug("Event dropped by ingestion sampling", "fingerprintId", sessionData.FingerprintID, "sampleRate", config.AnalyticsSampleRate)
+		return nil
+	}
+
+	durationMs := 0
+	if event.Type == "Pane" {
+		durationMs, _ = strconv.Atoi(event.Object)
+	}
+
+	actionEvent := &analytics.ActionEvent{
+		ObjectID:      event.ID,
+		ObjectType:    event.Type,
+		Verb:          event.Verb,
+		FingerprintID: sessionData.FingerprintID,
+		VisitID:       sessionData.VisitID,
+		Duration:      durationMs,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	eventRepo := tenantCtx.EventRepo()
+	if err := eventRepo.StoreActionEvent(actionEvent); err != nil {
+		return fmt.Errorf("failed to store %s action event: %w", event.Type, err)
+	}
+	return nil
+}
+
 func (s *EventProcessingService) captureVisibilitySnapshot(tenantCtx *tenant.Context, sessionID string, events []domainEvents.Event) map[string]map[string]bool {
 	snapshot := make(map[string]map[string]bool)
 	cacheManager := tenantCtx.CacheManager
@@ -0,0 +1,108 @@
+// Package services provides application-level services that orchestrate
+// business logic and coordinate between repositories and domain entities.
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+)
+
+// ExternalIDService orchestrates external ID mapping operations so content
+// imported from other systems keeps its original identifiers resolvable.
+type ExternalIDService struct {
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewExternalIDService creates a new external ID service singleton
+func NewExternalIDService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *ExternalIDService {
+	return &ExternalIDService{
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// LookupNode resolves a (system, externalId) pair to our node ID and type
+// (cache-first via repository).
+func (s *ExternalIDService) LookupNode(tenantCtx *tenant.Context, system, externalID string) (*content.ExternalIDMapping, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("lookup_external_id", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if system == "" || externalID == "" {
+		return nil, fmt.Errorf("system and externalId are required")
+	}
+
+	externalIDRepo := tenantCtx.ExternalIDRepo()
+	mapping, err := externalIDRepo.FindByExternalID(tenantCtx.TenantID, system, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup external ID %s/%s: %w", system, externalID, err)
+	}
+
+	s.logger.Content().Info("External ID lookup completed",
+		"tenantId", tenantCtx.TenantID,
+		"system", system,
+		"found", mapping != nil,
+		"duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return mapping, nil
+}
+
+// SaveMappings persists a node's external IDs ({system: externalId}) and keeps
+// the in-memory index current. Used by create and import paths so repeated
+// syncs of the same content do not duplicate mappings.
+func (s *ExternalIDService) SaveMappings(tenantCtx *tenant.Context, nodeID, nodeType string, externalIDs map[string]string) error {
+	if len(externalIDs) == 0 {
+		return nil
+	}
+
+	externalIDRepo := tenantCtx.ExternalIDRepo()
+	for system, externalID := range externalIDs {
+		if system == "" || externalID == "" {
+			return fmt.Errorf("external ID mappings require a non-empty system and externalId")
+		}
+		mapping := &content.ExternalIDMapping{
+			NodeID:     nodeID,
+			NodeType:   nodeType,
+			System:     system,
+			ExternalID: externalID,
+		}
+		if err := externalIDRepo.Upsert(tenantCtx.TenantID, mapping); err != nil {
+			return fmt.Errorf("failed to save external ID mapping for node %s: %w", nodeID, err)
+		}
+	}
+
+	s.logger.Content().Info("External ID mappings saved",
+		"tenantId", tenantCtx.TenantID,
+		"nodeId", nodeID,
+		"count", len(externalIDs))
+	return nil
+}
+
+// GetAllMappingsByNode returns every mapping grouped as nodeId ->
+// {system: externalId}, used when the content map is requested with
+// includeExternalIds=true. This also warms the in-memory index.
+func (s *ExternalIDService) GetAllMappingsByNode(tenantCtx *tenant.Context) (map[string]map[string]string, error) {
+	externalIDRepo := tenantCtx.ExternalIDRepo()
+	mappings, err := externalIDRepo.FindAll(tenantCtx.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load external ID mappings: %w", err)
+	}
+
+	tenantCtx.CacheManager.LoadExternalIDMappings(tenantCtx.TenantID, mappings)
+
+	byNode := make(map[string]map[string]string)
+	for _, mapping := range mappings {
+		if byNode[mapping.NodeID] == nil {
+			byNode[mapping.NodeID] = make(map[string]string)
+		}
+		byNode[mapping.NodeID][mapping.System] = mapping.ExternalID
+	}
+	return byNode, nil
+}
@@ -4,6 +4,8 @@ package services
 
 import (
 	"fmt"
+	"html"
+	"sync"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/beliefs"
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
@@ -14,6 +16,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/templates"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 // FragmentService orchestrates fragment generation with personalization
@@ -155,16 +158,45 @@ func (s *FragmentService) GenerateFragmentBatch(
 		s.logger.Content().Debug("🔍 BATCH WIDGET CONTEXT RESOLVED", "sessionId", sessionID)
 	}
 
-	// Process each pane
-	for _, paneID := range paneIDs {
-		html, err := s.generateSingleFragment(
-			tenantCtx, paneID, sessionID, storyfragmentID, beliefRegistry, widgetCtx,
-		)
-		if err != nil {
-			errors[paneID] = err.Error()
-			continue
+	// Render panes with bounded concurrency so one large page cannot starve
+	// other requests; with a limit of 1 this degrades to the sequential path.
+	// Each pane is keyed into the result maps, so assembly order is unaffected.
+	workers := max(1, config.PaneRenderConcurrency)
+	if workers == 1 || len(paneIDs) <= 1 {
+		for _, paneID := range paneIDs {
+			html, err := s.generateSingleFragment(
+				tenantCtx, paneID, sessionID, storyfragmentID, beliefRegistry, widgetCtx,
+			)
+			if err != nil {
+				errors[paneID] = err.Error()
+				continue
+			}
+			results[paneID] = html
+		}
+	} else {
+		sem := make(chan struct{}, workers)
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, paneID := range paneIDs {
+			wg.Add(1)
+			go func(paneID string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				html, err := s.generateSingleFragment(
+					tenantCtx, paneID, sessionID, storyfragmentID, beliefRegistry, widgetCtx,
+				)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errors[paneID] = err.Error()
+					return
+				}
+				results[paneID] = html
+			}(paneID)
 		}
-		results[paneID] = html
+		wg.Wait()
 	}
 
 	s.logger.Content().Debug("🔍 BATCH GENERATE COMPLETE", "successCount", len(results), "errorCount", len(errors))
@@ -278,8 +310,19 @@ func (s *FragmentService) getCachedOrGenerateHTML(tenantCtx *tenant.Context, pan
 	// Generate fresh HTML and cache it
 	htmlContent, err := s.generateBaseHTML(tenantCtx, pane)
 	if err != nil {
-		return "", err
+		if !config.PaneFallbackRender {
+			return "", err
+		}
+		// Serve a placeholder instead of breaking the page; cache it briefly
+		// so each request doesn't retry the broken render, and flag the pane
+		// for the admin render-failure list.
+		s.logger.Content().Error("Pane render failed, serving fallback", "paneId", pane.ID, "error", err.Error())
+		cacheManager.RecordPaneRenderFailure(tenantCtx.TenantID, pane.ID, err.Error())
+		fallback := s.renderPaneFallback(pane)
+		cacheManager.SetHTMLChunkWithTTL(tenantCtx.TenantID, pane.ID, variant, fallback, []string{pane.ID}, config.PaneFallbackTTL)
+		return fallback, nil
 	}
+	cacheManager.ClearPaneRenderFailure(tenantCtx.TenantID, pane.ID)
 
 	s.logger.Content().Debug("🔍 BASE HTML GENERATED", "paneId", pane.ID, "htmlLength", len(htmlContent))
 
@@ -301,6 +344,16 @@ func (s *FragmentService) getCachedOrGenerateHTML(tenantCtx *tenant.Context, pan
 	return htmlContent, nil
 }
 
+// renderPaneFallback builds the minimal placeholder served in place of a
+// pane whose node tree fails to render. It keeps the pane's DOM slot (and
+// title, when present) so the rest of the page lays out normally.
+func (s *FragmentService) renderPaneFallback(pane *content.PaneNode) string {
+	if pane.Title == "" {
+		return fmt.Sprintf(`<div id="pane-%s" class="pane-render-fallback"></div>`, pane.ID)
+	}
+	return fmt.Sprintf(`<div id="pane-%s" class="pane-render-fallback"><h2>%s</h2></div>`, pane.ID, html.EscapeString(pane.Title))
+}
+
 // generateFreshHTML generates HTML with widget personalization context
 func (s *FragmentService) generateFreshHTML(
 	tenantCtx *tenant.Context,
@@ -335,8 +388,9 @@ func (s *FragmentService) generateFreshHTMLWithWidgets(
 ) string {
 	nodesData, parentChildMap, err := templates.ExtractNodesFromPane(pane)
 	if err != nil {
-		s.logger.Content().Error("Failed to extract nodes", "error", err.Error(), "paneId", pane.ID)
-		return fmt.Sprintf(`<div>Error extracting nodes for pane %s</div>`, pane.ID)
+		s.logger.Content().Error("Failed to extract nodes, serving fallback", "error", err.Error(), "paneId", pane.ID)
+		tenantCtx.CacheManager.RecordPaneRenderFailure(tenantCtx.TenantID, pane.ID, err.Error())
+		return s.renderPaneFallback(pane)
 	}
 
 	paneNodeData := &rendering.NodeRenderData{
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// newRenderFixture builds a fragment service and a tenant context whose pane
+// cache is pre-seeded, so batch renders never touch a database.
+func newRenderFixture(t *testing.T, paneCount int) (*FragmentService, *tenant.Context, []string) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+
+	paneIDs := make([]string, 0, paneCount)
+	for i := 1; i <= paneCount; i++ {
+		paneID := fmt.Sprintf("pane-%d", i)
+		paneIDs = append(paneIDs, paneID)
+		cacheManager.SetPane("t1", &content.PaneNode{
+			ID:    paneID,
+			Title: fmt.Sprintf("Pane %d", i),
+			Slug:  fmt.Sprintf("pane-%d", i),
+			OptionsPayload: map[string]any{
+				"nodes": []any{
+					map[string]any{
+						"id":       paneID + "-p",
+						"nodeType": "TagElement",
+						"tagName":  "p",
+						"parentId": paneID,
+						"copy":     fmt.Sprintf("Distinct copy for pane %d", i),
+					},
+				},
+			},
+		})
+	}
+
+	perfTracker := performance.NewTracker(nil)
+	return NewFragmentService(nil, nil, nil, perfTracker, logger, nil, nil), tenantCtx, paneIDs
+}
+
+func TestGenerateFragmentBatchConcurrentMatchesSequential(t *testing.T) {
+	origWorkers := config.PaneRenderConcurrency
+	defer func() { config.PaneRenderConcurrency = origWorkers }()
+
+	// Render the same panes through the sequential path and the bounded
+	// concurrent path on separate fixtures so both runs do a real render.
+	config.PaneRenderConcurrency = 1
+	seqService, seqCtx, paneIDs := newRenderFixture(t, 8)
+	sequential, seqErrors, err := seqService.GenerateFragmentBatch(seqCtx, paneIDs, "", "")
+	if err != nil {
+		t.Fatalf("sequential batch failed: %v", err)
+	}
+	if len(seqErrors) != 0 {
+		t.Fatalf("sequential batch reported pane errors: %v", seqErrors)
+	}
+
+	config.PaneRenderConcurrency = 4
+	conService, conCtx, _ := newRenderFixture(t, 8)
+	concurrent, conErrors, err := conService.GenerateFragmentBatch(conCtx, paneIDs, "", "")
+	if err != nil {
+		t.Fatalf("concurrent batch failed: %v", err)
+	}
+	if len(conErrors) != 0 {
+		t.Fatalf("concurrent batch reported pane errors: %v", conErrors)
+	}
+
+	if len(sequential) != len(paneIDs) || len(concurrent) != len(paneIDs) {
+		t.Fatalf("expected %d rendered panes, got %d sequential and %d concurrent", len(paneIDs), len(sequential), len(concurrent))
+	}
+	for _, paneID := range paneIDs {
+		if sequential[paneID] == "" {
+			t.Fatalf("expected a non-empty render for %s", paneID)
+		}
+		if sequential[paneID] != concurrent[paneID] {
+			t.Errorf("expected byte-identical output for %s across render paths", paneID)
+		}
+	}
+}
+
+func TestGenerateFragmentBatchOutputsAreDistinctPerPane(t *testing.T) {
+	origWorkers := config.PaneRenderConcurrency
+	config.PaneRenderConcurrency = 4
+	defer func() { config.PaneRenderConcurrency = origWorkers }()
+
+	service, tenantCtx, paneIDs := newRenderFixture(t, 4)
+	results, errors, err := service.GenerateFragmentBatch(tenantCtx, paneIDs, "", "")
+	if err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	if len(errors) != 0 {
+		t.Fatalf("batch reported pane errors: %v", errors)
+	}
+
+	// Concurrency bugs tend to surface as crossed-over results; each pane's
+	// copy must land under its own key.
+	seen := make(map[string]string)
+	for paneID, html := range results {
+		if prior, dup := seen[html]; dup {
+			t.Errorf("panes %s and %s rendered identical output", prior, paneID)
+		}
+		seen[html] = paneID
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+func TestComputeFunnelWithThreeStepBins(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	// Two hourly bins: the second hour adds a fifth visitor to step 1, so the
+	// funnel must union visitors across bins before measuring retention.
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate":           {StepIndex: 1, Visitors: visitorSet("v1", "v2", "v3", "v4")},
+		"commitmentAction_read": {StepIndex: 2, Visitors: visitorSet("v1", "v2")},
+		"conversionAction_buy":  {StepIndex: 3, Visitors: visitorSet("v1")},
+	})
+	earlierHour := time.Now().UTC().Add(-2 * time.Hour).Format("2006-01-02-15")
+	seedEpinetBin(tenantCtx, "ep1", earlierHour, map[string]*types.HourlyEpinetStepData{
+		"belief_gate": {StepIndex: 1, Visitors: visitorSet("v1", "v5")},
+	})
+
+	funnel, err := service.ComputeFunnel(tenantCtx, "ep1", nil)
+	if err != nil {
+		t.Fatalf("ComputeFunnel failed: %v", err)
+	}
+	if len(funnel.Steps) != 3 {
+		t.Fatalf("expected 3 funnel steps, got %d", len(funnel.Steps))
+	}
+
+	first := funnel.Steps[0]
+	if first.StepIndex != 1 || first.Visitors != 5 {
+		t.Errorf("expected step 1 to union 5 unique visitors across bins, got %+v", first)
+	}
+	if first.RetentionPct != 100 || first.DropOffPct != 0 {
+		t.Errorf("expected the entry step at full retention, got %+v", first)
+	}
+
+	second := funnel.Steps[1]
+	if second.Visitors != 2 || second.RetentionPct != 40 || second.DropOffPct != 60 {
+		t.Errorf("expected step 2 to retain 2 of 5 visitors (40%%), got %+v", second)
+	}
+
+	third := funnel.Steps[2]
+	if third.Visitors != 1 || third.RetentionPct != 50 || third.DropOffPct != 50 {
+		t.Errorf("expected step 3 to retain 1 of 2 visitors (50%%), got %+v", third)
+	}
+}
+
+func TestComputeFunnelRoundsRetentionToOneDecimal(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate":           {StepIndex: 1, Visitors: visitorSet("v1", "v2", "v3")},
+		"commitmentAction_read": {StepIndex: 2, Visitors: visitorSet("v1", "v2")},
+	})
+
+	funnel, err := service.ComputeFunnel(tenantCtx, "ep1", nil)
+	if err != nil {
+		t.Fatalf("ComputeFunnel failed: %v", err)
+	}
+	if len(funnel.Steps) != 2 {
+		t.Fatalf("expected 2 funnel steps, got %d", len(funnel.Steps))
+	}
+	if got := funnel.Steps[1].RetentionPct; got != 66.7 {
+		t.Errorf("expected 2/3 retention to round to 66.7, got %v", got)
+	}
+	if got := funnel.Steps[1].DropOffPct; got != 33.3 {
+		t.Errorf("expected the drop-off to round to 33.3, got %v", got)
+	}
+}
+
+func TestComputeFunnelHonorsVisitorTypeFilter(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate": {
+			StepIndex:     1,
+			Visitors:      visitorSet("v1", "v2", "v3"),
+			KnownVisitors: visitorSet("v1"),
+		},
+	})
+
+	funnel, err := service.ComputeFunnel(tenantCtx, "ep1", &SankeyFilters{VisitorType: "known"})
+	if err != nil {
+		t.Fatalf("ComputeFunnel failed: %v", err)
+	}
+	if len(funnel.Steps) != 1 || funnel.Steps[0].Visitors != 1 {
+		t.Errorf("expected only the known visitor to be counted, got %+v", funnel.Steps)
+	}
+}
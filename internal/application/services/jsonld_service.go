@@ -0,0 +1,216 @@
+// Package services provides application-level services that orchestrate
+// business logic and coordinate between repositories and domain entities.
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+)
+
+// jsonLDVariant is the cache variant used for storing assembled JSON-LD
+// documents in the HTML chunk cache, so they share the dependency-based
+// invalidation lifecycle of rendered fragments.
+var jsonLDVariant = types.PaneVariant{BeliefMode: "jsonld"}
+
+// JSONLDService assembles schema.org structured data for storyfragments:
+// Article metadata from the fragment itself, FAQPage entries from designated
+// Q&A panes, and Product entries from referenced resources.
+type JSONLDService struct {
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewJSONLDService creates a new JSON-LD service singleton
+func NewJSONLDService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *JSONLDService {
+	return &JSONLDService{
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// GetStoryFragmentJSONLD returns the combined JSON-LD graph for a
+// storyfragment, cached with the same dependency-based invalidation as
+// rendered pane fragments. Entities with missing required fields are omitted
+// rather than emitted as broken markup.
+func (s *JSONLDService) GetStoryFragmentJSONLD(tenantCtx *tenant.Context, slug string) (json.RawMessage, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_storyfragment_jsonld", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if slug == "" {
+		return nil, fmt.Errorf("storyfragment slug cannot be empty")
+	}
+
+	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
+	storyFragment, err := storyFragmentRepo.FindBySlug(tenantCtx.TenantID, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storyfragment by slug %s: %w", slug, err)
+	}
+	if storyFragment == nil {
+		return nil, nil
+	}
+
+	cacheKey := "jsonld:" + storyFragment.ID
+	if chunk, found := tenantCtx.CacheManager.GetHTMLChunk(tenantCtx.TenantID, cacheKey, jsonLDVariant); found {
+		marker.SetSuccess(true)
+		return json.RawMessage(chunk.HTML), nil
+	}
+
+	graph := make([]map[string]any, 0)
+	dependsOn := []string{storyFragment.ID}
+
+	// Article metadata from the fragment itself.
+	article := map[string]any{
+		"@type":    "Article",
+		"headline": storyFragment.Title,
+		"url":      "/" + storyFragment.Slug,
+	}
+	if !storyFragment.Created.IsZero() {
+		article["datePublished"] = storyFragment.Created.UTC().Format(time.RFC3339)
+	}
+	if storyFragment.Changed != nil {
+		article["dateModified"] = storyFragment.Changed.UTC().Format(time.RFC3339)
+	}
+	if storyFragment.SocialImagePath != nil && *storyFragment.SocialImagePath != "" {
+		article["image"] = *storyFragment.SocialImagePath
+	}
+	if contentMap, found := tenantCtx.CacheManager.GetFullContentMap(tenantCtx.TenantID); found {
+		for _, item := range contentMap {
+			if item.ID == storyFragment.ID {
+				if item.Description != nil && *item.Description != "" {
+					article["description"] = *item.Description
+				}
+				if len(item.Topics) > 0 {
+					article["keywords"] = item.Topics
+				}
+				break
+			}
+		}
+	}
+	if storyFragment.Title != "" {
+		graph = append(graph, article)
+	}
+
+	// FAQ entries from designated Q&A panes and Product entries from
+	// referenced resources.
+	if len(storyFragment.PaneIDs) > 0 {
+		paneRepo := tenantCtx.PaneRepo()
+		panes, err := paneRepo.FindByIDs(tenantCtx.TenantID, storyFragment.PaneIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get panes for storyfragment %s: %w", storyFragment.ID, err)
+		}
+
+		faqEntries := make([]map[string]any, 0)
+		resourceSlugs := make([]string, 0)
+
+		for _, pane := range panes {
+			dependsOn = append(dependsOn, pane.ID)
+			if pane.OptionsPayload == nil {
+				continue
+			}
+
+			faqEntries = append(faqEntries, extractFAQEntries(pane.OptionsPayload)...)
+
+			if refs, ok := pane.OptionsPayload["resources"].([]any); ok {
+				for _, ref := range refs {
+					if resourceSlug, ok := ref.(string); ok && resourceSlug != "" {
+						resourceSlugs = append(resourceSlugs, resourceSlug)
+					}
+				}
+			}
+		}
+
+		if len(faqEntries) > 0 {
+			graph = append(graph, map[string]any{
+				"@type":      "FAQPage",
+				"mainEntity": faqEntries,
+			})
+		}
+
+		if len(resourceSlugs) > 0 {
+			resourceRepo := tenantCtx.ResourceRepo()
+			for _, resourceSlug := range resourceSlugs {
+				resource, err := resourceRepo.FindBySlug(tenantCtx.TenantID, resourceSlug)
+				if err != nil || resource == nil || resource.Title == "" {
+					continue
+				}
+				dependsOn = append(dependsOn, resource.ID)
+
+				product := map[string]any{
+					"@type": "Product",
+					"name":  resource.Title,
+				}
+				if resource.OneLiner != "" {
+					product["description"] = resource.OneLiner
+				}
+				// Category schemas may declare a JSON-LD template that
+				// overrides or extends the derived fields.
+				if template, ok := resource.OptionsPayload["jsonld"].(map[string]any); ok {
+					for field, value := range template {
+						product[field] = value
+					}
+				}
+				graph = append(graph, product)
+			}
+		}
+	}
+
+	document := map[string]any{
+		"@context": "https://schema.org",
+		"@graph":   graph,
+	}
+
+	serialized, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize JSON-LD graph: %w", err)
+	}
+
+	tenantCtx.CacheManager.SetHTMLChunk(tenantCtx.TenantID, cacheKey, jsonLDVariant, string(serialized), dependsOn)
+
+	s.logger.Content().Info("Assembled storyfragment JSON-LD",
+		"tenantId", tenantCtx.TenantID,
+		"slug", slug,
+		"entities", len(graph),
+		"duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	return serialized, nil
+}
+
+// extractFAQEntries pulls Question entities from a pane's options payload.
+// Q&A panes declare a "faq" list of {question, answer} objects; entries with
+// either field missing are skipped.
+func extractFAQEntries(optionsPayload map[string]any) []map[string]any {
+	raw, ok := optionsPayload["faq"].([]any)
+	if !ok {
+		return nil
+	}
+
+	entries := make([]map[string]any, 0, len(raw))
+	for _, item := range raw {
+		pair, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		question, _ := pair["question"].(string)
+		answer, _ := pair["answer"].(string)
+		if question == "" || answer == "" {
+			continue
+		}
+		entries = append(entries, map[string]any{
+			"@type": "Question",
+			"name":  question,
+			"acceptedAnswer": map[string]any{
+				"@type": "Answer",
+				"text":  answer,
+			},
+		})
+	}
+	return entries
+}
@@ -17,6 +17,12 @@ type LeadMetrics struct {
 	LeadSources      map[string]int `json:"leadSources"`
 	ConversionFunnel map[string]int `json:"conversionFunnel"`
 	Attribution      map[string]any `json:"attribution"`
+	// FirstTimeVisitors and ReturningVisitors classify the window's visitors
+	// by the fingerprint's first-seen timestamp rather than by bin presence,
+	// so a visitor spanning two query windows is only "new" in the one that
+	// contains their first visit.
+	FirstTimeVisitors int `json:"firstTimeVisitors"`
+	ReturningVisitors int `json:"returningVisitors"`
 }
 
 type LeadAnalyticsService struct {
@@ -37,9 +43,11 @@ func (s *LeadAnalyticsService) ComputeLeadMetrics(tenantCtx *tenant.Context, sta
 	defer marker.Complete()
 	hourKeys := s.getHourKeysForCustomRange(startHour, endHour)
 
-	totalVisitors := s.getTotalVisitors(tenantCtx, hourKeys)
+	windowVisitors := s.getWindowVisitors(tenantCtx, hourKeys)
+	totalVisitors := len(windowVisitors)
 	totalLeads := s.getTotalLeads(tenantCtx)
 	newLeads := s.getNewLeads(tenantCtx, hourKeys)
+	firstTime, returning := s.classifyVisitorsByFirstSeen(tenantCtx, windowVisitors, hourKeys)
 
 	var conversionRate float64
 	if totalVisitors > 0 {
@@ -55,22 +63,30 @@ func (s *LeadAnalyticsService) ComputeLeadMetrics(tenantCtx *tenant.Context, sta
 	s.logger.Perf().Info("Performance for ComputeLeadMetrics", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
 	return &LeadMetrics{
-		TotalLeads:       totalLeads,
-		NewLeads:         newLeads,
-		ConversionRate:   conversionRate,
-		LeadSources:      leadSources,
-		ConversionFunnel: conversionFunnel,
-		Attribution:      attribution,
+		TotalLeads:        totalLeads,
+		NewLeads:          newLeads,
+		ConversionRate:    conversionRate,
+		LeadSources:       leadSources,
+		ConversionFunnel:  conversionFunnel,
+		Attribution:       attribution,
+		FirstTimeVisitors: firstTime,
+		ReturningVisitors: returning,
 	}, nil
 }
 
 func (s *LeadAnalyticsService) getTotalVisitors(tenantCtx *tenant.Context, hourKeys []string) int {
+	return len(s.getWindowVisitors(tenantCtx, hourKeys))
+}
+
+// getWindowVisitors collects the unique visitor IDs seen in any epinet bin
+// within the window's hour keys.
+func (s *LeadAnalyticsService) getWindowVisitors(tenantCtx *tenant.Context, hourKeys []string) map[string]bool {
 	uniqueVisitors := make(map[string]bool)
 
 	epinetRepo := tenantCtx.EpinetRepo()
 	epinets, err := epinetRepo.FindAll(tenantCtx.TenantID)
 	if err != nil {
-		return 0
+		return uniqueVisitors
 	}
 
 	for _, epinet := range epinets {
@@ -90,7 +106,57 @@ func (s *LeadAnalyticsService) getTotalVisitors(tenantCtx *tenant.Context, hourK
 		}
 	}
 
-	return len(uniqueVisitors)
+	return uniqueVisitors
+}
+
+// classifyVisitorsByFirstSeen splits the window's visitors into first-time
+// and returning using each fingerprint's created_at. Bin presence alone would
+// count a visitor as "first-time" in every window they appear in; anchoring
+// on first-seen means they are only new in the window containing their
+// earliest visit. Fingerprints missing from the database count as first-time.
+func (s *LeadAnalyticsService) classifyVisitorsByFirstSeen(tenantCtx *tenant.Context, windowVisitors map[string]bool, hourKeys []string) (firstTime, returning int) {
+	if len(windowVisitors) == 0 || len(hourKeys) == 0 {
+		return 0, 0
+	}
+
+	oldestHourKey := hourKeys[len(hourKeys)-1]
+	windowStart, err := time.Parse("2006-01-02-15", oldestHourKey)
+	if err != nil {
+		return 0, 0
+	}
+
+	visitorIDs := make([]string, 0, len(windowVisitors))
+	for visitorID := range windowVisitors {
+		visitorIDs = append(visitorIDs, visitorID)
+	}
+
+	firstTime = len(windowVisitors)
+	const chunkSize = 500
+	for offset := 0; offset < len(visitorIDs); offset += chunkSize {
+		end := min(offset+chunkSize, len(visitorIDs))
+		chunk := visitorIDs[offset:end]
+
+		placeholders := strings.Repeat("?,", len(chunk))
+		query := fmt.Sprintf(
+			`SELECT COUNT(*) FROM fingerprints WHERE id IN (%s) AND created_at < ?`,
+			placeholders[:len(placeholders)-1],
+		)
+
+		args := make([]any, 0, len(chunk)+1)
+		for _, visitorID := range chunk {
+			args = append(args, visitorID)
+		}
+		args = append(args, windowStart.Format("2006-01-02 15:04:05"))
+
+		var count int
+		if err := tenantCtx.Database.Conn.QueryRow(query, args...).Scan(&count); err != nil {
+			continue
+		}
+		returning += count
+		firstTime -= count
+	}
+
+	return firstTime, returning
 }
 
 func (s *LeadAnalyticsService) getTotalLeads(tenantCtx *tenant.Context) int {
@@ -2,12 +2,17 @@
 package services
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"time"
 
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/cleanup"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/interfaces"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/email"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
@@ -23,6 +28,22 @@ type MultiTenantService struct {
 	emailService  email.Service
 	logger        *logging.ChanneledLogger
 	perfTracker   *performance.Tracker
+
+	// Warming dependencies, wired via SetWarmingDependencies, so tenants
+	// activated at runtime get the same cache warming as startup tenants.
+	warmingService        *WarmingService
+	contentMapService     *ContentMapService
+	beliefRegistryService *BeliefRegistryService
+	warmCache             interfaces.Cache
+}
+
+// SetWarmingDependencies wires the services needed to warm a tenant activated
+// at runtime; called once during container assembly.
+func (s *MultiTenantService) SetWarmingDependencies(warmingService *WarmingService, contentMapService *ContentMapService, beliefRegistryService *BeliefRegistryService, warmCache interfaces.Cache) {
+	s.warmingService = warmingService
+	s.contentMapService = contentMapService
+	s.beliefRegistryService = beliefRegistryService
+	s.warmCache = warmCache
 }
 
 // NewMultiTenantService creates a new MultiTenantService.
@@ -55,12 +76,21 @@ type ActivationRequest struct {
 	Token string `json:"token"`
 }
 
+// DeactivationRequest defines the input for retiring a tenant.
+type DeactivationRequest struct {
+	TenantID    string `json:"tenantId" binding:"required"`
+	PurgeCaches bool   `json:"purgeCaches,omitempty"`
+	ExportData  bool   `json:"exportData,omitempty"`
+}
+
 // CapacityResult defines the output for the capacity check.
 type CapacityResult struct {
-	Available      bool `json:"available"`
-	CurrentTenants int  `json:"currentTenants"`
-	MaxTenants     int  `json:"maxTenants"`
-	AvailableSlots int  `json:"availableSlots"`
+	Available       bool             `json:"available"`
+	CurrentTenants  int              `json:"currentTenants"`
+	MaxTenants      int              `json:"maxTenants"`
+	AvailableSlots  int              `json:"availableSlots"`
+	DiskQuotaBytes  int64            `json:"diskQuotaBytes,omitempty"`
+	TenantDiskUsage map[string]int64 `json:"tenantDiskUsage,omitempty"`
 }
 
 // ProvisionTenant handles the creation of a new, reserved tenant.
@@ -121,8 +151,9 @@ func (s *MultiTenantService) ProvisionTenant(req ProvisionRequest) (string, erro
 	return activationToken, nil
 }
 
-// ActivateTenant finalizes tenant setup by creating the database schema.
-func (s *MultiTenantService) ActivateTenant(token string) error {
+// ActivateTenant finalizes tenant setup by creating the database schema. It
+// returns the activated tenant's ID so callers can report warming progress.
+func (s *MultiTenantService) ActivateTenant(token string) (string, error) {
 	marker := s.perfTracker.StartOperation("service_activate_tenant", "unknown")
 	defer marker.Complete()
 
@@ -130,7 +161,7 @@ func (s *MultiTenantService) ActivateTenant(token string) error {
 	tenantID, err := s.findTenantByActivationToken(token)
 	if err != nil {
 		marker.SetError(err)
-		return err
+		return "", err
 	}
 	marker.TenantID = tenantID // Update marker with found tenant
 
@@ -138,7 +169,7 @@ func (s *MultiTenantService) ActivateTenant(token string) error {
 	ctx, err := s.tenantManager.NewContextFromID(tenantID)
 	if err != nil {
 		marker.SetError(err)
-		return fmt.Errorf("failed to create context for activation: %w", err)
+		return "", fmt.Errorf("failed to create context for activation: %w", err)
 	}
 	defer ctx.Close()
 
@@ -146,24 +177,24 @@ func (s *MultiTenantService) ActivateTenant(token string) error {
 	tableCreator := database.NewTableCreator()
 	if err := tableCreator.CreateSchema(ctx.Database.Conn); err != nil {
 		marker.SetError(err)
-		return fmt.Errorf("database schema creation failed: %w", err)
+		return "", fmt.Errorf("database schema creation failed: %w", err)
 	}
 	if err := tableCreator.SeedInitialContent(ctx.Database.Conn); err != nil {
 		marker.SetError(err)
-		return fmt.Errorf("database seeding failed: %w", err)
+		return "", fmt.Errorf("database seeding failed: %w", err)
 	}
 
 	// 4. Update Status
 	if err := s.updateTenantRegistry(tenantID, "active", nil); err != nil {
 		marker.SetError(err)
-		return err
+		return "", err
 	}
 
 	// Refresh detector registry to sync with updated file
 	detector := s.tenantManager.GetDetector()
 	if err := detector.RefreshRegistry(); err != nil {
 		marker.SetError(err)
-		return fmt.Errorf("failed to refresh tenant registry: %w", err)
+		return "", fmt.Errorf("failed to refresh tenant registry: %w", err)
 	}
 	// Invalidate cached tenant context to force recreation with new status
 	s.tenantManager.InvalidateTenantContext(tenantID)
@@ -174,11 +205,354 @@ func (s *MultiTenantService) ActivateTenant(token string) error {
 		s.logger.Tenant().Warn("Failed to clear activation token after activation", "error", err, "tenantId", tenantID)
 	}
 
+	// 6. Warm the fresh tenant in the background so its first visitor doesn't
+	// pay the full cold-cache cost; requests arriving mid-warm fall back to
+	// the database through the cache-first repositories.
+	s.warmActivatedTenant(tenantID)
+
 	marker.SetSuccess(true)
 	s.logger.Tenant().Info("Tenant successfully activated", "tenantId", tenantID)
+	return tenantID, nil
+}
+
+// GetTenantWarmStatus reports a tenant's runtime warming phase for activation
+// and health responses.
+func (s *MultiTenantService) GetTenantWarmStatus(tenantID string) string {
+	if s.warmingService == nil {
+		return "ready"
+	}
+	return s.warmingService.GetTenantWarmStatus(tenantID)
+}
+
+// warmActivatedTenant runs the startup WarmTenant flow asynchronously for a
+// tenant activated at runtime.
+func (s *MultiTenantService) warmActivatedTenant(tenantID string) {
+	if s.warmingService == nil || s.warmCache == nil {
+		return
+	}
+
+	s.warmingService.SetTenantWarmStatus(tenantID, "warming")
+	go func() {
+		start := time.Now()
+		s.warmingService.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "started"})
+
+		ctx, err := s.tenantManager.NewContextFromID(tenantID)
+		if err != nil {
+			s.warmingService.SetTenantWarmStatus(tenantID, "failed")
+			s.logger.Cache().Error("Failed to create context for post-activation warming", "tenantId", tenantID, "error", err)
+			s.warmingService.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "failed", DurationMs: time.Since(start).Milliseconds(), Error: err.Error()})
+			return
+		}
+
+		reporter := cleanup.NewReporter(s.warmCache)
+		if err := s.warmingService.WarmTenant(ctx, tenantID, s.warmCache, s.contentMapService, s.beliefRegistryService, reporter); err != nil {
+			s.warmingService.SetTenantWarmStatus(tenantID, "failed")
+			s.logger.Cache().Error("Post-activation warming failed", "tenantId", tenantID, "error", err)
+			s.warmingService.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "failed", DurationMs: time.Since(start).Milliseconds(), Error: err.Error()})
+			return
+		}
+
+		s.warmingService.SetTenantWarmStatus(tenantID, "ready")
+		s.logger.Cache().Info("Post-activation warming completed", "tenantId", tenantID, "duration", time.Since(start))
+		s.warmingService.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "completed", DurationMs: time.Since(start).Milliseconds()})
+	}()
+}
+
+// DeactivateTenant transitions an active tenant to archived: it optionally
+// dumps the tenant's content nodes to disk, issues a fresh activation token so
+// the existing activation endpoint can restore service later without a
+// restart, closes the cached database handle, and releases the tenant's
+// caches. The tenant middleware rejects archived tenants, so the tenant stops
+// serving as soon as the registry flips.
+func (s *MultiTenantService) DeactivateTenant(req DeactivationRequest) error {
+	marker := s.perfTracker.StartOperation("service_deactivate_tenant", req.TenantID)
+	defer marker.Complete()
+
+	detector := s.tenantManager.GetDetector()
+	info, exists := detector.GetRegistry().Tenants[req.TenantID]
+	if !exists {
+		err := fmt.Errorf("tenant '%s' not found", req.TenantID)
+		marker.SetError(err)
+		return err
+	}
+	if info.Status != "active" {
+		err := fmt.Errorf("tenant '%s' is not active (status: %s)", req.TenantID, info.Status)
+		marker.SetError(err)
+		return err
+	}
+
+	ctx, err := s.tenantManager.NewContextFromID(req.TenantID)
+	if err != nil {
+		marker.SetError(err)
+		return fmt.Errorf("failed to create context for deactivation: %w", err)
+	}
+
+	if req.ExportData {
+		if err := s.exportTenantContent(ctx); err != nil {
+			marker.SetError(err)
+			return fmt.Errorf("content export failed, tenant left active: %w", err)
+		}
+	}
+
+	activationToken, _ := security.GenerateSecureToken(32)
+	ctx.Config.ActivationToken = activationToken
+	if err := s.saveTenantConfig(ctx.Config); err != nil {
+		marker.SetError(err)
+		return err
+	}
+
+	if err := s.updateTenantRegistry(req.TenantID, "archived", nil); err != nil {
+		marker.SetError(err)
+		return err
+	}
+
+	// Close the cached database handle and drop the tenant's cache state; the
+	// cleanup worker reclaims whatever the invalidation leaves behind.
+	s.tenantManager.InvalidateTenantContext(req.TenantID)
+	cacheManager := s.tenantManager.GetCacheManager()
+	cacheManager.InvalidateTenant(req.TenantID)
+	if req.PurgeCaches {
+		cacheManager.CleanupIdleRateBuckets(req.TenantID, 0)
+	}
+
+	marker.SetSuccess(true)
+	s.logger.Tenant().Info("Tenant archived", "tenantId", req.TenantID, "exported", req.ExportData)
+	return nil
+}
+
+// exportTenantContent writes a JSON dump of the tenant's content nodes to its
+// config directory before archival.
+func (s *MultiTenantService) exportTenantContent(ctx *tenant.Context) error {
+	export := map[string]any{
+		"tenantId":   ctx.TenantID,
+		"exportedAt": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	sections := []struct {
+		key  string
+		load func() (any, error)
+	}{
+		{"tractstacks", func() (any, error) { return ctx.TractStackRepo().FindAll(ctx.TenantID) }},
+		{"storyfragments", func() (any, error) { return ctx.StoryFragmentRepo().FindAll(ctx.TenantID) }},
+		{"panes", func() (any, error) { return ctx.PaneRepo().FindAll(ctx.TenantID) }},
+		{"menus", func() (any, error) { return ctx.MenuRepo().FindAll(ctx.TenantID) }},
+		{"resources", func() (any, error) { return ctx.ResourceRepo().FindAll(ctx.TenantID) }},
+		{"beliefs", func() (any, error) { return ctx.BeliefRepo().FindAll(ctx.TenantID) }},
+		{"epinets", func() (any, error) { return ctx.EpinetRepo().FindAll(ctx.TenantID) }},
+		{"files", func() (any, error) { return ctx.ImageFileRepo().FindAll(ctx.TenantID) }},
+	}
+	for _, section := range sections {
+		nodes, err := section.load()
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", section.key, err)
+		}
+		export[section.key] = nodes
+	}
+
+	exportData, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal content export: %w", err)
+	}
+
+	exportPath := filepath.Join(config.DataDir, "config", ctx.TenantID,
+		fmt.Sprintf("content-export-%s.json", time.Now().UTC().Format("20060102-150405")))
+	if err := os.WriteFile(exportPath, exportData, 0600); err != nil {
+		return fmt.Errorf("failed to write content export: %w", err)
+	}
+
+	s.logger.Tenant().Info("Tenant content exported", "tenantId", ctx.TenantID, "path", exportPath)
 	return nil
 }
 
+// RotateTenantSecrets generates new primary JWT/AES secrets for a tenant,
+// demotes the current secrets to "previous," persists the config, and
+// hot-reloads the tenant context. New tokens sign with the new secrets while
+// tokens signed with the previous secrets keep verifying until the rotation
+// is finalized.
+func (s *MultiTenantService) RotateTenantSecrets(tenantID string) error {
+	marker := s.perfTracker.StartOperation("service_rotate_tenant_secrets", tenantID)
+	defer marker.Complete()
+
+	ctx, err := s.tenantManager.NewContextFromID(tenantID)
+	if err != nil {
+		marker.SetError(err)
+		return fmt.Errorf("failed to create context for secret rotation: %w", err)
+	}
+	defer ctx.Close()
+
+	newJWTSecret, err := security.GenerateSecureKey(64)
+	if err != nil {
+		marker.SetError(err)
+		return fmt.Errorf("failed to generate JWT secret: %w", err)
+	}
+	newAESKey, err := security.GenerateSecureKey(64)
+	if err != nil {
+		marker.SetError(err)
+		return fmt.Errorf("failed to generate AES key: %w", err)
+	}
+
+	ctx.Config.PreviousJWTSecret = ctx.Config.JWTSecret
+	ctx.Config.PreviousAESKey = ctx.Config.AESKey
+	ctx.Config.JWTSecret = newJWTSecret
+	ctx.Config.AESKey = newAESKey
+
+	if err := s.saveTenantConfig(ctx.Config); err != nil {
+		marker.SetError(err)
+		return err
+	}
+
+	// Force context recreation so in-flight requests pick up the new secrets.
+	s.tenantManager.InvalidateTenantContext(tenantID)
+
+	marker.SetSuccess(true)
+	s.logger.Tenant().Info("Tenant secrets rotated, previous secrets retained for grace window", "tenantId", tenantID)
+	return nil
+}
+
+// FinalizeSecretRotation drops a tenant's previous secrets, ending the grace
+// window started by RotateTenantSecrets. Tokens signed with the old secrets
+// stop verifying after this call.
+func (s *MultiTenantService) FinalizeSecretRotation(tenantID string) error {
+	marker := s.perfTracker.StartOperation("service_finalize_secret_rotation", tenantID)
+	defer marker.Complete()
+
+	ctx, err := s.tenantManager.NewContextFromID(tenantID)
+	if err != nil {
+		marker.SetError(err)
+		return fmt.Errorf("failed to create context for rotation finalize: %w", err)
+	}
+	defer ctx.Close()
+
+	if ctx.Config.PreviousJWTSecret == "" && ctx.Config.PreviousAESKey == "" {
+		err := fmt.Errorf("no secret rotation in progress for tenant %s", tenantID)
+		marker.SetError(err)
+		return err
+	}
+
+	// Lead secrets encrypted before the rotation are only readable through
+	// the previous-key fallback, so they must be rewritten under the current
+	// key before that key is dropped. A failed rewrite refuses the finalize
+	// rather than stranding unrecoverable ciphertexts.
+	if ctx.Config.PreviousAESKey != "" {
+		reencrypted, err := s.reencryptLeadSecrets(ctx)
+		if err != nil {
+			marker.SetError(err)
+			return fmt.Errorf("refusing to finalize rotation for tenant %s: %w", tenantID, err)
+		}
+		if reencrypted > 0 {
+			s.logger.Tenant().Info("Re-encrypted lead secrets under the rotated AES key", "tenantId", tenantID, "leads", reencrypted)
+		}
+	}
+
+	ctx.Config.PreviousJWTSecret = ""
+	ctx.Config.PreviousAESKey = ""
+
+	if err := s.saveTenantConfig(ctx.Config); err != nil {
+		marker.SetError(err)
+		return err
+	}
+
+	s.tenantManager.InvalidateTenantContext(tenantID)
+
+	marker.SetSuccess(true)
+	s.logger.Tenant().Info("Tenant secret rotation finalized, previous secrets dropped", "tenantId", tenantID)
+	return nil
+}
+
+// reencryptLeadSecrets rewrites leads.encrypted_email and encrypted_code
+// under the tenant's current AES key. Rows already readable with the current
+// key are left untouched; rows only readable with the previous key are
+// decrypted through the fallback and re-sealed. A row neither key can open
+// aborts the pass so the caller keeps the grace window alive.
+func (s *MultiTenantService) reencryptLeadSecrets(tenantCtx *tenant.Context) (int, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := conn.QueryContext(dbCtx, `SELECT id, encrypted_email, encrypted_code FROM leads`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query lead secrets: %w", err)
+	}
+	defer rows.Close()
+
+	type leadRewrite struct {
+		id    string
+		email sql.NullString
+		code  sql.NullString
+	}
+	var rewrites []leadRewrite
+	for rows.Next() {
+		var id string
+		var email, code sql.NullString
+		if err := rows.Scan(&id, &email, &code); err != nil {
+			return 0, fmt.Errorf("failed to scan lead secrets: %w", err)
+		}
+
+		rewrite := leadRewrite{id: id}
+		for _, field := range []struct {
+			name   string
+			value  sql.NullString
+			target *sql.NullString
+		}{
+			{"encrypted_email", email, &rewrite.email},
+			{"encrypted_code", code, &rewrite.code},
+		} {
+			if !field.value.Valid || field.value.String == "" {
+				continue
+			}
+			if _, err := security.Decrypt(field.value.String, tenantCtx.Config.AESKey); err == nil {
+				continue // already sealed under the current key
+			}
+			plaintext, err := security.Decrypt(field.value.String, tenantCtx.Config.PreviousAESKey)
+			if err != nil {
+				return 0, fmt.Errorf("lead %s: %s is unreadable with both keys", id, field.name)
+			}
+			resealed, err := security.Encrypt(plaintext, tenantCtx.Config.AESKey)
+			if err != nil {
+				return 0, fmt.Errorf("lead %s: failed to re-encrypt %s: %w", id, field.name, err)
+			}
+			*field.target = sql.NullString{String: resealed, Valid: true}
+		}
+		if rewrite.email.Valid || rewrite.code.Valid {
+			rewrites = append(rewrites, rewrite)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read lead secrets: %w", err)
+	}
+	if len(rewrites) == 0 {
+		return 0, nil
+	}
+
+	tx, err := conn.BeginTx(dbCtx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin re-encryption transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, rewrite := range rewrites {
+		if rewrite.email.Valid {
+			if _, err := tx.ExecContext(dbCtx, `UPDATE leads SET encrypted_email = ? WHERE id = ?`, rewrite.email.String, rewrite.id); err != nil {
+				return 0, fmt.Errorf("lead %s: failed to store re-encrypted email: %w", rewrite.id, err)
+			}
+		}
+		if rewrite.code.Valid {
+			if _, err := tx.ExecContext(dbCtx, `UPDATE leads SET encrypted_code = ? WHERE id = ?`, rewrite.code.String, rewrite.id); err != nil {
+				return 0, fmt.Errorf("lead %s: failed to store re-encrypted code: %w", rewrite.id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit lead re-encryption: %w", err)
+	}
+	return len(rewrites), nil
+}
+
 // GetCapacity checks the system's capacity for new tenants.
 func (s *MultiTenantService) GetCapacity() (*CapacityResult, error) {
 	// Use detector's in-memory registry instead of reading filesystem
@@ -190,11 +564,23 @@ func (s *MultiTenantService) GetCapacity() (*CapacityResult, error) {
 	availableSlots := maxTenants - currentTenants
 	availableSlots = max(0, availableSlots)
 
+	tenantDiskUsage := make(map[string]int64, currentTenants)
+	for tenantID := range registry.Tenants {
+		usage, err := tenant.DiskUsage(tenantID)
+		if err != nil {
+			s.logger.Tenant().Warn("Failed to measure tenant disk usage", "tenantId", tenantID, "error", err)
+			continue
+		}
+		tenantDiskUsage[tenantID] = usage
+	}
+
 	return &CapacityResult{
-		Available:      availableSlots > 0,
-		CurrentTenants: currentTenants,
-		MaxTenants:     maxTenants,
-		AvailableSlots: availableSlots,
+		Available:       availableSlots > 0,
+		CurrentTenants:  currentTenants,
+		MaxTenants:      maxTenants,
+		AvailableSlots:  availableSlots,
+		DiskQuotaBytes:  int64(config.TenantDiskQuotaMB) * 1024 * 1024,
+		TenantDiskUsage: tenantDiskUsage,
 	}, nil
 }
 
@@ -222,14 +608,14 @@ func (s *MultiTenantService) validateProvisionRequest(req ProvisionRequest) erro
 	return nil
 }
 
-func (s *MultiTenantService) saveTenantConfig(config *tenant.Config) error {
-	configPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", config.TenantID, "env.json")
+func (s *MultiTenantService) saveTenantConfig(tenantConfig *tenant.Config) error {
+	configPath := filepath.Join(config.DataDir, "config", tenantConfig.TenantID, "env.json")
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	configData, err := json.MarshalIndent(config, "", "  ")
+	configData, err := json.MarshalIndent(tenantConfig, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -238,7 +624,7 @@ func (s *MultiTenantService) saveTenantConfig(config *tenant.Config) error {
 }
 
 func (s *MultiTenantService) updateTenantRegistry(tenantID, status string, domains []string) error {
-	registryPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", "t8k", "tenants.json")
+	registryPath := filepath.Join(config.DataDir, "config", "t8k", "tenants.json")
 
 	// Use detector's in-memory registry as base instead of reading filesystem
 	detector := s.tenantManager.GetDetector()
@@ -281,8 +667,10 @@ func (s *MultiTenantService) findTenantByActivationToken(token string) (string,
 	detector := s.tenantManager.GetDetector()
 	registry := detector.GetRegistry()
 
+	// Archived tenants receive a fresh activation token on deactivation so the
+	// same flow can restore them without a process restart.
 	for tenantID, info := range registry.Tenants {
-		if info.Status == "reserved" {
+		if info.Status == "reserved" || info.Status == "archived" {
 			config, err := tenant.LoadTenantConfig(tenantID, s.logger)
 			if err != nil {
 				s.logger.System().Warn("Could not load config for reserved tenant during activation check", "tenantId", tenantID)
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newRotationFixture builds a tenant context backed by an in-memory SQLite
+// database mid-rotation: the previous AES key sealed existing rows, the
+// current key is the freshly rotated one.
+func newRotationFixture(t *testing.T) (*tenant.Context, string, string) {
+	t.Helper()
+
+	oldKey, err := security.GenerateSecureKey(64)
+	if err != nil {
+		t.Fatalf("failed to generate previous key: %v", err)
+	}
+	newKey, err := security.GenerateSecureKey(64)
+	if err != nil {
+		t.Fatalf("failed to generate current key: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE leads (id TEXT PRIMARY KEY, encrypted_email TEXT, encrypted_code TEXT)`); err != nil {
+		t.Fatalf("failed to create leads table: %v", err)
+	}
+
+	tenantCtx := &tenant.Context{
+		TenantID: "t1",
+		Config: &tenant.Config{
+			TenantID:       "t1",
+			AESKey:         newKey,
+			PreviousAESKey: oldKey,
+		},
+		Database: &tenant.Database{Conn: db, TenantID: "t1"},
+	}
+	return tenantCtx, oldKey, newKey
+}
+
+func seal(t *testing.T, plaintext, key string) string {
+	t.Helper()
+	encrypted, err := security.Encrypt(plaintext, key)
+	if err != nil {
+		t.Fatalf("failed to encrypt fixture value: %v", err)
+	}
+	return encrypted
+}
+
+func TestRotationGraceWindowReadsOldCiphertexts(t *testing.T) {
+	_, oldKey, newKey := newRotationFixture(t)
+	encrypted := seal(t, "lead@example.com", oldKey)
+
+	// During the grace window the fallback keeps pre-rotation rows readable.
+	plaintext, err := security.DecryptWithFallback(encrypted, newKey, oldKey)
+	if err != nil {
+		t.Fatalf("expected the previous-key fallback to decrypt: %v", err)
+	}
+	if plaintext != "lead@example.com" {
+		t.Errorf("expected original plaintext, got %q", plaintext)
+	}
+
+	// Without the fallback the row is unreadable — exactly what finalize
+	// would strand if it dropped the key without re-encrypting.
+	if _, err := security.Decrypt(encrypted, newKey); err == nil {
+		t.Error("expected the current key alone to fail on a pre-rotation ciphertext")
+	}
+}
+
+func TestReencryptLeadSecretsRewritesOldKeyRows(t *testing.T) {
+	tenantCtx, oldKey, newKey := newRotationFixture(t)
+	conn := tenantCtx.Database.Conn
+
+	staleEmail := seal(t, "stale@example.com", oldKey)
+	staleCode := seal(t, "stale-code", oldKey)
+	freshEmail := seal(t, "fresh@example.com", newKey)
+	if _, err := conn.Exec(`INSERT INTO leads (id, encrypted_email, encrypted_code) VALUES (?, ?, ?), (?, ?, NULL)`,
+		"lead-stale", staleEmail, staleCode, "lead-fresh", freshEmail); err != nil {
+		t.Fatalf("failed to seed leads: %v", err)
+	}
+
+	s := &MultiTenantService{}
+	rewritten, err := s.reencryptLeadSecrets(tenantCtx)
+	if err != nil {
+		t.Fatalf("reencryptLeadSecrets failed: %v", err)
+	}
+	if rewritten != 1 {
+		t.Errorf("expected 1 rewritten lead, got %d", rewritten)
+	}
+
+	var gotEmail, gotCode string
+	if err := conn.QueryRow(`SELECT encrypted_email, encrypted_code FROM leads WHERE id = ?`, "lead-stale").Scan(&gotEmail, &gotCode); err != nil {
+		t.Fatalf("failed to read rewritten lead: %v", err)
+	}
+	for name, ciphertext := range map[string]string{"encrypted_email": gotEmail, "encrypted_code": gotCode} {
+		plaintext, err := security.Decrypt(ciphertext, newKey)
+		if err != nil {
+			t.Errorf("%s is not readable with the current key alone: %v", name, err)
+		}
+		if name == "encrypted_email" && plaintext != "stale@example.com" {
+			t.Errorf("expected re-encrypted email plaintext preserved, got %q", plaintext)
+		}
+	}
+
+	// The row already sealed under the current key must be byte-identical.
+	var unchanged string
+	if err := conn.QueryRow(`SELECT encrypted_email FROM leads WHERE id = ?`, "lead-fresh").Scan(&unchanged); err != nil {
+		t.Fatalf("failed to read untouched lead: %v", err)
+	}
+	if unchanged != freshEmail {
+		t.Error("expected a current-key row to be left untouched")
+	}
+}
+
+func TestReencryptLeadSecretsRefusesUnreadableRows(t *testing.T) {
+	tenantCtx, oldKey, _ := newRotationFixture(t)
+	conn := tenantCtx.Database.Conn
+
+	readable := seal(t, "ok@example.com", oldKey)
+	if _, err := conn.Exec(`INSERT INTO leads (id, encrypted_email, encrypted_code) VALUES (?, ?, NULL), (?, ?, NULL)`,
+		"lead-ok", readable, "lead-broken", "not-a-ciphertext"); err != nil {
+		t.Fatalf("failed to seed leads: %v", err)
+	}
+
+	s := &MultiTenantService{}
+	if _, err := s.reencryptLeadSecrets(tenantCtx); err == nil {
+		t.Fatal("expected an unreadable row to abort the re-encryption pass")
+	}
+
+	// The abort must leave every row as it was so the grace window can stay
+	// open while the operator investigates.
+	var got string
+	if err := conn.QueryRow(`SELECT encrypted_email FROM leads WHERE id = ?`, "lead-ok").Scan(&got); err != nil {
+		t.Fatalf("failed to read lead after abort: %v", err)
+	}
+	if got != readable {
+		t.Error("expected the aborted pass to leave readable rows untouched")
+	}
+}
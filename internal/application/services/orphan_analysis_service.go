@@ -54,9 +54,16 @@ func (s *OrphanAnalysisService) GetOrphanAnalysis(tenantCtx *tenant.Context, cli
 	return loadingPayload, etag, nil
 }
 
-// computeOrphanAnalysisAsync performs the analysis computation in background
 // computeOrphanAnalysisAsync performs the analysis computation in background
 func (s *OrphanAnalysisService) computeOrphanAnalysisAsync(tenantCtx *tenant.Context, cacheManager interfaces.Cache) {
+	if err := s.RecomputeOrphanAnalysis(tenantCtx, cacheManager); err != nil {
+		s.logger.Content().Error("Failed to compute orphan analysis", "error", err, "tenantId", tenantCtx.TenantID)
+	}
+}
+
+// RecomputeOrphanAnalysis rebuilds the orphan analysis from the database and
+// caches the result. Also used by stale-while-revalidate background refreshes.
+func (s *OrphanAnalysisService) RecomputeOrphanAnalysis(tenantCtx *tenant.Context, cacheManager interfaces.Cache) error {
 	start := time.Now()
 	// Use bulk repository from tenant context
 	bulkRepo := tenantCtx.BulkRepo()
@@ -64,32 +71,27 @@ func (s *OrphanAnalysisService) computeOrphanAnalysisAsync(tenantCtx *tenant.Con
 	// 1. Build all 5 dependency maps using existing methods
 	storyFragmentDeps, err := bulkRepo.ScanStoryFragmentDependencies(tenantCtx.TenantID)
 	if err != nil {
-		s.logger.Content().Error("Failed to scan storyfragment dependencies", "error", err, "tenantId", tenantCtx.TenantID)
-		return
+		return fmt.Errorf("failed to scan storyfragment dependencies: %w", err)
 	}
 
 	paneDeps, err := bulkRepo.ScanPaneDependencies(tenantCtx.TenantID)
 	if err != nil {
-		s.logger.Content().Error("Failed to scan pane dependencies", "error", err, "tenantId", tenantCtx.TenantID)
-		return
+		return fmt.Errorf("failed to scan pane dependencies: %w", err)
 	}
 
 	menuDeps, err := bulkRepo.ScanMenuDependencies(tenantCtx.TenantID)
 	if err != nil {
-		s.logger.Content().Error("Failed to scan menu dependencies", "error", err, "tenantId", tenantCtx.TenantID)
-		return
+		return fmt.Errorf("failed to scan menu dependencies: %w", err)
 	}
 
 	fileDeps, err := bulkRepo.ScanFileDependencies(tenantCtx.TenantID)
 	if err != nil {
-		s.logger.Content().Error("Failed to scan file dependencies", "error", err, "tenantId", tenantCtx.TenantID)
-		return
+		return fmt.Errorf("failed to scan file dependencies: %w", err)
 	}
 
 	beliefDeps, err := bulkRepo.ScanBeliefDependencies(tenantCtx.TenantID)
 	if err != nil {
-		s.logger.Content().Error("Failed to scan belief dependencies", "error", err, "tenantId", tenantCtx.TenantID)
-		return
+		return fmt.Errorf("failed to scan belief dependencies: %w", err)
 	}
 
 	// 2. Build final payload using the dependency maps directly
@@ -106,7 +108,8 @@ func (s *OrphanAnalysisService) computeOrphanAnalysisAsync(tenantCtx *tenant.Con
 	etag := s.generateETag(tenantCtx.TenantID)
 	cacheManager.SetOrphanAnalysis(tenantCtx.TenantID, payload, etag)
 
-	s.logger.Content().Info("Successfully computed orphan analysis async", "tenantId", tenantCtx.TenantID, "duration", time.Since(start))
+	s.logger.Content().Info("Successfully computed orphan analysis", "tenantId", tenantCtx.TenantID, "duration", time.Since(start))
+	return nil
 }
 
 // generateETag creates a unique ETag for the orphan analysis
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// brokenPane builds a pane whose options payload cannot be parsed into a
+// node tree, forcing the base render to fail.
+func brokenPane(id, title string) *content.PaneNode {
+	return &content.PaneNode{
+		ID:             id,
+		Title:          title,
+		Slug:           id,
+		OptionsPayload: map[string]any{"nodes": "not-an-array"},
+	}
+}
+
+func TestFailingPaneRenderYieldsPlaceholder(t *testing.T) {
+	origFallback := config.PaneFallbackRender
+	config.PaneFallbackRender = true
+	defer func() { config.PaneFallbackRender = origFallback }()
+
+	service, tenantCtx, _ := newRenderFixture(t, 0)
+	pane := brokenPane("pane-broken", "Broken & Pane")
+
+	html, err := service.getCachedOrGenerateHTML(tenantCtx, pane)
+	if err != nil {
+		t.Fatalf("expected the fallback path to swallow the render error, got %v", err)
+	}
+	if !strings.Contains(html, "pane-render-fallback") {
+		t.Errorf("expected the placeholder wrapper, got %q", html)
+	}
+	if !strings.Contains(html, "Broken &amp; Pane") {
+		t.Errorf("expected the escaped pane title in the placeholder, got %q", html)
+	}
+
+	// The failure lands on the admin render-failure list and the placeholder
+	// is cached briefly so every request doesn't retry the broken render.
+	failures := tenantCtx.CacheManager.GetPaneRenderFailures("t1")
+	if len(failures) != 1 || failures[0].PaneID != "pane-broken" {
+		t.Errorf("expected the failure to be recorded for pane-broken, got %+v", failures)
+	}
+	variant := types.PaneVariant{BeliefMode: "default", HeldBeliefs: []string{}, WithheldBeliefs: []string{}}
+	if chunk, found := tenantCtx.CacheManager.GetHTMLChunk("t1", "pane-broken", variant); !found || chunk.HTML != html {
+		t.Error("expected the placeholder to be cached for the pane's default variant")
+	}
+}
+
+func TestFailingPaneRenderPropagatesErrorWhenFallbackDisabled(t *testing.T) {
+	origFallback := config.PaneFallbackRender
+	config.PaneFallbackRender = false
+	defer func() { config.PaneFallbackRender = origFallback }()
+
+	service, tenantCtx, _ := newRenderFixture(t, 0)
+	if _, err := service.getCachedOrGenerateHTML(tenantCtx, brokenPane("pane-broken", "Broken")); err == nil {
+		t.Error("expected the render error to propagate with PANE_FALLBACK_RENDER disabled")
+	}
+	if failures := tenantCtx.CacheManager.GetPaneRenderFailures("t1"); len(failures) != 0 {
+		t.Errorf("expected no recorded failure on the propagate path, got %+v", failures)
+	}
+}
@@ -3,7 +3,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
@@ -13,6 +15,10 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 )
 
+// ErrPaneStillReferenced is returned when a pane delete is refused because one
+// or more storyfragments still list the pane in their PaneIDs.
+var ErrPaneStillReferenced = errors.New("pane still referenced")
+
 // PaneService orchestrates pane operations with cache-first repository pattern
 type PaneService struct {
 	logger            *logging.ChanneledLogger
@@ -127,6 +133,40 @@ func (s *PaneService) GetBySlug(tenantCtx *tenant.Context, slug string) (*conten
 	return pane, nil
 }
 
+// GetBySlugs resolves multiple slugs to panes in one pass (cache-first via
+// repository). The result maps every requested slug; misses map to nil.
+func (s *PaneService) GetBySlugs(tenantCtx *tenant.Context, slugs []string) (map[string]*content.PaneNode, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_panes_by_slugs", tenantCtx.TenantID)
+	defer marker.Complete()
+	if len(slugs) == 0 {
+		return map[string]*content.PaneNode{}, nil
+	}
+
+	paneRepo := tenantCtx.PaneRepo()
+	results := make(map[string]*content.PaneNode, len(slugs))
+	foundCount := 0
+	for _, slug := range slugs {
+		if slug == "" {
+			continue
+		}
+		pane, err := paneRepo.FindBySlug(tenantCtx.TenantID, slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pane by slug %s: %w", slug, err)
+		}
+		results[slug] = pane
+		if pane != nil {
+			foundCount++
+		}
+	}
+
+	s.logger.Content().Info("Successfully resolved pane slugs", "tenantId", tenantCtx.TenantID, "requestedCount", len(slugs), "foundCount", foundCount, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for GetPanesBySlugs", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "requestedCount", len(slugs))
+
+	return results, nil
+}
+
 // GetContextPanes returns all context panes (cache-first with filtering via repository)
 func (s *PaneService) GetContextPanes(tenantCtx *tenant.Context) ([]*content.PaneNode, error) {
 	start := time.Now()
@@ -219,6 +259,12 @@ func (s *PaneService) Update(tenantCtx *tenant.Context, pane *content.PaneNode)
 
 	// Surgically update the item in the item cache. The ID list is not affected.
 	tenantCtx.CacheManager.SetPane(tenantCtx.TenantID, pane)
+	// Rendered HTML chunks embedding this pane are stale now; drop them so the
+	// next fragment request re-renders from the updated node.
+	tenantCtx.CacheManager.InvalidateByDependency(tenantCtx.TenantID, pane.ID)
+	// Belief registries for storyfragments containing this pane must rebuild
+	// since the pane's belief wiring may have changed.
+	s.invalidateContainingStoryfragments(tenantCtx, pane.ID)
 	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
 		s.logger.Content().Error("Failed to refresh content map after pane update",
 			"error", err, "paneId", pane.ID, "tenantId", tenantCtx.TenantID)
@@ -250,6 +296,16 @@ func (s *PaneService) Delete(tenantCtx *tenant.Context, id string) error {
 		return fmt.Errorf("pane %s not found", id)
 	}
 
+	// Refuse to delete a pane that a storyfragment still references; the
+	// storyfragment must drop it from PaneIDs first.
+	referencing, err := s.findContainingStoryfragments(tenantCtx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check storyfragment references for pane %s: %w", id, err)
+	}
+	if len(referencing) > 0 {
+		return fmt.Errorf("%w: pane %s is referenced by %d storyfragment(s)", ErrPaneStillReferenced, id, len(referencing))
+	}
+
 	err = paneRepo.Delete(tenantCtx.TenantID, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete pane %s: %w", id, err)
@@ -259,6 +315,8 @@ func (s *PaneService) Delete(tenantCtx *tenant.Context, id string) error {
 	tenantCtx.CacheManager.InvalidatePane(tenantCtx.TenantID, id)
 	// Surgically remove the ID from the master ID list.
 	tenantCtx.CacheManager.RemovePaneID(tenantCtx.TenantID, id)
+	// Drop any rendered HTML chunks that embedded this pane.
+	tenantCtx.CacheManager.InvalidateByDependency(tenantCtx.TenantID, id)
 	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
 		s.logger.Content().Error("Failed to refresh content map after pane deletion",
 			"error", err, "paneId", id, "tenantId", tenantCtx.TenantID)
@@ -271,6 +329,41 @@ func (s *PaneService) Delete(tenantCtx *tenant.Context, id string) error {
 	return nil
 }
 
+// findContainingStoryfragments returns the IDs of storyfragments whose PaneIDs
+// include the given pane.
+func (s *PaneService) findContainingStoryfragments(tenantCtx *tenant.Context, paneID string) ([]string, error) {
+	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
+	storyFragments, err := storyFragmentRepo.FindAll(tenantCtx.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load storyfragments: %w", err)
+	}
+
+	var containing []string
+	for _, storyFragment := range storyFragments {
+		for _, id := range storyFragment.PaneIDs {
+			if id == paneID {
+				containing = append(containing, storyFragment.ID)
+				break
+			}
+		}
+	}
+	return containing, nil
+}
+
+// invalidateContainingStoryfragments drops the belief registry of every
+// storyfragment containing the pane so personalization state rebuilds against
+// the updated pane.
+func (s *PaneService) invalidateContainingStoryfragments(tenantCtx *tenant.Context, paneID string) {
+	containing, err := s.findContainingStoryfragments(tenantCtx, paneID)
+	if err != nil {
+		s.logger.Content().Warn("Failed to find storyfragments containing pane for registry invalidation", "error", err, "paneId", paneID, "tenantId", tenantCtx.TenantID)
+		return
+	}
+	for _, storyfragmentID := range containing {
+		tenantCtx.CacheManager.InvalidateStoryfragmentBeliefRegistry(tenantCtx.TenantID, storyfragmentID)
+	}
+}
+
 // GetPaneTemplate returns a pane template in the same format as full-payload
 // This method contains the extraction logic, keeping the handler clean
 func (s *PaneService) GetPaneTemplate(tenantCtx *tenant.Context, paneID string) (*PaneTemplatePayload, error) {
@@ -354,14 +447,38 @@ func (s *PaneService) BulkUpdateFilePaneRelationships(tenantCtx *tenant.Context,
 		}
 	}
 
+	// Validate referenced file IDs so relationships can't point at deleted files.
+	fileRepo := tenantCtx.ImageFileRepo()
+	var missingFiles []string
+	seenFiles := make(map[string]bool)
+	for _, fileIDs := range relationships {
+		for _, fileID := range fileIDs {
+			if fileID == "" || seenFiles[fileID] {
+				continue
+			}
+			seenFiles[fileID] = true
+			file, err := fileRepo.FindByID(tenantCtx.TenantID, fileID)
+			if err != nil {
+				return fmt.Errorf("failed to verify file %s exists: %w", fileID, err)
+			}
+			if file == nil {
+				missingFiles = append(missingFiles, "file:"+fileID)
+			}
+		}
+	}
+	if len(missingFiles) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingReferences, strings.Join(missingFiles, ", "))
+	}
+
 	// Update relationships
 	if err := paneRepo.UpdateFilePaneRelationships(tenantCtx.TenantID, relationships); err != nil {
 		return fmt.Errorf("failed to bulk update file-pane relationships: %w", err)
 	}
 
-	// Invalidate relevant caches
+	// Invalidate relevant caches, cascading to storyfragments that embed
+	// each pane so their rendered fragments and registries rebuild too.
 	for paneID := range relationships {
-		tenantCtx.CacheManager.InvalidatePane(tenantCtx.TenantID, paneID)
+		tenantCtx.CacheManager.InvalidatePaneCascade(tenantCtx.TenantID, paneID)
 	}
 
 	s.logger.Content().Info("Successfully bulk updated file-pane relationships", "tenantId", tenantCtx.TenantID, "paneCount", len(relationships), "duration", time.Since(start))
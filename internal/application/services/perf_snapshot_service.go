@@ -0,0 +1,144 @@
+// Package services provides precomputed per-tenant performance snapshots
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// PerfSnapshot is the coarse latency and cache picture the frontend uses to
+// choose its loading strategy. All figures cover roughly the configured
+// rolling window, not the process lifetime.
+type PerfSnapshot struct {
+	TenantID          string  `json:"tenantId"`
+	GeneratedAt       string  `json:"generatedAt"`
+	WindowSeconds     int     `json:"windowSeconds"`
+	FragmentP50Ms     float64 `json:"fragmentP50Ms"`
+	FragmentP95Ms     float64 `json:"fragmentP95Ms"`
+	FullPayloadP50Ms  float64 `json:"fullPayloadP50Ms"`
+	FullPayloadP95Ms  float64 `json:"fullPayloadP95Ms"`
+	HTMLChunkHitRatio float64 `json:"htmlChunkHitRatio"`
+}
+
+// tenantPerfWindow caches one tenant's current snapshot plus the baselines
+// used to window the cumulative histograms and hit counters.
+type tenantPerfWindow struct {
+	snapshot           *PerfSnapshot
+	serialized         string
+	refreshedAt        time.Time
+	fragmentBaseline   *performance.LatencyHistogram
+	payloadBaseline    *performance.LatencyHistogram
+	hitsBaseline       int64
+	missesBaseline     int64
+	baselineCapturedAt time.Time
+}
+
+// PerfSnapshotService maintains per-tenant performance snapshots that are
+// refreshed at most once per PerfSnapshotInterval, so attaching them to hot
+// responses costs a map lookup rather than a percentile computation.
+type PerfSnapshotService struct {
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+	windows     map[string]*tenantPerfWindow
+	mu          sync.Mutex
+}
+
+// NewPerfSnapshotService creates a new perf snapshot service singleton
+func NewPerfSnapshotService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *PerfSnapshotService {
+	return &PerfSnapshotService{
+		logger:      logger,
+		perfTracker: perfTracker,
+		windows:     make(map[string]*tenantPerfWindow),
+	}
+}
+
+func matchFragmentRoute(route string) bool {
+	return strings.HasPrefix(route, "/api/v1/fragments")
+}
+
+func matchFullPayloadRoute(route string) bool {
+	return strings.Contains(route, "full-payload") || strings.Contains(route, "personalized-payload")
+}
+
+// GetSnapshot returns the current snapshot for a tenant, recomputing it only
+// when the cached copy is older than PerfSnapshotInterval.
+func (s *PerfSnapshotService) GetSnapshot(tenantCtx *tenant.Context) *PerfSnapshot {
+	snapshot, _ := s.getWindow(tenantCtx)
+	return snapshot
+}
+
+// GetSnapshotHeader returns the snapshot pre-serialized for use as a response
+// header value.
+func (s *PerfSnapshotService) GetSnapshotHeader(tenantCtx *tenant.Context) string {
+	_, serialized := s.getWindow(tenantCtx)
+	return serialized
+}
+
+func (s *PerfSnapshotService) getWindow(tenantCtx *tenant.Context) (*PerfSnapshot, string) {
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, exists := s.windows[tenantCtx.TenantID]
+	if exists && now.Sub(window.refreshedAt) < config.PerfSnapshotInterval {
+		return window.snapshot, window.serialized
+	}
+	if !exists {
+		window = &tenantPerfWindow{}
+		s.windows[tenantCtx.TenantID] = window
+	}
+
+	fragments := s.perfTracker.GetRouteGroupHistogram(tenantCtx.TenantID, matchFragmentRoute)
+	payloads := s.perfTracker.GetRouteGroupHistogram(tenantCtx.TenantID, matchFullPayloadRoute)
+	hits, misses := tenantCtx.CacheManager.GetHTMLChunkHitStats(tenantCtx.TenantID)
+
+	// Roll the window baseline forward once it is stale so percentiles and
+	// the hit ratio reflect recent traffic rather than process lifetime.
+	if window.baselineCapturedAt.IsZero() || now.Sub(window.baselineCapturedAt) > config.PerfSnapshotWindow {
+		window.fragmentBaseline = fragments
+		window.payloadBaseline = payloads
+		window.hitsBaseline = hits
+		window.missesBaseline = misses
+		window.baselineCapturedAt = now
+	}
+
+	fragmentDelta := fragments.Delta(window.fragmentBaseline)
+	payloadDelta := payloads.Delta(window.payloadBaseline)
+
+	windowHits := hits - window.hitsBaseline
+	windowMisses := misses - window.missesBaseline
+	hitRatio := 0.0
+	if windowHits+windowMisses > 0 {
+		hitRatio = float64(windowHits) / float64(windowHits+windowMisses)
+	}
+
+	snapshot := &PerfSnapshot{
+		TenantID:          tenantCtx.TenantID,
+		GeneratedAt:       now.Format(time.RFC3339),
+		WindowSeconds:     int(config.PerfSnapshotWindow.Seconds()),
+		FragmentP50Ms:     fragmentDelta.Percentile(50),
+		FragmentP95Ms:     fragmentDelta.Percentile(95),
+		FullPayloadP50Ms:  payloadDelta.Percentile(50),
+		FullPayloadP95Ms:  payloadDelta.Percentile(95),
+		HTMLChunkHitRatio: hitRatio,
+	}
+
+	serialized, err := json.Marshal(snapshot)
+	if err != nil {
+		s.logger.Perf().Error("Failed to serialize perf snapshot", "error", err.Error(), "tenantId", tenantCtx.TenantID)
+		return snapshot, ""
+	}
+
+	window.snapshot = snapshot
+	window.serialized = string(serialized)
+	window.refreshedAt = now
+	return window.snapshot, window.serialized
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newResourcePagingFixture seeds five resources in deliberately unsorted
+// insert order so the tests prove pagination sorts before slicing.
+func newResourcePagingFixture(t *testing.T) (*ResourceService, *tenant.Context) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	seed := []struct{ id, slug, category string }{
+		{"r-c", "guide-three", "docs"},
+		{"r-a", "guide-one", "docs"},
+		{"r-e", "post-two", "blog"},
+		{"r-b", "guide-two", "blog"},
+		{"r-d", "post-one", "blog"},
+	}
+	for _, row := range seed {
+		if _, err := db.Exec(
+			`INSERT INTO resources (id, title, slug, category_slug, oneliner, options_payload) VALUES (?, ?, ?, ?, '', '{}')`,
+			row.id, row.id, row.slug, row.category,
+		); err != nil {
+			t.Fatalf("failed to seed resource %s: %v", row.id, err)
+		}
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	perfTracker := performance.NewTracker(nil)
+	return NewResourceService(logger, perfTracker, NewContentMapService(logger, perfTracker)), tenantCtx
+}
+
+func TestGetIDsPageBoundaries(t *testing.T) {
+	service, tenantCtx := newResourcePagingFixture(t)
+
+	page, total, err := service.GetIDsPage(tenantCtx, "", "", 2, 0)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected a total of 5 resources, got %d", total)
+	}
+	if !reflect.DeepEqual(page, []string{"r-a", "r-b"}) {
+		t.Errorf("expected the first sorted page [r-a r-b], got %v", page)
+	}
+
+	// The final page is short rather than padded or wrapped.
+	page, total, err = service.GetIDsPage(tenantCtx, "", "", 2, 4)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 5 || !reflect.DeepEqual(page, []string{"r-e"}) {
+		t.Errorf("expected the trailing page [r-e] of 5, got %v of %d", page, total)
+	}
+
+	// An offset past the end yields an empty page, not an error.
+	page, total, err = service.GetIDsPage(tenantCtx, "", "", 2, 10)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 5 || len(page) != 0 {
+		t.Errorf("expected an empty page past the end, got %v of %d", page, total)
+	}
+
+	// A negative offset clamps to the start; a zero limit means no cap.
+	page, _, err = service.GetIDsPage(tenantCtx, "", "", 0, -3)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if !reflect.DeepEqual(page, []string{"r-a", "r-b", "r-c", "r-d", "r-e"}) {
+		t.Errorf("expected the full sorted set with no limit, got %v", page)
+	}
+}
+
+func TestGetIDsPageFiltersByCategoryAndSlugPrefix(t *testing.T) {
+	service, tenantCtx := newResourcePagingFixture(t)
+
+	page, total, err := service.GetIDsPage(tenantCtx, "docs", "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 2 || !reflect.DeepEqual(page, []string{"r-a", "r-c"}) {
+		t.Errorf("expected the docs category to page [r-a r-c], got %v of %d", page, total)
+	}
+
+	page, total, err = service.GetIDsPage(tenantCtx, "", "guide-", 10, 0)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 3 || !reflect.DeepEqual(page, []string{"r-a", "r-b", "r-c"}) {
+		t.Errorf("expected the guide- prefix to page [r-a r-b r-c], got %v of %d", page, total)
+	}
+
+	// Both filters combine, and paging applies to the filtered set.
+	page, total, err = service.GetIDsPage(tenantCtx, "docs", "guide-", 1, 1)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 2 || !reflect.DeepEqual(page, []string{"r-c"}) {
+		t.Errorf("expected the combined filter to page [r-c] of 2, got %v of %d", page, total)
+	}
+
+	// An unknown category yields an empty set, not an error.
+	page, total, err = service.GetIDsPage(tenantCtx, "missing", "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetIDsPage failed: %v", err)
+	}
+	if total != 0 || len(page) != 0 {
+		t.Errorf("expected an empty set for an unknown category, got %v of %d", page, total)
+	}
+}
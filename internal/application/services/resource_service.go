@@ -4,9 +4,12 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
@@ -143,6 +146,121 @@ func (s *ResourceService) GetByFilters(tenantCtx *tenant.Context, ids []string,
 	return resources, nil
 }
 
+// GetByOptionFilters returns resources whose options payload matches every
+// key/value filter. FindAll ensures the tenant's resources are cached, then
+// the content store's option index answers the filter without re-scanning;
+// the index is built on the first filtered query.
+func (s *ResourceService) GetByOptionFilters(tenantCtx *tenant.Context, filters map[string]string) ([]*content.ResourceNode, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_resources_by_option_filters", tenantCtx.TenantID)
+	defer marker.Complete()
+	if len(filters) == 0 {
+		return []*content.ResourceNode{}, nil
+	}
+
+	resourceRepo := tenantCtx.ResourceRepo()
+	if _, err := resourceRepo.FindAll(tenantCtx.TenantID); err != nil {
+		return nil, fmt.Errorf("failed to load resources for option filtering: %w", err)
+	}
+
+	ids := tenantCtx.CacheManager.GetResourceIDsByOptions(tenantCtx.TenantID, filters)
+	if len(ids) == 0 {
+		return []*content.ResourceNode{}, nil
+	}
+
+	resources, err := resourceRepo.FindByIDs(tenantCtx.TenantID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resources by option filters: %w", err)
+	}
+
+	s.logger.Content().Info("Successfully retrieved resources by option filters", "tenantId", tenantCtx.TenantID, "filterCount", len(filters), "foundCount", len(resources), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for GetByOptionFilters", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	return resources, nil
+}
+
+// GetIDsPage returns one page of resource IDs, optionally filtered by
+// category and slug prefix, plus the total size of the filtered set. IDs are
+// sorted so pagination stays stable across requests; an unknown category or
+// an offset past the end yields an empty page rather than an error.
+func (s *ResourceService) GetIDsPage(tenantCtx *tenant.Context, category, slugPrefix string, limit, offset int) ([]string, int, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_resource_ids_page", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	// A pure category filter is answered by the CategoryToIDs index without
+	// materializing any nodes; fall through to the repository when the index
+	// has no entry for this category yet.
+	var indexedIDs []string
+	indexHit := false
+	if category != "" && slugPrefix == "" {
+		indexedIDs, indexHit = tenantCtx.CacheManager.GetResourcesByCategory(tenantCtx.TenantID, category)
+	}
+
+	var ids []string
+	if indexHit {
+		ids = append(ids, indexedIDs...)
+	} else if category != "" || slugPrefix != "" {
+		var categories []string
+		if category != "" {
+			categories = []string{category}
+		}
+		resources, err := s.GetByFilters(tenantCtx, nil, categories, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		if category == "" {
+			// Slug-prefix only: GetByFilters with no criteria returns
+			// nothing, so walk the full set instead.
+			allIDs, err := s.GetAllIDs(tenantCtx)
+			if err != nil {
+				return nil, 0, err
+			}
+			resources, err = s.GetByIDs(tenantCtx, allIDs)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		for _, resource := range resources {
+			if resource == nil {
+				continue
+			}
+			if slugPrefix != "" && !strings.HasPrefix(resource.Slug, slugPrefix) {
+				continue
+			}
+			ids = append(ids, resource.ID)
+		}
+	} else {
+		allIDs, err := s.GetAllIDs(tenantCtx)
+		if err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, allIDs...)
+	}
+
+	sort.Strings(ids)
+	total := len(ids)
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	page := ids[offset:end]
+
+	s.logger.Content().Info("Successfully retrieved resource ID page", "tenantId", tenantCtx.TenantID, "category", category, "slugPrefix", slugPrefix, "offset", offset, "pageSize", len(page), "total", total, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for GetResourceIDsPage", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	return page, total, nil
+}
+
 // Create creates a new resource
 func (s *ResourceService) Create(tenantCtx *tenant.Context, resource *content.ResourceNode) error {
 	start := time.Now()
@@ -171,9 +289,9 @@ func (s *ResourceService) Create(tenantCtx *tenant.Context, resource *content.Re
 	tenantCtx.CacheManager.SetResource(tenantCtx.TenantID, resource)
 	// 2. Surgically add the new ID to the master ID list.
 	tenantCtx.CacheManager.AddResourceID(tenantCtx.TenantID, resource.ID)
-	// 3. Refresh content map after successful creation
-	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
-		s.logger.Content().Error("Failed to refresh content map after resource creation",
+	// 3. Surgically add the new entry to the content map after successful creation
+	if err := s.contentMapService.UpdateContentMapEntry(tenantCtx, tenantCtx.GetCacheManager(), resourceContentMapItem(resource)); err != nil {
+		s.logger.Content().Error("Failed to update content map after resource creation",
 			"error", err, "resourceId", resource.ID, "tenantId", tenantCtx.TenantID)
 		// Do not fail the operation; the content map will be refreshed on the next cache miss.
 	}
@@ -220,9 +338,9 @@ func (s *ResourceService) Update(tenantCtx *tenant.Context, resource *content.Re
 
 	// 1. Surgically update the item in the item cache. The ID list is not affected.
 	tenantCtx.CacheManager.SetResource(tenantCtx.TenantID, resource)
-	// 2. Refresh content map after successful creation
-	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
-		s.logger.Content().Error("Failed to refresh content map after resource update",
+	// 2. Surgically patch the entry in the content map after successful update
+	if err := s.contentMapService.UpdateContentMapEntry(tenantCtx, tenantCtx.GetCacheManager(), resourceContentMapItem(resource)); err != nil {
+		s.logger.Content().Error("Failed to update content map after resource update",
 			"error", err, "resourceId", resource.ID, "tenantId", tenantCtx.TenantID)
 		// Do not fail the operation; the content map will be refreshed on the next cache miss.
 	}
@@ -262,9 +380,9 @@ func (s *ResourceService) Delete(tenantCtx *tenant.Context, id string) error {
 	tenantCtx.CacheManager.InvalidateResource(tenantCtx.TenantID, id)
 	// 2. Surgically remove the ID from the master ID list.
 	tenantCtx.CacheManager.RemoveResourceID(tenantCtx.TenantID, id)
-	// 3. Refresh content map after successful creation
-	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
-		s.logger.Content().Error("Failed to refresh content map after resource deletion",
+	// 3. Surgically remove the entry from the content map after successful deletion
+	if err := s.contentMapService.RemoveContentMapEntry(tenantCtx, tenantCtx.GetCacheManager(), id); err != nil {
+		s.logger.Content().Error("Failed to update content map after resource deletion",
 			"error", err, "resourceId", id, "tenantId", tenantCtx.TenantID)
 		// Do not fail the operation; the content map will be refreshed on the next cache miss.
 	}
@@ -275,3 +393,15 @@ func (s *ResourceService) Delete(tenantCtx *tenant.Context, id string) error {
 
 	return nil
 }
+
+// resourceContentMapItem builds the content-map entry for a resource node,
+// mirroring the shape BuildContentMap produces for the resources table.
+func resourceContentMapItem(resource *content.ResourceNode) types.FullContentMapItem {
+	return types.FullContentMapItem{
+		ID:           resource.ID,
+		Title:        resource.Title,
+		Slug:         resource.Slug,
+		Type:         "Resource",
+		CategorySlug: resource.CategorySlug,
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+func TestSankeyLinksEveryConsecutiveStepPair(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	// Five ordered steps with a shrinking visitor cohort; the diagram must
+	// link every consecutive pair, not just the first two steps.
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate":              {StepIndex: 1, Visitors: visitorSet("v1", "v2", "v3", "v4", "v5")},
+		"identifyAs_member":        {StepIndex: 2, Visitors: visitorSet("v1", "v2", "v3", "v4")},
+		"commitmentAction_read":    {StepIndex: 3, Visitors: visitorSet("v1", "v2", "v3")},
+		"commitmentAction_share":   {StepIndex: 4, Visitors: visitorSet("v1", "v2")},
+		"conversionAction_convert": {StepIndex: 5, Visitors: visitorSet("v1")},
+	})
+
+	diagram, err := service.ComputeEpinetSankey(tenantCtx, "ep1", nil)
+	if err != nil {
+		t.Fatalf("ComputeEpinetSankey failed: %v", err)
+	}
+	if len(diagram.Nodes) != 5 {
+		t.Fatalf("expected all 5 step nodes in the diagram, got %d", len(diagram.Nodes))
+	}
+	if len(diagram.Links) != 4 {
+		t.Fatalf("expected 4 consecutive-step links, got %d", len(diagram.Links))
+	}
+
+	nodeByIndex := make(map[int]string, len(diagram.Nodes))
+	for i, node := range diagram.Nodes {
+		nodeByIndex[i] = node.ID
+	}
+	linkValues := make(map[string]int, len(diagram.Links))
+	for _, link := range diagram.Links {
+		linkValues[nodeByIndex[link.Source]+"->"+nodeByIndex[link.Target]] = link.Value
+	}
+
+	expected := map[string]int{
+		"belief-gate->identifyAs-member":                   4,
+		"identifyAs-member->commitmentAction-read":         3,
+		"commitmentAction-read->commitmentAction-share":    2,
+		"commitmentAction-share->conversionAction-convert": 1,
+	}
+	for transition, want := range expected {
+		got, exists := linkValues[transition]
+		if !exists {
+			t.Errorf("expected a link for %s, links were %v", transition, linkValues)
+			continue
+		}
+		if got != want {
+			t.Errorf("expected link %s to carry %d visitors, got %d", transition, want, got)
+		}
+	}
+}
+
+func TestSankeySkipsStepsWithNoSurvivors(t *testing.T) {
+	service, tenantCtx := newEpinetAnalyticsFixture(t)
+
+	// Nobody who reached step 2 continues to step 3, so the 2->3 link (and
+	// with it the step 3 node) drops out while 1->2 survives.
+	seedEpinetBin(tenantCtx, "ep1", lastHourKey(), map[string]*types.HourlyEpinetStepData{
+		"belief_gate":           {StepIndex: 1, Visitors: visitorSet("v1", "v2")},
+		"commitmentAction_read": {StepIndex: 2, Visitors: visitorSet("v1")},
+		"conversionAction_buy":  {StepIndex: 3, Visitors: visitorSet("v9")},
+	})
+
+	diagram, err := service.ComputeEpinetSankey(tenantCtx, "ep1", nil)
+	if err != nil {
+		t.Fatalf("ComputeEpinetSankey failed: %v", err)
+	}
+	if len(diagram.Links) != 1 {
+		t.Fatalf("expected only the 1->2 link to survive, got %d links", len(diagram.Links))
+	}
+	for _, node := range diagram.Nodes {
+		if node.ID == "conversionAction-buy" {
+			t.Error("expected the unreachable step 3 node to be pruned from the diagram")
+		}
+	}
+}
@@ -4,7 +4,10 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"strings"
 	"time"
@@ -22,13 +25,15 @@ import (
 type SessionService struct {
 	beliefBroadcaster *BeliefBroadcastService
 	logger            *logging.ChanneledLogger
+	auditLogger       *logging.AuditLogger
 	perfTracker       *performance.Tracker
 }
 
-func NewSessionService(beliefBroadcaster *BeliefBroadcastService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *SessionService {
+func NewSessionService(beliefBroadcaster *BeliefBroadcastService, logger *logging.ChanneledLogger, auditLogger *logging.AuditLogger, perfTracker *performance.Tracker) *SessionService {
 	return &SessionService{
 		beliefBroadcaster: beliefBroadcaster,
 		logger:            logger,
+		auditLogger:       auditLogger,
 		perfTracker:       perfTracker,
 	}
 }
@@ -68,7 +73,7 @@ type VisitRowData struct {
 	CreatedAt     time.Time
 }
 
-func (s *SessionService) ProcessVisitRequest(req *VisitRequest, storyfragmentID string, tenantCtx *tenant.Context) *SessionResult {
+func (s *SessionService) ProcessVisitRequest(req *VisitRequest, storyfragmentID, clientIP, idempotencyKey string, tenantCtx *tenant.Context) *SessionResult {
 	if req.SessionID == nil {
 		return &SessionResult{Success: false, Error: "session ID required"}
 	}
@@ -81,23 +86,79 @@ func (s *SessionService) ProcessVisitRequest(req *VisitRequest, storyfragmentID
 		consentValue = "unknown"
 	}
 
+	// Replay guard: a client retrying after a timeout sends the same
+	// Idempotency-Key, and gets the stored outcome back instead of a second
+	// fingerprint and visit. Keys are scoped to the session so different
+	// sessions can reuse the same key value safely.
+	var replayKey string
+	if idempotencyKey != "" {
+		replayKey = sessionID + "\x00" + idempotencyKey
+		if cached, found := tenantCtx.CacheManager.GetIdempotentResult(tenantCtx.TenantID, replayKey); found {
+			if result, ok := cached.(*SessionResult); ok {
+				s.logger.Auth().Debug("Visit request replayed from idempotency cache",
+					"tenantId", tenantCtx.TenantID, "sessionId", sessionID)
+				return result
+			}
+		}
+	}
+
+	result := s.resolveVisitResult(req, sessionID, storyfragmentID, consentValue, clientIP, tenantCtx)
+
+	if replayKey != "" && result.Success {
+		tenantCtx.CacheManager.SetIdempotentResult(tenantCtx.TenantID, replayKey, result)
+	}
+
+	return result
+}
+
+// resolveVisitResult runs the visit-processing priority chain for a session.
+func (s *SessionService) resolveVisitResult(req *VisitRequest, sessionID, storyfragmentID, consentValue, clientIP string, tenantCtx *tenant.Context) *SessionResult {
 	// Priority 1: Profile unlock (encrypted credentials provided)
 	if req.EncryptedEmail != nil && req.EncryptedCode != nil {
-		return s.processProfileUnlock(sessionID, storyfragmentID, *req.EncryptedEmail, *req.EncryptedCode, consentValue, tenantCtx)
+		result := s.processProfileUnlock(sessionID, storyfragmentID, *req.EncryptedEmail, *req.EncryptedCode, consentValue, clientIP, tenantCtx)
+		s.auditSessionResult(tenantCtx, "profile_unlock", clientIP, result)
+		return result
 	}
 
 	// Priority 2: Cross-tab session cloning (different session ID provided)
 	if req.TractStackSessionID != nil {
-		return s.processSessionCloning(sessionID, storyfragmentID, *req.TractStackSessionID, consentValue, tenantCtx)
+		result := s.processSessionCloning(sessionID, storyfragmentID, *req.TractStackSessionID, consentValue, tenantCtx)
+		s.auditSessionResult(tenantCtx, "session_clone", clientIP, result)
+		return result
 	}
 
 	// Priority 3: Existing session - check for same-session restoration
 	if existingSession, exists := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID); exists {
-		return s.processExistingSession(existingSession, sessionID, storyfragmentID, consentValue, tenantCtx)
+		result := s.processExistingSession(existingSession, sessionID, storyfragmentID, consentValue, tenantCtx)
+		s.auditSessionResult(tenantCtx, "visit", clientIP, result)
+		return result
 	}
 
 	// Priority 4: New session warming
-	return s.processSessionWarming(sessionID, consentValue, tenantCtx)
+	result := s.processSessionWarming(sessionID, consentValue, tenantCtx)
+	s.auditSessionResult(tenantCtx, "visit", clientIP, result)
+	return result
+}
+
+// auditSessionResult records the outcome of a visit-processing path in the
+// audit log. Failed results carry their error so the log tells the whole
+// story without cross-referencing the auth channel.
+func (s *SessionService) auditSessionResult(tenantCtx *tenant.Context, eventType, clientIP string, result *SessionResult) {
+	var extra map[string]string
+	if result.Error != "" {
+		extra = map[string]string{"error": result.Error}
+	}
+	s.auditLogger.LogAuthEvent(tenantCtx.TenantID, eventType, result.FingerprintID, result.SessionID, clientIP, result.Success, extra)
+}
+
+// sessionErrorString maps infrastructure failures onto the stable error
+// strings the HTTP layer switches on, so a dropped database connection
+// surfaces as a retryable condition rather than a generic failure.
+func sessionErrorString(err error, fallback string) string {
+	if errors.Is(err, tenant.ErrDatabaseUnavailable) {
+		return "database unavailable"
+	}
+	return fallback
 }
 
 func (s *SessionService) processExistingSession(session *types.SessionData, sessionID, storyfragmentID, consent string, tenantCtx *tenant.Context) *SessionResult {
@@ -160,13 +221,13 @@ func (s *SessionService) processSessionWarming(sessionID, consent string, tenant
 	fingerprintID := security.GenerateULID()
 	if err := s.CreateFingerprint(fingerprintID, nil, tenantCtx); err != nil {
 		if !strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return &SessionResult{Success: false, Error: "failed to create fingerprint"}
+			return &SessionResult{Success: false, Error: sessionErrorString(err, "failed to create fingerprint")}
 		}
 	}
 
 	visitID, err := s.HandleVisitCreation(fingerprintID, false, tenantCtx)
 	if err != nil {
-		return &SessionResult{Success: false, Error: "failed to create visit"}
+		return &SessionResult{Success: false, Error: sessionErrorString(err, "failed to create visit")}
 	}
 
 	s.updateCacheStates(tenantCtx, sessionID, fingerprintID, visitID, nil)
@@ -182,7 +243,7 @@ func (s *SessionService) processSessionWarming(sessionID, consent string, tenant
 	}
 }
 
-func (s *SessionService) processProfileUnlock(sessionID, storyfragmentID, encryptedEmail, encryptedCode, consent string, tenantCtx *tenant.Context) *SessionResult {
+func (s *SessionService) processProfileUnlock(sessionID, storyfragmentID, encryptedEmail, encryptedCode, consent, clientIP string, tenantCtx *tenant.Context) *SessionResult {
 	s.logger.Auth().Debug("processProfileUnlock ENTRY",
 		"sessionId", sessionID,
 		"storyfragmentId", storyfragmentID,
@@ -190,15 +251,15 @@ func (s *SessionService) processProfileUnlock(sessionID, storyfragmentID, encryp
 		"hasEncryptedCode", encryptedCode != "",
 		"consent", consent)
 
-	decryptedEmail, err := security.Decrypt(encryptedEmail, tenantCtx.Config.AESKey)
+	decryptedEmail, err := security.DecryptWithFallback(encryptedEmail, tenantCtx.Config.AESKey, tenantCtx.Config.PreviousAESKey)
 	if err != nil {
 		return &SessionResult{Success: false, Error: "failed to decrypt email"}
 	}
-	decryptedCode, err := security.Decrypt(encryptedCode, tenantCtx.Config.AESKey)
+	decryptedCode, err := security.DecryptWithFallback(encryptedCode, tenantCtx.Config.AESKey, tenantCtx.Config.PreviousAESKey)
 	if err != nil {
 		return &SessionResult{Success: false, Error: "failed to decrypt code"}
 	}
-	lead, err := s.ValidateLeadCredentials(decryptedEmail, decryptedCode, tenantCtx)
+	lead, err := s.ValidateLeadCredentials(decryptedEmail, decryptedCode, clientIP, tenantCtx)
 	if err != nil || lead == nil {
 		return &SessionResult{Success: false, Error: "invalid credentials"}
 	}
@@ -213,14 +274,14 @@ func (s *SessionService) processProfileUnlock(sessionID, storyfragmentID, encryp
 	if fingerprintID == nil {
 		newFpID := security.GenerateULID()
 		if err := s.CreateFingerprint(newFpID, &lead.ID, tenantCtx); err != nil {
-			return &SessionResult{Success: false, Error: "failed to create fingerprint for existing lead"}
+			return &SessionResult{Success: false, Error: sessionErrorString(err, "failed to create fingerprint for existing lead")}
 		}
 		fingerprintID = &newFpID
 	}
 
 	visitID, err := s.HandleVisitCreation(*fingerprintID, true, tenantCtx)
 	if err != nil {
-		return &SessionResult{Success: false, Error: "failed to create visit for profile"}
+		return &SessionResult{Success: false, Error: sessionErrorString(err, "failed to create visit for profile")}
 	}
 
 	s.updateCacheStates(tenantCtx, sessionID, *fingerprintID, visitID, &lead.ID)
@@ -359,6 +420,11 @@ func (s *SessionService) HandleVisitCreation(fingerprintID string, hasProfile bo
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return "", err
+	}
+
 	if latestVisit, err := s.GetLatestVisitByFingerprint(fingerprintID, tenantCtx); err == nil && latestVisit != nil {
 		if time.Since(latestVisit.CreatedAt) < 2*time.Hour {
 			return latestVisit.ID, nil
@@ -367,7 +433,7 @@ func (s *SessionService) HandleVisitCreation(fingerprintID string, hasProfile bo
 
 	visitID := security.GenerateULID()
 	query := `INSERT INTO visits (id, fingerprint_id, created_at) VALUES (?, ?, ?)`
-	_, err := tenantCtx.Database.Conn.ExecContext(ctx, query, visitID, fingerprintID, time.Now().UTC())
+	_, err = conn.ExecContext(ctx, query, visitID, fingerprintID, time.Now().UTC())
 	if err != nil {
 		return "", fmt.Errorf("failed to create visit: %w", err)
 	}
@@ -379,18 +445,23 @@ func (s *SessionService) GetLatestVisitByFingerprint(fingerprintID string, tenan
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `SELECT id, fingerprint_id, campaign_id, created_at
               FROM visits
               WHERE fingerprint_id = ?
               ORDER BY created_at DESC
               LIMIT 1`
 
-	row := tenantCtx.Database.Conn.QueryRowContext(ctx, query, fingerprintID)
+	row := conn.QueryRowContext(ctx, query, fingerprintID)
 
 	var visit VisitRowData
 	var campaignID sql.NullString
 
-	err := row.Scan(&visit.ID, &visit.FingerprintID, &campaignID, &visit.CreatedAt)
+	err = row.Scan(&visit.ID, &visit.FingerprintID, &campaignID, &visit.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -409,18 +480,41 @@ func (s *SessionService) CreateFingerprint(fingerprintID string, leadID *string,
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return err
+	}
+
 	query := `INSERT INTO fingerprints (id, lead_id, created_at) VALUES (?, ?, ?)`
-	_, err := tenantCtx.Database.Conn.ExecContext(ctx, query, fingerprintID, leadID, time.Now().UTC())
+	_, err = conn.ExecContext(ctx, query, fingerprintID, leadID, time.Now().UTC())
 	return err
 }
 
+// FindFingerprintByLeadID returns the lead's most recently active fingerprint.
+// A lead can accumulate several fingerprints (one per device), so activity —
+// the latest visit, falling back to fingerprint creation — decides which one
+// the auth flow treats as canonical.
 func (s *SessionService) FindFingerprintByLeadID(leadID string, tenantCtx *tenant.Context) *string {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, dbErr := tenantCtx.DB()
+	if dbErr != nil {
+		log.Printf("Error finding fingerprint by lead ID: %v", dbErr)
+		return nil
+	}
+
 	var fingerprintID string
-	query := `SELECT id FROM fingerprints WHERE lead_id = ? LIMIT 1`
-	err := tenantCtx.Database.Conn.QueryRowContext(ctx, query, leadID).Scan(&fingerprintID)
+	query := `
+		SELECT f.id
+		FROM fingerprints f
+		LEFT JOIN visits v ON v.fingerprint_id = f.id
+		WHERE f.lead_id = ?
+		GROUP BY f.id
+		ORDER BY COALESCE(MAX(v.created_at), f.created_at) DESC
+		LIMIT 1
+	`
+	err := conn.QueryRowContext(ctx, query, leadID).Scan(&fingerprintID)
 	if err == sql.ErrNoRows {
 		return nil
 	}
@@ -431,12 +525,401 @@ func (s *SessionService) FindFingerprintByLeadID(leadID string, tenantCtx *tenan
 	return &fingerprintID
 }
 
+// ConsolidateLeadFingerprints merges held beliefs from all of a lead's
+// fingerprints into the canonical (most recently active) one. Beliefs the
+// canonical fingerprint already holds win; beliefs unique to the duplicates
+// are copied over, taking the most recently updated row per belief. Returns
+// the canonical fingerprint ID and the number of belief rows copied.
+func (s *SessionService) ConsolidateLeadFingerprints(leadID string, tenantCtx *tenant.Context) (string, int, error) {
+	canonical := s.FindFingerprintByLeadID(leadID, tenantCtx)
+	if canonical == nil {
+		return "", 0, fmt.Errorf("no fingerprints found for lead %s", leadID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return "", 0, err
+	}
+
+	query := `
+		SELECT hb.belief_id, hb.verb, hb.object
+		FROM heldbeliefs hb
+		JOIN fingerprints f ON f.id = hb.fingerprint_id
+		WHERE f.lead_id = ?
+		  AND hb.fingerprint_id != ?
+		  AND hb.belief_id NOT IN (SELECT belief_id FROM heldbeliefs WHERE fingerprint_id = ?)
+		ORDER BY hb.updated_at DESC
+	`
+	rows, err := conn.QueryContext(ctx, query, leadID, *canonical, *canonical)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to query duplicate fingerprint beliefs: %w", err)
+	}
+	defer rows.Close()
+
+	type heldBelief struct {
+		beliefID string
+		verb     string
+		object   sql.NullString
+	}
+	seen := make(map[string]bool)
+	var toCopy []heldBelief
+	for rows.Next() {
+		var hb heldBelief
+		if err := rows.Scan(&hb.beliefID, &hb.verb, &hb.object); err != nil {
+			return "", 0, fmt.Errorf("failed to scan held belief: %w", err)
+		}
+		if seen[hb.beliefID] {
+			continue
+		}
+		seen[hb.beliefID] = true
+		toCopy = append(toCopy, hb)
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, fmt.Errorf("failed to read duplicate fingerprint beliefs: %w", err)
+	}
+
+	insertQuery := `INSERT INTO heldbeliefs (id, belief_id, fingerprint_id, verb, object, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
+	for _, hb := range toCopy {
+		if _, err := conn.ExecContext(ctx, insertQuery, security.GenerateULID(), hb.beliefID, *canonical, hb.verb, hb.object, time.Now().UTC()); err != nil {
+			return "", 0, fmt.Errorf("failed to copy held belief to canonical fingerprint: %w", err)
+		}
+	}
+
+	// Mirror the merge into the cached fingerprint states so personalization
+	// reflects the consolidation without waiting for a cache reload.
+	cacheManager := tenantCtx.CacheManager
+	if canonicalState, exists := cacheManager.GetFingerprintState(tenantCtx.TenantID, *canonical); exists {
+		otherRows, err := conn.QueryContext(ctx, `SELECT id FROM fingerprints WHERE lead_id = ? AND id != ?`, leadID, *canonical)
+		if err == nil {
+			defer otherRows.Close()
+			for otherRows.Next() {
+				var fpID string
+				if err := otherRows.Scan(&fpID); err != nil {
+					break
+				}
+				if dupState, ok := cacheManager.GetFingerprintState(tenantCtx.TenantID, fpID); ok {
+					for slug, values := range dupState.HeldBeliefs {
+						if _, held := canonicalState.HeldBeliefs[slug]; !held {
+							canonicalState.HeldBeliefs[slug] = values
+						}
+					}
+				}
+			}
+		}
+		cacheManager.SetFingerprintState(tenantCtx.TenantID, canonicalState)
+	}
+
+	s.logger.Auth().Info("Lead fingerprints consolidated",
+		"leadId", leadID, "canonicalFingerprintId", *canonical, "beliefsCopied", len(toCopy))
+
+	return *canonical, len(toCopy), nil
+}
+
+// MergeFingerprints consolidates two fingerprints into one, re-pointing all
+// visits, actions, and held beliefs from the secondary fingerprint onto the
+// primary and marking the secondary as merged. Cached sessions of the
+// secondary fingerprint are re-indexed under the primary so live
+// personalization follows the merge. Returns how many visit and action rows
+// were moved.
+func (s *SessionService) MergeFingerprints(primaryID, secondaryID, clientIP string, tenantCtx *tenant.Context) (int, int, error) {
+	if primaryID == secondaryID {
+		return 0, 0, fmt.Errorf("cannot merge a fingerprint into itself")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, fpID := range []string{primaryID, secondaryID} {
+		var existing string
+		if err := conn.QueryRowContext(ctx, `SELECT id FROM fingerprints WHERE id = ?`, fpID).Scan(&existing); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, 0, fmt.Errorf("fingerprint not found: %s", fpID)
+			}
+			return 0, 0, fmt.Errorf("failed to query fingerprint: %w", err)
+		}
+	}
+
+	// The re-points run inside one transaction so a mid-sequence failure
+	// can't leave a half-merged fingerprint.
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin merge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	visitResult, err := tx.ExecContext(ctx, `UPDATE visits SET fingerprint_id = ? WHERE fingerprint_id = ?`, primaryID, secondaryID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to re-point visits: %w", err)
+	}
+	visitCount, _ := visitResult.RowsAffected()
+
+	actionResult, err := tx.ExecContext(ctx, `UPDATE actions SET fingerprint_id = ? WHERE fingerprint_id = ?`, primaryID, secondaryID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to re-point actions: %w", err)
+	}
+	actionCount, _ := actionResult.RowsAffected()
+
+	// Move only beliefs the primary doesn't already hold (beliefs the primary
+	// holds win, matching ConsolidateLeadFingerprints), then drop the
+	// leftovers so the merge never produces duplicate belief rows.
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE heldbeliefs SET fingerprint_id = ?
+		WHERE fingerprint_id = ?
+		  AND belief_id NOT IN (SELECT belief_id FROM heldbeliefs WHERE fingerprint_id = ?)`,
+		primaryID, secondaryID, primaryID); err != nil {
+		return 0, 0, fmt.Errorf("failed to re-point held beliefs: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM heldbeliefs WHERE fingerprint_id = ?`, secondaryID); err != nil {
+		return 0, 0, fmt.Errorf("failed to drop duplicate held beliefs: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE fingerprints SET merged_into_id = ? WHERE id = ?`, primaryID, secondaryID); err != nil {
+		return 0, 0, fmt.Errorf("failed to mark fingerprint as merged: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit fingerprint merge: %w", err)
+	}
+
+	// Fold the secondary's cached beliefs into the primary's state (beliefs
+	// the primary already holds win) and re-index the secondary's sessions
+	// under the primary; SetSession rebuilds the fingerprint-to-sessions
+	// index when the fingerprint changes.
+	cacheManager := tenantCtx.CacheManager
+	if primaryState, exists := cacheManager.GetFingerprintState(tenantCtx.TenantID, primaryID); exists {
+		if secondaryState, ok := cacheManager.GetFingerprintState(tenantCtx.TenantID, secondaryID); ok {
+			for slug, values := range secondaryState.HeldBeliefs {
+				if _, held := primaryState.HeldBeliefs[slug]; !held {
+					primaryState.HeldBeliefs[slug] = values
+				}
+			}
+		}
+		cacheManager.SetFingerprintState(tenantCtx.TenantID, primaryState)
+	}
+
+	registryIDs := cacheManager.GetAllStoryfragmentBeliefRegistryIDs(tenantCtx.TenantID)
+	for _, sessionID := range cacheManager.GetSessionsByFingerprint(tenantCtx.TenantID, secondaryID) {
+		session, exists := cacheManager.GetSession(tenantCtx.TenantID, sessionID)
+		if !exists {
+			continue
+		}
+		session.FingerprintID = primaryID
+		cacheManager.SetSession(tenantCtx.TenantID, session)
+		for _, storyfragmentID := range registryIDs {
+			cacheManager.InvalidateSessionBeliefContext(tenantCtx.TenantID, sessionID, storyfragmentID)
+		}
+	}
+
+	s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "merge_fingerprints", primaryID, "", clientIP, true,
+		map[string]string{"secondaryFingerprintId": secondaryID})
+	s.logger.Auth().Info("Fingerprints merged",
+		"tenantId", tenantCtx.TenantID, "primaryFingerprintId", primaryID, "secondaryFingerprintId", secondaryID,
+		"mergedVisitCount", visitCount, "mergedActionCount", actionCount)
+
+	return int(visitCount), int(actionCount), nil
+}
+
+// ExportFingerprintData streams every row associated with a fingerprint as a
+// single JSON document with fingerprint, visits, actions, beliefs, and lead
+// sections. Rows are encoded as they are scanned so a long event history
+// never has to sit in memory, and the export is recorded in the audit log.
+func (s *SessionService) ExportFingerprintData(fingerprintID, clientIP string, tenantCtx *tenant.Context, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return err
+	}
+
+	var leadID, mergedIntoID sql.NullString
+	var fingerprintCreatedAt time.Time
+	if err := conn.QueryRowContext(ctx, `SELECT lead_id, merged_into_id, created_at FROM fingerprints WHERE id = ?`, fingerprintID).Scan(&leadID, &mergedIntoID, &fingerprintCreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("fingerprint not found: %s", fingerprintID)
+		}
+		return fmt.Errorf("failed to query fingerprint: %w", err)
+	}
+
+	enc := json.NewEncoder(w)
+
+	if _, err := io.WriteString(w, `{"fingerprint":`); err != nil {
+		return err
+	}
+	fingerprint := map[string]any{"id": fingerprintID, "createdAt": fingerprintCreatedAt}
+	if leadID.Valid {
+		fingerprint["leadId"] = leadID.String
+	}
+	if mergedIntoID.Valid {
+		fingerprint["mergedIntoId"] = mergedIntoID.String
+	}
+	if err := enc.Encode(fingerprint); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `,"visits":[`); err != nil {
+		return err
+	}
+	visitRows, err := conn.QueryContext(ctx, `SELECT id, campaign_id, created_at FROM visits WHERE fingerprint_id = ? ORDER BY created_at`, fingerprintID)
+	if err != nil {
+		return fmt.Errorf("failed to query visits: %w", err)
+	}
+	defer visitRows.Close()
+	first := true
+	for visitRows.Next() {
+		var id string
+		var campaignID sql.NullString
+		var createdAt time.Time
+		if err := visitRows.Scan(&id, &campaignID, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan visit row: %w", err)
+		}
+		visit := map[string]any{"id": id, "createdAt": createdAt}
+		if campaignID.Valid {
+			visit["campaignId"] = campaignID.String
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if err := enc.Encode(visit); err != nil {
+			return err
+		}
+	}
+	if err := visitRows.Err(); err != nil {
+		return fmt.Errorf("failed to read visit rows: %w", err)
+	}
+	if _, err := io.WriteString(w, `],"actions":[`); err != nil {
+		return err
+	}
+
+	actionRows, err := conn.QueryContext(ctx, `SELECT id, object_id, object_type, verb, duration, visit_id, created_at FROM actions WHERE fingerprint_id = ? ORDER BY created_at`, fingerprintID)
+	if err != nil {
+		return fmt.Errorf("failed to query actions: %w", err)
+	}
+	defer actionRows.Close()
+	first = true
+	for actionRows.Next() {
+		var id, objectID, objectType, verb, visitID string
+		var duration sql.NullInt64
+		var createdAt time.Time
+		if err := actionRows.Scan(&id, &objectID, &objectType, &verb, &duration, &visitID, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan action row: %w", err)
+		}
+		action := map[string]any{"id": id, "objectId": objectID, "objectType": objectType, "verb": verb, "visitId": visitID, "createdAt": createdAt}
+		if duration.Valid {
+			action["duration"] = duration.Int64
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+	}
+	if err := actionRows.Err(); err != nil {
+		return fmt.Errorf("failed to read action rows: %w", err)
+	}
+	if _, err := io.WriteString(w, `],"beliefs":[`); err != nil {
+		return err
+	}
+
+	beliefRows, err := conn.QueryContext(ctx, `SELECT b.slug, hb.verb, hb.object, hb.updated_at FROM heldbeliefs hb JOIN beliefs b ON b.id = hb.belief_id WHERE hb.fingerprint_id = ? ORDER BY hb.updated_at`, fingerprintID)
+	if err != nil {
+		return fmt.Errorf("failed to query held beliefs: %w", err)
+	}
+	defer beliefRows.Close()
+	first = true
+	for beliefRows.Next() {
+		var slug, verb string
+		var object sql.NullString
+		var updatedAt time.Time
+		if err := beliefRows.Scan(&slug, &verb, &object, &updatedAt); err != nil {
+			return fmt.Errorf("failed to scan held belief row: %w", err)
+		}
+		belief := map[string]any{"beliefSlug": slug, "verb": verb, "updatedAt": updatedAt}
+		if object.Valid {
+			belief["object"] = object.String
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if err := enc.Encode(belief); err != nil {
+			return err
+		}
+	}
+	if err := beliefRows.Err(); err != nil {
+		return fmt.Errorf("failed to read held belief rows: %w", err)
+	}
+	if _, err := io.WriteString(w, `],"lead":`); err != nil {
+		return err
+	}
+
+	if leadID.Valid {
+		var lead user.Lead
+		var shortBio, status sql.NullString
+		err := conn.QueryRowContext(ctx, `SELECT id, first_name, email, contact_persona, short_bio, status, created_at FROM leads WHERE id = ?`, leadID.String).Scan(
+			&lead.ID, &lead.FirstName, &lead.Email, &lead.ContactPersona, &shortBio, &status, &lead.CreatedAt)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to query lead: %w", err)
+		}
+		if err == sql.ErrNoRows {
+			if _, err := io.WriteString(w, "null"); err != nil {
+				return err
+			}
+		} else {
+			if shortBio.Valid {
+				lead.ShortBio = shortBio.String
+			}
+			lead.LeadStatus = user.LeadStatusActive
+			if status.Valid && status.String != "" {
+				lead.LeadStatus = status.String
+			}
+			if err := enc.Encode(&lead); err != nil {
+				return err
+			}
+		}
+	} else if _, err := io.WriteString(w, "null"); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "}"); err != nil {
+		return err
+	}
+
+	s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "data_export", fingerprintID, "", clientIP, true, nil)
+	s.logger.Auth().Info("Fingerprint data exported",
+		"tenantId", tenantCtx.TenantID, "fingerprintId", fingerprintID)
+
+	return nil
+}
+
 func (s *SessionService) GetLeadByFingerprint(fingerprintID string, tenantCtx *tenant.Context) (*user.Lead, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT l.id, l.first_name, l.email, l.password_hash, l.contact_persona, l.short_bio, l.encrypted_code, l.encrypted_email, l.created_at, l.changed
+		SELECT l.id, l.first_name, l.email, l.password_hash, l.contact_persona, l.short_bio, l.encrypted_code, l.encrypted_email, l.status, l.created_at, l.changed
 		FROM leads l
 		JOIN fingerprints f ON l.id = f.lead_id
 		WHERE f.id = ?
@@ -444,10 +927,10 @@ func (s *SessionService) GetLeadByFingerprint(fingerprintID string, tenantCtx *t
 	`
 
 	var lead user.Lead
-	var shortBio, encryptedCode, encryptedEmail sql.NullString
+	var shortBio, encryptedCode, encryptedEmail, status sql.NullString
 	var changed sql.NullTime
 
-	err := tenantCtx.Database.Conn.QueryRowContext(ctx, query, fingerprintID).Scan(
+	err = conn.QueryRowContext(ctx, query, fingerprintID).Scan(
 		&lead.ID,
 		&lead.FirstName,
 		&lead.Email,
@@ -456,6 +939,7 @@ func (s *SessionService) GetLeadByFingerprint(fingerprintID string, tenantCtx *t
 		&shortBio,
 		&encryptedCode,
 		&encryptedEmail,
+		&status,
 		&lead.CreatedAt,
 		&changed,
 	)
@@ -476,6 +960,10 @@ func (s *SessionService) GetLeadByFingerprint(fingerprintID string, tenantCtx *t
 	if encryptedEmail.Valid {
 		lead.EncryptedEmail = encryptedEmail.String
 	}
+	lead.LeadStatus = user.LeadStatusActive
+	if status.Valid && status.String != "" {
+		lead.LeadStatus = status.String
+	}
 	if changed.Valid {
 		lead.Changed = changed.Time
 	}
@@ -487,18 +975,23 @@ func (s *SessionService) GetLeadByID(leadID string, tenantCtx *tenant.Context) (
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, first_name, email, password_hash, contact_persona, short_bio, encrypted_code, encrypted_email, created_at, changed
+		SELECT id, first_name, email, password_hash, contact_persona, short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		FROM leads
 		WHERE id = ?
 		LIMIT 1
 	`
 
 	var lead user.Lead
-	var shortBio, encryptedCode, encryptedEmail sql.NullString
+	var shortBio, encryptedCode, encryptedEmail, status sql.NullString
 	var changed sql.NullTime
 
-	err := tenantCtx.Database.Conn.QueryRowContext(ctx, query, leadID).Scan(
+	err = conn.QueryRowContext(ctx, query, leadID).Scan(
 		&lead.ID,
 		&lead.FirstName,
 		&lead.Email,
@@ -507,6 +1000,7 @@ func (s *SessionService) GetLeadByID(leadID string, tenantCtx *tenant.Context) (
 		&shortBio,
 		&encryptedCode,
 		&encryptedEmail,
+		&status,
 		&lead.CreatedAt,
 		&changed,
 	)
@@ -527,6 +1021,10 @@ func (s *SessionService) GetLeadByID(leadID string, tenantCtx *tenant.Context) (
 	if encryptedEmail.Valid {
 		lead.EncryptedEmail = encryptedEmail.String
 	}
+	lead.LeadStatus = user.LeadStatusActive
+	if status.Valid && status.String != "" {
+		lead.LeadStatus = status.String
+	}
 	if changed.Valid {
 		lead.Changed = changed.Time
 	}
@@ -534,12 +1032,24 @@ func (s *SessionService) GetLeadByID(leadID string, tenantCtx *tenant.Context) (
 	return &lead, nil
 }
 
-func (s *SessionService) ValidateLeadCredentials(email, password string, tenantCtx *tenant.Context) (*user.Lead, error) {
+// GetLeadsByPersona returns a page of leads filtered by contact persona along
+// with the total match count. An empty persona matches all leads.
+func (s *SessionService) GetLeadsByPersona(persona string, limit, offset int, tenantCtx *tenant.Context) ([]*user.Lead, int, error) {
+	leadRepo := tenantCtx.LeadRepo()
+	leads, total, err := leadRepo.FindByPersona(persona, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get leads by persona: %w", err)
+	}
+	return leads, total, nil
+}
+
+func (s *SessionService) ValidateLeadCredentials(email, password, clientIP string, tenantCtx *tenant.Context) (*user.Lead, error) {
 	s.logger.Auth().Info("Validating lead credentials", "email", email)
 
 	lead, err := s.GetLeadByEmail(email, tenantCtx)
 	if err != nil || lead == nil {
 		s.logger.Auth().Error("Lead lookup failed", "email", email, "error", err)
+		s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "login", "", "", clientIP, false, map[string]string{"email": email, "error": "lead not found"})
 		return nil, fmt.Errorf("lead not found")
 	}
 
@@ -547,10 +1057,18 @@ func (s *SessionService) ValidateLeadCredentials(email, password string, tenantC
 
 	if err := bcrypt.CompareHashAndPassword([]byte(lead.PasswordHash), []byte(password)); err != nil {
 		s.logger.Auth().Error("Password validation failed", "email", email, "leadId", lead.ID, "error", err)
+		s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "login", "", "", clientIP, false, map[string]string{"email": email, "error": "invalid password"})
 		return nil, fmt.Errorf("invalid password")
 	}
 
+	if lead.LeadStatus != user.LeadStatusActive {
+		s.logger.Auth().Warn("Login rejected for unverified lead", "email", email, "leadId", lead.ID, "status", lead.LeadStatus)
+		s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "login", "", "", clientIP, false, map[string]string{"email": email, "error": "lead not active"})
+		return nil, fmt.Errorf("lead not active")
+	}
+
 	s.logger.Auth().Info("Lead credentials validated successfully", "email", email, "leadId", lead.ID)
+	s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "login", "", "", clientIP, true, map[string]string{"email": email, "leadId": lead.ID})
 	return lead, nil
 }
 
@@ -558,18 +1076,23 @@ func (s *SessionService) GetLeadByEmail(email string, tenantCtx *tenant.Context)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	conn, err := tenantCtx.DB()
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, first_name, email, password_hash, contact_persona, short_bio, encrypted_code, encrypted_email, created_at, changed
+		SELECT id, first_name, email, password_hash, contact_persona, short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		FROM leads
 		WHERE email = ?
 		LIMIT 1
 	`
 
 	var lead user.Lead
-	var shortBio, encryptedCode, encryptedEmail sql.NullString
+	var shortBio, encryptedCode, encryptedEmail, status sql.NullString
 	var changed sql.NullTime
 
-	err := tenantCtx.Database.Conn.QueryRowContext(ctx, query, email).Scan(
+	err = conn.QueryRowContext(ctx, query, email).Scan(
 		&lead.ID,
 		&lead.FirstName,
 		&lead.Email,
@@ -578,6 +1101,7 @@ func (s *SessionService) GetLeadByEmail(email string, tenantCtx *tenant.Context)
 		&shortBio,
 		&encryptedCode,
 		&encryptedEmail,
+		&status,
 		&lead.CreatedAt,
 		&changed,
 	)
@@ -598,6 +1122,10 @@ func (s *SessionService) GetLeadByEmail(email string, tenantCtx *tenant.Context)
 	if encryptedEmail.Valid {
 		lead.EncryptedEmail = encryptedEmail.String
 	}
+	lead.LeadStatus = user.LeadStatusActive
+	if status.Valid && status.String != "" {
+		lead.LeadStatus = status.String
+	}
 	if changed.Valid {
 		lead.Changed = changed.Time
 	}
@@ -605,7 +1133,7 @@ func (s *SessionService) GetLeadByEmail(email string, tenantCtx *tenant.Context)
 	return &lead, nil
 }
 
-func (s *SessionService) HandleProfileSession(tenantCtx *tenant.Context, profile *user.Profile, sessionID string) (*SessionResponse, error) {
+func (s *SessionService) HandleProfileSession(tenantCtx *tenant.Context, profile *user.Profile, sessionID, clientIP string) (*SessionResponse, error) {
 	s.logger.Auth().Debug("HandleProfileSession ENTRY",
 		"sessionId", sessionID,
 		"leadId", profile.LeadID,
@@ -684,7 +1212,6 @@ func (s *SessionService) HandleProfileSession(tenantCtx *tenant.Context, profile
 		LastActivity:  time.Now().UTC(),
 		CreatedAt:     time.Now().UTC(),
 		ExpiresAt:     time.Now().UTC().Add(24 * time.Hour),
-		IsExpired:     false,
 	}
 
 	tenantCtx.CacheManager.SetSession(tenantCtx.TenantID, sessionData)
@@ -755,12 +1282,29 @@ func (s *SessionService) HandleProfileSession(tenantCtx *tenant.Context, profile
 		"fingerprintId", fingerprintID,
 		"visitId", visitID)
 
+	s.auditLogger.LogAuthEvent(tenantCtx.TenantID, "profile_unlock", fingerprintID, sessionID, clientIP, true, map[string]string{"leadId": profile.LeadID})
+
 	return &SessionResponse{
 		Fingerprint: fingerprintID,
 		VisitID:     visitID,
 	}, nil
 }
 
+// ExtendSession resets an active session's hard expiry to 24 hours from now
+// and touches its last-activity timestamp. Returns false when the session is
+// unknown or already expired, so heartbeats cannot resurrect a dead session.
+func (s *SessionService) ExtendSession(sessionID string, tenantCtx *tenant.Context) bool {
+	session, exists := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID)
+	if !exists || session.IsExpired() {
+		return false
+	}
+
+	session.LastActivity = time.Now().UTC()
+	session.ExpiresAt = time.Now().UTC().Add(24 * time.Hour)
+	tenantCtx.CacheManager.SetSession(tenantCtx.TenantID, session)
+	return true
+}
+
 func (s *SessionService) updateCacheStates(tenantCtx *tenant.Context, sessionID, fingerprintID, visitID string, leadID *string) {
 	cacheManager := tenantCtx.CacheManager
 
@@ -772,7 +1316,6 @@ func (s *SessionService) updateCacheStates(tenantCtx *tenant.Context, sessionID,
 		LastActivity:  time.Now().UTC(),
 		CreatedAt:     time.Now().UTC(),
 		ExpiresAt:     time.Now().UTC().Add(24 * time.Hour),
-		IsExpired:     false,
 	}
 
 	cacheManager.SetSession(tenantCtx.TenantID, sessionData)
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newStoryFragmentFixture seeds one tractstack, one menu, and one pane so
+// tests can build both valid and dangling reference payloads.
+func newStoryFragmentFixture(t *testing.T) (*StoryFragmentService, *tenant.Context) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-1', 'Main', 'main')`); err != nil {
+		t.Fatalf("failed to seed tractstack: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO menus (id, title, theme, options_payload) VALUES ('menu-1', 'Main Menu', 'default', '[]')`); err != nil {
+		t.Fatalf("failed to seed menu: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO panes (id, title, slug, options_payload, pane_type) VALUES ('pane-1', 'Hero', 'hero', '{}', 'standard')`); err != nil {
+		t.Fatalf("failed to seed pane: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	perfTracker := performance.NewTracker(nil)
+	return NewStoryFragmentService(logger, perfTracker, NewContentMapService(logger, perfTracker), nil), tenantCtx
+}
+
+func TestCreateStoryFragmentAcceptsValidReferences(t *testing.T) {
+	service, tenantCtx := newStoryFragmentFixture(t)
+
+	menuID := "menu-1"
+	sf := &content.StoryFragmentNode{
+		ID:           "sf-1",
+		Title:        "Landing",
+		Slug:         "landing",
+		TractStackID: "ts-1",
+		MenuID:       &menuID,
+		PaneIDs:      []string{"pane-1"},
+	}
+	if err := service.Create(tenantCtx, sf); err != nil {
+		t.Fatalf("expected a fully-referenced storyfragment to save, got %v", err)
+	}
+	if stored, err := tenantCtx.StoryFragmentRepo().FindByID("t1", "sf-1"); err != nil || stored == nil {
+		t.Errorf("expected the storyfragment to be persisted, got node=%v err=%v", stored, err)
+	}
+}
+
+func TestCreateStoryFragmentRejectsDanglingReferences(t *testing.T) {
+	service, tenantCtx := newStoryFragmentFixture(t)
+
+	ghostMenu := "menu-ghost"
+	sf := &content.StoryFragmentNode{
+		ID:           "sf-1",
+		Title:        "Landing",
+		Slug:         "landing",
+		TractStackID: "ts-ghost",
+		MenuID:       &ghostMenu,
+		PaneIDs:      []string{"pane-1", "pane-ghost"},
+	}
+	err := service.Create(tenantCtx, sf)
+	if !errors.Is(err, ErrMissingReferences) {
+		t.Fatalf("expected ErrMissingReferences for dangling IDs, got %v", err)
+	}
+
+	// Every dangling reference is listed so the client can fix them in one
+	// round trip, and the valid pane is not flagged.
+	for _, want := range []string{"tractstack:ts-ghost", "menu:menu-ghost", "pane:pane-ghost"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to list %q, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "pane:pane-1") {
+		t.Errorf("expected the existing pane not to be flagged, got %v", err)
+	}
+
+	if stored, _ := tenantCtx.StoryFragmentRepo().FindByID("t1", "sf-1"); stored != nil {
+		t.Error("expected the rejected storyfragment not to be persisted")
+	}
+}
+
+func TestUpdateStoryFragmentRejectsDanglingPane(t *testing.T) {
+	service, tenantCtx := newStoryFragmentFixture(t)
+
+	sf := &content.StoryFragmentNode{
+		ID:           "sf-1",
+		Title:        "Landing",
+		Slug:         "landing",
+		TractStackID: "ts-1",
+		PaneIDs:      []string{"pane-1"},
+	}
+	if err := service.Create(tenantCtx, sf); err != nil {
+		t.Fatalf("failed to create storyfragment: %v", err)
+	}
+
+	sf.PaneIDs = []string{"pane-1", "pane-deleted"}
+	if err := service.Update(tenantCtx, sf); !errors.Is(err, ErrMissingReferences) {
+		t.Errorf("expected the update with a deleted pane to be rejected, got %v", err)
+	}
+}
@@ -3,16 +3,24 @@
 package services
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 )
 
+// ErrMissingReferences is returned when a write is refused because the payload
+// references content nodes that do not exist; handlers map it to a 400.
+var ErrMissingReferences = errors.New("missing content references")
+
 // StoryFragmentFullPayload represents the full editorial payload for a storyfragment
 type StoryFragmentFullPayload struct {
 	StoryFragment *content.StoryFragmentNode `json:"storyFragment"`
@@ -21,6 +29,11 @@ type StoryFragmentFullPayload struct {
 	Panes         []*content.PaneNode        `json:"panes,omitempty"`
 }
 
+// fullPayloadVariant is the cache variant used for storing assembled full
+// payloads in the HTML chunk cache so they share its dependency-based
+// invalidation with rendered fragments.
+var fullPayloadVariant = types.PaneVariant{BeliefMode: "fullpayload"}
+
 // StoryFragmentService orchestrates storyfragment operations with cache-first repository pattern
 type StoryFragmentService struct {
 	logger               *logging.ChanneledLogger
@@ -131,6 +144,40 @@ func (s *StoryFragmentService) GetBySlug(tenantCtx *tenant.Context, slug string)
 	return storyFragment, nil
 }
 
+// GetBySlugs resolves multiple slugs to storyfragments in one pass (cache-first
+// via repository). The result maps every requested slug; misses map to nil.
+func (s *StoryFragmentService) GetBySlugs(tenantCtx *tenant.Context, slugs []string) (map[string]*content.StoryFragmentNode, error) {
+	start := time.Now()
+	marker := s.perfTracker.StartOperation("get_storyfragments_by_slugs", tenantCtx.TenantID)
+	defer marker.Complete()
+	if len(slugs) == 0 {
+		return map[string]*content.StoryFragmentNode{}, nil
+	}
+
+	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
+	results := make(map[string]*content.StoryFragmentNode, len(slugs))
+	foundCount := 0
+	for _, slug := range slugs {
+		if slug == "" {
+			continue
+		}
+		storyFragment, err := storyFragmentRepo.FindBySlug(tenantCtx.TenantID, slug)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storyfragment by slug %s: %w", slug, err)
+		}
+		results[slug] = storyFragment
+		if storyFragment != nil {
+			foundCount++
+		}
+	}
+
+	s.logger.Content().Info("Successfully resolved storyfragment slugs", "tenantId", tenantCtx.TenantID, "requestedCount", len(slugs), "foundCount", foundCount, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	s.logger.Perf().Info("Performance for GetStoryFragmentsBySlugs", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "requestedCount", len(slugs))
+
+	return results, nil
+}
+
 // GetFullPayloadBySlug returns a storyfragment with full editorial payload (cache-first)
 func (s *StoryFragmentService) GetFullPayloadBySlug(tenantCtx *tenant.Context, slug string) (*StoryFragmentFullPayload, error) {
 	start := time.Now()
@@ -140,6 +187,18 @@ func (s *StoryFragmentService) GetFullPayloadBySlug(tenantCtx *tenant.Context, s
 		return nil, fmt.Errorf("storyfragment slug cannot be empty")
 	}
 
+	// Serve the assembled payload from the chunk cache when every constituent
+	// node is unchanged; dependency invalidation evicts it on any edit.
+	cacheKey := "fullpayload:slug:" + slug
+	if chunk, found := tenantCtx.CacheManager.GetHTMLChunk(tenantCtx.TenantID, cacheKey, fullPayloadVariant); found {
+		cached := &StoryFragmentFullPayload{}
+		if err := json.Unmarshal([]byte(chunk.HTML), cached); err == nil {
+			s.logger.Content().Debug("Served storyfragment full payload from cache", "tenantId", tenantCtx.TenantID, "slug", slug, "duration", time.Since(start))
+			marker.SetSuccess(true)
+			return cached, nil
+		}
+	}
+
 	// Use factory pattern to get repositories from tenant context
 	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
 	tractStackRepo := tenantCtx.TractStackRepo()
@@ -186,6 +245,20 @@ func (s *StoryFragmentService) GetFullPayloadBySlug(tenantCtx *tenant.Context, s
 		payload.Panes = panes
 	}
 
+	// Cache the assembled payload keyed by slug, depending on every
+	// constituent node so an edit to any of them invalidates the entry.
+	dependsOn := []string{storyFragment.ID}
+	if storyFragment.TractStackID != "" {
+		dependsOn = append(dependsOn, storyFragment.TractStackID)
+	}
+	if storyFragment.MenuID != nil && *storyFragment.MenuID != "" {
+		dependsOn = append(dependsOn, *storyFragment.MenuID)
+	}
+	dependsOn = append(dependsOn, storyFragment.PaneIDs...)
+	if serialized, err := json.Marshal(payload); err == nil {
+		tenantCtx.CacheManager.SetHTMLChunk(tenantCtx.TenantID, cacheKey, fullPayloadVariant, string(serialized), dependsOn)
+	}
+
 	s.logger.Content().Info("Successfully retrieved storyfragment full payload by slug", "tenantId", tenantCtx.TenantID, "slug", slug, "hasMenu", payload.Menu != nil, "hasTractStack", payload.TractStack != nil, "paneCount", len(payload.Panes), "duration", time.Since(start))
 	marker.SetSuccess(true)
 	s.logger.Perf().Info("Performance for GetFullPayloadBySlug", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
@@ -226,6 +299,46 @@ func (s *StoryFragmentService) GetHome(tenantCtx *tenant.Context, sessionID stri
 	return storyFragment, nil
 }
 
+// validateReferences verifies that every content node the storyfragment points
+// at exists, so a stale client cannot save a structurally broken page. All
+// dangling references are collected into a single ErrMissingReferences error.
+func (s *StoryFragmentService) validateReferences(tenantCtx *tenant.Context, sf *content.StoryFragmentNode) error {
+	var missing []string
+
+	tractStack, err := tenantCtx.TractStackRepo().FindByID(tenantCtx.TenantID, sf.TractStackID)
+	if err != nil {
+		return fmt.Errorf("failed to verify tractstack %s: %w", sf.TractStackID, err)
+	}
+	if tractStack == nil {
+		missing = append(missing, "tractstack:"+sf.TractStackID)
+	}
+
+	if sf.MenuID != nil && *sf.MenuID != "" {
+		menu, err := tenantCtx.MenuRepo().FindByID(tenantCtx.TenantID, *sf.MenuID)
+		if err != nil {
+			return fmt.Errorf("failed to verify menu %s: %w", *sf.MenuID, err)
+		}
+		if menu == nil {
+			missing = append(missing, "menu:"+*sf.MenuID)
+		}
+	}
+
+	for _, paneID := range sf.PaneIDs {
+		pane, err := tenantCtx.PaneRepo().FindByID(tenantCtx.TenantID, paneID)
+		if err != nil {
+			return fmt.Errorf("failed to verify pane %s: %w", paneID, err)
+		}
+		if pane == nil {
+			missing = append(missing, "pane:"+paneID)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingReferences, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // Create creates a new storyfragment
 func (s *StoryFragmentService) Create(tenantCtx *tenant.Context, sf *content.StoryFragmentNode) error {
 	start := time.Now()
@@ -246,6 +359,9 @@ func (s *StoryFragmentService) Create(tenantCtx *tenant.Context, sf *content.Sto
 	if sf.TractStackID == "" {
 		return fmt.Errorf("tractstack ID cannot be empty")
 	}
+	if err := s.validateReferences(tenantCtx, sf); err != nil {
+		return err
+	}
 
 	storyFragmentRepo := tenantCtx.StoryFragmentRepo()
 	err := storyFragmentRepo.Store(tenantCtx.TenantID, sf)
@@ -309,13 +425,35 @@ func (s *StoryFragmentService) Update(tenantCtx *tenant.Context, sf *content.Sto
 		return fmt.Errorf("storyfragment %s not found", sf.ID)
 	}
 
+	// Renaming the home storyfragment's slug would break home resolution;
+	// require the brand config's home slug to be repointed first.
+	if sf.Slug != existing.Slug && tenantCtx.Config != nil && tenantCtx.Config.BrandConfig != nil && tenantCtx.Config.BrandConfig.HomeSlug == existing.Slug {
+		return fmt.Errorf("storyfragment %s is the configured home page; update the home slug in brand config before renaming slug %q", sf.ID, existing.Slug)
+	}
+
+	if err := s.validateReferences(tenantCtx, sf); err != nil {
+		return err
+	}
+
 	err = storyFragmentRepo.Update(tenantCtx.TenantID, sf)
 	if err != nil {
 		return fmt.Errorf("failed to update storyfragment %s: %w", sf.ID, err)
 	}
 
 	// Surgically update the item in the item cache. The ID list is not affected.
+	// SetStoryFragment also drops the stale slug index entry on a rename.
 	tenantCtx.CacheManager.SetStoryFragment(tenantCtx.TenantID, sf)
+
+	// Evict cached HTML chunks and assembled payloads that embed this
+	// storyfragment or any pane it contained before or after the update.
+	tenantCtx.CacheManager.InvalidateByDependency(tenantCtx.TenantID, sf.ID)
+	for _, paneID := range unionPaneIDs(existing.PaneIDs, sf.PaneIDs) {
+		tenantCtx.CacheManager.InvalidateByDependency(tenantCtx.TenantID, paneID)
+	}
+
+	// Rebuild the belief registry so personalization reflects the new pane set.
+	s.rebuildBeliefRegistry(tenantCtx, sf)
+
 	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
 		s.logger.Content().Error("Failed to refresh content map after storyfragment update",
 			"error", err, "storyFragmentId", sf.ID, "tenantId", tenantCtx.TenantID)
@@ -361,6 +499,9 @@ func (s *StoryFragmentService) Delete(tenantCtx *tenant.Context, id string) erro
 	tenantCtx.CacheManager.InvalidateStoryFragment(tenantCtx.TenantID, id)
 	// Surgically remove the ID from the master ID list.
 	tenantCtx.CacheManager.RemoveStoryFragmentID(tenantCtx.TenantID, id)
+	// Evict dependent HTML chunks and the belief registry for the removed storyfragment.
+	tenantCtx.CacheManager.InvalidateByDependency(tenantCtx.TenantID, id)
+	tenantCtx.CacheManager.InvalidateStoryfragmentBeliefRegistry(tenantCtx.TenantID, id)
 	if err := s.contentMapService.RefreshContentMap(tenantCtx, tenantCtx.GetCacheManager()); err != nil {
 		s.logger.Content().Error("Failed to refresh content map after storyfragment deletion",
 			"error", err, "storyFragmentId", id, "tenantId", tenantCtx.TenantID)
@@ -373,6 +514,39 @@ func (s *StoryFragmentService) Delete(tenantCtx *tenant.Context, id string) erro
 	return nil
 }
 
+// rebuildBeliefRegistry reloads the storyfragment's panes and rebuilds its
+// cached belief registry. Failures are logged rather than returned; the
+// registry is rebuilt lazily on the next render if this misses.
+func (s *StoryFragmentService) rebuildBeliefRegistry(tenantCtx *tenant.Context, sf *content.StoryFragmentNode) {
+	tenantCtx.CacheManager.InvalidateStoryfragmentBeliefRegistry(tenantCtx.TenantID, sf.ID)
+	if len(sf.PaneIDs) == 0 {
+		return
+	}
+	panes, err := tenantCtx.PaneRepo().FindByIDs(tenantCtx.TenantID, sf.PaneIDs)
+	if err != nil {
+		s.logger.Content().Warn("Failed to load panes for belief registry rebuild", "tenantId", tenantCtx.TenantID, "storyFragmentId", sf.ID, "error", err)
+		return
+	}
+	if _, err := NewBeliefRegistryService(s.logger).BuildRegistryFromLoadedPanes(tenantCtx, sf.ID, panes); err != nil {
+		s.logger.Content().Warn("Failed to rebuild belief registry after storyfragment update", "tenantId", tenantCtx.TenantID, "storyFragmentId", sf.ID, "error", err)
+	}
+}
+
+// unionPaneIDs returns the deduplicated union of two pane ID lists, preserving
+// first-seen order.
+func unionPaneIDs(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var union []string
+	for _, id := range append(append([]string{}, a...), b...) {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		union = append(union, id)
+	}
+	return union
+}
+
 func (s *StoryFragmentService) EnrichWithMetadata(tenantCtx *tenant.Context, storyFragment *content.StoryFragmentNode, sessionID string) error {
 	if storyFragment == nil {
 		return fmt.Errorf("storyFragment cannot be nil")
@@ -594,6 +768,10 @@ func (s *StoryFragmentService) UpdateComplete(tenantCtx *tenant.Context, payload
 		return fmt.Errorf("storyfragment %s not found", payload.ID)
 	}
 
+	if err := s.validateReferences(tenantCtx, &payload.StoryFragmentNode); err != nil {
+		return err
+	}
+
 	// Update base storyfragment first
 	err = storyFragmentRepo.Update(tenantCtx.TenantID, &payload.StoryFragmentNode)
 	if err != nil {
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/cleanup"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newWarmingPlanFixture(t *testing.T) (*WarmingService, *tenant.Context, *sql.DB) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	return NewWarmingService(logger, performance.NewTracker(nil), nil, nil), tenantCtx, db
+}
+
+func TestWarmTenantPlanCountsWithoutCacheWrites(t *testing.T) {
+	warmingService, tenantCtx, db := newWarmingPlanFixture(t)
+
+	if _, err := db.Exec(`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-1', 'Main', 'main')`); err != nil {
+		t.Fatalf("failed to seed tractstack: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		if _, err := db.Exec(`INSERT INTO panes (id, title, slug, options_payload, pane_type) VALUES (?, ?, ?, '{}', 'standard')`,
+			fmt.Sprintf("pane-%d", i), fmt.Sprintf("Pane %d", i), fmt.Sprintf("pane-%d", i)); err != nil {
+			t.Fatalf("failed to seed pane: %v", err)
+		}
+	}
+	for i := 1; i <= 2; i++ {
+		if _, err := db.Exec(`INSERT INTO storyfragments (id, title, slug, tractstack_id) VALUES (?, ?, ?, 'ts-1')`,
+			fmt.Sprintf("sf-%d", i), fmt.Sprintf("Fragment %d", i), fmt.Sprintf("fragment-%d", i)); err != nil {
+			t.Fatalf("failed to seed storyfragment: %v", err)
+		}
+	}
+
+	reporter := cleanup.NewBufferedReporter(tenantCtx.CacheManager)
+	plan, err := warmingService.WarmTenantPlan(tenantCtx, "t1", reporter)
+	if err != nil {
+		t.Fatalf("WarmTenantPlan failed: %v", err)
+	}
+
+	if !plan.DryRun {
+		t.Error("expected the plan to be flagged as a dry run")
+	}
+	if plan.ContentCounts["tractstacks"] != 1 || plan.ContentCounts["panes"] != 3 || plan.ContentCounts["storyfragments"] != 2 {
+		t.Errorf("expected seeded counts 1/3/2, got %+v", plan.ContentCounts)
+	}
+	if plan.TotalContentItems != 6 {
+		t.Errorf("expected 6 total content items, got %d", plan.TotalContentItems)
+	}
+	if plan.BeliefRegistries != 2 {
+		t.Errorf("expected one belief registry per storyfragment, got %d", plan.BeliefRegistries)
+	}
+	if plan.EstimatedDuration <= 0 {
+		t.Errorf("expected a positive estimated duration, got %v", plan.EstimatedDuration)
+	}
+
+	// The whole point of the dry run: nothing may land in the cache.
+	if _, found := tenantCtx.CacheManager.GetAllPaneIDs("t1"); found {
+		t.Error("expected the dry run to leave the pane ID list unset")
+	}
+	if _, found := tenantCtx.CacheManager.GetPane("t1", "pane-1"); found {
+		t.Error("expected the dry run to leave pane nodes uncached")
+	}
+	if _, found := tenantCtx.CacheManager.GetFullContentMap("t1"); found {
+		t.Error("expected the dry run to leave the content map unbuilt")
+	}
+	if _, found := tenantCtx.CacheManager.GetStoryfragmentBeliefRegistry("t1", "sf-1"); found {
+		t.Error("expected the dry run to leave belief registries unbuilt")
+	}
+}
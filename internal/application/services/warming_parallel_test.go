@@ -0,0 +1,167 @@
+package services
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newEpinetWarmFixture seeds two epinets plus action and belief events in the
+// last two closed hours, so warming has overlapping verbs to union and more
+// than one bin to fill per epinet.
+func newEpinetWarmFixture(tb testing.TB) (*WarmingService, *tenant.Context, [2]string) {
+	tb.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		tb.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		tb.Fatalf("failed to open test database: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		tb.Fatalf("failed to create schema: %v", err)
+	}
+
+	hourOne := time.Now().UTC().Add(-time.Hour)
+	hourTwo := time.Now().UTC().Add(-2 * time.Hour)
+	const timeLayout = "2006-01-02 15:04:05"
+
+	seedStatements := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO epinets (id, title, options_payload) VALUES ('ep-a', 'Journey A', ?)`,
+			[]any{`[{"gateType":"commitmentAction","title":"Read","values":["READ"],"objectType":"StoryFragment"},{"gateType":"commitmentAction","title":"Share","values":["SHARE"],"objectType":"StoryFragment"}]`}},
+		{`INSERT INTO epinets (id, title, options_payload) VALUES ('ep-b', 'Journey B', ?)`,
+			[]any{`[{"gateType":"belief","title":"Agrees","values":["Agree"]},{"gateType":"commitmentAction","title":"Read","values":["READ"],"objectType":"StoryFragment"}]`}},
+		{`INSERT INTO beliefs (id, title, slug, scale) VALUES ('b-1', 'Belief', 'belief', 'yn')`, nil},
+		{`INSERT INTO leads (id, first_name, email, password_hash, contact_persona) VALUES ('lead-1', 'Lee', 'lee@example.com', 'x', 'major')`, nil},
+		{`INSERT INTO fingerprints (id, lead_id) VALUES ('fp-1', 'lead-1')`, nil},
+		{`INSERT INTO fingerprints (id) VALUES ('fp-2')`, nil},
+		{`INSERT INTO visits (id, fingerprint_id) VALUES ('visit-1', 'fp-1')`, nil},
+		{`INSERT INTO visits (id, fingerprint_id) VALUES ('visit-2', 'fp-2')`, nil},
+		{`INSERT INTO actions (id, object_id, object_type, visit_id, fingerprint_id, verb, created_at) VALUES ('a-1', 'sf-1', 'StoryFragment', 'visit-1', 'fp-1', 'READ', ?)`,
+			[]any{hourOne.Format(timeLayout)}},
+		{`INSERT INTO actions (id, object_id, object_type, visit_id, fingerprint_id, verb, created_at) VALUES ('a-2', 'sf-1', 'StoryFragment', 'visit-1', 'fp-1', 'SHARE', ?)`,
+			[]any{hourOne.Format(timeLayout)}},
+		{`INSERT INTO actions (id, object_id, object_type, visit_id, fingerprint_id, verb, created_at) VALUES ('a-3', 'sf-1', 'StoryFragment', 'visit-2', 'fp-2', 'READ', ?)`,
+			[]any{hourTwo.Format(timeLayout)}},
+		{`INSERT INTO heldbeliefs (id, belief_id, fingerprint_id, verb, object, updated_at) VALUES ('hb-1', 'b-1', 'fp-2', 'BELIEVES_YES', 'Agree', ?)`,
+			[]any{hourOne.Format(timeLayout)}},
+	}
+	for _, statement := range seedStatements {
+		if _, err := db.Exec(statement.query, statement.args...); err != nil {
+			tb.Fatalf("failed to seed warming data: %v", err)
+		}
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	warmingService := NewWarmingService(logger, performance.NewTracker(nil), nil, nil)
+	hourKeys := [2]string{hourOne.Format("2006-01-02-15"), hourTwo.Format("2006-01-02-15")}
+	return warmingService, tenantCtx, hourKeys
+}
+
+// warmIntoFreshCache runs one full warming pass at the given concurrency into
+// a new analytics cache and returns that cache.
+func warmIntoFreshCache(tb testing.TB, warmingService *WarmingService, tenantCtx *tenant.Context, concurrency int) *manager.Manager {
+	tb.Helper()
+
+	origConcurrency := config.EpinetWarmConcurrency
+	config.EpinetWarmConcurrency = concurrency
+	defer func() { config.EpinetWarmConcurrency = origConcurrency }()
+
+	target := manager.NewManager(nil)
+	target.InitializeTenant(tenantCtx.TenantID)
+	if err := warmingService.WarmHourlyEpinetData(tenantCtx, target, 24); err != nil {
+		tb.Fatalf("WarmHourlyEpinetData at concurrency %d failed: %v", concurrency, err)
+	}
+	return target
+}
+
+func TestWarmHourlyEpinetDataParallelMatchesSerial(t *testing.T) {
+	warmingService, tenantCtx, hourKeys := newEpinetWarmFixture(t)
+
+	serial := warmIntoFreshCache(t, warmingService, tenantCtx, 1)
+	parallel := warmIntoFreshCache(t, warmingService, tenantCtx, 4)
+
+	for _, epinetID := range []string{"ep-a", "ep-b"} {
+		for _, hourKey := range hourKeys {
+			serialBin, found := serial.GetHourlyEpinetBin("t1", epinetID, hourKey)
+			if !found {
+				t.Fatalf("expected the serial pass to write bin %s/%s", epinetID, hourKey)
+			}
+			parallelBin, found := parallel.GetHourlyEpinetBin("t1", epinetID, hourKey)
+			if !found {
+				t.Fatalf("expected the parallel pass to write bin %s/%s", epinetID, hourKey)
+			}
+			if !reflect.DeepEqual(serialBin.Data.Steps, parallelBin.Data.Steps) {
+				t.Errorf("expected identical steps for %s/%s, serial=%+v parallel=%+v", epinetID, hourKey, serialBin.Data.Steps, parallelBin.Data.Steps)
+			}
+			if !reflect.DeepEqual(serialBin.Data.Transitions, parallelBin.Data.Transitions) {
+				t.Errorf("expected identical transitions for %s/%s", epinetID, hourKey)
+			}
+		}
+	}
+
+	// Spot-check the content so "identical" can't mean "identically empty":
+	// fp-1 read sf-1 in the most recent closed hour.
+	bin, _ := serial.GetHourlyEpinetBin("t1", "ep-a", hourKeys[0])
+	readNode, exists := bin.Data.Steps["commitmentAction_StoryFragment_READ_sf-1"]
+	if !exists || !readNode.Visitors["fp-1"] {
+		t.Errorf("expected fp-1 on the READ step of ep-a for hour %s, got %+v", hourKeys[0], bin.Data.Steps)
+	}
+	if !readNode.KnownVisitors["fp-1"] {
+		t.Errorf("expected fp-1 to count as a known visitor via its lead, got %+v", readNode)
+	}
+
+	// The belief event only matches ep-b's belief gate.
+	beliefBin, _ := serial.GetHourlyEpinetBin("t1", "ep-b", hourKeys[0])
+	if node, exists := beliefBin.Data.Steps["belief_Agree"]; !exists || !node.Visitors["fp-2"] {
+		t.Errorf("expected fp-2 on ep-b's belief gate for hour %s, got %+v", hourKeys[0], beliefBin.Data.Steps)
+	}
+	if _, exists := bin.Data.Steps["belief_Agree"]; exists {
+		t.Error("expected the belief event not to leak into ep-a's bins")
+	}
+}
+
+func BenchmarkWarmHourlyEpinetDataSerial(b *testing.B) {
+	warmingService, tenantCtx, _ := newEpinetWarmFixture(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		warmIntoFreshCache(b, warmingService, tenantCtx, 1)
+	}
+}
+
+func BenchmarkWarmHourlyEpinetDataParallel(b *testing.B) {
+	warmingService, tenantCtx, _ := newEpinetWarmFixture(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		warmIntoFreshCache(b, warmingService, tenantCtx, 4)
+	}
+}
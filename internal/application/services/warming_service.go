@@ -2,16 +2,22 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/analytics"
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/rendering"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/adapters"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/cleanup"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/interfaces"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
@@ -20,6 +26,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/utilities"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/templates"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 const (
@@ -35,11 +42,49 @@ type EpinetAnalysis struct {
 	ObjectIDs        map[string]bool
 }
 
+// WarmEvent describes one tenant's progress through startup cache warming,
+// streamed to subscribed operators over SSE.
+type WarmEvent struct {
+	Tenant     string `json:"tenant"`
+	Status     string `json:"status"` // "started", "completed", or "failed"
+	DurationMs int64  `json:"durationMs,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
 type WarmingService struct {
 	logger                  *logging.ChanneledLogger
 	perfTracker             *performance.Tracker
 	beliefEvaluationService *BeliefEvaluationService
 	sessionBeliefService    *SessionBeliefService
+
+	// warmComplete flips once startup warming has finished so readiness
+	// probes can gate traffic until caches are hot.
+	warmComplete atomic.Bool
+
+	// warmSubscribers fans warm progress events out to SSE clients; slow
+	// subscribers drop events rather than stall warming.
+	warmSubscribers map[int]chan WarmEvent
+	warmSubMu       sync.Mutex
+	nextWarmSubID   int
+
+	// tenantWarmStatus tracks runtime warming for tenants activated after
+	// startup ("warming", "ready", or "failed").
+	tenantWarmStatus sync.Map
+}
+
+// SetTenantWarmStatus records a tenant's runtime warming phase for health and
+// activation responses.
+func (ws *WarmingService) SetTenantWarmStatus(tenantID, status string) {
+	ws.tenantWarmStatus.Store(tenantID, status)
+}
+
+// GetTenantWarmStatus reports a tenant's warming phase; tenants warmed at
+// startup (or never tracked) read as "ready".
+func (ws *WarmingService) GetTenantWarmStatus(tenantID string) string {
+	if status, ok := ws.tenantWarmStatus.Load(tenantID); ok {
+		return status.(string)
+	}
+	return "ready"
 }
 
 func NewWarmingService(logger *logging.ChanneledLogger, perfTracker *performance.Tracker, beliefEvaluationService *BeliefEvaluationService, sessionBeliefService *SessionBeliefService) *WarmingService {
@@ -48,9 +93,58 @@ func NewWarmingService(logger *logging.ChanneledLogger, perfTracker *performance
 		perfTracker:             perfTracker,
 		beliefEvaluationService: beliefEvaluationService,
 		sessionBeliefService:    sessionBeliefService,
+		warmSubscribers:         make(map[int]chan WarmEvent),
 	}
 }
 
+// SubscribeWarmProgress registers a warm progress subscriber and returns its
+// id plus the event channel. Callers must UnsubscribeWarmProgress when done.
+func (ws *WarmingService) SubscribeWarmProgress() (int, <-chan WarmEvent) {
+	ws.warmSubMu.Lock()
+	defer ws.warmSubMu.Unlock()
+
+	id := ws.nextWarmSubID
+	ws.nextWarmSubID++
+	ch := make(chan WarmEvent, 64)
+	ws.warmSubscribers[id] = ch
+	return id, ch
+}
+
+// UnsubscribeWarmProgress removes a subscriber and closes its channel.
+func (ws *WarmingService) UnsubscribeWarmProgress(id int) {
+	ws.warmSubMu.Lock()
+	defer ws.warmSubMu.Unlock()
+
+	if ch, exists := ws.warmSubscribers[id]; exists {
+		close(ch)
+		delete(ws.warmSubscribers, id)
+	}
+}
+
+// publishWarmEvent fans an event out to all subscribers without blocking;
+// subscribers whose buffers are full miss the event.
+func (ws *WarmingService) publishWarmEvent(event WarmEvent) {
+	ws.warmSubMu.Lock()
+	defer ws.warmSubMu.Unlock()
+
+	for _, ch := range ws.warmSubscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// MarkWarmComplete records that startup cache warming has finished.
+func (ws *WarmingService) MarkWarmComplete() {
+	ws.warmComplete.Store(true)
+}
+
+// IsWarmComplete reports whether startup cache warming has finished.
+func (ws *WarmingService) IsWarmComplete() bool {
+	return ws.warmComplete.Load()
+}
+
 func (ws *WarmingService) WarmAllTenants(tenantManager *tenant.Manager, cache interfaces.Cache, contentMapSvc *ContentMapService, beliefRegistrySvc *BeliefRegistryService, reporter *cleanup.Reporter) error {
 	start := time.Now()
 
@@ -59,34 +153,69 @@ func (ws *WarmingService) WarmAllTenants(tenantManager *tenant.Manager, cache in
 		return fmt.Errorf("failed to get active tenants: %w", err)
 	}
 
-	reporter.LogHeader(fmt.Sprintf("Cache Warming %d Tenants", len(tenants)))
+	maxConcurrentTenants := max(1, config.WarmingConcurrency)
+	reporter.LogHeader(fmt.Sprintf("Cache Warming %d Tenants (concurrency %d)", len(tenants), maxConcurrentTenants))
+
+	// Warm tenants in parallel with bounded concurrency so large hosts cold
+	// start in a fraction of the sequential time.
+	sem := make(chan struct{}, maxConcurrentTenants)
+	errs := make(chan error, len(tenants))
+	var wg sync.WaitGroup
 
-	var successCount int
 	for _, tenantID := range tenants {
-		tenantCtx, err := tenantManager.NewContextFromID(tenantID)
-		if err != nil {
-			reporter.LogError(fmt.Sprintf("Failed to create context for tenant %s", tenantID), err)
-			ws.logger.Cache().Error("Failed to create context for tenant during warming", "tenantId", tenantID, "error", err)
-			continue
-		}
+		wg.Add(1)
+		go func(tenantID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tenantStart := time.Now()
+			ws.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "started"})
+
+			// Buffer this tenant's reporter lines and flush them as one
+			// block so concurrent workers don't interleave output.
+			tenantReporter := cleanup.NewBufferedReporter(cache)
+			defer tenantReporter.Flush()
+
+			tenantCtx, err := tenantManager.NewContextFromID(tenantID)
+			if err != nil {
+				tenantReporter.LogError(fmt.Sprintf("Failed to create context for tenant %s", tenantID), err)
+				ws.logger.Cache().Error("Failed to create context for tenant during warming", "tenantId", tenantID, "error", err)
+				ws.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "failed", DurationMs: time.Since(tenantStart).Milliseconds(), Error: err.Error()})
+				errs <- fmt.Errorf("tenant %s: %w", tenantID, err)
+				return
+			}
+			defer tenantCtx.Close()
+
+			if err := ws.WarmTenant(tenantCtx, tenantID, cache, contentMapSvc, beliefRegistrySvc, tenantReporter); err != nil {
+				tenantReporter.LogError(fmt.Sprintf("Failed to warm tenant %s", tenantID), err)
+				ws.logger.Cache().Error("Failed to warm tenant", "tenantId", tenantID, "error", err)
+				ws.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "failed", DurationMs: time.Since(tenantStart).Milliseconds(), Error: err.Error()})
+				errs <- fmt.Errorf("tenant %s: %w", tenantID, err)
+				return
+			}
 
-		if err := ws.WarmTenant(tenantCtx, tenantID, cache, contentMapSvc, beliefRegistrySvc, reporter); err != nil {
-			reporter.LogError(fmt.Sprintf("Failed to warm tenant %s", tenantID), err)
-			ws.logger.Cache().Error("Failed to warm tenant", "tenantId", tenantID, "error", err)
-		} else {
-			successCount++
-		}
-		tenantCtx.Close()
+			ws.publishWarmEvent(WarmEvent{Tenant: tenantID, Status: "completed", DurationMs: time.Since(tenantStart).Milliseconds()})
+		}(tenantID)
 	}
 
+	wg.Wait()
+	close(errs)
+
+	var warmErrs []error
+	for err := range errs {
+		warmErrs = append(warmErrs, err)
+	}
+	successCount := len(tenants) - len(warmErrs)
+
 	duration := time.Since(start)
 	durationMs := float64(duration) / float64(time.Millisecond)
 	reporter.LogSubHeader(fmt.Sprintf("Strategic Warming Completed in %.2fms", durationMs))
 	reporter.LogSuccess("%d/%d tenants warmed successfully", successCount, len(tenants))
 	ws.logger.Cache().Info("Strategic warming completed for all tenants", "successCount", successCount, "totalTenants", len(tenants), "duration", duration)
 
-	if successCount < len(tenants) {
-		return fmt.Errorf("warming failed for %d tenants", len(tenants)-successCount)
+	if len(warmErrs) > 0 {
+		return fmt.Errorf("warming failed for %d tenants: %w", len(warmErrs), errors.Join(warmErrs...))
 	}
 
 	return nil
@@ -97,12 +226,18 @@ func (ws *WarmingService) WarmTenant(tenantCtx *tenant.Context, tenantID string,
 	reporter.LogSubHeader(fmt.Sprintf("Warming Tenant: %s", tenantID))
 	ws.logger.Cache().Info("Starting strategic warming for tenant", "tenantId", tenantID)
 
-	// Warm the unified content map.
-	if err := ws.warmContentMap(tenantCtx, contentMapSvc, cache); err != nil {
-		return fmt.Errorf("content map warming failed: %w", err)
+	// Warm the unified content map, unless deferred to first access for
+	// tenants with catalogs too large to build eagerly at startup.
+	if config.EagerContentMapWarm {
+		if err := ws.warmContentMap(tenantCtx, contentMapSvc, cache); err != nil {
+			return fmt.Errorf("content map warming failed: %w", err)
+		}
+		reporter.LogStepSuccess("Content Map warmed")
+		ws.logger.Cache().Debug("Content map warmed", "tenantId", tenantID)
+	} else {
+		reporter.LogStepSuccess("Content Map warming deferred to first access")
+		ws.logger.Cache().Debug("Content map warming deferred to first access", "tenantId", tenantID)
 	}
-	reporter.LogStepSuccess("Content Map warmed")
-	ws.logger.Cache().Debug("Content map warmed", "tenantId", tenantID)
 
 	// Bulk-load all content types into the cache.
 	if _, err := NewTractStackService(ws.logger, ws.perfTracker, contentMapSvc).GetAllIDs(tenantCtx); err != nil {
@@ -179,6 +314,80 @@ func (ws *WarmingService) WarmTenant(tenantCtx *tenant.Context, tenantID string,
 	return nil
 }
 
+// WarmingPlan summarizes what WarmTenant would load for a tenant. Produced
+// by WarmTenantPlan without mutating any cache, so operators can estimate the
+// cost of warming a large tenant before committing to it at startup.
+type WarmingPlan struct {
+	TenantID          string         `json:"tenantId"`
+	DryRun            bool           `json:"dryRun"`
+	ContentCounts     map[string]int `json:"contentCounts"`
+	TotalContentItems int            `json:"totalContentItems"`
+	BeliefRegistries  int            `json:"beliefRegistries"`
+	AnalyticsEvents   int            `json:"analyticsEvents"`
+	EstimatedDuration time.Duration  `json:"estimatedDuration"`
+	GeneratedAt       time.Time      `json:"generatedAt"`
+}
+
+// Per-item cost guesses used to size a warming run. They intentionally err
+// high so a huge tenant gets deferred rather than blocking startup.
+const (
+	planCostPerContentItem = 2 * time.Millisecond
+	planCostPerRegistry    = 5 * time.Millisecond
+	planCostPerEvent       = 50 * time.Microsecond
+)
+
+// WarmTenantPlan walks the same content types and storyfragment belief
+// registries as WarmTenant but only counts rows and estimates durations; no
+// cache is touched. The reporter output is prefixed DRY RUN throughout.
+func (ws *WarmingService) WarmTenantPlan(tenantCtx *tenant.Context, tenantID string, reporter *cleanup.Reporter) (*WarmingPlan, error) {
+	reporter.LogSubHeader(fmt.Sprintf("DRY RUN - Warming Plan: %s", tenantID))
+	ws.logger.Cache().Info("Building dry-run warming plan", "tenantId", tenantID)
+
+	plan := &WarmingPlan{
+		TenantID:      tenantID,
+		DryRun:        true,
+		ContentCounts: make(map[string]int),
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	// Count the same content types WarmTenant bulk-loads, straight from the
+	// tenant database so nothing lands in the cache.
+	contentTables := []string{"tractstacks", "storyfragments", "panes", "menus", "resources", "beliefs", "epinets", "files"}
+	for _, table := range contentTables {
+		var count int
+		if err := tenantCtx.Database.Conn.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&count); err != nil {
+			reporter.LogWarning("DRY RUN - failed to count %s: %v", table, err)
+			ws.logger.Cache().Warn("Dry-run count failed", "tenantId", tenantID, "table", table, "error", err)
+			continue
+		}
+		plan.ContentCounts[table] = count
+		plan.TotalContentItems += count
+	}
+
+	// One belief registry is built per storyfragment.
+	plan.BeliefRegistries = plan.ContentCounts["storyfragments"]
+
+	// Analytics warming cost scales with the event volume in the 28-day
+	// retention window.
+	now := time.Now().UTC()
+	if events, err := ws.countEventsInRange(tenantCtx, now.Add(-28*24*time.Hour), now); err != nil {
+		reporter.LogWarning("DRY RUN - failed to count analytics events: %v", err)
+		ws.logger.Cache().Warn("Dry-run event count failed", "tenantId", tenantID, "error", err)
+	} else {
+		plan.AnalyticsEvents = events
+	}
+
+	plan.EstimatedDuration = time.Duration(plan.TotalContentItems)*planCostPerContentItem +
+		time.Duration(plan.BeliefRegistries)*planCostPerRegistry +
+		time.Duration(plan.AnalyticsEvents)*planCostPerEvent
+
+	reporter.LogStepSuccess("DRY RUN - tenant %s: %d content items, %d belief registries, %d analytics events, estimated %v (no cache writes performed)",
+		tenantID, plan.TotalContentItems, plan.BeliefRegistries, plan.AnalyticsEvents, plan.EstimatedDuration)
+	ws.logger.Cache().Info("Dry-run warming plan complete", "tenantId", tenantID, "contentItems", plan.TotalContentItems, "beliefRegistries", plan.BeliefRegistries, "analyticsEvents", plan.AnalyticsEvents, "estimatedDuration", plan.EstimatedDuration)
+
+	return plan, nil
+}
+
 func (ws *WarmingService) WarmHourlyEpinetData(tenantCtx *tenant.Context, cache interfaces.WriteOnlyAnalyticsCache, hoursBack int) error {
 	const fullAnalyticsRange = 674
 
@@ -223,7 +432,7 @@ func (ws *WarmingService) WarmHourlyEpinetData(tenantCtx *tenant.Context, cache
 			return fmt.Errorf("batch failed for tenant '%s': could not get known fingerprints: %w", tenantCtx.TenantID, err)
 		}
 
-		analysis := ws.analyzeEpinet(epinets[0])
+		analysis := ws.analyzeEpinets(epinets)
 		allActionEvents, err := ws.getActionEventsForRange(tenantCtx, batchStartTime, batchEndTime, analysis)
 		if err != nil {
 			ws.logger.Cache().Error("Analytics warming batch failed: could not get action events", "tenantId", tenantCtx.TenantID, "error", err)
@@ -238,32 +447,47 @@ func (ws *WarmingService) WarmHourlyEpinetData(tenantCtx *tenant.Context, cache
 		eventsByHour := ws.groupEventsByHour(allActionEvents, allBeliefEvents)
 		batchHourKeys := ws.getHourKeysForBatch(startHourOffset, endHourOffset)
 
-		for _, hourKey := range batchHourKeys {
-			for _, epinet := range epinets {
-				events, hasEvents := eventsByHour[hourKey]
-
-				var steps map[string]*types.HourlyEpinetStepData
-				var transitions map[string]map[string]*types.HourlyEpinetTransitionData
+		// Build each epinet's bins in parallel: the shared event, content,
+		// and fingerprint maps are read-only from here on, and the analytics
+		// store serializes SetHourlyEpinetBin writes behind its own lock.
+		concurrency := max(1, config.EpinetWarmConcurrency)
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
 
-				if hasEvents {
-					steps = ws.buildStepsFromEvents(epinet, events.ActionEvents, events.BeliefEvents, contentItems, knownFingerprints)
-					transitions = ws.buildTransitionsFromSteps(steps)
-				} else {
-					steps = make(map[string]*types.HourlyEpinetStepData)
-					transitions = make(map[string]map[string]*types.HourlyEpinetTransitionData)
-				}
+		for _, epinet := range epinets {
+			wg.Add(1)
+			go func(epinet types.EpinetConfig) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				for _, hourKey := range batchHourKeys {
+					events, hasEvents := eventsByHour[hourKey]
+
+					var steps map[string]*types.HourlyEpinetStepData
+					var transitions map[string]map[string]*types.HourlyEpinetTransitionData
+
+					if hasEvents {
+						steps = ws.buildStepsFromEvents(epinet, events.ActionEvents, events.BeliefEvents, contentItems, knownFingerprints)
+						transitions = ws.buildTransitionsFromSteps(steps)
+					} else {
+						steps = make(map[string]*types.HourlyEpinetStepData)
+						transitions = make(map[string]map[string]*types.HourlyEpinetTransitionData)
+					}
 
-				bin := &types.HourlyEpinetBin{
-					Data: &types.HourlyEpinetData{
-						Steps:       steps,
-						Transitions: transitions,
-					},
-					ComputedAt: time.Now().UTC(),
-					TTL:        ws.getTTLForHour(hourKey),
+					bin := &types.HourlyEpinetBin{
+						Data: &types.HourlyEpinetData{
+							Steps:       steps,
+							Transitions: transitions,
+						},
+						ComputedAt: time.Now().UTC(),
+						TTL:        ws.getTTLForHour(hourKey),
+					}
+					cache.SetHourlyEpinetBin(tenantCtx.TenantID, epinet.ID, hourKey, bin)
 				}
-				cache.SetHourlyEpinetBin(tenantCtx.TenantID, epinet.ID, hourKey, bin)
-			}
+			}(epinet)
 		}
+		wg.Wait()
 	}
 
 	log.Printf("Analytics cache warming process for tenant '%s' completed successfully.", tenantCtx.TenantID)
@@ -276,6 +500,16 @@ func (ws *WarmingService) WarmRecentHours(tenantCtx *tenant.Context, cache inter
 		return nil
 	}
 
+	// One refresh per tenant at a time: the event scans are shared across
+	// every epinet, so concurrent callers would duplicate the same SQL.
+	locker := caching.GetGlobalWarmingLock()
+	lockKey := caching.RecentHoursWarmKey(tenantCtx.TenantID)
+	if !locker.TryLock(lockKey) {
+		ws.logger.Cache().Debug("Recent-hours refresh already in flight, skipping", "tenantId", tenantCtx.TenantID)
+		return nil
+	}
+	defer locker.Unlock(lockKey)
+
 	log.Printf("Rapid catch-up refresh for tenant '%s' - %d hours", tenantCtx.TenantID, len(missingHourKeys))
 	ws.logger.Cache().Info("Starting rapid catch-up refresh", "tenantId", tenantCtx.TenantID, "missingHours", len(missingHourKeys))
 
@@ -298,7 +532,7 @@ func (ws *WarmingService) WarmRecentHours(tenantCtx *tenant.Context, cache inter
 		return fmt.Errorf("invalid hour key: %w", err)
 	}
 
-	analysis := ws.analyzeEpinet(epinets[0])
+	analysis := ws.analyzeEpinets(epinets)
 	allActionEvents, err := ws.getActionEventsForRange(tenantCtx, oldestHour, now, analysis)
 	if err != nil {
 		ws.logger.Cache().Error("Failed to get action events for rapid catch-up", "tenantId", tenantCtx.TenantID, "error", err)
@@ -351,6 +585,57 @@ func (ws *WarmingService) WarmRecentHours(tenantCtx *tenant.Context, cache inter
 	return nil
 }
 
+// StartCurrentHourRefresher re-warms the current-hour epinet bin for every
+// active tenant on a fixed interval so dashboard requests after the
+// current-hour TTL expires serve slightly stale data instead of triggering a
+// synchronous recompute. Runs until ctx is cancelled; started from startup
+// alongside the cleanup worker.
+func (ws *WarmingService) StartCurrentHourRefresher(ctx context.Context, tenantManager *tenant.Manager) {
+	interval := config.CurrentHourRefreshInterval
+	if interval <= 0 {
+		ws.logger.Cache().Info("Current-hour analytics refresher disabled")
+		return
+	}
+
+	ws.logger.Cache().Info("Current-hour analytics refresher started", "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ws.logger.Cache().Info("Current-hour analytics refresher stopped")
+			return
+		case <-ticker.C:
+			ws.refreshCurrentHourForAllTenants(tenantManager)
+		}
+	}
+}
+
+func (ws *WarmingService) refreshCurrentHourForAllTenants(tenantManager *tenant.Manager) {
+	tenants, err := ws.getActiveTenants()
+	if err != nil {
+		ws.logger.Cache().Error("Current-hour refresh could not list active tenants", "error", err)
+		return
+	}
+
+	currentHourKey := utilities.GetCurrentHourKey()
+
+	for _, tenantID := range tenants {
+		tenantCtx, err := tenantManager.NewContextFromID(tenantID)
+		if err != nil {
+			ws.logger.Cache().Error("Current-hour refresh could not create tenant context", "tenantId", tenantID, "error", err)
+			continue
+		}
+
+		writeCache := adapters.NewWriteOnlyAnalyticsCacheAdapter(tenantCtx.CacheManager)
+		if err := ws.WarmRecentHours(tenantCtx, writeCache, []string{currentHourKey}); err != nil {
+			ws.logger.Cache().Error("Current-hour refresh failed", "tenantId", tenantID, "hourKey", currentHourKey, "error", err)
+		}
+		tenantCtx.Close()
+	}
+}
+
 func (ws *WarmingService) warmContentMap(tenantCtx *tenant.Context, contentMapSvc *ContentMapService, cache interfaces.Cache) error {
 	_, _, err := contentMapSvc.GetContentMap(tenantCtx, "", cache)
 	if err != nil {
@@ -544,6 +829,9 @@ func (ws *WarmingService) getEpinets(tenantCtx *tenant.Context) ([]types.EpinetC
 			if nodeStep.ObjectType != nil {
 				step.ObjectType = *nodeStep.ObjectType
 			}
+			if nodeStep.Weight != nil && *nodeStep.Weight > 0 {
+				step.Weight = *nodeStep.Weight
+			}
 			steps = append(steps, step)
 		}
 		epinets = append(epinets, types.EpinetConfig{
@@ -578,6 +866,38 @@ func (ws *WarmingService) getContentItems(tenantCtx *tenant.Context) (map[string
 	return contentItems, nil
 }
 
+// analyzeEpinets unions the analysis of every epinet so one pair of event
+// queries per batch covers all of them. Fetching with only one epinet's
+// verbs and values would silently drop events the other epinets gate on.
+func (ws *WarmingService) analyzeEpinets(epinets []types.EpinetConfig) *EpinetAnalysis {
+	combined := &EpinetAnalysis{
+		BeliefValues:     make(map[string]bool),
+		IdentifyAsValues: make(map[string]bool),
+		ActionVerbs:      make(map[string]bool),
+		ActionTypes:      make(map[string]bool),
+		ObjectIDs:        make(map[string]bool),
+	}
+	for _, epinet := range epinets {
+		analysis := ws.analyzeEpinet(epinet)
+		for val := range analysis.BeliefValues {
+			combined.BeliefValues[val] = true
+		}
+		for val := range analysis.IdentifyAsValues {
+			combined.IdentifyAsValues[val] = true
+		}
+		for verb := range analysis.ActionVerbs {
+			combined.ActionVerbs[verb] = true
+		}
+		for objType := range analysis.ActionTypes {
+			combined.ActionTypes[objType] = true
+		}
+		for id := range analysis.ObjectIDs {
+			combined.ObjectIDs[id] = true
+		}
+	}
+	return combined
+}
+
 func (ws *WarmingService) analyzeEpinet(epinet types.EpinetConfig) *EpinetAnalysis {
 	analysis := &EpinetAnalysis{
 		BeliefValues:     make(map[string]bool),
@@ -740,8 +1060,7 @@ func (ws *WarmingService) getNodeName(step types.EpinetStep, contentID string, c
 }
 
 func (ws *WarmingService) getTTLForHour(hourKey string) time.Duration {
-	now := time.Now().UTC()
-	currentHour := utilities.FormatHourKey(time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC))
+	currentHour := utilities.GetCurrentHourKey()
 	if hourKey == currentHour {
 		return 15 * time.Minute
 	}
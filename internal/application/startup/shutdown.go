@@ -0,0 +1,64 @@
+// Package startup prepares the application server
+package startup
+
+import (
+	"context"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+)
+
+// ShutdownPhase is a single step in the orchestrated shutdown sequence.
+// Each phase runs with its own timeout so a stuck phase cannot starve the
+// phases that follow it.
+type ShutdownPhase struct {
+	Name    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// ShutdownOrchestrator executes shutdown phases strictly in the order they
+// were registered: stop accepting requests, drain SSE, flush buffered events,
+// persist analytics, close databases.
+type ShutdownOrchestrator struct {
+	phases []ShutdownPhase
+	logger *logging.ChanneledLogger
+}
+
+// NewShutdownOrchestrator creates an empty shutdown sequence.
+func NewShutdownOrchestrator(logger *logging.ChanneledLogger) *ShutdownOrchestrator {
+	return &ShutdownOrchestrator{logger: logger}
+}
+
+// AddPhase appends a phase to the end of the shutdown sequence.
+func (o *ShutdownOrchestrator) AddPhase(name string, timeout time.Duration, run func(ctx context.Context) error) {
+	o.phases = append(o.phases, ShutdownPhase{Name: name, Timeout: timeout, Run: run})
+}
+
+// Execute runs every registered phase in order. A phase that errors or times
+// out is logged but does not abort the remaining phases - later phases like
+// database close must still run.
+func (o *ShutdownOrchestrator) Execute() {
+	for _, phase := range o.phases {
+		start := time.Now()
+		o.logger.Shutdown().Info("Starting shutdown phase", "phase", phase.Name, "timeout", phase.Timeout)
+
+		ctx, cancel := context.WithTimeout(context.Background(), phase.Timeout)
+		done := make(chan error, 1)
+		go func(p ShutdownPhase) {
+			done <- p.Run(ctx)
+		}(phase)
+
+		select {
+		case err := <-done:
+			if err != nil {
+				o.logger.Shutdown().Error("Shutdown phase failed", "phase", phase.Name, "error", err.Error(), "duration", time.Since(start))
+			} else {
+				o.logger.Shutdown().Info("Shutdown phase complete", "phase", phase.Name, "duration", time.Since(start))
+			}
+		case <-ctx.Done():
+			o.logger.Shutdown().Error("Shutdown phase timed out", "phase", phase.Name, "timeout", phase.Timeout)
+		}
+		cancel()
+	}
+}
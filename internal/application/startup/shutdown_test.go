@@ -0,0 +1,101 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+)
+
+// newTestLogger builds a quiet channeled logger so tests don't write log
+// files or console noise.
+func newTestLogger(t *testing.T) *logging.ChanneledLogger {
+	t.Helper()
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	return logger
+}
+
+func TestShutdownOrchestratorRunsPhasesInRegistrationOrder(t *testing.T) {
+	orchestrator := NewShutdownOrchestrator(newTestLogger(t))
+
+	var mu sync.Mutex
+	var ran []string
+	record := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	orchestrator.AddPhase("drain-sse", time.Second, record("drain-sse"))
+	orchestrator.AddPhase("stop-http-server", time.Second, record("stop-http-server"))
+	orchestrator.AddPhase("flush-webhooks", time.Second, record("flush-webhooks"))
+	orchestrator.AddPhase("close-databases", time.Second, record("close-databases"))
+	orchestrator.Execute()
+
+	want := []string{"drain-sse", "stop-http-server", "flush-webhooks", "close-databases"}
+	if len(ran) != len(want) {
+		t.Fatalf("expected %d phases to run, got %d: %v", len(want), len(ran), ran)
+	}
+	for i, name := range want {
+		if ran[i] != name {
+			t.Errorf("phase %d: expected %q, got %q", i, name, ran[i])
+		}
+	}
+}
+
+func TestShutdownOrchestratorContinuesPastFailedPhase(t *testing.T) {
+	orchestrator := NewShutdownOrchestrator(newTestLogger(t))
+
+	var laterRan bool
+	orchestrator.AddPhase("failing", time.Second, func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	orchestrator.AddPhase("close-databases", time.Second, func(ctx context.Context) error {
+		laterRan = true
+		return nil
+	})
+	orchestrator.Execute()
+
+	if !laterRan {
+		t.Error("expected phases after a failed phase to still run")
+	}
+}
+
+func TestShutdownOrchestratorContinuesPastTimedOutPhase(t *testing.T) {
+	orchestrator := NewShutdownOrchestrator(newTestLogger(t))
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var laterRan bool
+	orchestrator.AddPhase("stuck", 20*time.Millisecond, func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	orchestrator.AddPhase("close-databases", time.Second, func(ctx context.Context) error {
+		laterRan = true
+		return nil
+	})
+
+	start := time.Now()
+	orchestrator.Execute()
+
+	if !laterRan {
+		t.Error("expected phases after a timed-out phase to still run")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timed-out phase should not block the sequence, took %v", elapsed)
+	}
+}
@@ -7,18 +7,23 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/application/container"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/cleanup"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/server"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
-// Initialize performs the complete multi-tenant startup sequence
-func Initialize() error {
+// Initialize performs the complete multi-tenant startup sequence. When
+// skipSnapshot is true, cache snapshots are neither loaded at startup nor
+// saved at shutdown.
+func Initialize(skipSnapshot bool) error {
 	setupLogging()
 
 	start := time.Now().UTC()
@@ -106,7 +111,20 @@ func Initialize() error {
 	// Step 9: Initialize application services (handled by container)
 	logger.Startup().Info("Singleton application services initialized via container")
 
-	// Step 10: Initialize cache warming
+	// Step 10: Restore cache snapshots from the previous run, then warm.
+	// Fresh snapshots let repositories serve cache hits during warming so a
+	// redeploy skips most of the per-tenant SQLite reload.
+	snapshotter := manager.NewSnapshotter()
+	snapshotDir := filepath.Join(config.DataDir, "cache")
+	if !skipSnapshot {
+		startSnapshotTime := time.Now()
+		if err := snapshotter.Load(cacheManager, snapshotDir); err != nil {
+			logger.Startup().Warn("Cache snapshot load failed", "error", err.Error(), "dir", snapshotDir)
+		} else {
+			logger.Startup().Info("Cache snapshots loaded", "dir", snapshotDir, "duration", time.Since(startSnapshotTime))
+		}
+	}
+
 	logger.Startup().Info("Initializing cache warming...")
 	startWarmTime := time.Now()
 
@@ -115,10 +133,23 @@ func Initialize() error {
 	contentMapService := appContainer.ContentMapService
 	beliefRegistryService := appContainer.BeliefRegistryService
 
-	if err := warmingService.WarmAllTenants(tenantManager, cacheManager, contentMapService, beliefRegistryService, reporter); err != nil {
-		logger.Startup().Error("Cache warming failed", "error", err.Error(), "duration", time.Since(startWarmTime))
+	warmCaches := func() {
+		if err := warmingService.WarmAllTenants(tenantManager, cacheManager, contentMapService, beliefRegistryService, reporter); err != nil {
+			logger.Startup().Error("Cache warming failed", "error", err.Error(), "duration", time.Since(startWarmTime))
+		} else {
+			logger.Startup().Info("Cache warming completed successfully", "duration", time.Since(startWarmTime))
+		}
+		warmingService.MarkWarmComplete()
+	}
+
+	if config.WaitForWarm {
+		// Block until caches are hot so the listener only binds once ready.
+		warmCaches()
 	} else {
-		logger.Startup().Info("Cache warming completed successfully", "duration", time.Since(startWarmTime))
+		// Accept traffic immediately; the readiness endpoint reports
+		// not-ready until warming finishes in the background.
+		logger.Startup().Info("WAIT_FOR_WARM disabled - warming caches in background")
+		go warmCaches()
 	}
 
 	// Step 11: Start background cleanup worker
@@ -131,6 +162,36 @@ func Initialize() error {
 
 	logger.Startup().Info("Background cleanup worker started", "duration", time.Since(startWorkerTime))
 
+	// Per-tenant maintenance jobs (bin purge, anonymous-fingerprint purge)
+	// run on their own configurable schedules; see MAINTENANCE_* env vars.
+	logger.Startup().Info("Starting maintenance scheduler...")
+	go appContainer.MaintenanceScheduler.Start(ctx)
+
+	// Keep the current-hour analytics bin warm so dashboard requests never
+	// block on a synchronous recompute after the TTL expires.
+	go warmingService.StartCurrentHourRefresher(ctx, tenantManager)
+
+	// Persist cache snapshots on an interval so a crash loses at most one
+	// interval of warmed analytics; graceful shutdown saves a final copy.
+	if !skipSnapshot && config.SnapshotSaveInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(config.SnapshotSaveInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := snapshotter.Save(cacheManager, snapshotDir); err != nil {
+						logger.Cache().Warn("Periodic cache snapshot failed", "error", err.Error(), "dir", snapshotDir)
+					} else {
+						logger.Cache().Debug("Periodic cache snapshot saved", "dir", snapshotDir)
+					}
+				}
+			}
+		}()
+	}
+
 	// Step 12: Start HTTP server
 	logger.Startup().Info("Starting HTTP server...")
 	startServerTime := time.Now()
@@ -169,24 +230,33 @@ func Initialize() error {
 	// Cancel background tasks
 	cancelBackgroundTasks()
 
-	// Stop server
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	logger.Shutdown().Info("Stopping HTTP server...")
-	if err := httpServer.Stop(shutdownCtx); err != nil {
-		logger.Shutdown().Error("Error during server shutdown", "error", err.Error())
-	} else {
-		logger.Shutdown().Info("HTTP server stopped successfully")
-	}
-
-	// Close tenant manager
-	logger.Shutdown().Info("Closing tenant manager...")
-	if err := tenantManager.Close(); err != nil {
-		logger.Shutdown().Error("Error closing tenant manager", "error", err.Error())
-	} else {
-		logger.Shutdown().Info("Tenant manager closed successfully")
+	// Run the orchestrated shutdown sequence: drain SSE connections first so
+	// their request goroutines release (otherwise srv.Shutdown blocks on the
+	// open streams until its timeout), then stop accepting new requests, then
+	// close tenant databases. Each phase has its own configurable timeout.
+	orchestrator := NewShutdownOrchestrator(logger)
+	if !skipSnapshot {
+		orchestrator.AddPhase("snapshot-caches", config.ShutdownPersistTimeout, func(ctx context.Context) error {
+			return snapshotter.Save(cacheManager, snapshotDir)
+		})
 	}
+	orchestrator.AddPhase("drain-sse", config.ShutdownSSEDrainTimeout, func(ctx context.Context) error {
+		drained := appContainer.Broadcaster.CloseAllClients()
+		logger.Shutdown().Info("SSE drain complete", "connectionsDrained", drained)
+		return nil
+	})
+	orchestrator.AddPhase("stop-http-server", config.ShutdownServerTimeout, func(ctx context.Context) error {
+		return httpServer.Stop(ctx)
+	})
+	// Once no new requests can enqueue deliveries, wait out any webhooks
+	// still in their retry loops so queued events aren't silently dropped.
+	orchestrator.AddPhase("flush-webhooks", config.ShutdownFlushTimeout, func(ctx context.Context) error {
+		return appContainer.WebhookDispatcher.Flush(ctx)
+	})
+	orchestrator.AddPhase("close-databases", config.ShutdownDBCloseTimeout, func(ctx context.Context) error {
+		return tenantManager.Close()
+	})
+	orchestrator.Execute()
 
 	elapsed := time.Since(start)
 	logger.Shutdown().Info("Application shutdown complete",
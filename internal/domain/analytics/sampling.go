@@ -0,0 +1,29 @@
+// Package analytics defines the interfaces for accessing analytics data.
+package analytics
+
+import "hash/fnv"
+
+// SampledFingerprint reports whether events for a fingerprint should be
+// ingested under keep-1-in-rate sampling. The decision hashes only the
+// fingerprint ID, so a sampled visitor's entire journey is kept and an
+// unsampled visitor's journey is dropped coherently. A rate of 1 or below
+// disables sampling and keeps everything.
+func SampledFingerprint(fingerprintID string, rate int) bool {
+	if rate <= 1 {
+		return true
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(fingerprintID))
+	return h.Sum32()%uint32(rate) == 0
+}
+
+// ScaleCount scales an aggregate computed from sampled events back up to an
+// estimate of the unsampled total. Because sampling keeps 1 in rate
+// fingerprints, both event counts and unique-visitor counts scale linearly.
+func ScaleCount(count, rate int) int {
+	if rate <= 1 {
+		return count
+	}
+	return count * rate
+}
@@ -102,6 +102,7 @@ type EpinetStep struct {
 	Values     []string `json:"values"`
 	ObjectType *string  `json:"objectType,omitempty"`
 	ObjectIDs  []string `json:"objectIds,omitempty"`
+	Weight     *float64 `json:"weight,omitempty"`
 }
 
 type ImageFileNode struct {
@@ -124,3 +125,12 @@ type ImpressionNode struct {
 	ButtonText  string `json:"buttonText"`
 	ActionsLisp string `json:"actionsLisp"`
 }
+
+// ExternalIDMapping links a content node to the identifier it carries in an
+// external system (e.g. a CMS that content was imported from).
+type ExternalIDMapping struct {
+	NodeID     string `json:"nodeId"`
+	NodeType   string `json:"nodeType"`
+	System     string `json:"system"`
+	ExternalID string `json:"externalId"`
+}
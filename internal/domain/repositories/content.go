@@ -92,3 +92,11 @@ type ImageFileRepository interface {
 	Update(tenantID string, imageFile *content.ImageFileNode) error
 	Delete(tenantID, id string) error
 }
+
+type ExternalIDRepository interface {
+	FindByExternalID(tenantID, system, externalID string) (*content.ExternalIDMapping, error)
+	FindByNodeID(tenantID, nodeID string) ([]*content.ExternalIDMapping, error)
+	FindAll(tenantID string) ([]*content.ExternalIDMapping, error)
+	Upsert(tenantID string, mapping *content.ExternalIDMapping) error
+	DeleteByNodeID(tenantID, nodeID string) error
+}
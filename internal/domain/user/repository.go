@@ -6,6 +6,13 @@ package user
 
 import "time"
 
+// Lead status values. Pending leads have registered but not yet verified
+// their email address; only active leads may authenticate.
+const (
+	LeadStatusPending = "pending"
+	LeadStatusActive  = "active"
+)
+
 // Lead represents an authenticated user in the system.
 type Lead struct {
 	ID             string    `json:"id"`
@@ -16,6 +23,7 @@ type Lead struct {
 	ShortBio       string    `json:"shortBio"`
 	EncryptedCode  string    `json:"-"` // Never serialize encrypted code
 	EncryptedEmail string    `json:"-"` // Never serialize encrypted email
+	LeadStatus     string    `json:"status"`
 	CreatedAt      time.Time `json:"createdAt"`
 	Changed        time.Time `json:"changed"`
 }
@@ -51,6 +59,7 @@ type Profile struct {
 type LeadRepository interface {
 	FindByID(id string) (*Lead, error)
 	FindByEmail(email string) (*Lead, error)
+	FindByPersona(persona string, limit, offset int) ([]*Lead, int, error)
 	Store(lead *Lead) error
 	Update(lead *Lead) error
 	ValidateCredentials(email, password string) (*Lead, error)
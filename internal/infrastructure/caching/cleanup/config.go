@@ -14,6 +14,8 @@ type Config struct {
 	SessionCacheTTL   time.Duration
 	AnalyticsCacheTTL time.Duration
 	FragmentCacheTTL  time.Duration
+	RateBucketIdleTTL time.Duration
+	IdempotencyKeyTTL time.Duration
 }
 
 // NewConfig creates a new cleanup configuration by reading values
@@ -26,5 +28,7 @@ func NewConfig() *Config {
 		SessionCacheTTL:   config.UserStateTTL,
 		AnalyticsCacheTTL: config.AnalyticsBinTTL,
 		FragmentCacheTTL:  config.HTMLChunkTTL,
+		RateBucketIdleTTL: config.RateLimitIdleTTL,
+		IdempotencyKeyTTL: config.IdempotencyKeyTTL,
 	}
 }
@@ -4,6 +4,7 @@ package cleanup
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/interfaces"
@@ -28,47 +29,81 @@ const (
 
 type Reporter struct {
 	cache interfaces.Cache
+
+	// buf, when set, collects output so a concurrent caller can emit a
+	// tenant's lines as one block via Flush instead of interleaving them.
+	buf *strings.Builder
 }
 
+// flushMu serializes Flush calls so buffered blocks land whole on stdout.
+var flushMu sync.Mutex
+
 func NewReporter(cache interfaces.Cache) *Reporter {
 	return &Reporter{cache: cache}
 }
 
+// NewBufferedReporter returns a Reporter that accumulates output in memory
+// until Flush. Used by concurrent warming workers to keep per-tenant log
+// blocks contiguous.
+func NewBufferedReporter(cache interfaces.Cache) *Reporter {
+	return &Reporter{cache: cache, buf: &strings.Builder{}}
+}
+
+// Flush writes any buffered output to stdout as a single uninterrupted block.
+// No-op for unbuffered reporters.
+func (r *Reporter) Flush() {
+	if r.buf == nil || r.buf.Len() == 0 {
+		return
+	}
+	flushMu.Lock()
+	defer flushMu.Unlock()
+	fmt.Print(r.buf.String())
+	r.buf.Reset()
+}
+
+func (r *Reporter) printf(format string, args ...any) {
+	if r.buf != nil {
+		fmt.Fprintf(r.buf, format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
 func (r *Reporter) LogHeader(title string) {
-	fmt.Printf("%s%s✓ %s %s\n", bold, cyan, strings.ToUpper(title), reset)
+	r.printf("%s%s✓ %s %s\n", bold, cyan, strings.ToUpper(title), reset)
 }
 
 func (r *Reporter) LogSubHeader(text string) {
-	fmt.Printf("%s%s░▒▓ %s %s\n", bold, dimCyan, text, reset)
+	r.printf("%s%s░▒▓ %s %s\n", bold, dimCyan, text, reset)
 }
 
 func (r *Reporter) LogStepSuccess(message string, args ...any) {
 	formattedMsg := fmt.Sprintf(message, args...)
-	fmt.Printf("%s⚡ %s%s...%s\n", dimGrey, grey, formattedMsg, reset)
+	r.printf("%s⚡ %s%s...%s\n", dimGrey, grey, formattedMsg, reset)
 }
 
 func (r *Reporter) LogStage(message string, args ...any) {
 	formattedMsg := fmt.Sprintf(message, args...)
-	fmt.Printf("%s%s✦ %s%s%s\n", success, bold, grey, formattedMsg, reset)
+	r.printf("%s%s✦ %s%s%s\n", success, bold, grey, formattedMsg, reset)
 }
 
 func (r *Reporter) LogSuccess(message string, args ...any) {
 	formattedMsg := fmt.Sprintf(message, args...)
-	fmt.Printf("%s%s✦ %s%s%s\n", success, bold, white, formattedMsg, reset)
+	r.printf("%s%s✦ %s%s%s\n", success, bold, white, formattedMsg, reset)
 }
 
 func (r *Reporter) LogError(message string, err error) {
-	fmt.Printf("%s%s✖ ERROR: %s%s: %v%s\n", bold, errorRed, grey, message, err, reset)
+	r.printf("%s%s✖ ERROR: %s%s: %v%s\n", bold, errorRed, grey, message, err, reset)
 }
 
 func (r *Reporter) LogWarning(message string, args ...any) {
 	formattedMsg := fmt.Sprintf(message, args...)
-	fmt.Printf("%s%s⚠ WARNING: %s%s%s\n", bold, warning, grey, formattedMsg, reset)
+	r.printf("%s%s⚠ WARNING: %s%s%s\n", bold, warning, grey, formattedMsg, reset)
 }
 
 func (r *Reporter) LogInfo(message string, args ...any) {
 	formattedMsg := fmt.Sprintf(message, args...)
-	fmt.Printf("%s▶ %s%s%s\n", dimGrey, grey, formattedMsg, reset)
+	r.printf("%s▶ %s%s%s\n", dimGrey, grey, formattedMsg, reset)
 }
 
 func (r *Reporter) GenerateTenantReport(tenantID string) string {
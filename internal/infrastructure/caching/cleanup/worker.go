@@ -149,6 +149,9 @@ func (w *Worker) cleanupTenant(tenantID string) int {
 		contentCache.Mu.Unlock()
 	}
 
+	// Expire idle rate-limit buckets so one-off clients don't accumulate.
+	totalCleaned += manager.CleanupIdleRateBuckets(tenantID, w.config.RateBucketIdleTTL)
+
 	// 2. User State Cache Cleanup (2 hour TTL) - UPDATED FOR FINE-GRAINED LOCKING
 	userCache, err := manager.GetTenantUserStateCache(tenantID)
 	if err == nil && userCache != nil {
@@ -156,7 +159,7 @@ func (w *Worker) cleanupTenant(tenantID string) int {
 		userCache.SessionsMu.Lock()
 		var expiredSessionIDs []string
 		for sessionID, session := range userCache.SessionStates {
-			if time.Since(session.LastActivity) > w.config.SessionCacheTTL {
+			if session.IsExpired() || time.Since(session.LastActivity) > w.config.SessionCacheTTL {
 				expiredSessionIDs = append(expiredSessionIDs, sessionID)
 			}
 		}
@@ -204,6 +207,16 @@ func (w *Worker) cleanupTenant(tenantID string) int {
 		}
 		userCache.BeliefContextsMu.Unlock()
 
+		// Purge idempotency replay entries past their TTL
+		userCache.IdempotencyMu.Lock()
+		for key, entry := range userCache.IdempotentResults {
+			if time.Since(entry.StoredAt) > w.config.IdempotencyKeyTTL {
+				delete(userCache.IdempotentResults, key)
+				totalCleaned++
+			}
+		}
+		userCache.IdempotencyMu.Unlock()
+
 		// Check if entire cache needs clearing
 		userCache.MetadataMu.RLock()
 		needsFullClear := time.Since(userCache.LastLoaded) > w.config.SessionCacheTTL
@@ -225,6 +238,9 @@ func (w *Worker) cleanupTenant(tenantID string) int {
 			userCache.VisitStates = make(map[string]*types.VisitState)
 			userCache.SessionBeliefContexts = make(map[string]*types.SessionBeliefContext)
 			userCache.StoryfragmentBeliefRegistries = make(map[string]*types.StoryfragmentBeliefRegistry)
+			userCache.IdempotencyMu.Lock()
+			userCache.IdempotentResults = make(map[string]*types.IdempotentResult)
+			userCache.IdempotencyMu.Unlock()
 			userCache.LastLoaded = now
 			totalCleaned += 7
 
@@ -264,7 +280,7 @@ func (w *Worker) cleanupTenant(tenantID string) int {
 			lastColonIndex := strings.LastIndex(binKey, ":")
 			if lastColonIndex != -1 {
 				hourKey := binKey[lastColonIndex+1:]
-				currentHourKey := utilities.FormatHourKey(time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC))
+				currentHourKey := utilities.GetCurrentHourKey()
 				if hourKey == currentHourKey {
 					ttl = 15 * time.Minute
 				} else {
@@ -43,9 +43,18 @@ type ContentCache interface {
 	GetAllFileIDs(tenantID string) ([]string, bool)
 	SetAllFileIDs(tenantID string, ids []string)
 	GetContentBySlug(tenantID, slug string) (string, bool)
+	GetNodeByExternalID(tenantID, system, externalID string) (*content.ExternalIDMapping, bool)
+	SetExternalIDMapping(tenantID string, mapping *content.ExternalIDMapping)
+	LoadExternalIDMappings(tenantID string, mappings []*content.ExternalIDMapping)
+	RemoveExternalIDMappings(tenantID, nodeID string)
 	GetResourcesByCategory(tenantID, category string) ([]string, bool)
 	GetFullContentMap(tenantID string) ([]types.FullContentMapItem, bool)
 	SetFullContentMap(tenantID string, contentMap []types.FullContentMapItem)
+	UpdateContentMapItem(tenantID string, item types.FullContentMapItem) bool
+	RemoveContentMapItem(tenantID, id string) bool
+	GetContentVersion(tenantID string) time.Time
+	GetContentMapETag(tenantID string) string
+	LoadOrShare(key string, load func() (any, error)) (any, error)
 	GetOrphanAnalysis(tenantID string) (*types.OrphanAnalysisPayload, string, bool)
 	SetOrphanAnalysis(tenantID string, payload *types.OrphanAnalysisPayload, etag string)
 	InvalidateContentCache(tenantID string)
@@ -60,6 +69,7 @@ type ContentCache interface {
 	AddStoryFragmentID(tenantID, id string)
 	RemoveStoryFragmentID(tenantID, id string)
 	InvalidatePane(tenantID, id string)
+	InvalidatePaneCascade(tenantID, paneID string)
 	AddPaneID(tenantID, id string)
 	RemovePaneID(tenantID, id string)
 	InvalidateMenu(tenantID, id string)
@@ -87,6 +97,8 @@ type UserStateCache interface {
 	LoadKnownFingerprints(tenantID string, fingerprints map[string]bool)
 	GetSession(tenantID, sessionID string) (*types.SessionData, bool)
 	SetSession(tenantID string, sessionData *types.SessionData)
+	SetSessionQuota(tenantID string, quota int)
+	GetSessionQuota(tenantID string) int
 	RemoveSession(tenantID, sessionID string)
 	GetSessionsByFingerprint(tenantID, fingerprintID string) []string
 	GetStoryfragmentBeliefRegistry(tenantID, storyfragmentID string) (*types.StoryfragmentBeliefRegistry, bool)
@@ -96,6 +108,8 @@ type UserStateCache interface {
 	SetSessionBeliefContext(tenantID string, context *types.SessionBeliefContext)
 	InvalidateSessionBeliefContext(tenantID, sessionID, storyfragmentID string)
 	BatchInvalidateSessionBeliefContexts(tenantID string, targets []types.SessionBeliefTarget)
+	GetIdempotentResult(tenantID, key string) (any, bool)
+	SetIdempotentResult(tenantID, key string, value any)
 	InvalidateUserStateCache(tenantID string)
 	GetAllSessionIDs(tenantID string) []string
 	GetAllFingerprintIDs(tenantID string) []string
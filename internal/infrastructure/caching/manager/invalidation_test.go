@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+// fullPayloadVariant mirrors the variant the storyfragment service uses for
+// its assembled full-payload cache entries.
+var fullPayloadVariant = types.PaneVariant{BeliefMode: "fullpayload"}
+
+func TestInvalidateByDependencyEvictsFullPayload(t *testing.T) {
+	m := NewManager(nil)
+	m.InitializeTenant("t1")
+
+	// An assembled payload depends on the storyfragment plus every
+	// constituent node, exactly as GetFullPayloadBySlug stores it.
+	dependsOn := []string{"sf-1", "ts-1", "menu-1", "pane-1", "pane-2"}
+	m.SetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant, `{"payload":true}`, dependsOn)
+
+	if _, found := m.GetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant); !found {
+		t.Fatal("expected the stored payload to be cached")
+	}
+
+	// Editing a constituent pane must evict the assembled payload.
+	m.InvalidateByDependency("t1", "pane-2")
+
+	if _, found := m.GetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant); found {
+		t.Error("expected a pane edit to invalidate the cached full payload")
+	}
+}
+
+func TestInvalidateByDependencyEvictsForEveryConstituent(t *testing.T) {
+	for _, nodeID := range []string{"sf-1", "ts-1", "menu-1", "pane-1"} {
+		m := NewManager(nil)
+		m.InitializeTenant("t1")
+		m.SetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant, `{"payload":true}`, []string{"sf-1", "ts-1", "menu-1", "pane-1"})
+
+		m.InvalidateByDependency("t1", nodeID)
+
+		if _, found := m.GetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant); found {
+			t.Errorf("expected an edit to %s to invalidate the cached payload", nodeID)
+		}
+	}
+}
+
+func TestInvalidateByDependencyLeavesUnrelatedChunks(t *testing.T) {
+	m := NewManager(nil)
+	m.InitializeTenant("t1")
+	m.InitializeTenant("t2")
+
+	m.SetHTMLChunk("t1", "fullpayload:slug:home", fullPayloadVariant, `{"payload":1}`, []string{"pane-1"})
+	m.SetHTMLChunk("t1", "fullpayload:slug:about", fullPayloadVariant, `{"payload":2}`, []string{"pane-2"})
+	m.SetHTMLChunk("t2", "fullpayload:slug:home", fullPayloadVariant, `{"payload":3}`, []string{"pane-1"})
+
+	m.InvalidateByDependency("t1", "pane-1")
+
+	if _, found := m.GetHTMLChunk("t1", "fullpayload:slug:about", fullPayloadVariant); !found {
+		t.Error("expected a payload with unrelated dependencies to survive")
+	}
+	if _, found := m.GetHTMLChunk("t2", "fullpayload:slug:home", fullPayloadVariant); !found {
+		t.Error("expected invalidation to stay scoped to its tenant")
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/interfaces"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/stores"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/monitoring"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/utilities"
 	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"golang.org/x/sync/singleflight"
 )
 
 // Interface assertions to ensure Manager implements all required interfaces.
@@ -31,7 +34,29 @@ type Manager struct {
 	configStore    *stores.ConfigStore
 	sessionsStore  *stores.SessionsStore
 	fragmentsStore *stores.FragmentsStore
+	monitor        *monitoring.CachePerformanceMonitor
 	logger         *logging.ChanneledLogger
+	loadGroup      singleflight.Group
+
+	// ContentMapSWRWindow is how long the cached full content map (and
+	// orphan analysis) is served as-is before a hit also schedules a
+	// background refresh. Stale data is still returned immediately.
+	ContentMapSWRWindow     time.Duration
+	refreshMu               sync.Mutex
+	refreshing              map[string]bool
+	contentMapRefresher     func(tenantID string) error
+	orphanAnalysisRefresher func(tenantID string) error
+
+	// Token buckets for per-tenant request rate limiting, keyed
+	// tenantID -> clientIP. Guarded by rateMu; idle buckets are expired by
+	// the periodic cleanup worker via CleanupIdleRateBuckets.
+	rateMu      sync.Mutex
+	rateBuckets map[string]map[string]*rateBucket
+
+	// Timestamps of the last GDPR data export per fingerprint, keyed
+	// tenantID -> fingerprintID. Guarded by rateMu; stale stamps are
+	// expired alongside idle rate buckets.
+	exportStamps map[string]map[string]time.Time
 }
 
 func NewManager(logger *logging.ChanneledLogger) *Manager {
@@ -47,6 +72,11 @@ func NewManager(logger *logging.ChanneledLogger) *Manager {
 		sessionsStore:  stores.NewSessionsStore(logger),
 		fragmentsStore: stores.NewFragmentsStore(logger),
 		logger:         logger,
+
+		ContentMapSWRWindow: 5 * time.Minute,
+		refreshing:          make(map[string]bool),
+		rateBuckets:         make(map[string]map[string]*rateBucket),
+		exportStamps:        make(map[string]map[string]time.Time),
 	}
 }
 
@@ -109,8 +139,7 @@ func (m *Manager) InitializeTenant(tenantID string) {
 func (m *Manager) GetRangeCacheStatus(tenantID, epinetID string, startHour, endHour int) types.RangeCacheStatus {
 	hourKeys := utilities.GetHourKeysForCustomRange(startHour, endHour)
 
-	now := time.Now().UTC()
-	currentHourKey := utilities.FormatHourKey(time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC))
+	currentHourKey := utilities.GetCurrentHourKey()
 
 	var missingHours []string
 	currentHourExpired := false
@@ -151,7 +180,14 @@ func (m *Manager) GetRangeCacheStatus(tenantID, epinetID string, startHour, endH
 	if len(missingHours) == 0 {
 		action = "proceed"
 	} else if currentHourExpired && !historicalMissing {
-		action = "refresh_current"
+		// If a recent-hours refresh is already in flight for this tenant,
+		// tell readers to proceed with the slightly stale current-hour bin
+		// instead of stacking up duplicate warming tasks.
+		if caching.GetGlobalWarmingLock().IsLocked(caching.RecentHoursWarmKey(tenantID)) {
+			action = "proceed"
+		} else {
+			action = "refresh_current"
+		}
 	} else {
 		action = "load_range"
 	}
@@ -165,7 +201,10 @@ func (m *Manager) GetRangeCacheStatus(tenantID, epinetID string, startHour, endH
 }
 
 func (m *Manager) GetHourlyEpinetBin(tenantID, epinetID, hourKey string) (*types.HourlyEpinetBin, bool) {
-	return m.analyticsStore.GetHourlyEpinetBin(tenantID, epinetID, hourKey)
+	start := time.Now()
+	bin, found := m.analyticsStore.GetHourlyEpinetBin(tenantID, epinetID, hourKey)
+	m.recordCacheOperation("analytics", tenantID, found, start)
+	return bin, found
 }
 
 func (m *Manager) SetHourlyEpinetBin(tenantID, epinetID, hourKey string, bin *types.HourlyEpinetBin) {
@@ -174,7 +213,10 @@ func (m *Manager) SetHourlyEpinetBin(tenantID, epinetID, hourKey string, bin *ty
 }
 
 func (m *Manager) GetHourlyContentBin(tenantID, contentID, hourKey string) (*types.HourlyContentBin, bool) {
-	return m.analyticsStore.GetHourlyContentBin(tenantID, contentID, hourKey)
+	start := time.Now()
+	bin, found := m.analyticsStore.GetHourlyContentBin(tenantID, contentID, hourKey)
+	m.recordCacheOperation("analytics", tenantID, found, start)
+	return bin, found
 }
 
 func (m *Manager) SetHourlyContentBin(tenantID, contentID, hourKey string, bin *types.HourlyContentBin) {
@@ -183,7 +225,10 @@ func (m *Manager) SetHourlyContentBin(tenantID, contentID, hourKey string, bin *
 }
 
 func (m *Manager) GetHourlySiteBin(tenantID, hourKey string) (*types.HourlySiteBin, bool) {
-	return m.analyticsStore.GetHourlySiteBin(tenantID, hourKey)
+	start := time.Now()
+	bin, found := m.analyticsStore.GetHourlySiteBin(tenantID, hourKey)
+	m.recordCacheOperation("analytics", tenantID, found, start)
+	return bin, found
 }
 
 func (m *Manager) SetHourlySiteBin(tenantID, hourKey string, bin *types.HourlySiteBin) {
@@ -192,7 +237,10 @@ func (m *Manager) SetHourlySiteBin(tenantID, hourKey string, bin *types.HourlySi
 }
 
 func (m *Manager) GetLeadMetrics(tenantID string) (*types.LeadMetricsCache, bool) {
-	return m.analyticsStore.GetLeadMetrics(tenantID)
+	start := time.Now()
+	metrics, found := m.analyticsStore.GetLeadMetrics(tenantID)
+	m.recordCacheOperation("analytics", tenantID, found, start)
+	return metrics, found
 }
 
 func (m *Manager) SetLeadMetrics(tenantID string, metrics *types.LeadMetricsCache) {
@@ -218,7 +266,10 @@ func (m *Manager) SetLeadMetricsWithETag(tenantID, cacheKey string, data *types.
 }
 
 func (m *Manager) GetDashboardData(tenantID string) (*types.DashboardCache, bool) {
-	return m.analyticsStore.GetDashboardData(tenantID)
+	start := time.Now()
+	data, found := m.analyticsStore.GetDashboardData(tenantID)
+	m.recordCacheOperation("analytics", tenantID, found, start)
+	return data, found
 }
 
 func (m *Manager) SetDashboardData(tenantID string, data *types.DashboardCache) {
@@ -263,7 +314,14 @@ func (m *Manager) UpdateLastFullHour(tenantID, hourKey string) {
 }
 
 func (m *Manager) GetTractStack(tenantID, id string) (*content.TractStackNode, bool) {
-	return m.contentStore.GetTractStack(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetTractStack(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetTractStack(tenantID string, node *content.TractStackNode) {
@@ -283,6 +341,9 @@ func (m *Manager) GetAllTractStackIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllTractStackIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().TractStack; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllTractStackIDs))
 	copy(ids, cache.AllTractStackIDs)
 	return ids, true
@@ -299,7 +360,14 @@ func (m *Manager) SetAllTractStackIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetStoryFragment(tenantID, id string) (*content.StoryFragmentNode, bool) {
-	return m.contentStore.GetStoryFragment(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetStoryFragment(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetStoryFragment(tenantID string, node *content.StoryFragmentNode) {
@@ -317,6 +385,9 @@ func (m *Manager) GetAllStoryFragmentIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllStoryFragmentIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().StoryFragment; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllStoryFragmentIDs))
 	copy(ids, cache.AllStoryFragmentIDs)
 	return ids, true
@@ -333,7 +404,14 @@ func (m *Manager) SetAllStoryFragmentIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetPane(tenantID, id string) (*content.PaneNode, bool) {
-	return m.contentStore.GetPane(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetPane(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetPane(tenantID string, node *content.PaneNode) {
@@ -351,6 +429,9 @@ func (m *Manager) GetAllPaneIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllPaneIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().Pane; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllPaneIDs))
 	copy(ids, cache.AllPaneIDs)
 	return ids, true
@@ -367,7 +448,14 @@ func (m *Manager) SetAllPaneIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetMenu(tenantID, id string) (*content.MenuNode, bool) {
-	return m.contentStore.GetMenu(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetMenu(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetMenu(tenantID string, node *content.MenuNode) {
@@ -384,6 +472,9 @@ func (m *Manager) GetAllMenuIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllMenuIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().Menu; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllMenuIDs))
 	copy(ids, cache.AllMenuIDs)
 	return ids, true
@@ -400,7 +491,14 @@ func (m *Manager) SetAllMenuIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetResource(tenantID, id string) (*content.ResourceNode, bool) {
-	return m.contentStore.GetResource(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetResource(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetResource(tenantID string, node *content.ResourceNode) {
@@ -417,6 +515,9 @@ func (m *Manager) GetAllResourceIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllResourceIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().Resource; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllResourceIDs))
 	copy(ids, cache.AllResourceIDs)
 	return ids, true
@@ -433,7 +534,14 @@ func (m *Manager) SetAllResourceIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetBelief(tenantID, id string) (*content.BeliefNode, bool) {
-	return m.contentStore.GetBelief(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetBelief(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetBelief(tenantID string, node *content.BeliefNode) {
@@ -450,6 +558,9 @@ func (m *Manager) GetAllBeliefIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllBeliefIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().Belief; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllBeliefIDs))
 	copy(ids, cache.AllBeliefIDs)
 	return ids, true
@@ -466,7 +577,14 @@ func (m *Manager) SetAllBeliefIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetEpinet(tenantID, id string) (*content.EpinetNode, bool) {
-	return m.contentStore.GetEpinet(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetEpinet(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetEpinet(tenantID string, node *content.EpinetNode) {
@@ -483,6 +601,9 @@ func (m *Manager) GetAllEpinetIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllEpinetIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().Epinet; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllEpinetIDs))
 	copy(ids, cache.AllEpinetIDs)
 	return ids, true
@@ -499,7 +620,14 @@ func (m *Manager) SetAllEpinetIDs(tenantID string, ids []string) {
 }
 
 func (m *Manager) GetFile(tenantID, id string) (*content.ImageFileNode, bool) {
-	return m.contentStore.GetImageFile(tenantID, id)
+	start := time.Now()
+	node, found := m.contentStore.GetImageFile(tenantID, id)
+	var itemSize int64
+	if found {
+		itemSize = m.estimateItemSize(node)
+	}
+	m.recordCacheOperationSize("content", tenantID, found, start, itemSize)
+	return node, found
 }
 
 func (m *Manager) SetFile(tenantID string, node *content.ImageFileNode) {
@@ -516,6 +644,9 @@ func (m *Manager) GetAllFileIDs(tenantID string) ([]string, bool) {
 	if len(cache.AllFileIDs) == 0 {
 		return nil, false
 	}
+	if ttl := m.contentStore.TTLConfig().File; ttl > 0 && time.Since(cache.LastUpdated) > ttl {
+		return nil, false
+	}
 	ids := make([]string, len(cache.AllFileIDs))
 	copy(ids, cache.AllFileIDs)
 	return ids, true
@@ -542,6 +673,60 @@ func (m *Manager) GetContentBySlug(tenantID, slug string) (string, bool) {
 	return id, exists
 }
 
+// GetNodeByExternalID resolves a (system, externalId) pair against the
+// in-memory external ID index.
+func (m *Manager) GetNodeByExternalID(tenantID, system, externalID string) (*content.ExternalIDMapping, bool) {
+	cache, err := m.GetTenantContentCache(tenantID)
+	if err != nil {
+		return nil, false
+	}
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+	mapping, exists := cache.ExternalIDs[system+":"+externalID]
+	return mapping, exists
+}
+
+// SetExternalIDMapping records a single external ID mapping in the index.
+func (m *Manager) SetExternalIDMapping(tenantID string, mapping *content.ExternalIDMapping) {
+	cache, err := m.GetTenantContentCache(tenantID)
+	if err != nil {
+		return
+	}
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+	cache.ExternalIDs[mapping.System+":"+mapping.ExternalID] = mapping
+}
+
+// LoadExternalIDMappings bulk-populates the external ID index, used when
+// warming the content cache for a tenant.
+func (m *Manager) LoadExternalIDMappings(tenantID string, mappings []*content.ExternalIDMapping) {
+	cache, err := m.GetTenantContentCache(tenantID)
+	if err != nil {
+		return
+	}
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+	for _, mapping := range mappings {
+		cache.ExternalIDs[mapping.System+":"+mapping.ExternalID] = mapping
+	}
+}
+
+// RemoveExternalIDMappings drops every index entry that points at a node,
+// used when the node is deleted.
+func (m *Manager) RemoveExternalIDMappings(tenantID, nodeID string) {
+	cache, err := m.GetTenantContentCache(tenantID)
+	if err != nil {
+		return
+	}
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+	for key, mapping := range cache.ExternalIDs {
+		if mapping.NodeID == nodeID {
+			delete(cache.ExternalIDs, key)
+		}
+	}
+}
+
 func (m *Manager) GetResourcesByCategory(tenantID, category string) ([]string, bool) {
 	cache, err := m.GetTenantContentCache(tenantID)
 	if err != nil {
@@ -553,16 +738,61 @@ func (m *Manager) GetResourcesByCategory(tenantID, category string) ([]string, b
 	return ids, exists
 }
 
+// GetResourceIDsByOptions returns cached resource IDs whose options payload
+// matches every key/value filter, via the content store's option index.
+func (m *Manager) GetResourceIDsByOptions(tenantID string, filters map[string]string) []string {
+	return m.contentStore.GetResourceIDsByOptions(tenantID, filters)
+}
+
 func (m *Manager) GetFullContentMap(tenantID string) ([]types.FullContentMapItem, bool) {
-	return m.contentStore.GetFullContentMap(tenantID)
+	start := time.Now()
+	contentMap, found := m.contentStore.GetFullContentMap(tenantID)
+	m.recordCacheOperation("content", tenantID, found, start)
+	if found && m.isStale(m.contentStore.GetContentMapLastUpdated(tenantID)) {
+		// Stale-while-revalidate: serve the old map now, rebuild behind it.
+		m.scheduleRefresh(tenantID, "contentmap", m.contentMapRefresher)
+	}
+	return contentMap, found
+}
+
+func (m *Manager) GetContentVersion(tenantID string) time.Time {
+	return m.contentStore.GetContentVersion(tenantID)
+}
+
+func (m *Manager) GetContentMapETag(tenantID string) string {
+	return m.contentStore.GetContentMapETag(tenantID)
 }
 
 func (m *Manager) SetFullContentMap(tenantID string, contentMap []types.FullContentMapItem) {
 	m.contentStore.SetFullContentMap(tenantID, contentMap)
 }
 
+// UpdateContentMapItem patches a single entry in the cached content map in
+// place. Returns false when no map is cached and a full rebuild is needed.
+func (m *Manager) UpdateContentMapItem(tenantID string, item types.FullContentMapItem) bool {
+	patched := m.contentStore.UpdateContentMapItem(tenantID, item)
+	if patched {
+		m.updateTenantAccessTime(tenantID)
+	}
+	return patched
+}
+
+// RemoveContentMapItem removes a single entry from the cached content map.
+// Returns false when no map is cached and a full rebuild is needed.
+func (m *Manager) RemoveContentMapItem(tenantID, id string) bool {
+	removed := m.contentStore.RemoveContentMapItem(tenantID, id)
+	if removed {
+		m.updateTenantAccessTime(tenantID)
+	}
+	return removed
+}
+
 func (m *Manager) GetOrphanAnalysis(tenantID string) (*types.OrphanAnalysisPayload, string, bool) {
-	return m.contentStore.GetOrphanAnalysis(tenantID)
+	payload, etag, found := m.contentStore.GetOrphanAnalysis(tenantID)
+	if found && m.isStale(m.contentStore.GetOrphanAnalysisLastUpdated(tenantID)) {
+		m.scheduleRefresh(tenantID, "orphan_analysis", m.orphanAnalysisRefresher)
+	}
+	return payload, etag, found
 }
 
 func (m *Manager) SetOrphanAnalysis(tenantID string, payload *types.OrphanAnalysisPayload, etag string) {
@@ -574,7 +804,10 @@ func (m *Manager) InvalidateContentCache(tenantID string) {
 }
 
 func (m *Manager) GetVisitState(tenantID, visitID string) (*types.VisitState, bool) {
-	return m.sessionsStore.GetVisitState(tenantID, visitID)
+	start := time.Now()
+	state, found := m.sessionsStore.GetVisitState(tenantID, visitID)
+	m.recordCacheOperation("user_state", tenantID, found, start)
+	return state, found
 }
 
 func (m *Manager) SetVisitState(tenantID string, state *types.VisitState) {
@@ -582,7 +815,10 @@ func (m *Manager) SetVisitState(tenantID string, state *types.VisitState) {
 }
 
 func (m *Manager) GetFingerprintState(tenantID, fingerprintID string) (*types.FingerprintState, bool) {
-	return m.sessionsStore.GetFingerprintState(tenantID, fingerprintID)
+	start := time.Now()
+	state, found := m.sessionsStore.GetFingerprintState(tenantID, fingerprintID)
+	m.recordCacheOperation("user_state", tenantID, found, start)
+	return state, found
 }
 
 func (m *Manager) SetFingerprintState(tenantID string, state *types.FingerprintState) {
@@ -602,15 +838,29 @@ func (m *Manager) LoadKnownFingerprints(tenantID string, fingerprints map[string
 }
 
 func (m *Manager) GetSession(tenantID, sessionID string) (*types.SessionData, bool) {
-	return m.sessionsStore.GetSession(tenantID, sessionID)
+	start := time.Now()
+	sessionData, found := m.sessionsStore.GetSession(tenantID, sessionID)
+	m.recordCacheOperation("user_state", tenantID, found, start)
+	return sessionData, found
 }
 
 func (m *Manager) SetSession(tenantID string, sessionData *types.SessionData) {
 	m.sessionsStore.SetSession(tenantID, sessionData)
 }
 
+func (m *Manager) SetSessionQuota(tenantID string, quota int) {
+	m.sessionsStore.SetSessionQuota(tenantID, quota)
+}
+
+func (m *Manager) GetSessionQuota(tenantID string) int {
+	return m.sessionsStore.GetSessionQuota(tenantID)
+}
+
 func (m *Manager) GetStoryfragmentBeliefRegistry(tenantID, storyfragmentID string) (*types.StoryfragmentBeliefRegistry, bool) {
-	return m.sessionsStore.GetStoryfragmentBeliefRegistry(tenantID, storyfragmentID)
+	start := time.Now()
+	registry, found := m.sessionsStore.GetStoryfragmentBeliefRegistry(tenantID, storyfragmentID)
+	m.recordCacheOperation("user_state", tenantID, found, start)
+	return registry, found
 }
 
 func (m *Manager) SetStoryfragmentBeliefRegistry(tenantID string, registry *types.StoryfragmentBeliefRegistry) {
@@ -622,7 +872,10 @@ func (m *Manager) InvalidateStoryfragmentBeliefRegistry(tenantID, storyfragmentI
 }
 
 func (m *Manager) GetSessionBeliefContext(tenantID, sessionID, storyfragmentID string) (*types.SessionBeliefContext, bool) {
-	return m.sessionsStore.GetSessionBeliefContext(tenantID, sessionID, storyfragmentID)
+	start := time.Now()
+	beliefContext, found := m.sessionsStore.GetSessionBeliefContext(tenantID, sessionID, storyfragmentID)
+	m.recordCacheOperation("user_state", tenantID, found, start)
+	return beliefContext, found
 }
 
 func (m *Manager) SetSessionBeliefContext(tenantID string, context *types.SessionBeliefContext) {
@@ -637,14 +890,127 @@ func (m *Manager) InvalidateUserStateCache(tenantID string) {
 	m.sessionsStore.InvalidateUserStateCache(tenantID)
 }
 
+// LoadOrShare collapses concurrent cache-miss loads of the same key into a
+// single execution of load; every waiting caller receives the shared result.
+// The content repositories route their cold-miss DB reads through this so a
+// thundering herd on one node costs one query; load should re-check the cache
+// first since an earlier flight may have populated it. Keys must include the
+// tenant ID so tenants never share a flight. The zero-value
+// singleflight.Group on the Manager is ready to use.
+func (m *Manager) LoadOrShare(key string, load func() (any, error)) (any, error) {
+	result, err, _ := m.loadGroup.Do(key, load)
+	return result, err
+}
+
+// isStale reports whether a cached timestamp has aged past the
+// stale-while-revalidate window. A zero window disables staleness checks.
+func (m *Manager) isStale(lastUpdated time.Time) bool {
+	return m.ContentMapSWRWindow > 0 && !lastUpdated.IsZero() && time.Since(lastUpdated) > m.ContentMapSWRWindow
+}
+
+// scheduleRefresh starts at most one background refresh per tenant and kind.
+// The flag is cleared once the refresh completes, success or not, so the
+// next stale hit can schedule another one.
+func (m *Manager) scheduleRefresh(tenantID, kind string, refresh func(tenantID string) error) {
+	if refresh == nil {
+		return
+	}
+
+	key := tenantID + ":" + kind
+	m.refreshMu.Lock()
+	if m.refreshing[key] {
+		m.refreshMu.Unlock()
+		return
+	}
+	m.refreshing[key] = true
+	m.refreshMu.Unlock()
+
+	go func() {
+		defer func() {
+			m.refreshMu.Lock()
+			delete(m.refreshing, key)
+			m.refreshMu.Unlock()
+		}()
+
+		if err := refresh(tenantID); err != nil && m.logger != nil {
+			m.logger.Cache().Warn("Background cache refresh failed", "tenantId", tenantID, "kind", kind, "error", err)
+		}
+	}()
+}
+
+// SetContentMapRefresher registers the callback used to rebuild a stale full
+// content map in the background.
+func (m *Manager) SetContentMapRefresher(refresh func(tenantID string) error) {
+	m.contentMapRefresher = refresh
+}
+
+// SetOrphanAnalysisRefresher registers the callback used to recompute a stale
+// orphan analysis in the background.
+func (m *Manager) SetOrphanAnalysisRefresher(refresh func(tenantID string) error) {
+	m.orphanAnalysisRefresher = refresh
+}
+
+// recordCacheOperation forwards a get-path observation to the attached
+// monitor, if any, so hit ratios and latencies reflect real traffic.
+func (m *Manager) recordCacheOperation(layerName, tenantID string, hit bool, start time.Time) {
+	m.recordCacheOperationSize(layerName, tenantID, hit, start, 0)
+}
+
+// recordCacheOperationSize is recordCacheOperation for getters that can
+// cheaply approximate the size of the item they returned.
+func (m *Manager) recordCacheOperationSize(layerName, tenantID string, hit bool, start time.Time, itemSizeBytes int64) {
+	if m.monitor != nil {
+		m.monitor.RecordCacheOperation(layerName, tenantID, hit, time.Since(start), itemSizeBytes)
+	}
+}
+
+// estimateItemSize approximates a returned item's size for the monitor,
+// skipping the serialization cost entirely when no monitor is attached.
+func (m *Manager) estimateItemSize(v any) int64 {
+	if m.monitor == nil {
+		return 0
+	}
+	return types.EstimateSize(v)
+}
+
+// SetCacheMonitor attaches a cache performance monitor so store-level
+// evictions are reflected in its stats.
+func (m *Manager) SetCacheMonitor(monitor *monitoring.CachePerformanceMonitor) {
+	m.monitor = monitor
+	m.fragmentsStore.SetMonitor(monitor)
+}
+
 func (m *Manager) GetHTMLChunk(tenantID, paneID string, variant types.PaneVariant) (*types.HTMLChunk, bool) {
-	return m.fragmentsStore.GetHTMLChunk(tenantID, paneID, variant)
+	start := time.Now()
+	chunk, found := m.fragmentsStore.GetHTMLChunk(tenantID, paneID, variant)
+	var itemSize int64
+	if found {
+		itemSize = int64(len(chunk.HTML))
+	}
+	m.recordCacheOperationSize("html_chunk", tenantID, found, start, itemSize)
+	return chunk, found
 }
 
 func (m *Manager) SetHTMLChunk(tenantID, paneID string, variant types.PaneVariant, html string, dependsOn []string) {
 	m.fragmentsStore.SetHTMLChunk(tenantID, paneID, variant, html, dependsOn)
 }
 
+func (m *Manager) SetHTMLChunkWithTTL(tenantID, paneID string, variant types.PaneVariant, html string, dependsOn []string, ttl time.Duration) {
+	m.fragmentsStore.SetHTMLChunkWithTTL(tenantID, paneID, variant, html, dependsOn, ttl)
+}
+
+func (m *Manager) RecordPaneRenderFailure(tenantID, paneID, errMsg string) {
+	m.fragmentsStore.RecordPaneRenderFailure(tenantID, paneID, errMsg)
+}
+
+func (m *Manager) ClearPaneRenderFailure(tenantID, paneID string) {
+	m.fragmentsStore.ClearPaneRenderFailure(tenantID, paneID)
+}
+
+func (m *Manager) GetPaneRenderFailures(tenantID string) []*types.PaneRenderFailure {
+	return m.fragmentsStore.GetPaneRenderFailures(tenantID)
+}
+
 func (m *Manager) GetChunkDependencies(tenantID, nodeID string) ([]string, bool) {
 	cache, err := m.GetTenantHTMLChunkCache(tenantID)
 	if err != nil {
@@ -656,6 +1022,12 @@ func (m *Manager) GetChunkDependencies(tenantID, nodeID string) ([]string, bool)
 	return deps, exists
 }
 
+// GetHTMLChunkHitStats returns cumulative hit and miss counts for a tenant's
+// HTML chunk cache lookups.
+func (m *Manager) GetHTMLChunkHitStats(tenantID string) (hits, misses int64) {
+	return m.fragmentsStore.GetHTMLChunkHitStats(tenantID)
+}
+
 func (m *Manager) InvalidateByDependency(tenantID, nodeID string) {
 	m.fragmentsStore.InvalidateByDependency(tenantID, nodeID)
 }
@@ -685,12 +1057,48 @@ func (m *Manager) InvalidateTenant(tenantID string) {
 	}
 }
 
+// GetTenantStats summarizes a tenant's cache footprint. Hit and miss counters
+// come from the HTML chunk store (the only per-tenant counters we keep) and
+// Size is the estimated bytes across all four stores. A tenant whose stores
+// were never initialized reports zeros rather than an error.
 func (m *Manager) GetTenantStats(tenantID string) interfaces.CacheStats {
-	return interfaces.CacheStats{}
+	stats := m.GetDetailedStats(tenantID)
+	hits, misses := m.fragmentsStore.GetHTMLChunkHitStats(tenantID)
+	return interfaces.CacheStats{
+		Hits:   int(hits),
+		Misses: int(misses),
+		Size:   stats.Content.EstimatedBytes + stats.UserState.EstimatedBytes + stats.HTMLChunks.EstimatedBytes + stats.Analytics.EstimatedBytes,
+	}
 }
 
+// GetMemoryStats aggregates cache memory usage across every tenant the
+// manager has seen, for capacity planning on the admin dashboard.
 func (m *Manager) GetMemoryStats() map[string]any {
-	return make(map[string]any)
+	var totalBytes, htmlChunkBytes int64
+	var htmlChunks, analyticsBins, contentItems, sessions int
+	perTenantBytes := make(map[string]int64)
+
+	for _, stats := range m.GetAllDetailedStats() {
+		tenantBytes := stats.Content.EstimatedBytes + stats.UserState.EstimatedBytes + stats.HTMLChunks.EstimatedBytes + stats.Analytics.EstimatedBytes
+		perTenantBytes[stats.TenantID] = tenantBytes
+		totalBytes += tenantBytes
+		htmlChunkBytes += stats.HTMLChunks.EstimatedBytes
+		htmlChunks += stats.HTMLChunks.Items
+		analyticsBins += stats.Analytics.Items
+		contentItems += stats.Content.Items
+		sessions += len(m.GetAllSessionIDs(stats.TenantID))
+	}
+
+	return map[string]any{
+		"tenants":             len(perTenantBytes),
+		"totalEstimatedBytes": totalBytes,
+		"totalHTMLChunkBytes": htmlChunkBytes,
+		"totalHTMLChunks":     htmlChunks,
+		"totalAnalyticsBins":  analyticsBins,
+		"totalContentItems":   contentItems,
+		"totalSessions":       sessions,
+		"perTenantBytes":      perTenantBytes,
+	}
 }
 
 func (m *Manager) InvalidateAll() {
@@ -837,6 +1245,7 @@ func (m *Manager) RemoveResourceID(tenantID, id string) {
 
 func (m *Manager) InvalidateTractStack(tenantID, id string) {
 	m.contentStore.InvalidateTractStack(tenantID, id)
+	m.fragmentsStore.InvalidateByDependency(tenantID, id)
 	m.updateTenantAccessTime(tenantID)
 }
 
@@ -852,6 +1261,7 @@ func (m *Manager) RemoveTractStackID(tenantID, id string) {
 
 func (m *Manager) InvalidateStoryFragment(tenantID, id string) {
 	m.contentStore.InvalidateStoryFragment(tenantID, id)
+	m.fragmentsStore.InvalidateByDependency(tenantID, id)
 	m.updateTenantAccessTime(tenantID)
 }
 
@@ -867,9 +1277,37 @@ func (m *Manager) RemoveStoryFragmentID(tenantID, id string) {
 
 func (m *Manager) InvalidatePane(tenantID, id string) {
 	m.contentStore.InvalidatePane(tenantID, id)
+	m.fragmentsStore.InvalidateByDependency(tenantID, id)
 	m.updateTenantAccessTime(tenantID)
 }
 
+// InvalidatePaneCascade invalidates a pane and, one level up, every cached
+// storyfragment that references it along with its belief registry. The cascade
+// deliberately stops at storyfragments (no storyfragment -> tractstack hop) so
+// a single pane change cannot trigger unbounded invalidation work.
+func (m *Manager) InvalidatePaneCascade(tenantID, paneID string) {
+	storyfragmentIDs := m.contentStore.GetStoryFragmentIDsForPane(tenantID, paneID)
+	m.contentStore.InvalidatePane(tenantID, paneID)
+	m.fragmentsStore.InvalidateByDependency(tenantID, paneID)
+	for _, storyfragmentID := range storyfragmentIDs {
+		m.contentStore.InvalidateStoryFragment(tenantID, storyfragmentID)
+		m.fragmentsStore.InvalidateByDependency(tenantID, storyfragmentID)
+		m.sessionsStore.InvalidateStoryfragmentBeliefRegistry(tenantID, storyfragmentID)
+	}
+	m.updateTenantAccessTime(tenantID)
+}
+
+// GetStoryFragmentIDsForPane returns the IDs of cached storyfragments whose
+// PaneIDs include the given pane.
+func (m *Manager) GetStoryFragmentIDsForPane(tenantID, paneID string) []string {
+	return m.contentStore.GetStoryFragmentIDsForPane(tenantID, paneID)
+}
+
+// GetChunksByPaneID returns all cached HTML chunk variants for a pane.
+func (m *Manager) GetChunksByPaneID(tenantID, paneID string) map[string]*types.HTMLChunk {
+	return m.fragmentsStore.GetChunksByPaneID(tenantID, paneID)
+}
+
 func (m *Manager) AddPaneID(tenantID, id string) {
 	m.contentStore.AddPaneID(tenantID, id)
 	m.updateTenantAccessTime(tenantID)
@@ -882,6 +1320,7 @@ func (m *Manager) RemovePaneID(tenantID, id string) {
 
 func (m *Manager) InvalidateMenu(tenantID, id string) {
 	m.contentStore.InvalidateMenu(tenantID, id)
+	m.fragmentsStore.InvalidateByDependency(tenantID, id)
 	m.updateTenantAccessTime(tenantID)
 }
 
@@ -957,3 +1396,18 @@ func (m *Manager) RemoveSession(tenantID, sessionID string) {
 func (m *Manager) BatchInvalidateSessionBeliefContexts(tenantID string, targets []types.SessionBeliefTarget) {
 	m.sessionsStore.BatchInvalidateSessionBeliefContexts(tenantID, targets)
 }
+
+// GetIdempotentResult returns the stored outcome for an Idempotency-Key
+func (m *Manager) GetIdempotentResult(tenantID, key string) (any, bool) {
+	return m.sessionsStore.GetIdempotentResult(tenantID, key)
+}
+
+// SetIdempotentResult stores a request outcome under its Idempotency-Key
+func (m *Manager) SetIdempotentResult(tenantID, key string, value any) {
+	m.sessionsStore.SetIdempotentResult(tenantID, key, value)
+}
+
+// CleanupExpiredIdempotentResults purges idempotency entries past their TTL
+func (m *Manager) CleanupExpiredIdempotentResults(tenantID string) int {
+	return m.sessionsStore.CleanupExpiredIdempotentResults(tenantID)
+}
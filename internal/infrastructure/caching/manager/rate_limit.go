@@ -0,0 +1,148 @@
+// Package manager provides the token-bucket state behind the per-tenant
+// request rate limiting middleware.
+package manager
+
+import (
+	"math"
+	"time"
+)
+
+// rateBucket is one token bucket for a (tenant, client IP) pair. Tokens
+// refill continuously at the configured per-minute rate up to the burst cap.
+type rateBucket struct {
+	tokens     float64
+	lastRefill time.Time
+	lastSeen   time.Time
+}
+
+// AllowRequest takes one token from the bucket for the given tenant and
+// client IP, creating the bucket on first sight. It returns whether the
+// request is allowed and, when it is not, how long the client should wait
+// before retrying. A non-positive perMinute rate disables limiting.
+func (m *Manager) AllowRequest(tenantID, clientIP string, perMinute, burst int) (bool, time.Duration) {
+	if perMinute <= 0 {
+		return true, 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	now := time.Now()
+	refillPerSecond := float64(perMinute) / 60.0
+
+	m.rateMu.Lock()
+	defer m.rateMu.Unlock()
+
+	if m.rateBuckets[tenantID] == nil {
+		m.rateBuckets[tenantID] = make(map[string]*rateBucket)
+	}
+
+	bucket, exists := m.rateBuckets[tenantID][clientIP]
+	if !exists {
+		bucket = &rateBucket{tokens: float64(burst), lastRefill: now}
+		m.rateBuckets[tenantID][clientIP] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(burst), bucket.tokens+now.Sub(bucket.lastRefill).Seconds()*refillPerSecond)
+	bucket.lastRefill = now
+	bucket.lastSeen = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	retryAfter := time.Duration((1 - bucket.tokens) / refillPerSecond * float64(time.Second))
+	return false, retryAfter
+}
+
+// tenantWideBucketKey is the reserved bucket key for a tenant's aggregate
+// limit; a NUL byte can never appear in a real client IP.
+const tenantWideBucketKey = "\x00tenant-wide"
+
+// AllowTenantRequest takes one token from the tenant's aggregate bucket,
+// capping total request volume regardless of how many client IPs the traffic
+// arrives from. Semantics match AllowRequest.
+func (m *Manager) AllowTenantRequest(tenantID string, perMinute, burst int) (bool, time.Duration) {
+	return m.AllowRequest(tenantID, tenantWideBucketKey, perMinute, burst)
+}
+
+// fingerprintBucketPrefix namespaces per-fingerprint buckets away from the
+// per-IP buckets sharing the same map; the NUL byte can never appear in a
+// real client IP.
+const fingerprintBucketPrefix = "\x00fp:"
+
+// AllowFingerprintRequest takes one token from the bucket for the given
+// tenant and fingerprint, throttling state mutations from a single anonymous
+// visitor regardless of which IP they arrive from. Semantics match
+// AllowRequest, and idle buckets age out with the per-IP ones.
+func (m *Manager) AllowFingerprintRequest(tenantID, fingerprintID string, perMinute, burst int) (bool, time.Duration) {
+	return m.AllowRequest(tenantID, fingerprintBucketPrefix+fingerprintID, perMinute, burst)
+}
+
+// exportWindow is how long a fingerprint must wait between GDPR data
+// exports.
+const exportWindow = time.Hour
+
+// AllowFingerprintExport permits one data export per fingerprint per hour.
+// It returns whether the export may proceed and, when it may not, how long
+// the caller should wait before retrying.
+func (m *Manager) AllowFingerprintExport(tenantID, fingerprintID string) (bool, time.Duration) {
+	now := time.Now()
+
+	m.rateMu.Lock()
+	defer m.rateMu.Unlock()
+
+	if m.exportStamps[tenantID] == nil {
+		m.exportStamps[tenantID] = make(map[string]time.Time)
+	}
+
+	if last, exists := m.exportStamps[tenantID][fingerprintID]; exists {
+		if elapsed := now.Sub(last); elapsed < exportWindow {
+			return false, exportWindow - elapsed
+		}
+	}
+
+	m.exportStamps[tenantID][fingerprintID] = now
+	return true, 0
+}
+
+// CleanupIdleRateBuckets drops buckets for a tenant that have not seen a
+// request within maxIdle, returning how many were removed. Called by the
+// periodic cache cleanup worker.
+func (m *Manager) CleanupIdleRateBuckets(tenantID string, maxIdle time.Duration) int {
+	cutoff := time.Now().Add(-maxIdle)
+
+	m.rateMu.Lock()
+	defer m.rateMu.Unlock()
+
+	buckets, exists := m.rateBuckets[tenantID]
+	if !exists {
+		return 0
+	}
+
+	removed := 0
+	for clientIP, bucket := range buckets {
+		if bucket.lastSeen.Before(cutoff) {
+			delete(buckets, clientIP)
+			removed++
+		}
+	}
+	if len(buckets) == 0 {
+		delete(m.rateBuckets, tenantID)
+	}
+
+	if stamps, exists := m.exportStamps[tenantID]; exists {
+		expired := time.Now().Add(-exportWindow)
+		for fingerprintID, stamp := range stamps {
+			if stamp.Before(expired) {
+				delete(stamps, fingerprintID)
+			}
+		}
+		if len(stamps) == 0 {
+			delete(m.exportStamps, tenantID)
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,345 @@
+// Package manager provides centralized cache operations with proper tenant isolation
+package manager
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/utilities"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+const (
+	contentSnapshotFile   = "content.gob"
+	analyticsSnapshotFile = "analytics.gob"
+
+	// snapshotVersion is embedded in every snapshot file; bump it whenever a
+	// persisted struct changes shape so old files are discarded instead of
+	// half-decoding into the new layout.
+	snapshotVersion = 1
+)
+
+func init() {
+	// Pane and resource options payloads are decoded JSON, so their interface
+	// values must be registered for gob round-tripping.
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+}
+
+// contentSnapshot is the serializable subset of a tenant's content cache.
+// Lookup indices and node maps are persisted verbatim; the mutex and other
+// runtime-only state are rebuilt on load.
+type contentSnapshot struct {
+	Version int
+	SavedAt time.Time
+
+	TractStacks    map[string]*content.TractStackNode
+	StoryFragments map[string]*content.StoryFragmentNode
+	Panes          map[string]*content.PaneNode
+	Menus          map[string]*content.MenuNode
+	Resources      map[string]*content.ResourceNode
+	Epinets        map[string]*content.EpinetNode
+	Beliefs        map[string]*content.BeliefNode
+	Files          map[string]*content.ImageFileNode
+
+	StoryfragmentBeliefRegistries map[string]*types.StoryfragmentBeliefRegistry
+
+	SlugToID      map[string]string
+	CategoryToIDs map[string][]string
+	ExternalIDs   map[string]*content.ExternalIDMapping
+
+	AllTractStackIDs    []string
+	AllStoryFragmentIDs []string
+	AllPaneIDs          []string
+	AllMenuIDs          []string
+	AllResourceIDs      []string
+	AllBeliefIDs        []string
+	AllEpinetIDs        []string
+	AllFileIDs          []string
+
+	FullContentMap        []types.FullContentMapItem
+	ContentMapLastUpdated time.Time
+	ContentMapETag        string
+
+	LastUpdated time.Time
+}
+
+// analyticsSnapshot is the serializable subset of a tenant's analytics cache.
+// Computed metrics (lead metrics, dashboards) are cheap to rebuild and are
+// not persisted.
+type analyticsSnapshot struct {
+	Version int
+	SavedAt time.Time
+
+	EpinetBins  map[string]*types.HourlyEpinetBin
+	ContentBins map[string]*types.HourlyContentBin
+	SiteBins    map[string]*types.HourlySiteBin
+
+	LastFullHour string
+	LastUpdated  time.Time
+}
+
+// Snapshotter persists content and analytics caches across restarts so a
+// redeploy does not pay the full warm-from-SQLite cost for every tenant.
+// User-state caches are deliberately excluded; sessions are ephemeral.
+type Snapshotter struct{}
+
+// NewSnapshotter creates a cache snapshotter.
+func NewSnapshotter() *Snapshotter {
+	return &Snapshotter{}
+}
+
+// Save writes each tenant's content and analytics caches as gob files under
+// dir/<tenantID>/. Failures for one tenant are collected but do not stop the
+// remaining tenants from being saved.
+func (s *Snapshotter) Save(m *Manager, dir string) error {
+	var errs []error
+	for _, tenantID := range m.contentStore.GetAllTenantIDs() {
+		tenantDir := filepath.Join(dir, tenantID)
+		if err := os.MkdirAll(tenantDir, 0o755); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+			continue
+		}
+		if err := s.saveContent(m, tenantID, filepath.Join(tenantDir, contentSnapshotFile)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s content: %w", tenantID, err))
+		}
+		if err := s.saveAnalytics(m, tenantID, filepath.Join(tenantDir, analyticsSnapshotFile)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s analytics: %w", tenantID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Load restores tenant caches from snapshot files under dir. Snapshots older
+// than the corresponding cache TTL are ignored so stale data never shadows
+// the database. A missing snapshot directory is not an error.
+func (s *Snapshotter) Load(m *Manager, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tenantID := entry.Name()
+		if err := s.loadContent(m, tenantID, filepath.Join(dir, tenantID, contentSnapshotFile)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s content: %w", tenantID, err))
+		}
+		if err := s.loadAnalytics(m, tenantID, filepath.Join(dir, tenantID, analyticsSnapshotFile)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s analytics: %w", tenantID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Snapshotter) saveContent(m *Manager, tenantID, path string) error {
+	cache, exists := m.contentStore.GetTenantCache(tenantID)
+	if !exists {
+		return nil
+	}
+
+	// Clone the maps and slices under the lock: periodic saves run while the
+	// cache is live, so encoding the originals after unlock would race writers.
+	cache.Mu.RLock()
+	snap := contentSnapshot{
+		Version:                       snapshotVersion,
+		SavedAt:                       time.Now().UTC(),
+		TractStacks:                   maps.Clone(cache.TractStacks),
+		StoryFragments:                maps.Clone(cache.StoryFragments),
+		Panes:                         maps.Clone(cache.Panes),
+		Menus:                         maps.Clone(cache.Menus),
+		Resources:                     maps.Clone(cache.Resources),
+		Epinets:                       maps.Clone(cache.Epinets),
+		Beliefs:                       maps.Clone(cache.Beliefs),
+		Files:                         maps.Clone(cache.Files),
+		StoryfragmentBeliefRegistries: maps.Clone(cache.StoryfragmentBeliefRegistries),
+		SlugToID:                      maps.Clone(cache.SlugToID),
+		CategoryToIDs:                 maps.Clone(cache.CategoryToIDs),
+		ExternalIDs:                   maps.Clone(cache.ExternalIDs),
+		AllTractStackIDs:              slices.Clone(cache.AllTractStackIDs),
+		AllStoryFragmentIDs:           slices.Clone(cache.AllStoryFragmentIDs),
+		AllPaneIDs:                    slices.Clone(cache.AllPaneIDs),
+		AllMenuIDs:                    slices.Clone(cache.AllMenuIDs),
+		AllResourceIDs:                slices.Clone(cache.AllResourceIDs),
+		AllBeliefIDs:                  slices.Clone(cache.AllBeliefIDs),
+		AllEpinetIDs:                  slices.Clone(cache.AllEpinetIDs),
+		AllFileIDs:                    slices.Clone(cache.AllFileIDs),
+		FullContentMap:                slices.Clone(cache.FullContentMap),
+		ContentMapLastUpdated:         cache.ContentMapLastUpdated,
+		ContentMapETag:                cache.ContentMapETag,
+		LastUpdated:                   cache.LastUpdated,
+	}
+	cache.Mu.RUnlock()
+
+	return writeSnapshotFile(path, &snap)
+}
+
+func (s *Snapshotter) saveAnalytics(m *Manager, tenantID, path string) error {
+	cache, exists := m.analyticsStore.GetTenantCache(tenantID)
+	if !exists {
+		return nil
+	}
+
+	// The current-hour bin is still accumulating and re-warms in seconds, so
+	// it is excluded; everything older is immutable and worth persisting.
+	currentHour := utilities.GetCurrentHourKey()
+	currentHourSuffix := ":" + currentHour
+
+	cache.Mu.RLock()
+	snap := analyticsSnapshot{
+		Version:      snapshotVersion,
+		SavedAt:      time.Now().UTC(),
+		EpinetBins:   make(map[string]*types.HourlyEpinetBin, len(cache.EpinetBins)),
+		ContentBins:  make(map[string]*types.HourlyContentBin, len(cache.ContentBins)),
+		SiteBins:     make(map[string]*types.HourlySiteBin, len(cache.SiteBins)),
+		LastFullHour: cache.LastFullHour,
+		LastUpdated:  cache.LastUpdated,
+	}
+	for key, bin := range cache.EpinetBins {
+		if !strings.HasSuffix(key, currentHourSuffix) {
+			snap.EpinetBins[key] = bin
+		}
+	}
+	for key, bin := range cache.ContentBins {
+		if !strings.HasSuffix(key, currentHourSuffix) {
+			snap.ContentBins[key] = bin
+		}
+	}
+	for key, bin := range cache.SiteBins {
+		if key != currentHour {
+			snap.SiteBins[key] = bin
+		}
+	}
+	cache.Mu.RUnlock()
+
+	return writeSnapshotFile(path, &snap)
+}
+
+func (s *Snapshotter) loadContent(m *Manager, tenantID, path string) error {
+	var snap contentSnapshot
+	ok, err := readSnapshotFile(path, &snap)
+	if err != nil || !ok {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("snapshot version %d does not match %d, ignoring", snap.Version, snapshotVersion)
+	}
+	if time.Since(snap.SavedAt) > config.ContentCacheTTL {
+		return nil
+	}
+
+	m.contentStore.InitializeTenant(tenantID)
+	cache, exists := m.contentStore.GetTenantCache(tenantID)
+	if !exists {
+		return fmt.Errorf("tenant cache unavailable after initialization")
+	}
+
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+	cache.TractStacks = snap.TractStacks
+	cache.StoryFragments = snap.StoryFragments
+	cache.Panes = snap.Panes
+	cache.Menus = snap.Menus
+	cache.Resources = snap.Resources
+	cache.Epinets = snap.Epinets
+	cache.Beliefs = snap.Beliefs
+	cache.Files = snap.Files
+	cache.StoryfragmentBeliefRegistries = snap.StoryfragmentBeliefRegistries
+	cache.SlugToID = snap.SlugToID
+	cache.CategoryToIDs = snap.CategoryToIDs
+	cache.ExternalIDs = snap.ExternalIDs
+	cache.AllTractStackIDs = snap.AllTractStackIDs
+	cache.AllStoryFragmentIDs = snap.AllStoryFragmentIDs
+	cache.AllPaneIDs = snap.AllPaneIDs
+	cache.AllMenuIDs = snap.AllMenuIDs
+	cache.AllResourceIDs = snap.AllResourceIDs
+	cache.AllBeliefIDs = snap.AllBeliefIDs
+	cache.AllEpinetIDs = snap.AllEpinetIDs
+	cache.AllFileIDs = snap.AllFileIDs
+	cache.FullContentMap = snap.FullContentMap
+	cache.ContentMapLastUpdated = snap.ContentMapLastUpdated
+	cache.ContentMapETag = snap.ContentMapETag
+	cache.LastUpdated = snap.LastUpdated
+	return nil
+}
+
+func (s *Snapshotter) loadAnalytics(m *Manager, tenantID, path string) error {
+	var snap analyticsSnapshot
+	ok, err := readSnapshotFile(path, &snap)
+	if err != nil || !ok {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("snapshot version %d does not match %d, ignoring", snap.Version, snapshotVersion)
+	}
+	if time.Since(snap.SavedAt) > config.AnalyticsBinTTL {
+		return nil
+	}
+
+	m.analyticsStore.InitializeTenant(tenantID)
+	cache, exists := m.analyticsStore.GetTenantCache(tenantID)
+	if !exists {
+		return fmt.Errorf("tenant cache unavailable after initialization")
+	}
+
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+	cache.EpinetBins = snap.EpinetBins
+	cache.ContentBins = snap.ContentBins
+	cache.SiteBins = snap.SiteBins
+	cache.LastFullHour = snap.LastFullHour
+	cache.LastUpdated = snap.LastUpdated
+	return nil
+}
+
+// writeSnapshotFile gob-encodes v to a temp file and renames it into place so
+// a crash mid-write never leaves a truncated snapshot.
+func writeSnapshotFile(path string, v any) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(tmp).Encode(v); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// readSnapshotFile gob-decodes path into v, reporting false when no snapshot
+// exists.
+func readSnapshotFile(path string, v any) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close()
+	if err := gob.NewDecoder(f).Decode(v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/utilities"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+func TestSnapshotRoundTripRestoresCaches(t *testing.T) {
+	dir := t.TempDir()
+
+	source := NewManager(nil)
+	source.InitializeTenant("t1")
+	source.SetTractStack("t1", &content.TractStackNode{ID: "ts-1", Title: "Main", Slug: "main"})
+	source.SetPane("t1", &content.PaneNode{ID: "pane-1", Title: "Hero", Slug: "hero"})
+	source.SetAllPaneIDs("t1", []string{"pane-1"})
+	source.SetFullContentMap("t1", []types.FullContentMapItem{
+		{ID: "ts-1", Title: "Main", Slug: "main", Type: "TractStack"},
+	})
+	sourceETag := source.GetContentMapETag("t1")
+	source.SetHourlySiteBin("t1", "2020-01-01-00", &types.HourlySiteBin{
+		Data:       &types.HourlySiteData{},
+		ComputedAt: time.Now().UTC(),
+	})
+
+	if err := NewSnapshotter().Save(source, dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewManager(nil)
+	if err := NewSnapshotter().Load(restored, dir); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if node, found := restored.GetTractStack("t1", "ts-1"); !found || node.Title != "Main" {
+		t.Errorf("expected the tractstack node to round-trip, found=%v node=%+v", found, node)
+	}
+	if node, found := restored.GetPane("t1", "pane-1"); !found || node.Slug != "hero" {
+		t.Errorf("expected the pane node to round-trip, found=%v node=%+v", found, node)
+	}
+	if ids, found := restored.GetAllPaneIDs("t1"); !found || len(ids) != 1 || ids[0] != "pane-1" {
+		t.Errorf("expected the pane ID list to round-trip, found=%v ids=%v", found, ids)
+	}
+	if items, found := restored.GetFullContentMap("t1"); !found || len(items) != 1 {
+		t.Errorf("expected the full content map to round-trip, found=%v items=%d", found, len(items))
+	}
+	if etag := restored.GetContentMapETag("t1"); etag != sourceETag {
+		t.Errorf("expected the content map ETag to round-trip, got %q want %q", etag, sourceETag)
+	}
+	if _, found := restored.GetHourlySiteBin("t1", "2020-01-01-00"); !found {
+		t.Error("expected the closed-hour analytics bin to round-trip")
+	}
+}
+
+func TestSnapshotSaveExcludesCurrentHourBin(t *testing.T) {
+	dir := t.TempDir()
+	currentHour := utilities.GetCurrentHourKey()
+
+	source := NewManager(nil)
+	source.InitializeTenant("t1")
+	source.SetHourlySiteBin("t1", currentHour, &types.HourlySiteBin{
+		Data:       &types.HourlySiteData{},
+		ComputedAt: time.Now().UTC(),
+	})
+	source.SetHourlySiteBin("t1", "2020-01-01-00", &types.HourlySiteBin{
+		Data:       &types.HourlySiteData{},
+		ComputedAt: time.Now().UTC(),
+	})
+
+	if err := NewSnapshotter().Save(source, dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewManager(nil)
+	if err := NewSnapshotter().Load(restored, dir); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// The still-accumulating current hour re-warms in seconds and is not
+	// persisted; closed hours are.
+	if _, found := restored.GetHourlySiteBin("t1", currentHour); found {
+		t.Error("expected the current-hour bin to be excluded from the snapshot")
+	}
+	if _, found := restored.GetHourlySiteBin("t1", "2020-01-01-00"); !found {
+		t.Error("expected the closed-hour bin to be restored")
+	}
+}
+
+func TestSnapshotLoadIgnoresStaleSnapshots(t *testing.T) {
+	dir := t.TempDir()
+
+	source := NewManager(nil)
+	source.InitializeTenant("t1")
+	source.SetPane("t1", &content.PaneNode{ID: "pane-1", Title: "Hero", Slug: "hero"})
+	if err := NewSnapshotter().Save(source, dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Shrink the TTLs so the snapshot on disk is already older than the
+	// cache lifetime; stale data must never shadow the database.
+	origContentTTL, origBinTTL := config.ContentCacheTTL, config.AnalyticsBinTTL
+	config.ContentCacheTTL = time.Nanosecond
+	config.AnalyticsBinTTL = time.Nanosecond
+	defer func() { config.ContentCacheTTL, config.AnalyticsBinTTL = origContentTTL, origBinTTL }()
+	time.Sleep(time.Millisecond)
+
+	restored := NewManager(nil)
+	if err := NewSnapshotter().Load(restored, dir); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, found := restored.GetPane("t1", "pane-1"); found {
+		t.Error("expected a stale snapshot to be ignored on load")
+	}
+}
+
+func TestSnapshotLoadMissingDirectoryIsNotAnError(t *testing.T) {
+	if err := NewSnapshotter().Load(NewManager(nil), t.TempDir()+"/absent"); err != nil {
+		t.Errorf("expected a missing snapshot directory to load cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package manager provides centralized cache operations with proper tenant isolation
+package manager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+// StoreStats summarizes one cache store for a single tenant.
+type StoreStats struct {
+	Items          int       `json:"items"`
+	EstimatedBytes int64     `json:"estimatedBytes"`
+	LastUpdated    time.Time `json:"lastUpdated,omitempty"`
+	HitRatio       float64   `json:"hitRatio"`
+}
+
+// DetailedCacheStats aggregates per-store counts, estimated memory, hit
+// ratios, and eviction counts for a single tenant.
+type DetailedCacheStats struct {
+	TenantID       string           `json:"tenantId"`
+	GeneratedAt    time.Time        `json:"generatedAt"`
+	LastAccessed   time.Time        `json:"lastAccessed,omitempty"`
+	Content        StoreStats       `json:"content"`
+	UserState      StoreStats       `json:"userState"`
+	HTMLChunks     StoreStats       `json:"htmlChunks"`
+	Analytics      StoreStats       `json:"analytics"`
+	TotalEvictions map[string]int64 `json:"totalEvictions,omitempty"`
+}
+
+// estimateJSONBytes approximates the in-memory footprint of a cache section
+// by its JSON encoding; see types.EstimateSize.
+func estimateJSONBytes(v any) int64 {
+	return types.EstimateSize(v)
+}
+
+// GetDetailedStats walks all four cache stores for a tenant and returns item
+// counts, estimated memory, hit ratios, and eviction counts. Tenants whose
+// caches are not initialized report zeroed sections.
+func (m *Manager) GetDetailedStats(tenantID string) *DetailedCacheStats {
+	stats := &DetailedCacheStats{
+		TenantID:    tenantID,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	m.Mu.RLock()
+	stats.LastAccessed = m.LastAccessed[tenantID]
+	m.Mu.RUnlock()
+
+	if contentCache, exists := m.contentStore.GetTenantCache(tenantID); exists {
+		contentCache.Mu.RLock()
+		stats.Content.Items = len(contentCache.TractStacks) + len(contentCache.StoryFragments) +
+			len(contentCache.Panes) + len(contentCache.Menus) + len(contentCache.Resources) +
+			len(contentCache.Epinets) + len(contentCache.Beliefs) + len(contentCache.Files) +
+			len(contentCache.FullContentMap)
+		stats.Content.EstimatedBytes = estimateJSONBytes(contentCache.TractStacks) +
+			estimateJSONBytes(contentCache.StoryFragments) +
+			estimateJSONBytes(contentCache.Panes) +
+			estimateJSONBytes(contentCache.Menus) +
+			estimateJSONBytes(contentCache.Resources) +
+			estimateJSONBytes(contentCache.Epinets) +
+			estimateJSONBytes(contentCache.Beliefs) +
+			estimateJSONBytes(contentCache.Files) +
+			estimateJSONBytes(contentCache.FullContentMap)
+		stats.Content.LastUpdated = contentCache.LastUpdated
+		contentCache.Mu.RUnlock()
+	}
+
+	if userStateCache, exists := m.sessionsStore.GetTenantCache(tenantID); exists {
+		userStateCache.FingerprintsMu.RLock()
+		stats.UserState.Items += len(userStateCache.FingerprintStates)
+		stats.UserState.EstimatedBytes += estimateJSONBytes(userStateCache.FingerprintStates)
+		userStateCache.FingerprintsMu.RUnlock()
+
+		userStateCache.VisitsMu.RLock()
+		stats.UserState.Items += len(userStateCache.VisitStates)
+		stats.UserState.EstimatedBytes += estimateJSONBytes(userStateCache.VisitStates)
+		userStateCache.VisitsMu.RUnlock()
+
+		userStateCache.SessionsMu.RLock()
+		stats.UserState.Items += len(userStateCache.SessionStates)
+		stats.UserState.EstimatedBytes += estimateJSONBytes(userStateCache.SessionStates)
+		userStateCache.SessionsMu.RUnlock()
+
+		userStateCache.BeliefContextsMu.RLock()
+		stats.UserState.Items += len(userStateCache.SessionBeliefContexts)
+		stats.UserState.EstimatedBytes += estimateJSONBytes(userStateCache.SessionBeliefContexts)
+		userStateCache.BeliefContextsMu.RUnlock()
+
+		userStateCache.BeliefRegistriesMu.RLock()
+		stats.UserState.Items += len(userStateCache.StoryfragmentBeliefRegistries)
+		stats.UserState.EstimatedBytes += estimateJSONBytes(userStateCache.StoryfragmentBeliefRegistries)
+		userStateCache.BeliefRegistriesMu.RUnlock()
+
+		userStateCache.MetadataMu.RLock()
+		stats.UserState.LastUpdated = userStateCache.LastLoaded
+		userStateCache.MetadataMu.RUnlock()
+	}
+
+	if chunkCache, exists := m.fragmentsStore.GetTenantCache(tenantID); exists {
+		chunkCache.Mu.RLock()
+		stats.HTMLChunks.Items = len(chunkCache.Chunks)
+		for _, chunk := range chunkCache.Chunks {
+			stats.HTMLChunks.EstimatedBytes += types.EstimateHTMLChunkBytes(chunk)
+			if chunk.LastUpdated.After(stats.HTMLChunks.LastUpdated) {
+				stats.HTMLChunks.LastUpdated = chunk.LastUpdated
+			}
+		}
+		chunkCache.Mu.RUnlock()
+
+		hits, misses := m.fragmentsStore.GetHTMLChunkHitStats(tenantID)
+		if total := hits + misses; total > 0 {
+			stats.HTMLChunks.HitRatio = float64(hits) / float64(total)
+		}
+	}
+
+	if analyticsCache, exists := m.analyticsStore.GetTenantCache(tenantID); exists {
+		analyticsCache.Mu.RLock()
+		stats.Analytics.Items = len(analyticsCache.EpinetBins) + len(analyticsCache.ContentBins) + len(analyticsCache.SiteBins)
+		stats.Analytics.EstimatedBytes = estimateJSONBytes(analyticsCache.EpinetBins) +
+			estimateJSONBytes(analyticsCache.ContentBins) +
+			estimateJSONBytes(analyticsCache.SiteBins)
+		stats.Analytics.LastUpdated = analyticsCache.LastUpdated
+		analyticsCache.Mu.RUnlock()
+	}
+
+	if m.monitor != nil {
+		if metrics := m.monitor.GetLayerMetrics("content"); metrics != nil {
+			stats.Content.HitRatio = metrics.HitRatio
+		}
+		if metrics := m.monitor.GetLayerMetrics("user_state"); metrics != nil {
+			stats.UserState.HitRatio = metrics.HitRatio
+		}
+		if metrics := m.monitor.GetLayerMetrics("analytics"); metrics != nil {
+			stats.Analytics.HitRatio = metrics.HitRatio
+		}
+		if evictions := m.monitor.GetEvictionStats(); evictions != nil {
+			stats.TotalEvictions = make(map[string]int64, len(evictions.LayerEvictions))
+			for layerName, layerStats := range evictions.LayerEvictions {
+				stats.TotalEvictions[layerName] = layerStats.TotalEvictions
+			}
+		}
+	}
+
+	return stats
+}
+
+// GetAllDetailedStats returns detailed stats for every tenant the manager has
+// seen, ordered by tenant ID.
+func (m *Manager) GetAllDetailedStats() []*DetailedCacheStats {
+	m.Mu.RLock()
+	tenantIDs := make([]string, 0, len(m.LastAccessed))
+	for tenantID := range m.LastAccessed {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	m.Mu.RUnlock()
+	sort.Strings(tenantIDs)
+
+	allStats := make([]*DetailedCacheStats, 0, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		allStats = append(allStats, m.GetDetailedStats(tenantID))
+	}
+	return allStats
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+)
+
+// backdateContentCache simulates elapsed time by moving the tenant content
+// cache's write clock into the past, since the TTL getters compare against
+// cache.LastUpdated rather than an injectable clock.
+func backdateContentCache(t *testing.T, m *Manager, tenantID string, age time.Duration) {
+	t.Helper()
+	cache, exists := m.contentStore.GetTenantCache(tenantID)
+	if !exists {
+		t.Fatalf("expected tenant %s content cache to exist", tenantID)
+	}
+	cache.Mu.Lock()
+	cache.LastUpdated = time.Now().UTC().Add(-age)
+	cache.Mu.Unlock()
+}
+
+func TestIDListGettersExpirePerTypeTTL(t *testing.T) {
+	m := NewManager(nil)
+	m.InitializeTenant("t1")
+
+	ttls := m.contentStore.TTLConfig()
+	origPane, origTractStack := ttls.Pane, ttls.TractStack
+	ttls.Pane = time.Hour
+	ttls.TractStack = 3 * time.Hour
+	defer func() { ttls.Pane, ttls.TractStack = origPane, origTractStack }()
+
+	m.SetAllPaneIDs("t1", []string{"pane-1", "pane-2"})
+	m.SetAllTractStackIDs("t1", []string{"ts-1"})
+
+	if _, found := m.GetAllPaneIDs("t1"); !found {
+		t.Fatal("expected a fresh pane ID list to hit")
+	}
+
+	// Two simulated hours: past the pane TTL, within the tractstack TTL.
+	backdateContentCache(t, m, "t1", 2*time.Hour)
+
+	if _, found := m.GetAllPaneIDs("t1"); found {
+		t.Error("expected the pane ID list to expire past its one-hour TTL")
+	}
+	if ids, found := m.GetAllTractStackIDs("t1"); !found || len(ids) != 1 {
+		t.Errorf("expected the tractstack ID list to survive within its three-hour TTL, found=%v ids=%v", found, ids)
+	}
+
+	// Past every configured boundary, both lists are gone.
+	backdateContentCache(t, m, "t1", 4*time.Hour)
+	if _, found := m.GetAllTractStackIDs("t1"); found {
+		t.Error("expected the tractstack ID list to expire past its TTL")
+	}
+}
+
+func TestIDListGettersZeroTTLDisablesExpiry(t *testing.T) {
+	m := NewManager(nil)
+	m.InitializeTenant("t1")
+
+	ttls := m.contentStore.TTLConfig()
+	origMenu := ttls.Menu
+	ttls.Menu = 0
+	defer func() { ttls.Menu = origMenu }()
+
+	m.SetAllMenuIDs("t1", []string{"menu-1"})
+	backdateContentCache(t, m, "t1", 240*time.Hour)
+
+	if _, found := m.GetAllMenuIDs("t1"); !found {
+		t.Error("expected a zero TTL to disable ID-list expiry")
+	}
+}
+
+func TestNodeGettersExpirePerTypeTTL(t *testing.T) {
+	m := NewManager(nil)
+	m.InitializeTenant("t1")
+
+	ttls := m.contentStore.TTLConfig()
+	origPane, origBelief := ttls.Pane, ttls.Belief
+	ttls.Pane = time.Hour
+	ttls.Belief = 3 * time.Hour
+	defer func() { ttls.Pane, ttls.Belief = origPane, origBelief }()
+
+	m.SetPane("t1", &content.PaneNode{ID: "pane-1", Title: "Hero", Slug: "hero"})
+	m.SetBelief("t1", &content.BeliefNode{ID: "belief-1", Title: "Interest", Slug: "interest", Scale: "yn"})
+
+	backdateContentCache(t, m, "t1", 2*time.Hour)
+
+	if _, found := m.GetPane("t1", "pane-1"); found {
+		t.Error("expected the pane node to expire past its one-hour TTL")
+	}
+	if _, found := m.GetBelief("t1", "belief-1"); !found {
+		t.Error("expected the belief node to survive within its three-hour TTL")
+	}
+}
@@ -2,20 +2,53 @@
 package stores
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
+// ContentTTLConfig holds per-content-type expiry cutoffs so analytics-heavy
+// types can expire faster than rarely-changing editorial content.
+type ContentTTLConfig struct {
+	TractStack    time.Duration
+	StoryFragment time.Duration
+	Pane          time.Duration
+	Menu          time.Duration
+	Resource      time.Duration
+	Belief        time.Duration
+	Epinet        time.Duration
+	File          time.Duration
+}
+
+// defaultContentTTLConfig loads per-type TTLs from environment configuration.
+func defaultContentTTLConfig() *ContentTTLConfig {
+	return &ContentTTLConfig{
+		TractStack:    config.TractStackCacheTTL,
+		StoryFragment: config.StoryFragmentCacheTTL,
+		Pane:          config.PaneCacheTTL,
+		Menu:          config.MenuCacheTTL,
+		Resource:      config.ResourceCacheTTL,
+		Belief:        config.BeliefCacheTTL,
+		Epinet:        config.EpinetCacheTTL,
+		File:          config.FileCacheTTL,
+	}
+}
+
 // ContentStore implements content caching operations with tenant isolation
 type ContentStore struct {
 	tenantCaches map[string]*types.TenantContentCache
 	mu           sync.RWMutex
 	logger       *logging.ChanneledLogger
+	ttlConfig    *ContentTTLConfig
 }
 
 // NewContentStore creates a new content cache store
@@ -26,9 +59,16 @@ func NewContentStore(logger *logging.ChanneledLogger) *ContentStore {
 	return &ContentStore{
 		tenantCaches: make(map[string]*types.TenantContentCache),
 		logger:       logger,
+		ttlConfig:    defaultContentTTLConfig(),
 	}
 }
 
+// TTLConfig exposes the per-content-type expiry configuration so the cache
+// manager's ID-list getters can apply the same cutoffs as the node getters.
+func (cs *ContentStore) TTLConfig() *ContentTTLConfig {
+	return cs.ttlConfig
+}
+
 // InitializeTenant creates cache structures for a tenant
 func (cs *ContentStore) InitializeTenant(tenantID string) {
 	start := time.Now()
@@ -52,6 +92,8 @@ func (cs *ContentStore) InitializeTenant(tenantID string) {
 			StoryfragmentBeliefRegistries: make(map[string]*types.StoryfragmentBeliefRegistry),
 			SlugToID:                      make(map[string]string),
 			CategoryToIDs:                 make(map[string][]string),
+			PaneToStoryFragments:          make(map[string][]string),
+			ExternalIDs:                   make(map[string]*content.ExternalIDMapping),
 			AllPaneIDs:                    make([]string, 0),
 			FullContentMap:                make([]types.FullContentMapItem, 0),
 			ContentMapLastUpdated:         time.Now().UTC(),
@@ -116,6 +158,37 @@ func (cs *ContentStore) GetFullContentMap(tenantID string) ([]types.FullContentM
 	return cache.FullContentMap, true
 }
 
+// GetContentMapLastUpdated reports when the cached full content map was last
+// rebuilt, or the zero time if the tenant has no cached map.
+func (cs *ContentStore) GetContentMapLastUpdated(tenantID string) time.Time {
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return time.Time{}
+	}
+
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+	return cache.ContentMapLastUpdated
+}
+
+// GetContentVersion returns the tenant content cache clock used for ETag
+// derivation. Every node Set bumps LastUpdated, so any content write moves
+// the version forward automatically.
+func (cs *ContentStore) GetContentVersion(tenantID string) time.Time {
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return time.Time{}
+	}
+
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+
+	if cache.ContentMapLastUpdated.After(cache.LastUpdated) {
+		return cache.ContentMapLastUpdated
+	}
+	return cache.LastUpdated
+}
+
 // SetFullContentMap stores the full content map for a tenant
 func (cs *ContentStore) SetFullContentMap(tenantID string, contentMap []types.FullContentMapItem) {
 	start := time.Now()
@@ -131,10 +204,104 @@ func (cs *ContentStore) SetFullContentMap(tenantID string, contentMap []types.Fu
 	cache.FullContentMap = contentMap
 	cache.ContentMapLastUpdated = time.Now().UTC()
 	cache.LastUpdated = time.Now().UTC()
+	cache.ContentMapETag = computeContentMapETag(contentMap, cache.ContentMapLastUpdated)
+
+	if cs.logger != nil {
+		cs.logger.Cache().Debug("Cache operation", "operation", "set", "type", "contentmap", "tenantId", tenantID, "items", len(contentMap), "etag", cache.ContentMapETag, "duration", time.Since(start))
+	}
+}
+
+// UpdateContentMapItem surgically patches a single entry in the cached full
+// content map, replacing the item with the same ID or appending it when new,
+// and recomputes the map's timestamp and ETag. Returns false when no map is
+// cached so callers can fall back to a full rebuild.
+func (cs *ContentStore) UpdateContentMapItem(tenantID string, item types.FullContentMapItem) bool {
+	start := time.Now()
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return false
+	}
+
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+
+	if len(cache.FullContentMap) == 0 {
+		return false
+	}
+
+	replaced := false
+	for i := range cache.FullContentMap {
+		if cache.FullContentMap[i].ID == item.ID {
+			cache.FullContentMap[i] = item
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		cache.FullContentMap = append(cache.FullContentMap, item)
+	}
+	cache.ContentMapLastUpdated = time.Now().UTC()
+	cache.LastUpdated = time.Now().UTC()
+	cache.ContentMapETag = computeContentMapETag(cache.FullContentMap, cache.ContentMapLastUpdated)
+
+	if cs.logger != nil {
+		cs.logger.Cache().Debug("Cache operation", "operation", "patch", "type", "contentmap", "tenantId", tenantID, "key", item.ID, "replaced", replaced, "duration", time.Since(start))
+	}
+	return true
+}
+
+// RemoveContentMapItem surgically removes a single entry from the cached full
+// content map and recomputes the map's timestamp and ETag. Returns false when
+// no map is cached so callers can fall back to a full rebuild.
+func (cs *ContentStore) RemoveContentMapItem(tenantID, id string) bool {
+	start := time.Now()
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return false
+	}
+
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+
+	if len(cache.FullContentMap) == 0 {
+		return false
+	}
+
+	cache.FullContentMap = slices.DeleteFunc(cache.FullContentMap, func(existing types.FullContentMapItem) bool {
+		return existing.ID == id
+	})
+	cache.ContentMapLastUpdated = time.Now().UTC()
+	cache.LastUpdated = time.Now().UTC()
+	cache.ContentMapETag = computeContentMapETag(cache.FullContentMap, cache.ContentMapLastUpdated)
 
 	if cs.logger != nil {
-		cs.logger.Cache().Debug("Cache operation", "operation", "set", "type", "contentmap", "tenantId", tenantID, "items", len(contentMap), "duration", time.Since(start))
+		cs.logger.Cache().Debug("Cache operation", "operation", "remove", "type", "contentmap", "tenantId", tenantID, "key", id, "duration", time.Since(start))
+	}
+	return true
+}
+
+// computeContentMapETag derives a stable ETag from the serialized content map
+// and its rebuild timestamp, mirroring the orphan-analysis ETag pattern.
+func computeContentMapETag(contentMap []types.FullContentMapItem, lastUpdated time.Time) string {
+	hasher := sha256.New()
+	if serialized, err := json.Marshal(contentMap); err == nil {
+		hasher.Write(serialized)
 	}
+	hasher.Write([]byte(strconv.FormatInt(lastUpdated.UnixNano(), 10)))
+	return `"` + hex.EncodeToString(hasher.Sum(nil)[:16]) + `"`
+}
+
+// GetContentMapETag returns the stored ETag for the tenant's full content map,
+// or the empty string when no map is cached.
+func (cs *ContentStore) GetContentMapETag(tenantID string) string {
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return ""
+	}
+
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+	return cache.ContentMapETag
 }
 
 // =============================================================================
@@ -177,6 +344,23 @@ func (cs *ContentStore) GetOrphanAnalysis(tenantID string) (*types.OrphanAnalysi
 	return cache.OrphanAnalysis.Data, cache.OrphanAnalysis.ETag, true
 }
 
+// GetOrphanAnalysisLastUpdated reports when the cached orphan analysis was
+// last computed, or the zero time if none is cached.
+func (cs *ContentStore) GetOrphanAnalysisLastUpdated(tenantID string) time.Time {
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return time.Time{}
+	}
+
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+
+	if cache.OrphanAnalysis == nil {
+		return time.Time{}
+	}
+	return cache.OrphanAnalysis.LastUpdated
+}
+
 // SetOrphanAnalysis stores orphan analysis data with ETag
 func (cs *ContentStore) SetOrphanAnalysis(tenantID string, payload *types.OrphanAnalysisPayload, etag string) {
 	start := time.Now()
@@ -219,7 +403,7 @@ func (cs *ContentStore) GetTractStack(tenantID, id string) (*content.TractStackN
 	defer cache.Mu.RUnlock()
 
 	// Check cache expiration (24 hours TTL)
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.TractStack {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "tractstack", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -268,7 +452,7 @@ func (cs *ContentStore) GetStoryFragment(tenantID, id string) (*content.StoryFra
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.StoryFragment {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "storyfragment", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -294,6 +478,15 @@ func (cs *ContentStore) SetStoryFragment(tenantID string, node *content.StoryFra
 	cache.Mu.Lock()
 	defer cache.Mu.Unlock()
 
+	// Drop the stale slug index entry when an update renames the slug, so
+	// the old slug no longer resolves to this storyfragment.
+	if prev, ok := cache.StoryFragments[node.ID]; ok {
+		if prev.Slug != node.Slug {
+			delete(cache.SlugToID, prev.Slug)
+		}
+		unindexStoryFragmentPanes(cache, prev)
+	}
+	indexStoryFragmentPanes(cache, node)
 	cache.StoryFragments[node.ID] = node
 	cache.SlugToID[node.Slug] = node.ID
 	cache.LastUpdated = time.Now().UTC()
@@ -303,6 +496,46 @@ func (cs *ContentStore) SetStoryFragment(tenantID string, node *content.StoryFra
 	}
 }
 
+// indexStoryFragmentPanes records a storyfragment in the pane -> storyfragment
+// reverse index. Callers must hold the tenant cache write lock.
+func indexStoryFragmentPanes(cache *types.TenantContentCache, node *content.StoryFragmentNode) {
+	if cache.PaneToStoryFragments == nil {
+		cache.PaneToStoryFragments = make(map[string][]string)
+	}
+	for _, paneID := range node.PaneIDs {
+		if !slices.Contains(cache.PaneToStoryFragments[paneID], node.ID) {
+			cache.PaneToStoryFragments[paneID] = append(cache.PaneToStoryFragments[paneID], node.ID)
+		}
+	}
+}
+
+// unindexStoryFragmentPanes removes a storyfragment from the pane ->
+// storyfragment reverse index. Callers must hold the tenant cache write lock.
+func unindexStoryFragmentPanes(cache *types.TenantContentCache, node *content.StoryFragmentNode) {
+	for _, paneID := range node.PaneIDs {
+		ids := slices.DeleteFunc(cache.PaneToStoryFragments[paneID], func(id string) bool {
+			return id == node.ID
+		})
+		if len(ids) == 0 {
+			delete(cache.PaneToStoryFragments, paneID)
+		} else {
+			cache.PaneToStoryFragments[paneID] = ids
+		}
+	}
+}
+
+// GetStoryFragmentIDsForPane returns the IDs of cached storyfragments whose
+// PaneIDs include the given pane.
+func (cs *ContentStore) GetStoryFragmentIDsForPane(tenantID, paneID string) []string {
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return nil
+	}
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+	return append([]string(nil), cache.PaneToStoryFragments[paneID]...)
+}
+
 // GetPane retrieves a pane by ID
 func (cs *ContentStore) GetPane(tenantID, id string) (*content.PaneNode, bool) {
 	start := time.Now()
@@ -317,7 +550,7 @@ func (cs *ContentStore) GetPane(tenantID, id string) (*content.PaneNode, bool) {
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.Pane {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "pane", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -366,7 +599,7 @@ func (cs *ContentStore) GetMenu(tenantID, id string) (*content.MenuNode, bool) {
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.Menu {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "menu", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -414,7 +647,7 @@ func (cs *ContentStore) GetResource(tenantID, id string) (*content.ResourceNode,
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.Resource {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "resource", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -440,6 +673,13 @@ func (cs *ContentStore) SetResource(tenantID string, node *content.ResourceNode)
 	cache.Mu.Lock()
 	defer cache.Mu.Unlock()
 
+	if cache.ResourceOptionIndex != nil {
+		if prev, ok := cache.Resources[node.ID]; ok {
+			unindexResourceOptions(cache, prev)
+		}
+		indexResourceOptions(cache, node)
+	}
+
 	cache.Resources[node.ID] = node
 	cache.SlugToID[node.Slug] = node.ID
 	cache.LastUpdated = time.Now().UTC()
@@ -449,6 +689,107 @@ func (cs *ContentStore) SetResource(tenantID string, node *content.ResourceNode)
 	}
 }
 
+// canonicalOptionValue reduces an options payload value to the string form
+// used by the option index. Strings index as-is; numbers, booleans, and
+// anything else compare by their JSON representation.
+func canonicalOptionValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// indexResourceOptions adds a resource's option payload to the option index.
+// Callers must hold the cache write lock.
+func indexResourceOptions(cache *types.TenantContentCache, node *content.ResourceNode) {
+	for key, value := range node.OptionsPayload {
+		canonical := canonicalOptionValue(value)
+		if canonical == "" {
+			continue
+		}
+		byValue, ok := cache.ResourceOptionIndex[key]
+		if !ok {
+			byValue = make(map[string][]string)
+			cache.ResourceOptionIndex[key] = byValue
+		}
+		if !slices.Contains(byValue[canonical], node.ID) {
+			byValue[canonical] = append(byValue[canonical], node.ID)
+		}
+	}
+}
+
+// unindexResourceOptions removes a resource's option payload from the option
+// index. Callers must hold the cache write lock.
+func unindexResourceOptions(cache *types.TenantContentCache, node *content.ResourceNode) {
+	for key, value := range node.OptionsPayload {
+		canonical := canonicalOptionValue(value)
+		byValue, ok := cache.ResourceOptionIndex[key]
+		if !ok {
+			continue
+		}
+		byValue[canonical] = slices.DeleteFunc(byValue[canonical], func(id string) bool {
+			return id == node.ID
+		})
+		if len(byValue[canonical]) == 0 {
+			delete(byValue, canonical)
+		}
+		if len(byValue) == 0 {
+			delete(cache.ResourceOptionIndex, key)
+		}
+	}
+}
+
+// GetResourceIDsByOptions returns the IDs of cached resources whose options
+// payload matches every key/value filter. The first call builds the option
+// index from the cached resources; later calls answer from the index, which
+// SetResource and InvalidateResource keep current.
+func (cs *ContentStore) GetResourceIDsByOptions(tenantID string, filters map[string]string) []string {
+	if len(filters) == 0 {
+		return nil
+	}
+	cache, exists := cs.GetTenantCache(tenantID)
+	if !exists {
+		return nil
+	}
+
+	cache.Mu.Lock()
+	defer cache.Mu.Unlock()
+
+	if cache.ResourceOptionIndex == nil {
+		cache.ResourceOptionIndex = make(map[string]map[string][]string)
+		for _, node := range cache.Resources {
+			indexResourceOptions(cache, node)
+		}
+	}
+
+	var matched []string
+	first := true
+	for key, value := range filters {
+		candidates := cache.ResourceOptionIndex[key][value]
+		if len(candidates) == 0 {
+			return nil
+		}
+		if first {
+			matched = slices.Clone(candidates)
+			first = false
+			continue
+		}
+		matched = slices.DeleteFunc(matched, func(id string) bool {
+			return !slices.Contains(candidates, id)
+		})
+		if len(matched) == 0 {
+			return nil
+		}
+	}
+
+	slices.Sort(matched)
+	return matched
+}
+
 // GetEpinet retrieves an epinet by ID
 func (cs *ContentStore) GetEpinet(tenantID, id string) (*content.EpinetNode, bool) {
 	start := time.Now()
@@ -463,7 +804,7 @@ func (cs *ContentStore) GetEpinet(tenantID, id string) (*content.EpinetNode, boo
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.Epinet {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "epinet", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -511,7 +852,7 @@ func (cs *ContentStore) GetBelief(tenantID, id string) (*content.BeliefNode, boo
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.Belief {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "belief", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -560,7 +901,7 @@ func (cs *ContentStore) GetImageFile(tenantID, id string) (*content.ImageFileNod
 	cache.Mu.RLock()
 	defer cache.Mu.RUnlock()
 
-	if time.Since(cache.LastUpdated) > 24*time.Hour {
+	if time.Since(cache.LastUpdated) > cs.ttlConfig.File {
 		if cs.logger != nil {
 			cs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "imagefile", "tenantId", tenantID, "key", id, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
@@ -624,6 +965,8 @@ func (cs *ContentStore) InvalidateContentCache(tenantID string) {
 	cache.Files = make(map[string]*content.ImageFileNode)
 	cache.SlugToID = make(map[string]string)
 	cache.CategoryToIDs = make(map[string][]string)
+	cache.ExternalIDs = make(map[string]*content.ExternalIDMapping)
+	cache.ResourceOptionIndex = nil
 	cache.AllPaneIDs = make([]string, 0)
 
 	// Clear content map and orphan analysis
@@ -646,6 +989,9 @@ func (cs *ContentStore) InvalidateResource(tenantID, id string) {
 	defer cache.Mu.Unlock()
 	if resource, ok := cache.Resources[id]; ok {
 		delete(cache.SlugToID, "resource:"+resource.Slug)
+		if cache.ResourceOptionIndex != nil {
+			unindexResourceOptions(cache, resource)
+		}
 	}
 	delete(cache.Resources, id)
 }
@@ -719,7 +1065,8 @@ func (cs *ContentStore) InvalidateStoryFragment(tenantID, id string) {
 	cache.Mu.Lock()
 	defer cache.Mu.Unlock()
 	if storyFragment, ok := cache.StoryFragments[id]; ok {
-		delete(cache.SlugToID, "storyfragment:"+storyFragment.Slug)
+		delete(cache.SlugToID, storyFragment.Slug)
+		unindexStoryFragmentPanes(cache, storyFragment)
 	}
 	delete(cache.StoryFragments, id)
 }
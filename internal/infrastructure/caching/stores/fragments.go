@@ -2,18 +2,39 @@
 package stores
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/monitoring"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 // FragmentsStore implements HTML fragment caching operations with tenant isolation
 type FragmentsStore struct {
-	tenantCaches map[string]*types.TenantHTMLChunkCache
-	mu           sync.RWMutex
-	logger       *logging.ChanneledLogger
+	tenantCaches   map[string]*types.TenantHTMLChunkCache
+	hitStats       map[string]*chunkHitStats
+	renderFailures map[string]map[string]*types.PaneRenderFailure
+	mu             sync.RWMutex
+	logger         *logging.ChanneledLogger
+	monitor        *monitoring.CachePerformanceMonitor
+}
+
+// chunkHitStats accumulates lookup counters for one tenant's chunk cache.
+// Fields are accessed atomically so hot-path reads never take the store lock.
+type chunkHitStats struct {
+	hits   atomic.Int64
+	misses atomic.Int64
 }
 
 // NewFragmentsStore creates a new fragments cache store
@@ -22,11 +43,113 @@ func NewFragmentsStore(logger *logging.ChanneledLogger) *FragmentsStore {
 		logger.Cache().Info("Initializing fragments cache store")
 	}
 	return &FragmentsStore{
-		tenantCaches: make(map[string]*types.TenantHTMLChunkCache),
-		logger:       logger,
+		tenantCaches:   make(map[string]*types.TenantHTMLChunkCache),
+		hitStats:       make(map[string]*chunkHitStats),
+		renderFailures: make(map[string]map[string]*types.PaneRenderFailure),
+		logger:         logger,
+	}
+}
+
+// SetMonitor attaches a cache performance monitor so evictions show up in
+// its stats. Safe to leave unset; eviction then goes unreported.
+func (fs *FragmentsStore) SetMonitor(monitor *monitoring.CachePerformanceMonitor) {
+	fs.monitor = monitor
+}
+
+// recordChunkLookup counts a chunk cache hit or miss for a tenant.
+func (fs *FragmentsStore) recordChunkLookup(tenantID string, hit bool) {
+	fs.mu.RLock()
+	stats, exists := fs.hitStats[tenantID]
+	fs.mu.RUnlock()
+
+	if !exists {
+		fs.mu.Lock()
+		if stats = fs.hitStats[tenantID]; stats == nil {
+			stats = &chunkHitStats{}
+			fs.hitStats[tenantID] = stats
+		}
+		fs.mu.Unlock()
+	}
+
+	if hit {
+		stats.hits.Add(1)
+	} else {
+		stats.misses.Add(1)
+	}
+}
+
+// RecordPaneRenderFailure flags a pane whose node tree failed to render so it
+// shows up on the admin render-failure list while the fallback is served.
+func (fs *FragmentsStore) RecordPaneRenderFailure(tenantID, paneID, errMsg string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	failures, exists := fs.renderFailures[tenantID]
+	if !exists {
+		failures = make(map[string]*types.PaneRenderFailure)
+		fs.renderFailures[tenantID] = failures
+	}
+
+	now := time.Now().UTC()
+	if failure, ok := failures[paneID]; ok {
+		failure.LastError = errMsg
+		failure.LastFailed = now
+		failure.Count++
+		return
+	}
+	failures[paneID] = &types.PaneRenderFailure{
+		PaneID:      paneID,
+		LastError:   errMsg,
+		FirstFailed: now,
+		LastFailed:  now,
+		Count:       1,
 	}
 }
 
+// ClearPaneRenderFailure removes a pane from the render-failure list after a
+// successful render.
+func (fs *FragmentsStore) ClearPaneRenderFailure(tenantID, paneID string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	failures, exists := fs.renderFailures[tenantID]
+	if !exists {
+		return
+	}
+	delete(failures, paneID)
+	if len(failures) == 0 {
+		delete(fs.renderFailures, tenantID)
+	}
+}
+
+// GetPaneRenderFailures returns the panes currently flagged as render-failing
+// for a tenant, sorted by pane ID.
+func (fs *FragmentsStore) GetPaneRenderFailures(tenantID string) []*types.PaneRenderFailure {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	failures := make([]*types.PaneRenderFailure, 0, len(fs.renderFailures[tenantID]))
+	for _, failure := range fs.renderFailures[tenantID] {
+		failureCopy := *failure
+		failures = append(failures, &failureCopy)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].PaneID < failures[j].PaneID
+	})
+	return failures
+}
+
+// GetHTMLChunkHitStats returns cumulative hit and miss counts for a tenant's
+// chunk cache lookups.
+func (fs *FragmentsStore) GetHTMLChunkHitStats(tenantID string) (hits, misses int64) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if stats, exists := fs.hitStats[tenantID]; exists {
+		return stats.hits.Load(), stats.misses.Load()
+	}
+	return 0, 0
+}
+
 // InitializeTenant creates cache structures for a tenant
 func (fs *FragmentsStore) InitializeTenant(tenantID string) {
 	start := time.Now()
@@ -80,20 +203,43 @@ func (fs *FragmentsStore) GetHTMLChunk(tenantID, paneID string, variant types.Pa
 	chunk, found := cache.Chunks[chunkKey]
 
 	if !found {
+		fs.recordChunkLookup(tenantID, false)
 		if fs.logger != nil {
 			fs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "html_chunk", "tenantId", tenantID, "paneId", paneID, "chunkKey", chunkKey, "hit", false, "reason", "not_found", "duration", time.Since(start))
 		}
 		return nil, false
 	}
 
-	// Check if chunk is expired (1 hour TTL for HTML fragments)
-	if time.Since(chunk.LastUpdated) > time.Hour {
+	// Check if chunk is expired (1 hour TTL unless the chunk carries its own)
+	if time.Since(chunk.LastUpdated) > chunkTTL(chunk) {
+		fs.recordChunkLookup(tenantID, false)
 		if fs.logger != nil {
 			fs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "html_chunk", "tenantId", tenantID, "paneId", paneID, "chunkKey", chunkKey, "hit", false, "reason", "expired", "duration", time.Since(start))
 		}
 		return nil, false
 	}
 
+	atomic.StoreInt64(&chunk.LastAccessed, time.Now().UnixNano())
+
+	// Transparently decompress large chunks stored gzip-compressed; callers
+	// always see the HTML body populated. The cached entry stays compressed.
+	if chunk.Compressed {
+		html, err := decompressHTML(chunk.Data)
+		if err != nil {
+			fs.recordChunkLookup(tenantID, false)
+			if fs.logger != nil {
+				fs.logger.Cache().Warn("Failed to decompress cached HTML chunk", "tenantId", tenantID, "paneId", paneID, "chunkKey", chunkKey, "error", err)
+			}
+			return nil, false
+		}
+		inflated := *chunk
+		inflated.HTML = html
+		inflated.Compressed = false
+		inflated.Data = nil
+		chunk = &inflated
+	}
+
+	fs.recordChunkLookup(tenantID, true)
 	if fs.logger != nil {
 		fs.logger.Cache().Debug("Cache operation", "operation", "get", "type", "html_chunk", "tenantId", tenantID, "paneId", paneID, "chunkKey", chunkKey, "hit", true, "dependencies", len(chunk.DependsOn), "duration", time.Since(start))
 	}
@@ -103,6 +249,13 @@ func (fs *FragmentsStore) GetHTMLChunk(tenantID, paneID string, variant types.Pa
 
 // SetHTMLChunk stores an HTML chunk with dependencies
 func (fs *FragmentsStore) SetHTMLChunk(tenantID, paneID string, variant types.PaneVariant, html string, dependsOn []string) {
+	fs.SetHTMLChunkWithTTL(tenantID, paneID, variant, html, dependsOn, 0)
+}
+
+// SetHTMLChunkWithTTL stores an HTML chunk that expires after ttl instead of
+// the default one-hour window; a ttl of zero keeps the default. Used for
+// short-lived entries like fallback renders of failing panes.
+func (fs *FragmentsStore) SetHTMLChunkWithTTL(tenantID, paneID string, variant types.PaneVariant, html string, dependsOn []string, ttl time.Duration) {
 	start := time.Now()
 	cache, exists := fs.GetTenantCache(tenantID)
 	if !exists {
@@ -117,11 +270,23 @@ func (fs *FragmentsStore) SetHTMLChunk(tenantID, paneID string, variant types.Pa
 
 	// Create HTML chunk
 	chunk := &types.HTMLChunk{
-		HTML:        html,
-		PaneID:      paneID,
-		Variant:     variant,
-		DependsOn:   dependsOn,
-		LastUpdated: time.Now().UTC(),
+		HTML:         html,
+		PaneID:       paneID,
+		Variant:      variant,
+		DependsOn:    dependsOn,
+		LastUpdated:  time.Now().UTC(),
+		LastAccessed: time.Now().UnixNano(),
+		TTL:          ttl,
+	}
+
+	// Large bodies are held gzip-compressed to keep per-tenant memory down;
+	// GetHTMLChunk decompresses transparently on read.
+	if threshold := config.HTMLCompressionThreshold; threshold > 0 && len(html) > threshold {
+		if data, err := compressHTML(html); err == nil && len(data) < len(html) {
+			chunk.Compressed = true
+			chunk.Data = data
+			chunk.HTML = ""
+		}
 	}
 
 	// Store chunk
@@ -130,37 +295,156 @@ func (fs *FragmentsStore) SetHTMLChunk(tenantID, paneID string, variant types.Pa
 	// Update dependency mappings
 	fs.updateDependencies(cache, chunkKey, dependsOn)
 
+	// Enforce the per-tenant chunk count and total size caps with LRU eviction
+	maxBytes := int64(config.MaxHTMLChunkMBPerTenant) * 1024 * 1024
+	if evicted := fs.evictLRUChunks(cache, config.MaxHTMLChunksPerTenant, maxBytes); len(evicted) > 0 {
+		if fs.logger != nil {
+			fs.logger.Cache().Info("Evicted LRU HTML chunks over capacity", "tenantId", tenantID, "evicted", len(evicted), "maxChunks", config.MaxHTMLChunksPerTenant, "maxBytes", maxBytes)
+		}
+	}
+
 	if fs.logger != nil {
 		fs.logger.Cache().Debug("Cache operation", "operation", "set", "type", "html_chunk", "tenantId", tenantID, "paneId", paneID, "chunkKey", chunkKey, "htmlSize", len(html), "dependencies", len(dependsOn), "duration", time.Since(start))
 	}
 }
 
-// BuildChunkKey creates a unique key for HTML chunks based on pane ID and variant
-func (fs *FragmentsStore) BuildChunkKey(paneID string, variant types.PaneVariant) string {
-	if variant.BeliefMode == "" {
-		return paneID + ":default"
+// compressHTML gzip-compresses an HTML body for in-memory storage at the
+// configured compression level.
+func compressHTML(html string) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, config.CompressionLevel)
+	if err != nil {
+		w = gzip.NewWriter(&buf)
+	}
+	if _, err := w.Write([]byte(html)); err != nil {
+		return nil, err
 	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
 
-	// Include belief context in key for personalized variants
-	key := paneID + ":" + variant.BeliefMode
+// decompressHTML restores an HTML body stored by compressHTML.
+func decompressHTML(data []byte) (string, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	html, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(html), nil
+}
+
+// chunkBytes returns the in-memory size of a chunk's body, whichever form it
+// is stored in.
+func chunkBytes(chunk *types.HTMLChunk) int64 {
+	return int64(len(chunk.HTML) + len(chunk.Data))
+}
+
+// chunkTTL returns a chunk's effective expiry window.
+func chunkTTL(chunk *types.HTMLChunk) time.Duration {
+	if chunk.TTL > 0 {
+		return chunk.TTL
+	}
+	return time.Hour
+}
+
+// evictLRUChunks removes least-recently-accessed chunks until the tenant is
+// within both the chunk count cap and the total byte budget, returning the
+// evicted keys. Caller must hold the cache write lock. A limit of zero or
+// below disables that limit.
+func (fs *FragmentsStore) evictLRUChunks(cache *types.TenantHTMLChunkCache, maxChunks int, maxBytes int64) []string {
+	var totalBytes int64
+	if maxBytes > 0 {
+		for _, chunk := range cache.Chunks {
+			totalBytes += chunkBytes(chunk)
+		}
+	}
 
-	if len(variant.HeldBeliefs) > 0 {
-		key += ":held"
-		for _, belief := range variant.HeldBeliefs {
-			key += "-" + belief
+	overLimit := func() bool {
+		if maxChunks > 0 && len(cache.Chunks) > maxChunks {
+			return true
 		}
+		return maxBytes > 0 && totalBytes > maxBytes
+	}
+
+	if !overLimit() {
+		return nil
 	}
 
-	if len(variant.WithheldBeliefs) > 0 {
-		key += ":withheld"
-		for _, belief := range variant.WithheldBeliefs {
-			key += "-" + belief
+	var evictedKeys []string
+	for overLimit() {
+		victimKey := ""
+		victimAccess := int64(0)
+		for chunkKey, chunk := range cache.Chunks {
+			accessed := atomic.LoadInt64(&chunk.LastAccessed)
+			if accessed == 0 {
+				accessed = chunk.LastUpdated.UnixNano()
+			}
+			if victimKey == "" || accessed < victimAccess {
+				victimKey = chunkKey
+				victimAccess = accessed
+			}
 		}
+
+		victimBytes := chunkBytes(cache.Chunks[victimKey])
+		if fs.monitor != nil {
+			fs.monitor.RecordEviction("html_chunk", "capacity", victimBytes)
+		}
+		delete(cache.Chunks, victimKey)
+		totalBytes -= victimBytes
+		evictedKeys = append(evictedKeys, victimKey)
 	}
 
+	// Keep the dependency reverse-index consistent with the evicted keys
+	fs.cleanupOrphanedDependencies(cache, evictedKeys)
+
+	return evictedKeys
+}
+
+// BuildChunkKey creates a deterministic key for HTML chunks based on pane ID
+// and variant. The belief slices are sorted and hashed so two semantically
+// equal belief sets produce the same key regardless of slice order, while the
+// paneID prefix stays intact for pattern-based invalidation.
+func (fs *FragmentsStore) BuildChunkKey(paneID string, variant types.PaneVariant) string {
+	if variant.BeliefMode == "" {
+		return paneID + ":default"
+	}
+
+	key := paneID + ":" + variant.BeliefMode
+	if beliefHash := hashBeliefVariant(variant.HeldBeliefs, variant.WithheldBeliefs); beliefHash != "" {
+		key += ":" + beliefHash
+	}
 	return key
 }
 
+// hashBeliefVariant canonicalizes the belief slices into a short deterministic
+// hash: each slice is sorted on a copy, held and withheld stay distinct, and
+// the result is empty when no beliefs apply.
+func hashBeliefVariant(held, withheld []string) string {
+	if len(held) == 0 && len(withheld) == 0 {
+		return ""
+	}
+
+	sortedHeld := append([]string(nil), held...)
+	sort.Strings(sortedHeld)
+	sortedWithheld := append([]string(nil), withheld...)
+	sort.Strings(sortedWithheld)
+
+	canonical := make([]string, 0, len(held)+len(withheld)+2)
+	canonical = append(canonical, "held")
+	canonical = append(canonical, sortedHeld...)
+	canonical = append(canonical, "withheld")
+	canonical = append(canonical, sortedWithheld...)
+
+	sum := sha256.Sum256([]byte(strings.Join(canonical, "|")))
+	return hex.EncodeToString(sum[:8])
+}
+
 // updateDependencies updates the dependency mappings for invalidation
 func (fs *FragmentsStore) updateDependencies(cache *types.TenantHTMLChunkCache, chunkKey string, dependsOn []string) {
 	// For each dependency, add this chunk key to its dependents list
@@ -302,21 +586,25 @@ func (fs *FragmentsStore) InvalidateByPattern(tenantID, pattern string) {
 	}
 }
 
-// matchesPattern checks if a chunk key matches the given pattern
+// matchesPattern checks if a chunk key matches the given glob pattern.
+// "*" matches everything; otherwise filepath.Match semantics apply, so
+// prefix ("paneID:*"), suffix ("*:default"), and middle ("*belief*")
+// wildcards all work. Patterns without metacharacters, or malformed ones,
+// fall back to exact comparison.
 func (fs *FragmentsStore) matchesPattern(chunkKey, pattern string) bool {
-	// Simple pattern matching - extend as needed
 	if pattern == "*" {
 		return true
 	}
 
-	// Pattern like "paneID:*" matches all variants of a pane
-	if len(pattern) > 2 && pattern[len(pattern)-2:] == ":*" {
-		panePrefix := pattern[:len(pattern)-1] // Remove the "*"
-		return len(chunkKey) >= len(panePrefix) && chunkKey[:len(panePrefix)] == panePrefix
+	if !strings.ContainsAny(pattern, "*?[") {
+		return chunkKey == pattern
 	}
 
-	// Exact match
-	return chunkKey == pattern
+	matched, err := filepath.Match(pattern, chunkKey)
+	if err != nil {
+		return chunkKey == pattern
+	}
+	return matched
 }
 
 // =============================================================================
@@ -445,7 +733,7 @@ func (fs *FragmentsStore) PurgeExpiredChunks(tenantID string) int {
 
 	// Find expired chunks
 	for chunkKey, chunk := range cache.Chunks {
-		if time.Since(chunk.LastUpdated) > time.Hour {
+		if time.Since(chunk.LastUpdated) > chunkTTL(chunk) {
 			expiredKeys = append(expiredKeys, chunkKey)
 		}
 	}
@@ -0,0 +1,98 @@
+package stores
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// chunkBody100KB builds a representative compressible HTML body of ~100 KB.
+func chunkBody100KB() string {
+	return strings.Repeat("<p class=\"copy\">The quick brown fox jumps over the lazy dog.</p>\n", 100*1024/64)
+}
+
+func TestSetHTMLChunkCompressesLargeBodiesTransparently(t *testing.T) {
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+	body := chunkBody100KB()
+
+	fs.SetHTMLChunk("t1", "pane-1", variant, body, nil)
+
+	cache, _ := fs.GetTenantCache("t1")
+	cache.Mu.RLock()
+	chunk := cache.Chunks[fs.BuildChunkKey("pane-1", variant)]
+	cache.Mu.RUnlock()
+	if chunk == nil {
+		t.Fatal("expected the chunk to be cached")
+	}
+	if !chunk.Compressed {
+		t.Fatalf("expected a %d-byte body over the %d-byte threshold to be stored compressed", len(body), config.HTMLCompressionThreshold)
+	}
+	if len(chunk.Data) >= len(body) {
+		t.Errorf("expected the stored form to be smaller than the %d-byte body, got %d bytes", len(body), len(chunk.Data))
+	}
+
+	got, found := fs.GetHTMLChunk("t1", "pane-1", variant)
+	if !found {
+		t.Fatal("expected the compressed chunk to be readable")
+	}
+	if got.HTML != body {
+		t.Error("expected the decompressed body to be byte-identical to the original")
+	}
+}
+
+// BenchmarkSetHTMLChunk100KBGzip measures the allocation cost of storing a
+// 100 KB chunk with the gzip path active (the production default).
+func BenchmarkSetHTMLChunk100KBGzip(b *testing.B) {
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+	body := chunkBody100KB()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fs.SetHTMLChunk("t1", "pane-1", variant, body, nil)
+	}
+}
+
+// BenchmarkSetHTMLChunk100KBUncompressed is the same write with compression
+// disabled, for comparing the gzip path's memory overhead.
+func BenchmarkSetHTMLChunk100KBUncompressed(b *testing.B) {
+	origThreshold := config.HTMLCompressionThreshold
+	config.HTMLCompressionThreshold = 0
+	defer func() { config.HTMLCompressionThreshold = origThreshold }()
+
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+	body := chunkBody100KB()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fs.SetHTMLChunk("t1", "pane-1", variant, body, nil)
+	}
+}
+
+// BenchmarkGetHTMLChunk100KBGzip measures the transparent decompression cost
+// on the read path.
+func BenchmarkGetHTMLChunk100KBGzip(b *testing.B) {
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+	fs.SetHTMLChunk("t1", "pane-1", variant, chunkBody100KB(), nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, found := fs.GetHTMLChunk("t1", "pane-1", variant); !found {
+			b.Fatal("expected the chunk to be cached")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package stores
+
+import (
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+func TestBuildChunkKeyIsOrderIndependent(t *testing.T) {
+	fs := NewFragmentsStore(nil)
+
+	base := fs.BuildChunkKey("pane-1", types.PaneVariant{
+		BeliefMode:      "match",
+		HeldBeliefs:     []string{"alpha", "beta", "gamma"},
+		WithheldBeliefs: []string{"delta", "epsilon"},
+	})
+	reordered := fs.BuildChunkKey("pane-1", types.PaneVariant{
+		BeliefMode:      "match",
+		HeldBeliefs:     []string{"gamma", "alpha", "beta"},
+		WithheldBeliefs: []string{"epsilon", "delta"},
+	})
+	if base != reordered {
+		t.Errorf("expected reordered belief slices to hash to the same key, got %q vs %q", base, reordered)
+	}
+
+	// The pane ID prefix survives hashing so pane-1:* invalidation still works.
+	if fs.matchesPattern(base, "pane-1:*") != true {
+		t.Errorf("expected the hashed key %q to stay under the pane-1: prefix", base)
+	}
+}
+
+func TestBuildChunkKeyKeepsDistinctVariantsDistinct(t *testing.T) {
+	fs := NewFragmentsStore(nil)
+
+	held := fs.BuildChunkKey("pane-1", types.PaneVariant{BeliefMode: "match", HeldBeliefs: []string{"alpha"}})
+	withheld := fs.BuildChunkKey("pane-1", types.PaneVariant{BeliefMode: "match", WithheldBeliefs: []string{"alpha"}})
+	if held == withheld {
+		t.Errorf("expected held and withheld beliefs to produce different keys, both got %q", held)
+	}
+
+	other := fs.BuildChunkKey("pane-1", types.PaneVariant{BeliefMode: "match", HeldBeliefs: []string{"beta"}})
+	if held == other {
+		t.Errorf("expected different belief sets to produce different keys, both got %q", held)
+	}
+
+	if a, b := fs.BuildChunkKey("pane-1", types.PaneVariant{BeliefMode: "match", HeldBeliefs: []string{"alpha"}}), held; a != b {
+		t.Errorf("expected the key to be deterministic across calls, got %q vs %q", a, b)
+	}
+}
+
+func TestBuildChunkKeyDefaultVariantsStayReadable(t *testing.T) {
+	fs := NewFragmentsStore(nil)
+
+	if key := fs.BuildChunkKey("pane-1", types.PaneVariant{}); key != "pane-1:default" {
+		t.Errorf("expected an empty variant to key as pane-1:default, got %q", key)
+	}
+	if key := fs.BuildChunkKey("pane-1", types.PaneVariant{BeliefMode: "default", HeldBeliefs: []string{}, WithheldBeliefs: []string{}}); key != "pane-1:default" {
+		t.Errorf("expected the default mode with no beliefs to key as pane-1:default, got %q", key)
+	}
+}
@@ -0,0 +1,153 @@
+package stores
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+func TestSetHTMLChunkEvictsLRUPastChunkCap(t *testing.T) {
+	origMax, origMB := config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant
+	config.MaxHTMLChunksPerTenant = 3
+	config.MaxHTMLChunkMBPerTenant = 1024
+	defer func() {
+		config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant = origMax, origMB
+	}()
+
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+
+	for i := 1; i <= 5; i++ {
+		fs.SetHTMLChunk("t1", fmt.Sprintf("pane-%d", i), variant, "<div/>", []string{fmt.Sprintf("dep-%d", i)})
+		time.Sleep(time.Millisecond) // separate LastAccessed stamps
+	}
+
+	// The two oldest-accessed chunks fall out; the three newest survive.
+	for _, paneID := range []string{"pane-1", "pane-2"} {
+		if _, found := fs.GetHTMLChunk("t1", paneID, variant); found {
+			t.Errorf("expected %s to be evicted past the chunk cap", paneID)
+		}
+	}
+	for _, paneID := range []string{"pane-3", "pane-4", "pane-5"} {
+		if _, found := fs.GetHTMLChunk("t1", paneID, variant); !found {
+			t.Errorf("expected %s to survive eviction", paneID)
+		}
+	}
+}
+
+func TestSetHTMLChunkEvictionRespectsAccessOrder(t *testing.T) {
+	origMax, origMB := config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant
+	config.MaxHTMLChunksPerTenant = 3
+	config.MaxHTMLChunkMBPerTenant = 1024
+	defer func() {
+		config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant = origMax, origMB
+	}()
+
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+
+	for i := 1; i <= 3; i++ {
+		fs.SetHTMLChunk("t1", fmt.Sprintf("pane-%d", i), variant, "<div/>", nil)
+		time.Sleep(time.Millisecond)
+	}
+
+	// Touch pane-1 so pane-2 becomes the least recently accessed.
+	if _, found := fs.GetHTMLChunk("t1", "pane-1", variant); !found {
+		t.Fatal("expected pane-1 to be cached before the touch")
+	}
+	time.Sleep(time.Millisecond)
+	fs.SetHTMLChunk("t1", "pane-4", variant, "<div/>", nil)
+
+	if _, found := fs.GetHTMLChunk("t1", "pane-2", variant); found {
+		t.Error("expected the least-recently-accessed pane-2 to be evicted")
+	}
+	if _, found := fs.GetHTMLChunk("t1", "pane-1", variant); !found {
+		t.Error("expected the recently-touched pane-1 to survive")
+	}
+}
+
+func TestSetHTMLChunkEvictsLRUPastByteBudget(t *testing.T) {
+	origMax, origMB, origThreshold := config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant, config.HTMLCompressionThreshold
+	config.MaxHTMLChunksPerTenant = 100
+	config.MaxHTMLChunkMBPerTenant = 1
+	// Keep bodies uncompressed so the byte accounting is predictable.
+	config.HTMLCompressionThreshold = 0
+	defer func() {
+		config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant, config.HTMLCompressionThreshold = origMax, origMB, origThreshold
+	}()
+
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+	body := strings.Repeat("x", 300*1024) // 300 KB per chunk against a 1 MB budget
+
+	for i := 1; i <= 4; i++ {
+		fs.SetHTMLChunk("t1", fmt.Sprintf("pane-%d", i), variant, body, []string{fmt.Sprintf("dep-%d", i)})
+		time.Sleep(time.Millisecond)
+	}
+
+	// Four 300 KB bodies exceed the 1 MB budget; the oldest-accessed chunk
+	// goes first even though the chunk count cap is nowhere near reached.
+	if _, found := fs.GetHTMLChunk("t1", "pane-1", variant); found {
+		t.Error("expected pane-1 to be evicted past the byte budget")
+	}
+	for _, paneID := range []string{"pane-2", "pane-3", "pane-4"} {
+		if _, found := fs.GetHTMLChunk("t1", paneID, variant); !found {
+			t.Errorf("expected %s to survive byte-budget eviction", paneID)
+		}
+	}
+
+	cache, _ := fs.GetTenantCache("t1")
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+	if _, exists := cache.Deps["dep-1"]; exists {
+		t.Error("expected the evicted chunk's dependency to be removed")
+	}
+}
+
+func TestLRUEvictionKeepsDependencyIndexConsistent(t *testing.T) {
+	origMax, origMB := config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant
+	config.MaxHTMLChunksPerTenant = 2
+	config.MaxHTMLChunkMBPerTenant = 1024
+	defer func() {
+		config.MaxHTMLChunksPerTenant, config.MaxHTMLChunkMBPerTenant = origMax, origMB
+	}()
+
+	fs := NewFragmentsStore(nil)
+	fs.InitializeTenant("t1")
+	variant := types.PaneVariant{BeliefMode: "default"}
+
+	// Every chunk shares one dependency, so the reverse index must be
+	// rewritten (not just dropped) when a chunk is evicted.
+	for i := 1; i <= 4; i++ {
+		fs.SetHTMLChunk("t1", fmt.Sprintf("pane-%d", i), variant, "<div/>", []string{"shared-node", fmt.Sprintf("dep-%d", i)})
+		time.Sleep(time.Millisecond)
+	}
+
+	cache, _ := fs.GetTenantCache("t1")
+	cache.Mu.RLock()
+	defer cache.Mu.RUnlock()
+
+	if len(cache.Chunks) != 2 {
+		t.Fatalf("expected 2 surviving chunks, got %d", len(cache.Chunks))
+	}
+	for depID, chunkKeys := range cache.Deps {
+		for _, chunkKey := range chunkKeys {
+			if _, alive := cache.Chunks[chunkKey]; !alive {
+				t.Errorf("dependency %s still references evicted chunk %s", depID, chunkKey)
+			}
+		}
+	}
+	// Dependencies owned solely by evicted chunks disappear outright.
+	for _, depID := range []string{"dep-1", "dep-2"} {
+		if _, exists := cache.Deps[depID]; exists {
+			t.Errorf("expected orphaned dependency %s to be removed", depID)
+		}
+	}
+}
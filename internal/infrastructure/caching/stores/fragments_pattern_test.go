@@ -0,0 +1,37 @@
+package stores
+
+import "testing"
+
+func TestMatchesPatternGlobsOverChunkKeys(t *testing.T) {
+	fs := NewFragmentsStore(nil)
+
+	cases := []struct {
+		name     string
+		chunkKey string
+		pattern  string
+		want     bool
+	}{
+		{"star matches everything", "pane-1:default", "*", true},
+		{"exact key without metacharacters", "pane-1:default", "pane-1:default", true},
+		{"exact mismatch without metacharacters", "pane-1:default", "pane-2:default", false},
+		{"pane prefix matches default variant", "pane-1:default", "pane-1:*", true},
+		{"pane prefix matches belief variant", "pane-1:match:a1b2c3d4e5f6a7b8", "pane-1:*", true},
+		{"pane prefix leaves other panes alone", "pane-2:default", "pane-1:*", false},
+		{"pane prefix is not a substring match", "pane-10:default", "pane-1:*", false},
+		{"suffix selects one variant across panes", "pane-2:default", "*:default", true},
+		{"suffix skips other variants", "pane-2:match:a1b2c3d4e5f6a7b8", "*:default", false},
+		{"middle wildcard spans segments", "pane-1:match:a1b2c3d4e5f6a7b8", "pane-1:match:*", true},
+		{"question mark matches a single rune", "pane-1:default", "pane-?:default", true},
+		{"question mark will not span runes", "pane-10:default", "pane-?:default", false},
+		{"malformed pattern falls back to exact", "pane-[1:default", "pane-[1:default", true},
+		{"malformed pattern rejects other keys", "pane-1:default", "pane-[1:default", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fs.matchesPattern(tc.chunkKey, tc.pattern); got != tc.want {
+				t.Errorf("matchesPattern(%q, %q) = %v, want %v", tc.chunkKey, tc.pattern, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+// Package stores provides concrete cache store implementations
+package stores
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+)
+
+// SessionBackend abstracts the storage layer behind the session operations on
+// SessionsStore so session state can live in a shared store (Redis) instead of
+// per-process memory. Implementations must keep the fingerprint-to-sessions
+// inverted index consistent with the stored sessions.
+type SessionBackend interface {
+	GetSession(tenantID, sessionID string) (*types.SessionData, bool)
+	SetSession(tenantID string, sessionData *types.SessionData)
+	RemoveSession(tenantID, sessionID string)
+	GetSessionsByFingerprint(tenantID, fingerprintID string) []string
+}
+
+// sessionBackendTTL mirrors the 24-hour expiry the in-memory store applies via
+// its LastLoaded check; Redis enforces it with per-key TTLs instead.
+const sessionBackendTTL = 24 * time.Hour
+
+// RedisSessionBackend stores session state in Redis so multiple server
+// instances share one view of active sessions. Sessions are JSON values under
+// "t8k:{tenantID}:session:{sessionID}" and the inverted index is a set under
+// "t8k:{tenantID}:fpsessions:{fingerprintID}". It speaks the RESP protocol
+// directly over a single mutex-guarded connection, which is plenty for the
+// session read/write rates this store sees.
+type RedisSessionBackend struct {
+	addr     string
+	password string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+
+	logger *logging.ChanneledLogger
+}
+
+// NewRedisSessionBackend parses a redis:// URL, verifies connectivity with a
+// PING, and returns a ready backend.
+func NewRedisSessionBackend(rawURL string, logger *logging.ChanneledLogger) (*RedisSessionBackend, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	if parsed.Scheme != "redis" {
+		return nil, fmt.Errorf("unsupported redis url scheme %q", parsed.Scheme)
+	}
+
+	addr := parsed.Host
+	if parsed.Port() == "" {
+		addr = net.JoinHostPort(parsed.Hostname(), "6379")
+	}
+
+	backend := &RedisSessionBackend{
+		addr:   addr,
+		logger: logger,
+	}
+	if password, ok := parsed.User.Password(); ok {
+		backend.password = password
+	}
+
+	if _, err := backend.do("PING"); err != nil {
+		return nil, fmt.Errorf("redis connection check failed: %w", err)
+	}
+
+	return backend, nil
+}
+
+// GetSession retrieves session data by session ID
+func (rb *RedisSessionBackend) GetSession(tenantID, sessionID string) (*types.SessionData, bool) {
+	start := time.Now()
+	reply, err := rb.do("GET", rb.sessionKey(tenantID, sessionID))
+	if err != nil {
+		rb.logWarn("get", tenantID, err)
+		return nil, false
+	}
+
+	raw, found := reply.(string)
+	if !found {
+		if rb.logger != nil {
+			rb.logger.Cache().Debug("Cache operation", "operation", "get", "type", "session", "backend", "redis", "tenantId", tenantID, "sessionId", sessionID, "hit", false, "duration", time.Since(start))
+		}
+		return nil, false
+	}
+
+	var sessionData types.SessionData
+	if err := json.Unmarshal([]byte(raw), &sessionData); err != nil {
+		rb.logWarn("get", tenantID, err)
+		return nil, false
+	}
+
+	if rb.logger != nil {
+		rb.logger.Cache().Debug("Cache operation", "operation", "get", "type", "session", "backend", "redis", "tenantId", tenantID, "sessionId", sessionID, "hit", true, "duration", time.Since(start))
+	}
+	return &sessionData, true
+}
+
+// SetSession stores session data and maintains the inverted index
+func (rb *RedisSessionBackend) SetSession(tenantID string, sessionData *types.SessionData) {
+	start := time.Now()
+	raw, err := json.Marshal(sessionData)
+	if err != nil {
+		rb.logWarn("set", tenantID, err)
+		return
+	}
+
+	// If the session already exists under a different fingerprint, drop it
+	// from the old fingerprint's set before re-indexing.
+	if existing, found := rb.GetSession(tenantID, sessionData.SessionID); found && existing.FingerprintID != sessionData.FingerprintID {
+		if _, err := rb.do("SREM", rb.fingerprintKey(tenantID, existing.FingerprintID), sessionData.SessionID); err != nil {
+			rb.logWarn("set", tenantID, err)
+		}
+	}
+
+	ttlSeconds := strconv.Itoa(int(sessionBackendTTL / time.Second))
+	if _, err := rb.do("SET", rb.sessionKey(tenantID, sessionData.SessionID), string(raw), "EX", ttlSeconds); err != nil {
+		rb.logWarn("set", tenantID, err)
+		return
+	}
+
+	fingerprintKey := rb.fingerprintKey(tenantID, sessionData.FingerprintID)
+	if _, err := rb.do("SADD", fingerprintKey, sessionData.SessionID); err != nil {
+		rb.logWarn("set", tenantID, err)
+		return
+	}
+	if _, err := rb.do("EXPIRE", fingerprintKey, ttlSeconds); err != nil {
+		rb.logWarn("set", tenantID, err)
+	}
+
+	if rb.logger != nil {
+		rb.logger.Cache().Debug("Cache operation", "operation", "set", "type", "session", "backend", "redis", "tenantId", tenantID, "sessionId", sessionData.SessionID, "fingerprintId", sessionData.FingerprintID, "duration", time.Since(start))
+	}
+}
+
+// RemoveSession removes a session and updates the inverted index
+func (rb *RedisSessionBackend) RemoveSession(tenantID, sessionID string) {
+	start := time.Now()
+	if sessionData, found := rb.GetSession(tenantID, sessionID); found {
+		if _, err := rb.do("SREM", rb.fingerprintKey(tenantID, sessionData.FingerprintID), sessionID); err != nil {
+			rb.logWarn("remove", tenantID, err)
+		}
+	}
+
+	if _, err := rb.do("DEL", rb.sessionKey(tenantID, sessionID)); err != nil {
+		rb.logWarn("remove", tenantID, err)
+		return
+	}
+
+	if rb.logger != nil {
+		rb.logger.Cache().Debug("Cache operation", "operation", "remove", "type", "session", "backend", "redis", "tenantId", tenantID, "sessionId", sessionID, "duration", time.Since(start))
+	}
+}
+
+// GetSessionsByFingerprint returns all session IDs for a given fingerprint
+func (rb *RedisSessionBackend) GetSessionsByFingerprint(tenantID, fingerprintID string) []string {
+	start := time.Now()
+	reply, err := rb.do("SMEMBERS", rb.fingerprintKey(tenantID, fingerprintID))
+	if err != nil {
+		rb.logWarn("get_sessions_by_fingerprint", tenantID, err)
+		return []string{}
+	}
+
+	members, ok := reply.([]any)
+	if !ok {
+		return []string{}
+	}
+
+	sessionIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		if sessionID, ok := member.(string); ok {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+
+	if rb.logger != nil {
+		rb.logger.Cache().Debug("Cache operation", "operation", "get_sessions_by_fingerprint", "type", "session", "backend", "redis", "tenantId", tenantID, "fingerprintId", fingerprintID, "sessionCount", len(sessionIDs), "duration", time.Since(start))
+	}
+	return sessionIDs
+}
+
+func (rb *RedisSessionBackend) sessionKey(tenantID, sessionID string) string {
+	return "t8k:" + tenantID + ":session:" + sessionID
+}
+
+func (rb *RedisSessionBackend) fingerprintKey(tenantID, fingerprintID string) string {
+	return "t8k:" + tenantID + ":fpsessions:" + fingerprintID
+}
+
+func (rb *RedisSessionBackend) logWarn(operation, tenantID string, err error) {
+	if rb.logger != nil {
+		rb.logger.Cache().Warn("Redis session backend operation failed", "operation", operation, "tenantId", tenantID, "error", err.Error())
+	}
+}
+
+// =============================================================================
+// Minimal RESP client
+// =============================================================================
+
+// do sends one command and reads one reply, redialing once if the connection
+// has gone away since the last command.
+func (rb *RedisSessionBackend) do(args ...string) (any, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	reply, err := rb.roundTrip(args)
+	if err == nil {
+		return reply, nil
+	}
+
+	// Stale connection (server restart, idle timeout): reconnect and retry once.
+	rb.closeConn()
+	return rb.roundTrip(args)
+}
+
+func (rb *RedisSessionBackend) roundTrip(args []string) (any, error) {
+	if err := rb.ensureConn(); err != nil {
+		return nil, err
+	}
+
+	if err := rb.writeCommand(args); err != nil {
+		return nil, err
+	}
+
+	reply, err := rb.readReply()
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (rb *RedisSessionBackend) ensureConn() error {
+	if rb.conn != nil {
+		return nil
+	}
+
+	conn, err := net.DialTimeout("tcp", rb.addr, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to dial redis at %s: %w", rb.addr, err)
+	}
+	rb.conn = conn
+	rb.reader = bufio.NewReader(conn)
+
+	if rb.password != "" {
+		if err := rb.writeCommand([]string{"AUTH", rb.password}); err != nil {
+			rb.closeConn()
+			return err
+		}
+		if _, err := rb.readReply(); err != nil {
+			rb.closeConn()
+			return fmt.Errorf("redis auth failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (rb *RedisSessionBackend) closeConn() {
+	if rb.conn != nil {
+		rb.conn.Close()
+		rb.conn = nil
+		rb.reader = nil
+	}
+}
+
+func (rb *RedisSessionBackend) writeCommand(args []string) error {
+	var sb strings.Builder
+	sb.WriteString("*")
+	sb.WriteString(strconv.Itoa(len(args)))
+	sb.WriteString("\r\n")
+	for _, arg := range args {
+		sb.WriteString("$")
+		sb.WriteString(strconv.Itoa(len(arg)))
+		sb.WriteString("\r\n")
+		sb.WriteString(arg)
+		sb.WriteString("\r\n")
+	}
+
+	rb.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	_, err := rb.conn.Write([]byte(sb.String()))
+	return err
+}
+
+// readReply parses one RESP reply. Simple strings and bulk strings come back
+// as string, integers as int64, arrays as []any, and null bulk strings as nil.
+func (rb *RedisSessionBackend) readReply() (any, error) {
+	rb.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	line, err := rb.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	if line == "" {
+		return nil, fmt.Errorf("empty redis reply")
+	}
+
+	payload := line[1:]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, fmt.Errorf("redis error: %s", payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		length, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk string length: %s", payload)
+		}
+		if length < 0 {
+			return nil, nil // null bulk string (key miss)
+		}
+		buf := make([]byte, length+2) // value plus trailing \r\n
+		if _, err := io.ReadFull(rb.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:length]), nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length: %s", payload)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		elements := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			element, err := rb.readReply()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		}
+		return elements, nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply type %q", line[0])
+	}
+}
@@ -0,0 +1,293 @@
+package stores
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+)
+
+// fakeRedis is a minimal in-process RESP server implementing just the
+// commands RedisSessionBackend issues, so the hand-rolled client can be
+// integration-tested without a real Redis. It records the TTL passed with
+// each SET/EXPIRE for assertions.
+type fakeRedis struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]struct{}
+	ttls    map[string]int
+	conns   []net.Conn
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeRedis{
+		listener: listener,
+		strings:  make(map[string]string),
+		sets:     make(map[string]map[string]struct{}),
+		ttls:     make(map[string]int),
+	}
+	go server.acceptLoop()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (f *fakeRedis) url() string {
+	return "redis://" + f.listener.Addr().String()
+}
+
+func (f *fakeRedis) acceptLoop() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+		go f.serve(conn)
+	}
+}
+
+// dropConnections closes every live connection to simulate a Redis restart.
+func (f *fakeRedis) dropConnections() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+}
+
+func (f *fakeRedis) ttlFor(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ttls[key]
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(f.execute(args))); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand parses one RESP command array of bulk strings.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	header = strings.TrimSuffix(strings.TrimSuffix(header, "\n"), "\r")
+	if len(header) == 0 || header[0] != '*' {
+		return nil, fmt.Errorf("expected array header, got %q", header)
+	}
+	count, err := strconv.Atoi(header[1:])
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		lengthLine, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		lengthLine = strings.TrimSuffix(strings.TrimSuffix(lengthLine, "\n"), "\r")
+		length, err := strconv.Atoi(strings.TrimPrefix(lengthLine, "$"))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:length]))
+	}
+	return args, nil
+}
+
+func (f *fakeRedis) execute(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		value, ok := f.strings[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		f.strings[args[1]] = args[2]
+		if len(args) >= 5 && strings.ToUpper(args[3]) == "EX" {
+			seconds, _ := strconv.Atoi(args[4])
+			f.ttls[args[1]] = seconds
+		}
+		return "+OK\r\n"
+	case "DEL":
+		removed := 0
+		if _, ok := f.strings[args[1]]; ok {
+			delete(f.strings, args[1])
+			removed = 1
+		}
+		return fmt.Sprintf(":%d\r\n", removed)
+	case "SADD":
+		if f.sets[args[1]] == nil {
+			f.sets[args[1]] = make(map[string]struct{})
+		}
+		f.sets[args[1]][args[2]] = struct{}{}
+		return ":1\r\n"
+	case "SREM":
+		delete(f.sets[args[1]], args[2])
+		return ":1\r\n"
+	case "SMEMBERS":
+		members := make([]string, 0, len(f.sets[args[1]]))
+		for member := range f.sets[args[1]] {
+			members = append(members, member)
+		}
+		sort.Strings(members)
+		reply := fmt.Sprintf("*%d\r\n", len(members))
+		for _, member := range members {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(member), member)
+		}
+		return reply
+	case "EXPIRE":
+		seconds, _ := strconv.Atoi(args[2])
+		f.ttls[args[1]] = seconds
+		return ":1\r\n"
+	default:
+		return fmt.Sprintf("-ERR unknown command %q\r\n", args[0])
+	}
+}
+
+func newBackendSession(sessionID, fingerprintID string) *types.SessionData {
+	return &types.SessionData{
+		SessionID:     sessionID,
+		FingerprintID: fingerprintID,
+		LastActivity:  time.Now().UTC(),
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+	}
+}
+
+func TestRedisSessionBackendRoundTrip(t *testing.T) {
+	server := newFakeRedis(t)
+	backend, err := NewRedisSessionBackend(server.url(), nil)
+	if err != nil {
+		t.Fatalf("failed to connect to fake redis: %v", err)
+	}
+
+	backend.SetSession("t1", newBackendSession("session-1", "fp-1"))
+
+	got, found := backend.GetSession("t1", "session-1")
+	if !found {
+		t.Fatal("expected the stored session to round-trip through RESP")
+	}
+	if got.FingerprintID != "fp-1" {
+		t.Errorf("expected fingerprint fp-1, got %q", got.FingerprintID)
+	}
+
+	if _, found := backend.GetSession("t1", "missing"); found {
+		t.Error("expected a null bulk string to read as a miss")
+	}
+	if _, found := backend.GetSession("t2", "session-1"); found {
+		t.Error("expected tenant-prefixed keys to isolate tenants")
+	}
+}
+
+func TestRedisSessionBackendAppliesSessionTTL(t *testing.T) {
+	server := newFakeRedis(t)
+	backend, err := NewRedisSessionBackend(server.url(), nil)
+	if err != nil {
+		t.Fatalf("failed to connect to fake redis: %v", err)
+	}
+
+	backend.SetSession("t1", newBackendSession("session-1", "fp-1"))
+
+	wantSeconds := int(sessionBackendTTL / time.Second)
+	if got := server.ttlFor("t8k:t1:session:session-1"); got != wantSeconds {
+		t.Errorf("expected session key TTL %d, got %d", wantSeconds, got)
+	}
+	if got := server.ttlFor("t8k:t1:fpsessions:fp-1"); got != wantSeconds {
+		t.Errorf("expected fingerprint set TTL %d, got %d", wantSeconds, got)
+	}
+}
+
+func TestRedisSessionBackendMaintainsFingerprintIndex(t *testing.T) {
+	server := newFakeRedis(t)
+	backend, err := NewRedisSessionBackend(server.url(), nil)
+	if err != nil {
+		t.Fatalf("failed to connect to fake redis: %v", err)
+	}
+
+	backend.SetSession("t1", newBackendSession("session-1", "fp-1"))
+	backend.SetSession("t1", newBackendSession("session-2", "fp-1"))
+
+	got := backend.GetSessionsByFingerprint("t1", "fp-1")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 indexed sessions, got %v", got)
+	}
+
+	// Re-pointing a session at another fingerprint must SREM it from the old
+	// set before SADDing it to the new one.
+	backend.SetSession("t1", newBackendSession("session-2", "fp-2"))
+	if got := backend.GetSessionsByFingerprint("t1", "fp-1"); len(got) != 1 || got[0] != "session-1" {
+		t.Errorf("expected fp-1 index reduced to [session-1], got %v", got)
+	}
+	if got := backend.GetSessionsByFingerprint("t1", "fp-2"); len(got) != 1 || got[0] != "session-2" {
+		t.Errorf("expected fp-2 index [session-2], got %v", got)
+	}
+
+	// Removing a session drops it from both the key space and the index.
+	backend.RemoveSession("t1", "session-1")
+	if _, found := backend.GetSession("t1", "session-1"); found {
+		t.Error("expected the removed session to be gone")
+	}
+	if got := backend.GetSessionsByFingerprint("t1", "fp-1"); len(got) != 0 {
+		t.Errorf("expected an empty fp-1 index after removal, got %v", got)
+	}
+}
+
+func TestRedisSessionBackendRedialsAfterConnectionLoss(t *testing.T) {
+	server := newFakeRedis(t)
+	backend, err := NewRedisSessionBackend(server.url(), nil)
+	if err != nil {
+		t.Fatalf("failed to connect to fake redis: %v", err)
+	}
+
+	backend.SetSession("t1", newBackendSession("session-1", "fp-1"))
+
+	// Simulate a Redis restart: the client's next command fails on the stale
+	// connection and must transparently redial and retry once.
+	server.dropConnections()
+
+	got, found := backend.GetSession("t1", "session-1")
+	if !found {
+		t.Fatal("expected the client to redial after a dropped connection")
+	}
+	if got.SessionID != "session-1" {
+		t.Errorf("expected session-1 after redial, got %q", got.SessionID)
+	}
+}
@@ -0,0 +1,52 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+func TestNewRedisSessionBackendRejectsInvalidURLs(t *testing.T) {
+	if _, err := NewRedisSessionBackend("http://localhost:6379", nil); err == nil {
+		t.Error("expected an error for a non-redis URL scheme")
+	}
+	if _, err := NewRedisSessionBackend("redis://127.0.0.1:1", nil); err == nil {
+		t.Error("expected an error when the Redis server is unreachable")
+	}
+}
+
+func TestSessionsStoreFallsBackToMemoryWhenRedisUnavailable(t *testing.T) {
+	orig := config.RedisURL
+	config.RedisURL = "redis://127.0.0.1:1"
+	defer func() { config.RedisURL = orig }()
+
+	store := NewSessionsStore(nil)
+	if store.backend != nil {
+		t.Fatal("expected an unreachable Redis to leave the store on the in-memory backend")
+	}
+
+	// Session operations must keep working in-memory after the fallback.
+	store.InitializeTenant("t1")
+	session := &types.SessionData{
+		SessionID:     "session-1",
+		FingerprintID: "fp-1",
+		LastActivity:  time.Now().UTC(),
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+	}
+	store.SetSession("t1", session)
+
+	got, found := store.GetSession("t1", "session-1")
+	if !found {
+		t.Fatal("expected the in-memory fallback to serve the stored session")
+	}
+	if got.FingerprintID != "fp-1" {
+		t.Errorf("expected fingerprint fp-1, got %q", got.FingerprintID)
+	}
+
+	sessions := store.GetSessionsByFingerprint("t1", "fp-1")
+	if len(sessions) != 1 || sessions[0] != "session-1" {
+		t.Errorf("expected fingerprint index [session-1], got %v", sessions)
+	}
+}
@@ -7,23 +7,105 @@ import (
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
-// SessionsStore implements user state caching operations with tenant isolation
+// SessionsStore implements user state caching operations with tenant isolation.
+// Session records and the fingerprint inverted index can optionally be backed
+// by a shared store (see SessionBackend); everything else stays in-memory.
 type SessionsStore struct {
 	tenantCaches map[string]*types.TenantUserStateCache
 	mu           sync.RWMutex
+	backend      SessionBackend
 	logger       *logging.ChanneledLogger
+
+	// QuotaMap holds per-tenant session count overrides; tenants without an
+	// entry fall back to the global config.MaxSessionsPerTenant.
+	QuotaMap      map[string]int
+	quotaMu       sync.RWMutex
+	sessionMemory map[string]int64
 }
 
-// NewSessionsStore creates a new sessions cache store
+// NewSessionsStore creates a new sessions cache store. When REDIS_URL is
+// configured the session operations delegate to Redis so multiple instances
+// share session state; any connection failure falls back to in-memory.
 func NewSessionsStore(logger *logging.ChanneledLogger) *SessionsStore {
 	if logger != nil {
 		logger.Cache().Info("Initializing sessions cache store")
 	}
+
+	var backend SessionBackend
+	if config.RedisURL != "" {
+		redisBackend, err := NewRedisSessionBackend(config.RedisURL, logger)
+		if err != nil {
+			if logger != nil {
+				logger.Cache().Warn("Redis session backend unavailable, falling back to in-memory sessions", "error", err.Error())
+			}
+		} else {
+			backend = redisBackend
+			if logger != nil {
+				logger.Cache().Info("Sessions cache store using Redis backend")
+			}
+		}
+	}
+
 	return &SessionsStore{
-		tenantCaches: make(map[string]*types.TenantUserStateCache),
-		logger:       logger,
+		tenantCaches:  make(map[string]*types.TenantUserStateCache),
+		backend:       backend,
+		logger:        logger,
+		QuotaMap:      make(map[string]int),
+		sessionMemory: make(map[string]int64),
+	}
+}
+
+// SetSessionQuota records a per-tenant session count override. A quota of zero
+// or less removes the override so the tenant falls back to the global limit.
+func (ss *SessionsStore) SetSessionQuota(tenantID string, quota int) {
+	ss.quotaMu.Lock()
+	defer ss.quotaMu.Unlock()
+	if quota <= 0 {
+		delete(ss.QuotaMap, tenantID)
+		return
+	}
+	ss.QuotaMap[tenantID] = quota
+}
+
+// GetSessionQuota returns the tenant's session count limit, falling back to
+// config.MaxSessionsPerTenant when no override is set.
+func (ss *SessionsStore) GetSessionQuota(tenantID string) int {
+	ss.quotaMu.RLock()
+	defer ss.quotaMu.RUnlock()
+	if quota, exists := ss.QuotaMap[tenantID]; exists && quota > 0 {
+		return quota
+	}
+	return config.MaxSessionsPerTenant
+}
+
+// estimateSessionBytes is a coarse per-session memory estimate used for the
+// soft memory quota; see types.EstimateSessionBytes.
+func estimateSessionBytes(sessionData *types.SessionData) int64 {
+	return types.EstimateSessionBytes(sessionData)
+}
+
+// reserveSessionMemory accounts bytes against the tenant's session memory
+// budget; it returns false without reserving when the budget would be exceeded.
+func (ss *SessionsStore) reserveSessionMemory(tenantID string, bytes int64) bool {
+	ss.quotaMu.Lock()
+	defer ss.quotaMu.Unlock()
+	if config.MaxSessionMemoryBytes > 0 && ss.sessionMemory[tenantID]+bytes > int64(config.MaxSessionMemoryBytes) {
+		return false
+	}
+	ss.sessionMemory[tenantID] += bytes
+	return true
+}
+
+// adjustSessionMemory applies a delta to the tenant's session memory counter.
+func (ss *SessionsStore) adjustSessionMemory(tenantID string, delta int64) {
+	ss.quotaMu.Lock()
+	defer ss.quotaMu.Unlock()
+	ss.sessionMemory[tenantID] += delta
+	if ss.sessionMemory[tenantID] < 0 {
+		ss.sessionMemory[tenantID] = 0
 	}
 }
 
@@ -46,6 +128,7 @@ func (ss *SessionsStore) InitializeTenant(tenantID string) {
 			StoryfragmentBeliefRegistries: make(map[string]*types.StoryfragmentBeliefRegistry),
 			SessionBeliefContexts:         make(map[string]*types.SessionBeliefContext),
 			FingerprintToSessions:         make(map[string][]string),
+			IdempotentResults:             make(map[string]*types.IdempotentResult),
 			LastLoaded:                    time.Now().UTC(),
 		}
 
@@ -145,12 +228,79 @@ func (ss *SessionsStore) LoadKnownFingerprints(tenantID string, fingerprints map
 	}
 }
 
+// =============================================================================
+// Idempotency Replay Operations
+// =============================================================================
+
+// GetIdempotentResult returns the stored outcome for an Idempotency-Key,
+// treating entries older than the configured TTL as misses.
+func (ss *SessionsStore) GetIdempotentResult(tenantID, key string) (any, bool) {
+	cache, exists := ss.GetTenantCache(tenantID)
+	if !exists {
+		return nil, false
+	}
+
+	cache.IdempotencyMu.RLock()
+	entry, found := cache.IdempotentResults[key]
+	cache.IdempotencyMu.RUnlock()
+
+	if !found || time.Since(entry.StoredAt) > config.IdempotencyKeyTTL {
+		return nil, false
+	}
+
+	if ss.logger != nil {
+		ss.logger.Cache().Debug("Cache operation", "operation", "get", "type", "idempotent_result", "tenantId", tenantID, "hit", true)
+	}
+	return entry.Value, true
+}
+
+// SetIdempotentResult stores the outcome of a completed request under its
+// Idempotency-Key so a client retry can be answered without side effects.
+func (ss *SessionsStore) SetIdempotentResult(tenantID, key string, value any) {
+	cache, exists := ss.GetTenantCache(tenantID)
+	if !exists {
+		ss.InitializeTenant(tenantID)
+		cache, _ = ss.GetTenantCache(tenantID)
+	}
+
+	cache.IdempotencyMu.Lock()
+	cache.IdempotentResults[key] = &types.IdempotentResult{Value: value, StoredAt: time.Now().UTC()}
+	cache.IdempotencyMu.Unlock()
+}
+
+// CleanupExpiredIdempotentResults purges idempotency entries past their TTL,
+// returning how many were removed. Called by the periodic cleanup worker.
+func (ss *SessionsStore) CleanupExpiredIdempotentResults(tenantID string) int {
+	cache, exists := ss.GetTenantCache(tenantID)
+	if !exists {
+		return 0
+	}
+
+	cutoff := time.Now().Add(-config.IdempotencyKeyTTL)
+
+	cache.IdempotencyMu.Lock()
+	defer cache.IdempotencyMu.Unlock()
+
+	removed := 0
+	for key, entry := range cache.IdempotentResults {
+		if entry.StoredAt.Before(cutoff) {
+			delete(cache.IdempotentResults, key)
+			removed++
+		}
+	}
+	return removed
+}
+
 // =============================================================================
 // Session Operations
 // =============================================================================
 
 // GetSession retrieves session data by session ID
 func (ss *SessionsStore) GetSession(tenantID, sessionID string) (*types.SessionData, bool) {
+	if ss.backend != nil {
+		return ss.backend.GetSession(tenantID, sessionID)
+	}
+
 	start := time.Now()
 	cache, exists := ss.GetTenantCache(tenantID)
 	if !exists {
@@ -183,6 +333,11 @@ func (ss *SessionsStore) GetSession(tenantID, sessionID string) (*types.SessionD
 
 // SetSession stores session data and maintains the inverted index
 func (ss *SessionsStore) SetSession(tenantID string, sessionData *types.SessionData) {
+	if ss.backend != nil {
+		ss.backend.SetSession(tenantID, sessionData)
+		return
+	}
+
 	start := time.Now()
 	cache, exists := ss.GetTenantCache(tenantID)
 	if !exists {
@@ -199,6 +354,21 @@ func (ss *SessionsStore) SetSession(tenantID string, sessionData *types.SessionD
 		if existingSession.FingerprintID != sessionData.FingerprintID {
 			ss.removeSessionFromFingerprintIndex(cache, existingSession.FingerprintID, sessionData.SessionID)
 		}
+		ss.adjustSessionMemory(tenantID, estimateSessionBytes(sessionData)-estimateSessionBytes(existingSession))
+	} else {
+		// New sessions are subject to the tenant's count and memory quotas.
+		if quota := ss.GetSessionQuota(tenantID); len(cache.SessionStates) >= quota {
+			if ss.logger != nil {
+				ss.logger.Cache().Warn("Session quota exceeded, refusing new session", "tenantId", tenantID, "sessionId", sessionData.SessionID, "quota", quota, "sessionCount", len(cache.SessionStates))
+			}
+			return
+		}
+		if !ss.reserveSessionMemory(tenantID, estimateSessionBytes(sessionData)) {
+			if ss.logger != nil {
+				ss.logger.Cache().Warn("Session memory quota exceeded, refusing new session", "tenantId", tenantID, "sessionId", sessionData.SessionID, "maxBytes", config.MaxSessionMemoryBytes)
+			}
+			return
+		}
 	}
 
 	// Store the session
@@ -218,6 +388,11 @@ func (ss *SessionsStore) SetSession(tenantID string, sessionData *types.SessionD
 
 // RemoveSession removes a session and updates the inverted index
 func (ss *SessionsStore) RemoveSession(tenantID, sessionID string) {
+	if ss.backend != nil {
+		ss.backend.RemoveSession(tenantID, sessionID)
+		return
+	}
+
 	start := time.Now()
 	cache, exists := ss.GetTenantCache(tenantID)
 	if !exists {
@@ -237,6 +412,7 @@ func (ss *SessionsStore) RemoveSession(tenantID, sessionID string) {
 
 		// Remove from session states
 		delete(cache.SessionStates, sessionID)
+		ss.adjustSessionMemory(tenantID, -estimateSessionBytes(sessionData))
 
 		cache.MetadataMu.Lock()
 		cache.LastLoaded = time.Now().UTC()
@@ -254,6 +430,10 @@ func (ss *SessionsStore) RemoveSession(tenantID, sessionID string) {
 
 // GetSessionsByFingerprint returns all session IDs for a given fingerprint - NOW O(1)!
 func (ss *SessionsStore) GetSessionsByFingerprint(tenantID, fingerprintID string) []string {
+	if ss.backend != nil {
+		return ss.backend.GetSessionsByFingerprint(tenantID, fingerprintID)
+	}
+
 	start := time.Now()
 	cache, exists := ss.GetTenantCache(tenantID)
 	if !exists {
@@ -681,6 +861,10 @@ func (ss *SessionsStore) InvalidateUserStateCache(tenantID string) {
 	cache.StoryfragmentBeliefRegistries = make(map[string]*types.StoryfragmentBeliefRegistry)
 	cache.LastLoaded = time.Now().UTC()
 
+	cache.IdempotencyMu.Lock()
+	cache.IdempotentResults = make(map[string]*types.IdempotentResult)
+	cache.IdempotencyMu.Unlock()
+
 	cache.MetadataMu.Unlock()
 	cache.BeliefContextsMu.Unlock()
 	cache.BeliefRegistriesMu.Unlock()
@@ -688,6 +872,10 @@ func (ss *SessionsStore) InvalidateUserStateCache(tenantID string) {
 	cache.SessionsMu.Unlock()
 	cache.FingerprintsMu.Unlock()
 
+	ss.quotaMu.Lock()
+	ss.sessionMemory[tenantID] = 0
+	ss.quotaMu.Unlock()
+
 	if ss.logger != nil {
 		ss.logger.Cache().Info("All user state cache invalidated", "tenantId", tenantID, "duration", time.Since(start))
 	}
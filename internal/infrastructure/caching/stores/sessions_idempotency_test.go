@@ -0,0 +1,79 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+func TestIdempotentResultReplay(t *testing.T) {
+	store := NewSessionsStore(nil)
+	store.InitializeTenant("t1")
+
+	key := "session-1\x00abc123"
+	store.SetIdempotentResult("t1", key, "stored-result")
+
+	value, found := store.GetIdempotentResult("t1", key)
+	if !found {
+		t.Fatal("expected a replayed key to return the stored result")
+	}
+	if value != "stored-result" {
+		t.Errorf("expected stored result, got %v", value)
+	}
+
+	if _, found := store.GetIdempotentResult("t1", "session-1\x00other"); found {
+		t.Error("expected a different key to miss")
+	}
+	if _, found := store.GetIdempotentResult("t2", key); found {
+		t.Error("expected the same key under another tenant to miss")
+	}
+}
+
+func TestIdempotentResultExpiresAfterTTL(t *testing.T) {
+	orig := config.IdempotencyKeyTTL
+	config.IdempotencyKeyTTL = 10 * time.Millisecond
+	defer func() { config.IdempotencyKeyTTL = orig }()
+
+	store := NewSessionsStore(nil)
+	store.InitializeTenant("t1")
+	store.SetIdempotentResult("t1", "key", "stored-result")
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, found := store.GetIdempotentResult("t1", "key"); found {
+		t.Error("expected an expired key to miss")
+	}
+}
+
+func TestCleanupExpiredIdempotentResults(t *testing.T) {
+	orig := config.IdempotencyKeyTTL
+	config.IdempotencyKeyTTL = 10 * time.Millisecond
+	defer func() { config.IdempotencyKeyTTL = orig }()
+
+	store := NewSessionsStore(nil)
+	store.InitializeTenant("t1")
+	store.SetIdempotentResult("t1", "stale", "old-result")
+
+	time.Sleep(20 * time.Millisecond)
+	store.SetIdempotentResult("t1", "fresh", "new-result")
+
+	if removed := store.CleanupExpiredIdempotentResults("t1"); removed != 1 {
+		t.Errorf("expected cleanup to remove 1 expired entry, removed %d", removed)
+	}
+	if _, found := store.GetIdempotentResult("t1", "fresh"); !found {
+		t.Error("expected cleanup to keep unexpired entries")
+	}
+}
+
+func TestInvalidateUserStateCacheDropsIdempotentResults(t *testing.T) {
+	store := NewSessionsStore(nil)
+	store.InitializeTenant("t1")
+	store.SetIdempotentResult("t1", "key", "stored-result")
+
+	store.InvalidateUserStateCache("t1")
+
+	if _, found := store.GetIdempotentResult("t1", "key"); found {
+		t.Error("expected invalidation to drop stored idempotent results")
+	}
+}
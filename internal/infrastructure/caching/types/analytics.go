@@ -156,6 +156,7 @@ type EpinetStep struct {
 	ObjectType string   `json:"objectType"`
 	ObjectIds  []string `json:"objectIds"`
 	Title      string   `json:"title"`
+	Weight     float64  `json:"weight,omitempty"`
 }
 
 // ContentItem holds a simplified view of a content node for analytics naming.
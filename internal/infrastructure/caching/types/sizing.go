@@ -0,0 +1,40 @@
+// Size estimation helpers for cached values. These feed the cache metrics
+// (RecordCacheOperation item sizes, GetMemoryStats) and the per-tenant byte
+// accounting behind memory quotas. All of them are cheap approximations —
+// serialized length or counted string bytes — not exact reflection walks of
+// Go runtime overhead; what matters is that they track growth proportionally.
+package types
+
+import "encoding/json"
+
+// EstimateSize approximates the in-memory footprint of a cached value by the
+// length of its JSON encoding. Values that fail to marshal report zero.
+func EstimateSize(v any) int64 {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
+
+// EstimateHTMLChunkBytes sizes a rendered HTML chunk: the markup (or its
+// compressed form) plus the dependency keys held alongside it.
+func EstimateHTMLChunkBytes(chunk *HTMLChunk) int64 {
+	if chunk == nil {
+		return 0
+	}
+	size := int64(len(chunk.HTML) + len(chunk.Data))
+	for _, dep := range chunk.DependsOn {
+		size += int64(len(dep))
+	}
+	return size
+}
+
+// EstimateSessionBytes is a coarse per-session memory estimate used for the
+// soft memory quota: the two ID strings plus a flat struct/index overhead.
+func EstimateSessionBytes(sessionData *SessionData) int64 {
+	if sessionData == nil {
+		return 0
+	}
+	return int64(len(sessionData.SessionID)+len(sessionData.FingerprintID)) + 200
+}
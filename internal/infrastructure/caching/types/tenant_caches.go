@@ -22,8 +22,20 @@ type TenantContentCache struct {
 	StoryfragmentBeliefRegistries map[string]*StoryfragmentBeliefRegistry // storyfragmentId -> belief registry
 
 	// Lookup indices
-	SlugToID      map[string]string   // slug -> id
-	CategoryToIDs map[string][]string // category -> []id
+	SlugToID      map[string]string                     // slug -> id
+	CategoryToIDs map[string][]string                   // category -> []id
+	ExternalIDs   map[string]*content.ExternalIDMapping // "system:externalId" -> mapping
+
+	// ResourceOptionIndex maps optionKey -> canonical value -> []resourceID
+	// for filtering resources by option payload. It is built lazily on the
+	// first filtered query (nil means not built) and kept current by
+	// SetResource/InvalidateResource afterwards.
+	ResourceOptionIndex map[string]map[string][]string
+
+	// PaneToStoryFragments maps paneID -> IDs of storyfragments whose
+	// PaneIDs include that pane. Maintained by SetStoryFragment and
+	// InvalidateStoryFragment so pane invalidation can cascade to parents.
+	PaneToStoryFragments map[string][]string
 
 	AllTractStackIDs    []string
 	AllStoryFragmentIDs []string
@@ -37,6 +49,7 @@ type TenantContentCache struct {
 	// Content map cache
 	FullContentMap        []FullContentMapItem `json:"fullContentMap,omitempty"`
 	ContentMapLastUpdated time.Time            `json:"contentMapLastUpdated"`
+	ContentMapETag        string               `json:"contentMapETag,omitempty"`
 
 	// Orphan analysis
 	OrphanAnalysis *OrphanAnalysisCache `json:"orphanAnalysis"`
@@ -67,6 +80,31 @@ type HTMLChunk struct {
 	Variant     PaneVariant `json:"variant"`
 	DependsOn   []string    `json:"dependsOn"`
 	LastUpdated time.Time   `json:"lastUpdated"`
+
+	// Compressed marks that the HTML body is stored gzip-compressed in Data
+	// (with HTML left empty) to reduce in-process memory for large panes;
+	// reads decompress transparently.
+	Compressed bool   `json:"compressed,omitempty"`
+	Data       []byte `json:"data,omitempty"`
+
+	// TTL overrides the default one-hour chunk expiry when positive; used for
+	// short-lived entries like fallback renders of failing panes.
+	TTL time.Duration `json:"ttl,omitempty"`
+
+	// LastAccessed is a unix-nano timestamp updated atomically on reads so
+	// LRU eviction can pick victims without taking the cache write lock.
+	LastAccessed int64 `json:"-"`
+}
+
+// PaneRenderFailure records a pane whose node tree failed to render; the
+// admin render-failure list surfaces these while the fallback placeholder is
+// served in the pane's place.
+type PaneRenderFailure struct {
+	PaneID      string    `json:"paneId"`
+	LastError   string    `json:"lastError"`
+	FirstFailed time.Time `json:"firstFailed"`
+	LastFailed  time.Time `json:"lastFailed"`
+	Count       int64     `json:"count"`
 }
 
 // TenantAnalyticsCache holds analytics data for a single tenant
@@ -135,6 +173,9 @@ type StoryfragmentBeliefRegistry struct {
 	RequiredBadges     []string                  `json:"requiredBadges"`     // badge requirements
 	PaneWidgetBeliefs  map[string][]string       `json:"paneWidgetBeliefs"`  // paneId -> belief slugs used by widgets
 	AllWidgetBeliefs   map[string]bool           `json:"allWidgetBeliefs"`   // flat lookup for all widget beliefs
+	PaneEvalCosts      map[string]int            `json:"paneEvalCosts"`      // paneId -> belief comparison count per render
+	TotalEvalCost      int                       `json:"totalEvalCost"`      // sum across panes, compared against threshold
+	ComplexityFlagged  bool                      `json:"complexityFlagged"`  // true when TotalEvalCost exceeds the configured threshold
 	LastUpdated        time.Time                 `json:"lastUpdated"`
 }
 
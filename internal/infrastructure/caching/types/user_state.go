@@ -35,11 +35,23 @@ type TenantUserStateCache struct {
 	StoryfragmentBeliefRegistries map[string]*StoryfragmentBeliefRegistry
 	BeliefRegistriesMu            sync.RWMutex
 
+	// Idempotency replay data with dedicated mutex
+	IdempotentResults map[string]*IdempotentResult
+	IdempotencyMu     sync.RWMutex
+
 	// Cache metadata with dedicated mutex
 	LastLoaded time.Time
 	MetadataMu sync.RWMutex
 }
 
+// IdempotentResult caches the outcome of a completed mutating request so a
+// client retry carrying the same Idempotency-Key can be answered without
+// re-running the side effects.
+type IdempotentResult struct {
+	Value    any
+	StoredAt time.Time
+}
+
 // FingerprintState represents a user fingerprint's persistent state
 type FingerprintState struct {
 	FingerprintID string              `json:"fingerprintId"`
@@ -71,7 +83,13 @@ type SessionData struct {
 	CreatedAt     time.Time `json:"createdAt"`
 	LastActivity  time.Time `json:"lastActivity"`
 	ExpiresAt     time.Time `json:"expiresAt"`
-	IsExpired     bool      `json:"isExpired"`
+}
+
+// IsExpired reports whether the session's hard expiry has passed. Heartbeats
+// push ExpiresAt forward, so a long-reading page stays live as long as the
+// front-end keeps checking in.
+func (s *SessionData) IsExpired() bool {
+	return time.Now().UTC().After(s.ExpiresAt)
 }
 
 // SessionBeliefContext represents belief evaluation context for a session+storyfragment.
@@ -37,6 +37,16 @@ func (l *WarmingLock) TryLock(key string) bool {
 	return true
 }
 
+// IsLocked reports whether a lock for a given key is currently held, without
+// attempting to acquire it.
+func (l *WarmingLock) IsLocked(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, exists := l.locks[key]
+	return exists
+}
+
 // Unlock releases a lock for a given key.
 // This should be called with `defer` in the goroutine that acquired the lock.
 func (l *WarmingLock) Unlock(key string) {
@@ -46,6 +56,13 @@ func (l *WarmingLock) Unlock(key string) {
 	delete(l.locks, key)
 }
 
+// RecentHoursWarmKey is the warming-lock key guarding recent-hours analytics
+// refreshes for a tenant. Shared between the warming service and the cache
+// manager's range status check.
+func RecentHoursWarmKey(tenantID string) string {
+	return "warm:recent:" + tenantID
+}
+
 // --- Global Singleton Instance ---
 
 var (
@@ -4,6 +4,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
@@ -30,6 +31,15 @@ func (tc *TableCreator) CreateSchema(db *sql.DB) error {
 			return fmt.Errorf("failed to create index for query [%s]: %w", indexSQL, err)
 		}
 	}
+
+	// Apply additive migrations for databases created before a column existed.
+	// Every statement here must be safe to attempt on an up-to-date schema;
+	// "duplicate column" failures just mean the migration already applied.
+	for _, migrationSQL := range migrations {
+		if _, err := db.Exec(migrationSQL); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("failed to apply migration [%s]: %w", migrationSQL, err)
+		}
+	}
 	return nil
 }
 
@@ -98,8 +108,8 @@ var tables = []string{
 	`CREATE TABLE IF NOT EXISTS storyfragment_panes (id TEXT PRIMARY KEY, storyfragment_id TEXT NOT NULL REFERENCES storyfragments(id), pane_id TEXT NOT NULL REFERENCES panes(id), weight INTEGER NOT NULL, UNIQUE(storyfragment_id, pane_id))`,
 	`CREATE TABLE IF NOT EXISTS file_panes (id TEXT PRIMARY KEY, file_id TEXT NOT NULL REFERENCES files(id), pane_id TEXT NOT NULL REFERENCES panes(id), UNIQUE(file_id, pane_id))`,
 	`CREATE TABLE IF NOT EXISTS visits (id TEXT PRIMARY KEY, fingerprint_id TEXT NOT NULL REFERENCES fingerprints(id), campaign_id TEXT REFERENCES campaigns(id), created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
-	`CREATE TABLE IF NOT EXISTS fingerprints (id TEXT PRIMARY KEY, lead_id TEXT REFERENCES leads(id), created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
-	`CREATE TABLE IF NOT EXISTS leads (id TEXT PRIMARY KEY, first_name TEXT NOT NULL, email TEXT NOT NULL UNIQUE, password_hash TEXT NOT NULL, contact_persona TEXT NOT NULL, short_bio TEXT, encrypted_code TEXT, encrypted_email TEXT, created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, changed TIMESTAMP)`,
+	`CREATE TABLE IF NOT EXISTS fingerprints (id TEXT PRIMARY KEY, lead_id TEXT REFERENCES leads(id), merged_into_id TEXT REFERENCES fingerprints(id), created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
+	`CREATE TABLE IF NOT EXISTS leads (id TEXT PRIMARY KEY, first_name TEXT NOT NULL, email TEXT NOT NULL UNIQUE, password_hash TEXT NOT NULL, contact_persona TEXT NOT NULL, short_bio TEXT, encrypted_code TEXT, encrypted_email TEXT, status TEXT NOT NULL DEFAULT 'active', created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, changed TIMESTAMP)`,
 	`CREATE TABLE IF NOT EXISTS campaigns (id TEXT PRIMARY KEY, name TEXT NOT NULL, source TEXT, medium TEXT, term TEXT, content TEXT, http_referrer TEXT, created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
 	`CREATE TABLE IF NOT EXISTS actions (id TEXT PRIMARY KEY, object_id TEXT NOT NULL, object_type TEXT NOT NULL, duration INTEGER, visit_id TEXT NOT NULL REFERENCES visits(id), fingerprint_id TEXT NOT NULL REFERENCES fingerprints(id), verb TEXT NOT NULL, created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`,
 	`CREATE TABLE IF NOT EXISTS beliefs (id TEXT PRIMARY KEY, title TEXT NOT NULL, slug TEXT NOT NULL UNIQUE, scale TEXT NOT NULL, custom_values TEXT)`,
@@ -110,6 +120,7 @@ var tables = []string{
 	`CREATE TABLE IF NOT EXISTS storyfragment_topics (id NUMERIC PRIMARY KEY, title TEXT NOT NULL)`,
 	`CREATE TABLE IF NOT EXISTS storyfragment_has_topic (id NUMERIC PRIMARY KEY, storyfragment_id TEXT NOT NULL REFERENCES storyfragments(id), topic_id NUMERIC NOT NULL REFERENCES storyfragment_topics(id))`,
 	`CREATE TABLE IF NOT EXISTS storyfragment_details (id NUMERIC PRIMARY KEY, storyfragment_id TEXT NOT NULL REFERENCES storyfragments(id), description TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS external_ids (id TEXT PRIMARY KEY, node_id TEXT NOT NULL, node_type TEXT NOT NULL, system TEXT NOT NULL, external_id TEXT NOT NULL, UNIQUE(system, external_id))`,
 }
 
 var indexes = []string{
@@ -145,4 +156,11 @@ var indexes = []string{
 	`CREATE INDEX IF NOT EXISTS idx_files_resource_resource_id ON files_resource(resource_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_files_resource_file_id ON files_resource(file_id)`,
 	`CREATE UNIQUE INDEX IF NOT EXISTS idx_files_resource_unique ON files_resource(resource_id, file_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_external_ids_node_id ON external_ids(node_id)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_external_ids_unique ON external_ids(system, external_id)`,
+}
+
+var migrations = []string{
+	`ALTER TABLE leads ADD COLUMN status TEXT NOT NULL DEFAULT 'active'`,
+	`ALTER TABLE fingerprints ADD COLUMN merged_into_id TEXT REFERENCES fingerprints(id)`,
 }
@@ -0,0 +1,71 @@
+package email
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+// SMTPSender delivers mail over a plain SMTP transport using net/smtp. It is
+// used for tenant-facing lead emails (such as verification codes) where
+// operators point the server at their own relay rather than the Resend API.
+type SMTPSender struct {
+	host string
+	port string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPSender builds a sender from the SMTP_HOST, SMTP_PORT, and SMTP_FROM
+// environment variables, with optional SMTP_USERNAME/SMTP_PASSWORD for
+// authenticated relays. Configuration is validated lazily at send time so
+// deployments without an SMTP relay can still boot.
+func NewSMTPSender() *SMTPSender {
+	sender := &SMTPSender{
+		host: os.Getenv("SMTP_HOST"),
+		port: os.Getenv("SMTP_PORT"),
+		from: os.Getenv("SMTP_FROM"),
+	}
+	if sender.port == "" {
+		sender.port = "587"
+	}
+
+	username := os.Getenv("SMTP_USERNAME")
+	password := os.Getenv("SMTP_PASSWORD")
+	if username != "" && password != "" {
+		sender.auth = smtp.PlainAuth("", username, password, sender.host)
+	}
+
+	return sender
+}
+
+// SendLeadVerificationEmail sends the registration verification code to a
+// pending lead.
+func (s *SMTPSender) SendLeadVerificationEmail(toEmail, firstName, code string) error {
+	if s.host == "" {
+		return fmt.Errorf("SMTP_HOST environment variable is required")
+	}
+	if s.from == "" {
+		return fmt.Errorf("SMTP_FROM environment variable is required")
+	}
+
+	subject := "Verify your email address"
+	body := fmt.Sprintf("Hi %s,\r\n\r\nYour verification code is:\r\n\r\n%s\r\n\r\nIf you did not request this, you can safely ignore this email.\r\n", firstName, code)
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", toEmail)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+	if err := smtp.SendMail(addr, s.auth, s.from, []string{toEmail}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send verification email via SMTP: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+// Package maintenance runs per-tenant maintenance jobs on operator-tunable
+// schedules. Each job has its own interval sourced from central config, so a
+// deployment can slow down or disable an individual job without a code change;
+// last-run status is exposed to the admin API for inspection.
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// JobFunc performs one maintenance pass for a single tenant and reports how
+// many items it affected.
+type JobFunc func(tenantCtx *tenant.Context) (int, error)
+
+// Job is a named maintenance task with its own schedule. A zero interval
+// disables the job; it stays registered so the status endpoint still lists it.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	run      JobFunc
+
+	nextRun time.Time
+}
+
+// JobStatus records the outcome of a job's most recent run for one tenant.
+type JobStatus struct {
+	Job           string        `json:"job"`
+	TenantID      string        `json:"tenantId"`
+	Interval      time.Duration `json:"interval"`
+	Enabled       bool          `json:"enabled"`
+	LastRun       time.Time     `json:"lastRun"`
+	LastDuration  time.Duration `json:"lastDuration"`
+	ItemsAffected int           `json:"itemsAffected"`
+	Runs          int           `json:"runs"`
+	LastError     string        `json:"lastError,omitempty"`
+}
+
+// Scheduler drives all registered maintenance jobs from a single ticker,
+// running each due job once per active tenant.
+type Scheduler struct {
+	tenantManager *tenant.Manager
+	cacheManager  *manager.Manager
+	logger        *logging.ChanneledLogger
+
+	mu     sync.RWMutex
+	jobs   []*Job
+	status map[string]map[string]*JobStatus // job name -> tenant ID -> status
+}
+
+// NewScheduler creates a maintenance scheduler with the built-in jobs
+// registered. Additional jobs can be added with Register before Start.
+func NewScheduler(tenantManager *tenant.Manager, cacheManager *manager.Manager, logger *logging.ChanneledLogger) *Scheduler {
+	s := &Scheduler{
+		tenantManager: tenantManager,
+		cacheManager:  cacheManager,
+		logger:        logger,
+		status:        make(map[string]map[string]*JobStatus),
+	}
+
+	s.Register("analytics-bin-purge", config.MaintenanceBinPurgeInterval, s.purgeAnalyticsBins)
+	s.Register("anonymous-fingerprint-purge", config.MaintenanceAnonFingerprintInterval, s.purgeAnonymousFingerprints)
+	s.Register("pending-lead-purge", config.MaintenancePendingLeadInterval, s.purgePendingLeads)
+
+	return s
+}
+
+// Register adds a named job to the schedule. The first run happens one full
+// interval after Start so a restart doesn't trigger every job at once.
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &Job{
+		Name:     name,
+		Interval: interval,
+		run:      run,
+		nextRun:  time.Now().UTC().Add(interval),
+	})
+	s.status[name] = make(map[string]*JobStatus)
+}
+
+// Start begins the scheduler loop, checking for due jobs on every tick until
+// the context is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(config.MaintenanceTickInterval)
+	defer ticker.Stop()
+
+	s.logger.System().Info("Maintenance scheduler started",
+		"tick", config.MaintenanceTickInterval, "jobs", len(s.jobs))
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.System().Info("Maintenance scheduler stopping...")
+			return
+		case <-ticker.C:
+			s.runDueJobs(ctx)
+		}
+	}
+}
+
+// runDueJobs executes every enabled job whose next-run time has passed.
+func (s *Scheduler) runDueJobs(ctx context.Context) {
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	var due []*Job
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			continue
+		}
+		if !now.Before(job.nextRun) {
+			job.nextRun = now.Add(job.Interval)
+			due = append(due, job)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, job := range due {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			s.runJob(job)
+		}
+	}
+}
+
+// runJob executes one job across all active tenants, recording per-tenant
+// status as it goes.
+func (s *Scheduler) runJob(job *Job) {
+	tenants, err := getActiveTenants()
+	if err != nil {
+		s.logger.System().Error("Maintenance job failed to load tenant registry", "job", job.Name, "error", err)
+		return
+	}
+
+	for _, tenantID := range tenants {
+		start := time.Now()
+		tenantCtx, err := s.tenantManager.NewContextFromID(tenantID)
+		if err != nil {
+			s.recordRun(job, tenantID, 0, time.Since(start), err)
+			s.logger.System().Error("Maintenance job failed to create tenant context", "job", job.Name, "tenantId", tenantID, "error", err)
+			continue
+		}
+
+		affected, err := job.run(tenantCtx)
+		tenantCtx.Close()
+		s.recordRun(job, tenantID, affected, time.Since(start), err)
+
+		if err != nil {
+			s.logger.System().Error("Maintenance job failed", "job", job.Name, "tenantId", tenantID, "error", err)
+		} else if affected > 0 {
+			s.logger.System().Info("Maintenance job completed", "job", job.Name, "tenantId", tenantID, "itemsAffected", affected)
+		}
+	}
+}
+
+// recordRun updates the per-tenant status entry for a job.
+func (s *Scheduler) recordRun(job *Job, tenantID string, affected int, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.status[job.Name][tenantID]
+	if !exists {
+		entry = &JobStatus{Job: job.Name, TenantID: tenantID}
+		s.status[job.Name][tenantID] = entry
+	}
+	entry.Interval = job.Interval
+	entry.Enabled = job.Interval > 0
+	entry.LastRun = time.Now().UTC()
+	entry.LastDuration = duration
+	entry.ItemsAffected = affected
+	entry.Runs++
+	if err != nil {
+		entry.LastError = err.Error()
+	} else {
+		entry.LastError = ""
+	}
+}
+
+// Status returns a snapshot of every registered job, including per-tenant
+// last-run entries for jobs that have already executed.
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var snapshot []JobStatus
+	for _, job := range s.jobs {
+		tenantStatuses := s.status[job.Name]
+		if len(tenantStatuses) == 0 {
+			// Not yet run (or disabled) - still list it so operators can
+			// see the configured schedule.
+			snapshot = append(snapshot, JobStatus{
+				Job:      job.Name,
+				Interval: job.Interval,
+				Enabled:  job.Interval > 0,
+			})
+			continue
+		}
+		for _, entry := range tenantStatuses {
+			snapshot = append(snapshot, *entry)
+		}
+	}
+	return snapshot
+}
+
+// purgeAnalyticsBins evicts expired hourly analytics bins from the tenant's
+// cache. The purge count isn't surfaced by the analytics store, so this job
+// reports zero items affected on success.
+func (s *Scheduler) purgeAnalyticsBins(tenantCtx *tenant.Context) (int, error) {
+	s.cacheManager.PurgeExpiredBins(tenantCtx.TenantID, "expired")
+	return 0, nil
+}
+
+// purgeAnonymousFingerprints hard-deletes fingerprints that never linked to a
+// lead, were never a merge target, and have had no visit inside the configured
+// retention window, along with their visits, actions, and held beliefs.
+func (s *Scheduler) purgeAnonymousFingerprints(tenantCtx *tenant.Context) (int, error) {
+	db, err := tenantCtx.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().UTC().Add(-config.MaintenanceAnonFingerprintMaxAge)
+
+	rows, err := db.Query(`
+		SELECT id FROM fingerprints
+		WHERE lead_id IS NULL
+		  AND merged_into_id IS NULL
+		  AND created_at < ?
+		  AND id NOT IN (SELECT merged_into_id FROM fingerprints WHERE merged_into_id IS NOT NULL)
+		  AND id NOT IN (SELECT fingerprint_id FROM visits WHERE created_at >= ?)`,
+		cutoff, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var candidates []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		candidates = append(candidates, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, fingerprintID := range candidates {
+		if _, err := tx.Exec(`DELETE FROM heldbeliefs WHERE fingerprint_id = ?`, fingerprintID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM actions WHERE fingerprint_id = ?`, fingerprintID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM visits WHERE fingerprint_id = ?`, fingerprintID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(`DELETE FROM fingerprints WHERE id = ?`, fingerprintID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(candidates), nil
+}
+
+// purgePendingLeads removes leads that registered but never verified within
+// the pending-lead TTL. Their email addresses become claimable again by a
+// fresh registration.
+func (s *Scheduler) purgePendingLeads(tenantCtx *tenant.Context) (int, error) {
+	if config.PendingLeadTTL <= 0 {
+		return 0, nil
+	}
+
+	db, err := tenantCtx.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().UTC().Add(-config.PendingLeadTTL)
+	result, err := db.Exec(`DELETE FROM leads WHERE status = ? AND changed < ?`, "pending", cutoff)
+	if err != nil {
+		return 0, err
+	}
+	purged, _ := result.RowsAffected()
+	return int(purged), nil
+}
+
+// getActiveTenants loads the tenant registry and returns active tenant IDs.
+func getActiveTenants() ([]string, error) {
+	registry, err := tenant.LoadTenantRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	activeTenants := make([]string, 0)
+	for tenantID, tenantInfo := range registry.Tenants {
+		if tenantInfo.Status == "active" {
+			activeTenants = append(activeTenants, tenantID)
+		}
+	}
+
+	return activeTenants, nil
+}
@@ -0,0 +1,283 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newQuietLogger(t *testing.T) *logging.ChanneledLogger {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	return logger
+}
+
+// newSchedulerFixture points config.DataDir at a temp directory holding a
+// registry with one active tenant, so runJob iterates a real tenant context
+// without touching the deployment's data directory.
+func newSchedulerFixture(t *testing.T) (*Scheduler, *logging.ChanneledLogger) {
+	t.Helper()
+
+	logger := newQuietLogger(t)
+
+	dataDir := t.TempDir()
+	origDataDir := config.DataDir
+	config.DataDir = dataDir
+	t.Cleanup(func() { config.DataDir = origDataDir })
+
+	registryDir := filepath.Join(dataDir, "config", "t8k")
+	if err := os.MkdirAll(registryDir, 0755); err != nil {
+		t.Fatalf("failed to create registry directory: %v", err)
+	}
+	registry := `{"tenants":{"maint-t1":{"tenantId":"maint-t1","domains":["*"],"status":"active","databaseType":"sqlite3"}}}`
+	if err := os.WriteFile(filepath.Join(registryDir, "tenants.json"), []byte(registry), 0644); err != nil {
+		t.Fatalf("failed to write tenant registry: %v", err)
+	}
+
+	tenantDir := filepath.Join(dataDir, "config", "maint-t1")
+	if err := os.MkdirAll(tenantDir, 0755); err != nil {
+		t.Fatalf("failed to create tenant config directory: %v", err)
+	}
+	envJSON := `{"TURSO_ENABLED":false,"JWT_SECRET":"test-secret","AES_KEY":"test-key"}`
+	if err := os.WriteFile(filepath.Join(tenantDir, "env.json"), []byte(envJSON), 0644); err != nil {
+		t.Fatalf("failed to write tenant env.json: %v", err)
+	}
+
+	tenantManager := tenant.NewManager(logger)
+	return NewScheduler(tenantManager, manager.NewManager(nil), logger), logger
+}
+
+func findJob(t *testing.T, s *Scheduler, name string) *Job {
+	t.Helper()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	t.Fatalf("job %s not registered", name)
+	return nil
+}
+
+func TestRunDueJobsHonorsConfiguredInterval(t *testing.T) {
+	scheduler, _ := newSchedulerFixture(t)
+
+	var runs atomic.Int32
+	var lastTenant atomic.Value
+	scheduler.Register("test-counter", 100*time.Millisecond, func(tenantCtx *tenant.Context) (int, error) {
+		runs.Add(1)
+		lastTenant.Store(tenantCtx.TenantID)
+		return 3, nil
+	})
+
+	ctx := context.Background()
+
+	// The first run happens one full interval after registration.
+	scheduler.runDueJobs(ctx)
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected no run before the first interval elapses, got %d", got)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	scheduler.runDueJobs(ctx)
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected one run after the interval elapsed, got %d", got)
+	}
+	if got := lastTenant.Load(); got != "maint-t1" {
+		t.Errorf("expected the job to run against the active tenant, got %v", got)
+	}
+
+	// Running again inside the same interval must not re-trigger the job.
+	scheduler.runDueJobs(ctx)
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected no re-run within the interval, got %d", got)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	scheduler.runDueJobs(ctx)
+	if got := runs.Load(); got != 2 {
+		t.Fatalf("expected a second run after the next interval, got %d", got)
+	}
+
+	var entry *JobStatus
+	for _, status := range scheduler.Status() {
+		if status.Job == "test-counter" && status.TenantID == "maint-t1" {
+			entry = &status
+			break
+		}
+	}
+	if entry == nil {
+		t.Fatal("expected a per-tenant status entry for the test job")
+	}
+	if !entry.Enabled || entry.Runs != 2 || entry.ItemsAffected != 3 || entry.LastError != "" {
+		t.Errorf("unexpected status entry: %+v", entry)
+	}
+}
+
+func TestRunDueJobsSkipsDisabledJob(t *testing.T) {
+	scheduler, _ := newSchedulerFixture(t)
+
+	var runs atomic.Int32
+	scheduler.Register("test-disabled", 0, func(tenantCtx *tenant.Context) (int, error) {
+		runs.Add(1)
+		return 0, nil
+	})
+
+	// Even with a next-run time far in the past, a zero interval means the
+	// job is never selected.
+	findJob(t, scheduler, "test-disabled").nextRun = time.Now().UTC().Add(-time.Hour)
+	scheduler.runDueJobs(context.Background())
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected the disabled job to be skipped, got %d runs", got)
+	}
+
+	for _, status := range scheduler.Status() {
+		if status.Job == "test-disabled" {
+			if status.Enabled {
+				t.Errorf("expected the disabled job reported as not enabled, got %+v", status)
+			}
+			return
+		}
+	}
+	t.Fatal("expected the disabled job to still appear in the status snapshot")
+}
+
+// newPurgeFixture builds a tenant context over an in-memory database seeded
+// with one purgeable fingerprint and several that must survive the purge.
+func newPurgeFixture(t *testing.T) (*Scheduler, *tenant.Context, *sql.DB) {
+	t.Helper()
+
+	logger := newQuietLogger(t)
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	const timeLayout = "2006-01-02 15:04:05"
+	old := time.Now().UTC().Add(-72 * time.Hour).Format(timeLayout)
+	recent := time.Now().UTC().Add(-time.Hour).Format(timeLayout)
+
+	seedStatements := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO leads (id, first_name, email, password_hash, contact_persona) VALUES ('lead-1', 'Lee', 'lee@example.com', 'x', 'major')`, nil},
+		{`INSERT INTO beliefs (id, title, slug, scale) VALUES ('b-1', 'Belief', 'belief', 'yn')`, nil},
+		// Stale and anonymous: the only purge candidate.
+		{`INSERT INTO fingerprints (id, created_at) VALUES ('fp-stale', ?)`, []any{old}},
+		// Stale but linked to a lead.
+		{`INSERT INTO fingerprints (id, lead_id, created_at) VALUES ('fp-lead', 'lead-1', ?)`, []any{old}},
+		// Stale and anonymous, but another fingerprint merged into it.
+		{`INSERT INTO fingerprints (id, created_at) VALUES ('fp-target', ?)`, []any{old}},
+		{`INSERT INTO fingerprints (id, merged_into_id, created_at) VALUES ('fp-merged', 'fp-target', ?)`, []any{old}},
+		// Created long ago but visited inside the retention window.
+		{`INSERT INTO fingerprints (id, created_at) VALUES ('fp-active', ?)`, []any{old}},
+		{`INSERT INTO visits (id, fingerprint_id, created_at) VALUES ('v-stale', 'fp-stale', ?)`, []any{old}},
+		{`INSERT INTO visits (id, fingerprint_id, created_at) VALUES ('v-active', 'fp-active', ?)`, []any{recent}},
+		{`INSERT INTO actions (id, object_id, object_type, visit_id, fingerprint_id, verb, created_at) VALUES ('a-stale', 'sf-1', 'StoryFragment', 'v-stale', 'fp-stale', 'READ', ?)`, []any{old}},
+		{`INSERT INTO actions (id, object_id, object_type, visit_id, fingerprint_id, verb, created_at) VALUES ('a-active', 'sf-1', 'StoryFragment', 'v-active', 'fp-active', 'READ', ?)`, []any{recent}},
+		{`INSERT INTO heldbeliefs (id, belief_id, fingerprint_id, verb) VALUES ('hb-stale', 'b-1', 'fp-stale', 'BELIEVES_YES')`, nil},
+	}
+	for _, statement := range seedStatements {
+		if _, err := db.Exec(statement.query, statement.args...); err != nil {
+			t.Fatalf("failed to seed fingerprint data: %v", err)
+		}
+	}
+
+	tenantCtx := &tenant.Context{
+		TenantID: "t1",
+		Config:   &tenant.Config{TenantID: "t1"},
+		Database: &tenant.Database{Conn: db, TenantID: "t1"},
+		Logger:   logger,
+	}
+	return NewScheduler(nil, manager.NewManager(nil), logger), tenantCtx, db
+}
+
+func countRows(t *testing.T, db *sql.DB, query string, args ...any) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow(query, args...).Scan(&count); err != nil {
+		t.Fatalf("count query failed: %v", err)
+	}
+	return count
+}
+
+func TestPurgeAnonymousFingerprintsHardDeletesStaleRows(t *testing.T) {
+	scheduler, tenantCtx, db := newPurgeFixture(t)
+
+	origMaxAge := config.MaintenanceAnonFingerprintMaxAge
+	config.MaintenanceAnonFingerprintMaxAge = 24 * time.Hour
+	defer func() { config.MaintenanceAnonFingerprintMaxAge = origMaxAge }()
+
+	purged, err := scheduler.purgeAnonymousFingerprints(tenantCtx)
+	if err != nil {
+		t.Fatalf("purgeAnonymousFingerprints failed: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("expected exactly the stale anonymous fingerprint purged, got %d", purged)
+	}
+
+	if got := countRows(t, db, `SELECT COUNT(*) FROM fingerprints WHERE id = 'fp-stale'`); got != 0 {
+		t.Error("expected fp-stale hard-deleted")
+	}
+	for _, kept := range []string{"fp-lead", "fp-target", "fp-merged", "fp-active"} {
+		if got := countRows(t, db, `SELECT COUNT(*) FROM fingerprints WHERE id = ?`, kept); got != 1 {
+			t.Errorf("expected %s to survive the purge", kept)
+		}
+	}
+
+	// The purge cascades through the stale fingerprint's dependent rows but
+	// leaves everyone else's history intact.
+	for _, table := range []string{"heldbeliefs", "actions", "visits"} {
+		if got := countRows(t, db, `SELECT COUNT(*) FROM `+table+` WHERE fingerprint_id = 'fp-stale'`); got != 0 {
+			t.Errorf("expected no %s rows left for fp-stale, got %d", table, got)
+		}
+	}
+	if got := countRows(t, db, `SELECT COUNT(*) FROM visits WHERE fingerprint_id = 'fp-active'`); got != 1 {
+		t.Error("expected fp-active's visit to survive")
+	}
+	if got := countRows(t, db, `SELECT COUNT(*) FROM actions WHERE fingerprint_id = 'fp-active'`); got != 1 {
+		t.Error("expected fp-active's action to survive")
+	}
+
+	// A second pass finds nothing left to purge.
+	if purged, err := scheduler.purgeAnonymousFingerprints(tenantCtx); err != nil || purged != 0 {
+		t.Errorf("expected an idempotent second pass, got purged=%d err=%v", purged, err)
+	}
+}
+
+func TestPurgeAnonymousFingerprintsRequiresDatabase(t *testing.T) {
+	scheduler, tenantCtx, _ := newPurgeFixture(t)
+	tenantCtx.Database = nil
+
+	if _, err := scheduler.purgeAnonymousFingerprints(tenantCtx); !errors.Is(err, tenant.ErrDatabaseUnavailable) {
+		t.Errorf("expected ErrDatabaseUnavailable without a connection, got %v", err)
+	}
+}
@@ -6,15 +6,35 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
+// Replay buffer sizing: enough to bridge a mobile network blip without
+// holding broadcast history for long-gone sessions.
+const (
+	sseReplayBufferSize = 50
+	sseReplayRetention  = 2 * time.Minute
+)
+
+// bufferedEvent is one broadcast message held for replay to reconnecting
+// clients, tagged with its monotonically increasing SSE event ID.
+type bufferedEvent struct {
+	ID      uint64
+	Message string
+	SentAt  time.Time
+}
+
 // SSEBroadcaster manages tenant-scoped, session-specific SSE connections.
 type SSEBroadcaster struct {
-	tenantSessions map[string]map[string][]chan string // tenantId -> sessionId -> []channels
-	mu             sync.Mutex
-	logger         *logging.ChanneledLogger
+	tenantSessions  map[string]map[string][]chan string   // tenantId -> sessionId -> []channels
+	eventBuffers    map[string]map[string][]bufferedEvent // tenantId -> sessionId -> ring of recent events
+	nextEventID     uint64
+	lastBufferSweep time.Time
+	mu              sync.Mutex
+	logger          *logging.ChanneledLogger
 }
 
 var (
@@ -38,8 +58,10 @@ type BatchUpdate struct {
 func NewSSEBroadcaster(logger *logging.ChanneledLogger) *SSEBroadcaster {
 	once.Do(func() {
 		globalBroadcaster = &SSEBroadcaster{
-			tenantSessions: make(map[string]map[string][]chan string),
-			logger:         logger,
+			tenantSessions:  make(map[string]map[string][]chan string),
+			eventBuffers:    make(map[string]map[string][]bufferedEvent),
+			lastBufferSweep: time.Now(),
+			logger:          logger,
 		}
 	})
 	return globalBroadcaster
@@ -130,24 +152,168 @@ func (b *SSEBroadcaster) BroadcastToSpecificSession(tenantID, sessionID, storyfr
 		return
 	}
 
-	message := fmt.Sprintf("event: panes_updated\ndata: %s\n\n", string(dataJSON))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextEventID++
+	message := fmt.Sprintf("id: %d\nevent: panes_updated\ndata: %s\n\n", b.nextEventID, string(dataJSON))
 
 	b.logger.SSE().Debug("Broadcasting to session", "message", strings.ReplaceAll(message, "\n", "\\n"), "tenantId", tenantID, "sessionId", sessionID)
 
+	// Buffer the event so a briefly disconnected client can replay it via
+	// Last-Event-ID on reconnect.
+	b.bufferEventLocked(tenantID, sessionID, bufferedEvent{ID: b.nextEventID, Message: message, SentAt: time.Now()})
+
+	if tenantSessions, exists := b.tenantSessions[tenantID]; exists {
+		if sessionClients, exists := tenantSessions[sessionID]; exists {
+			for _, ch := range sessionClients {
+				select {
+				case ch <- message:
+				default:
+					b.logger.SSE().Warn("SSE channel full, message dropped", "tenantId", tenantID, "sessionId", sessionID)
+				}
+			}
+		}
+	}
+}
+
+// bufferEventLocked appends an event to a session's replay ring, trimming by
+// size and retention. Callers must hold b.mu. Piggybacks an occasional sweep
+// of stale session buffers so memory doesn't accumulate for gone sessions.
+func (b *SSEBroadcaster) bufferEventLocked(tenantID, sessionID string, event bufferedEvent) {
+	if b.eventBuffers[tenantID] == nil {
+		b.eventBuffers[tenantID] = make(map[string][]bufferedEvent)
+	}
+
+	buffer := append(b.eventBuffers[tenantID][sessionID], event)
+	cutoff := time.Now().Add(-sseReplayRetention)
+	for len(buffer) > sseReplayBufferSize || (len(buffer) > 0 && buffer[0].SentAt.Before(cutoff)) {
+		buffer = buffer[1:]
+	}
+	b.eventBuffers[tenantID][sessionID] = buffer
+
+	if time.Since(b.lastBufferSweep) > sseReplayRetention {
+		b.sweepStaleBuffersLocked(cutoff)
+	}
+}
+
+// sweepStaleBuffersLocked drops replay buffers whose newest event has aged
+// out, so sessions that never reconnect don't pin memory. Callers hold b.mu.
+func (b *SSEBroadcaster) sweepStaleBuffersLocked(cutoff time.Time) {
+	for tenantID, sessionBuffers := range b.eventBuffers {
+		for sessionID, buffer := range sessionBuffers {
+			if len(buffer) == 0 || buffer[len(buffer)-1].SentAt.Before(cutoff) {
+				delete(sessionBuffers, sessionID)
+			}
+		}
+		if len(sessionBuffers) == 0 {
+			delete(b.eventBuffers, tenantID)
+		}
+	}
+	b.lastBufferSweep = time.Now()
+}
+
+// ReplaySessionEventsSince returns the buffered messages for a session with
+// event IDs greater than lastEventID, oldest first, skipping anything past
+// the retention window. Used to honor the Last-Event-ID header on reconnect.
+func (b *SSEBroadcaster) ReplaySessionEventsSince(tenantID, sessionID string, lastEventID uint64) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sessionBuffers, exists := b.eventBuffers[tenantID]
+	if !exists {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-sseReplayRetention)
+	var missed []string
+	for _, event := range sessionBuffers[sessionID] {
+		if event.ID > lastEventID && !event.SentAt.Before(cutoff) {
+			missed = append(missed, event.Message)
+		}
+	}
+	return missed
+}
+
+// PurgeSessionEvents drops a session's replay buffer; called when the
+// session itself expires.
+func (b *SSEBroadcaster) PurgeSessionEvents(tenantID, sessionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sessionBuffers, exists := b.eventBuffers[tenantID]; exists {
+		delete(sessionBuffers, sessionID)
+		if len(sessionBuffers) == 0 {
+			delete(b.eventBuffers, tenantID)
+		}
+	}
+}
+
+// ShutdownMessage is the sentinel sent to every SSE client during graceful
+// shutdown. Handlers recognize it (by suffix, since a retry: directive may be
+// prepended) and terminate their connection loop; the channel itself stays
+// owned (and closed) by the handler.
+const ShutdownMessage = "event: shutdown\ndata: {\"reason\":\"server_shutdown\"}\n\n"
+
+// CloseAllClients notifies every registered SSE client that the server is
+// shutting down so their handlers return, and reports how many connections
+// were signalled. The message carries a retry: directive so EventSource
+// clients reconnect on their own once the server is back. Used during
+// graceful shutdown before the HTTP server itself is stopped, so SSE request
+// goroutines release instead of pinning srv.Shutdown until its timeout.
+func (b *SSEBroadcaster) CloseAllClients() int {
+	message := ShutdownMessage
+	if config.SSEShutdownRetryMs > 0 {
+		message = fmt.Sprintf("retry: %d\n%s", config.SSEShutdownRetryMs, ShutdownMessage)
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	drained := 0
+	for tenantID, tenantSessions := range b.tenantSessions {
+		for sessionID, sessionClients := range tenantSessions {
+			for _, ch := range sessionClients {
+				select {
+				case ch <- message:
+					drained++
+				default:
+					b.logger.SSE().Warn("SSE channel full during drain, client skipped", "tenantId", tenantID, "sessionId", sessionID)
+				}
+			}
+		}
+	}
+
+	if drained > 0 {
+		b.logger.SSE().Info("SSE connections drained", "count", drained)
+	}
+	return drained
+}
+
+// BroadcastSessionResync tells every connection for a session to discard its
+// local state and re-fetch from the server. Used by admin bulk operations that
+// mutate session or belief state out from under connected clients. Returns the
+// number of connections signalled.
+func (b *SSEBroadcaster) BroadcastSessionResync(tenantID, sessionID string) int {
+	message := "event: resync\ndata: {\"reason\":\"session_invalidated\"}\n\n"
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	signalled := 0
 	if tenantSessions, exists := b.tenantSessions[tenantID]; exists {
 		if sessionClients, exists := tenantSessions[sessionID]; exists {
 			for _, ch := range sessionClients {
 				select {
 				case ch <- message:
+					signalled++
 				default:
-					b.logger.SSE().Warn("SSE channel full, message dropped", "tenantId", tenantID, "sessionId", sessionID)
+					b.logger.SSE().Warn("SSE channel full, resync dropped", "tenantId", tenantID, "sessionId", sessionID)
 				}
 			}
 		}
 	}
+	return signalled
 }
 
 // HasViewingSessions checks if any sessions are viewing a specific storyfragment.
@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+)
+
+// newTestBroadcaster builds a broadcaster directly rather than through the
+// NewSSEBroadcaster singleton so each test gets isolated buffers.
+func newTestBroadcaster(t *testing.T) *SSEBroadcaster {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	return &SSEBroadcaster{
+		tenantSessions:  make(map[string]map[string][]chan string),
+		eventBuffers:    make(map[string]map[string][]bufferedEvent),
+		lastBufferSweep: time.Now(),
+		logger:          logger,
+	}
+}
+
+func TestReplaySessionEventsSinceReturnsMissedEvents(t *testing.T) {
+	b := newTestBroadcaster(t)
+
+	// Three broadcasts while the client is away; event IDs are 1, 2, 3.
+	for _, sfID := range []string{"sf-a", "sf-b", "sf-c"} {
+		b.BroadcastToSpecificSession("t1", "sess-1", sfID, []string{"pane-1"}, nil)
+	}
+
+	// A client that saw event 1 replays only the two it missed, oldest first.
+	missed := b.ReplaySessionEventsSince("t1", "sess-1", 1)
+	if len(missed) != 2 {
+		t.Fatalf("expected 2 missed events after Last-Event-ID 1, got %d", len(missed))
+	}
+	if !strings.HasPrefix(missed[0], "id: 2\n") || !strings.HasPrefix(missed[1], "id: 3\n") {
+		t.Errorf("expected events 2 and 3 in order, got %q then %q", missed[0], missed[1])
+	}
+	for _, message := range missed {
+		if !strings.Contains(message, "event: panes_updated\n") {
+			t.Errorf("expected a panes_updated frame, got %q", message)
+		}
+	}
+
+	// A client that saw everything gets nothing to replay.
+	if missed := b.ReplaySessionEventsSince("t1", "sess-1", 3); len(missed) != 0 {
+		t.Errorf("expected no replay for an up-to-date client, got %d events", len(missed))
+	}
+}
+
+func TestReplaySessionEventsSinceIsolatesSessionsAndTenants(t *testing.T) {
+	b := newTestBroadcaster(t)
+	b.BroadcastToSpecificSession("t1", "sess-1", "sf-a", []string{"pane-1"}, nil)
+
+	if missed := b.ReplaySessionEventsSince("t1", "sess-other", 0); missed != nil {
+		t.Errorf("expected no replay for a different session, got %v", missed)
+	}
+	if missed := b.ReplaySessionEventsSince("t2", "sess-1", 0); missed != nil {
+		t.Errorf("expected no replay for a different tenant, got %v", missed)
+	}
+}
+
+func TestPurgeSessionEventsDropsReplayBuffer(t *testing.T) {
+	b := newTestBroadcaster(t)
+	b.BroadcastToSpecificSession("t1", "sess-1", "sf-a", []string{"pane-1"}, nil)
+
+	b.PurgeSessionEvents("t1", "sess-1")
+	if missed := b.ReplaySessionEventsSince("t1", "sess-1", 0); len(missed) != 0 {
+		t.Errorf("expected an empty replay buffer after purge, got %d events", len(missed))
+	}
+}
@@ -7,5 +7,9 @@ type Broadcaster interface {
 	RemoveClientWithSession(ch chan string, tenantID, sessionID string)
 	GetSessionConnectionCount(tenantID, sessionID string) int
 	BroadcastToSpecificSession(tenantID, sessionID, storyfragmentID string, paneIDs []string, scrollTarget *string)
+	BroadcastSessionResync(tenantID, sessionID string) int
+	ReplaySessionEventsSince(tenantID, sessionID string, lastEventID uint64) []string
+	PurgeSessionEvents(tenantID, sessionID string)
 	HasViewingSessions(tenantID, storyfragmentID string) bool
+	CloseAllClients() int
 }
@@ -0,0 +1,198 @@
+// Package messaging provides asynchronous webhook delivery with retry and dead-letter handling.
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// WebhookEvent is one outbound webhook delivery. Events that exhaust their
+// retry budget are kept in a per-tenant dead-letter buffer for inspection
+// and replay.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	TenantID  string          `json:"tenantId"`
+	URL       string          `json:"url"`
+	EventType string          `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"lastError,omitempty"`
+	FailedAt  time.Time       `json:"failedAt,omitempty"`
+}
+
+// WebhookDispatcher delivers webhook events asynchronously with exponential
+// backoff. Delivery never blocks the caller; exhausted events land in a
+// bounded per-tenant dead-letter buffer.
+type WebhookDispatcher struct {
+	mu          sync.Mutex
+	inflight    sync.WaitGroup
+	deadLetters map[string][]*WebhookEvent // tenantId -> oldest-first events
+	client      *http.Client
+	logger      *logging.ChanneledLogger
+}
+
+// NewWebhookDispatcher creates a webhook dispatcher using the configured
+// retry budget and timeouts.
+func NewWebhookDispatcher(logger *logging.ChanneledLogger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		deadLetters: make(map[string][]*WebhookEvent),
+		client:      &http.Client{Timeout: config.WebhookTimeout},
+		logger:      logger,
+	}
+}
+
+// Deliver queues an event for asynchronous delivery to url. The payload is
+// marshaled immediately so the caller can mutate it afterwards.
+func (d *WebhookDispatcher) Deliver(tenantID, url, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	event := &WebhookEvent{
+		ID:        security.GenerateULID(),
+		TenantID:  tenantID,
+		URL:       url,
+		EventType: eventType,
+		Payload:   data,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	d.dispatch(event)
+	return nil
+}
+
+// dispatch hands an event to a delivery goroutine tracked by the in-flight
+// wait group so Flush can wait for it during shutdown.
+func (d *WebhookDispatcher) dispatch(event *WebhookEvent) {
+	d.inflight.Add(1)
+	go func() {
+		defer d.inflight.Done()
+		d.deliverWithRetry(event)
+	}()
+}
+
+// Flush blocks until all in-flight deliveries have finished (delivered or
+// dead-lettered) or the context expires, so a graceful shutdown doesn't drop
+// queued webhooks mid-retry.
+func (d *WebhookDispatcher) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DeadLetters returns a copy of a tenant's dead-letter buffer, oldest first.
+func (d *WebhookDispatcher) DeadLetters(tenantID string) []*WebhookEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := make([]*WebhookEvent, len(d.deadLetters[tenantID]))
+	copy(events, d.deadLetters[tenantID])
+	return events
+}
+
+// Replay removes an event from the dead-letter buffer and re-queues it for
+// delivery with a fresh retry budget.
+func (d *WebhookDispatcher) Replay(tenantID, eventID string) error {
+	d.mu.Lock()
+	var event *WebhookEvent
+	buffer := d.deadLetters[tenantID]
+	for i, candidate := range buffer {
+		if candidate.ID == eventID {
+			event = candidate
+			d.deadLetters[tenantID] = append(buffer[:i], buffer[i+1:]...)
+			break
+		}
+	}
+	d.mu.Unlock()
+
+	if event == nil {
+		return fmt.Errorf("dead-letter event %s not found for tenant %s", eventID, tenantID)
+	}
+
+	event.Attempts = 0
+	event.LastError = ""
+	event.FailedAt = time.Time{}
+	d.dispatch(event)
+	return nil
+}
+
+func (d *WebhookDispatcher) deliverWithRetry(event *WebhookEvent) {
+	for event.Attempts < config.WebhookMaxAttempts {
+		if event.Attempts > 0 {
+			// Exponential backoff: base, 2x base, 4x base, ...
+			time.Sleep(config.WebhookRetryBackoff << (event.Attempts - 1))
+		}
+		event.Attempts++
+
+		if err := d.attempt(event); err != nil {
+			event.LastError = err.Error()
+			if d.logger != nil {
+				d.logger.System().Warn("Webhook delivery attempt failed", "tenantId", event.TenantID, "eventId", event.ID, "eventType", event.EventType, "attempt", event.Attempts, "error", err)
+			}
+			continue
+		}
+
+		if d.logger != nil {
+			d.logger.System().Info("Webhook delivered", "tenantId", event.TenantID, "eventId", event.ID, "eventType", event.EventType, "attempts", event.Attempts)
+		}
+		return
+	}
+
+	event.FailedAt = time.Now().UTC()
+	d.addDeadLetter(event)
+	if d.logger != nil {
+		d.logger.System().Error("Webhook delivery exhausted, moved to dead-letter", "tenantId", event.TenantID, "eventId", event.ID, "eventType", event.EventType, "attempts", event.Attempts, "lastError", event.LastError)
+	}
+}
+
+func (d *WebhookDispatcher) attempt(event *WebhookEvent) error {
+	req, err := http.NewRequest(http.MethodPost, event.URL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-TractStack-Event", event.EventType)
+	req.Header.Set("X-TractStack-Delivery", event.ID)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) addDeadLetter(event *WebhookEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buffer := append(d.deadLetters[event.TenantID], event)
+	if overflow := len(buffer) - config.WebhookDeadLetterLimit; overflow > 0 {
+		buffer = buffer[overflow:]
+	}
+	d.deadLetters[event.TenantID] = buffer
+}
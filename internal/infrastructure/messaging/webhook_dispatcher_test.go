@@ -0,0 +1,143 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// overrideWebhookConfig shrinks the retry budget so exhaustion happens in
+// milliseconds instead of the production backoff schedule.
+func overrideWebhookConfig(t *testing.T, maxAttempts int) {
+	t.Helper()
+	origAttempts, origBackoff, origLimit := config.WebhookMaxAttempts, config.WebhookRetryBackoff, config.WebhookDeadLetterLimit
+	config.WebhookMaxAttempts = maxAttempts
+	config.WebhookRetryBackoff = time.Millisecond
+	config.WebhookDeadLetterLimit = 2
+	t.Cleanup(func() {
+		config.WebhookMaxAttempts, config.WebhookRetryBackoff, config.WebhookDeadLetterLimit = origAttempts, origBackoff, origLimit
+	})
+}
+
+func flushDispatcher(t *testing.T, d *WebhookDispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.Flush(ctx); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+}
+
+func TestDeliverExhaustsRetriesIntoDeadLetter(t *testing.T) {
+	overrideWebhookConfig(t, 3)
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(nil)
+	if err := d.Deliver("t1", server.URL, "lead.created", map[string]string{"id": "lead-1"}); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	flushDispatcher(t, d)
+
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("expected 3 delivery attempts, got %d", got)
+	}
+	dead := d.DeadLetters("t1")
+	if len(dead) != 1 {
+		t.Fatalf("expected 1 dead-lettered event, got %d", len(dead))
+	}
+	event := dead[0]
+	if event.Attempts != 3 {
+		t.Errorf("expected the event to record 3 attempts, got %d", event.Attempts)
+	}
+	if event.LastError == "" {
+		t.Error("expected the event to record its last error")
+	}
+	if event.FailedAt.IsZero() {
+		t.Error("expected the event to record when it was dead-lettered")
+	}
+	if len(d.DeadLetters("t2")) != 0 {
+		t.Error("expected other tenants' dead-letter buffers to stay empty")
+	}
+}
+
+func TestReplayDrainsDeadLetterOnSuccess(t *testing.T) {
+	overrideWebhookConfig(t, 2)
+
+	var failing atomic.Bool
+	failing.Store(true)
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get("X-TractStack-Event") != "lead.created" {
+			t.Errorf("expected the event type header on replay, got %q", r.Header.Get("X-TractStack-Event"))
+		}
+		delivered.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(nil)
+	if err := d.Deliver("t1", server.URL, "lead.created", map[string]string{"id": "lead-1"}); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	flushDispatcher(t, d)
+
+	dead := d.DeadLetters("t1")
+	if len(dead) != 1 {
+		t.Fatalf("expected 1 dead-lettered event before replay, got %d", len(dead))
+	}
+
+	// The endpoint recovers; replaying hands the event a fresh retry budget.
+	failing.Store(false)
+	if err := d.Replay("t1", dead[0].ID); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	flushDispatcher(t, d)
+
+	if delivered.Load() != 1 {
+		t.Errorf("expected the replayed event to be delivered once, got %d", delivered.Load())
+	}
+	if remaining := d.DeadLetters("t1"); len(remaining) != 0 {
+		t.Errorf("expected the dead-letter buffer to drain after replay, got %d events", len(remaining))
+	}
+
+	if err := d.Replay("t1", dead[0].ID); err == nil {
+		t.Error("expected replaying an already-drained event to fail")
+	}
+}
+
+func TestDeadLetterBufferIsBounded(t *testing.T) {
+	overrideWebhookConfig(t, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(nil)
+	for i := 0; i < 3; i++ {
+		if err := d.Deliver("t1", server.URL, "lead.created", map[string]int{"seq": i}); err != nil {
+			t.Fatalf("Deliver failed: %v", err)
+		}
+		flushDispatcher(t, d)
+	}
+
+	dead := d.DeadLetters("t1")
+	if len(dead) != config.WebhookDeadLetterLimit {
+		t.Errorf("expected the buffer to hold the configured limit of %d events, got %d", config.WebhookDeadLetterLimit, len(dead))
+	}
+}
@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// auditFileName is the dedicated audit log file inside the log directory.
+const auditFileName = "audit.log"
+
+// AuditEvent is one JSON line in the audit log.
+type AuditEvent struct {
+	Timestamp     string            `json:"timestamp"`
+	TenantID      string            `json:"tenantId"`
+	EventType     string            `json:"eventType"`
+	FingerprintID string            `json:"fingerprintId,omitempty"`
+	SessionID     string            `json:"sessionId,omitempty"`
+	IP            string            `json:"ip,omitempty"`
+	Success       bool              `json:"success"`
+	Extra         map[string]string `json:"extra,omitempty"`
+}
+
+// AuditLogger writes authentication events as JSON lines to audit.log,
+// separate from the channel logs. Unlike those, the audit log is
+// append-only and never rotated: it is the durable record of who
+// authenticated, from where, and whether it worked. Writes are
+// serialized so concurrent requests never interleave lines.
+type AuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+	path string
+}
+
+// NewAuditLogger opens (or creates) audit.log in the given log directory.
+func NewAuditLogger(logDirectory string) (*AuditLogger, error) {
+	if err := os.MkdirAll(logDirectory, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(logDirectory, auditFileName)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLogger{file: file, path: path}, nil
+}
+
+// LogAuthEvent appends one authentication event to the audit log. Failures
+// are swallowed: auditing must never break the auth flow it observes.
+func (a *AuditLogger) LogAuthEvent(tenantID, eventType, fingerprintID, sessionID, ip string, success bool, extra map[string]string) {
+	if a == nil {
+		return
+	}
+	event := AuditEvent{
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		TenantID:      tenantID,
+		EventType:     eventType,
+		FingerprintID: fingerprintID,
+		SessionID:     sessionID,
+		IP:            ip,
+		Success:       success,
+		Extra:         extra,
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file != nil {
+		a.file.Write(line)
+	}
+}
+
+// Tail returns the last n events from the audit log, oldest first. Lines
+// that fail to parse (e.g. a partial write from a crash) are skipped.
+func (a *AuditLogger) Tail(n int) ([]AuditEvent, error) {
+	if n <= 0 {
+		return []AuditEvent{}, nil
+	}
+
+	a.mu.Lock()
+	path := a.path
+	a.mu.Unlock()
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return []AuditEvent{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Keep only the last n lines in a ring so an old, large audit log does
+	// not get held in memory wholesale.
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = append(lines[1:], scanner.Text())
+		} else {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]AuditEvent, 0, len(lines))
+	for _, line := range lines {
+		var event AuditEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Close closes the underlying audit log file.
+func (a *AuditLogger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
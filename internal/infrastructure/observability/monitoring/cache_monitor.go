@@ -866,6 +866,27 @@ func (cpm *CachePerformanceMonitor) GetTenantMetrics(tenantID string) *TenantCac
 	return nil
 }
 
+// GetAllTenantMetrics returns cache metrics for every tenant seen so far
+func (cpm *CachePerformanceMonitor) GetAllTenantMetrics() map[string]*TenantCacheMetrics {
+	cpm.mu.RLock()
+	defer cpm.mu.RUnlock()
+
+	all := make(map[string]*TenantCacheMetrics, len(cpm.tenantMetrics))
+	for tenantID, metrics := range cpm.tenantMetrics {
+		metricsCopy := *metrics
+
+		// Deep copy layer metrics
+		metricsCopy.LayerMetrics = make(map[string]*TenantLayerMetrics)
+		for layerName, layerMetrics := range metrics.LayerMetrics {
+			layerCopy := *layerMetrics
+			metricsCopy.LayerMetrics[layerName] = &layerCopy
+		}
+
+		all[tenantID] = &metricsCopy
+	}
+	return all
+}
+
 // GetEvictionStats returns cache eviction statistics
 func (cpm *CachePerformanceMonitor) GetEvictionStats() *EvictionStats {
 	cpm.mu.RLock()
@@ -0,0 +1,207 @@
+// Package monitoring provides cache performance monitoring and health tracking
+// for TractStack's multi-layered cache architecture with detailed analytics.
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// PrometheusCollector renders CachePerformanceMonitor state in the Prometheus
+// text exposition format (version 0.0.4). The module deliberately avoids a
+// dependency on the Prometheus client library; everything the monitor tracks
+// is already aggregated, so emitting the text format directly keeps the
+// dependency graph small while remaining scrapeable by any Prometheus server.
+type PrometheusCollector struct {
+	monitor *CachePerformanceMonitor
+}
+
+// NewPrometheusCollector creates a collector wrapping the given monitor
+func NewPrometheusCollector(monitor *CachePerformanceMonitor) *PrometheusCollector {
+	return &PrometheusCollector{monitor: monitor}
+}
+
+// cacheLayerNames lists the monitored cache layers in stable output order
+var cacheLayerNames = []string{"analytics", "content", "html_chunk", "user_state"}
+
+// WriteMetrics writes all cache metrics to w in the text exposition format.
+// Output order is deterministic so successive scrapes diff cleanly.
+func (pc *PrometheusCollector) WriteMetrics(w io.Writer) error {
+	if err := pc.writeLayerMetrics(w); err != nil {
+		return err
+	}
+	if err := pc.writeTenantMetrics(w); err != nil {
+		return err
+	}
+	if err := pc.writeEvictionMetrics(w); err != nil {
+		return err
+	}
+	return pc.writeWarmingMetrics(w)
+}
+
+// writeLayerMetrics emits per-layer hit ratios, request counters, latency
+// gauges, and memory usage.
+func (pc *PrometheusCollector) writeLayerMetrics(w io.Writer) error {
+	type layerSnapshot struct {
+		name    string
+		metrics *CacheLayerMetrics
+	}
+
+	layers := make([]layerSnapshot, 0, len(cacheLayerNames))
+	for _, name := range cacheLayerNames {
+		if metrics := pc.monitor.GetLayerMetrics(name); metrics != nil {
+			layers = append(layers, layerSnapshot{name: name, metrics: metrics})
+		}
+	}
+
+	if err := writeHeader(w, "tractstack_cache_hit_ratio", "gauge", "Cache hit ratio per cache layer"); err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := writeSample(w, "tractstack_cache_hit_ratio", fmt.Sprintf(`layer=%q`, layer.name), layer.metrics.HitRatio); err != nil {
+			return err
+		}
+	}
+
+	if err := writeHeader(w, "tractstack_cache_requests_total", "counter", "Cache requests per layer and result"); err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := writeSample(w, "tractstack_cache_requests_total", fmt.Sprintf(`layer=%q,result="hit"`, layer.name), float64(layer.metrics.CacheHits)); err != nil {
+			return err
+		}
+		if err := writeSample(w, "tractstack_cache_requests_total", fmt.Sprintf(`layer=%q,result="miss"`, layer.name), float64(layer.metrics.CacheMisses)); err != nil {
+			return err
+		}
+	}
+
+	if err := writeHeader(w, "tractstack_cache_operation_duration_seconds", "gauge", "Moving-average cache operation latency per layer and operation"); err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := writeSample(w, "tractstack_cache_operation_duration_seconds", fmt.Sprintf(`layer=%q,operation="hit"`, layer.name), layer.metrics.AvgHitLatency.Seconds()); err != nil {
+			return err
+		}
+		if err := writeSample(w, "tractstack_cache_operation_duration_seconds", fmt.Sprintf(`layer=%q,operation="miss"`, layer.name), layer.metrics.AvgMissLatency.Seconds()); err != nil {
+			return err
+		}
+	}
+
+	if err := writeHeader(w, "tractstack_cache_size_bytes", "gauge", "Estimated cache memory usage per layer"); err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		if err := writeSample(w, "tractstack_cache_size_bytes", fmt.Sprintf(`layer=%q`, layer.name), float64(layer.metrics.TotalSizeBytes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTenantMetrics emits per-tenant hit ratios with layer and tenant labels.
+func (pc *PrometheusCollector) writeTenantMetrics(w io.Writer) error {
+	allTenants := pc.monitor.GetAllTenantMetrics()
+	tenantIDs := make([]string, 0, len(allTenants))
+	for tenantID := range allTenants {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	sort.Strings(tenantIDs)
+
+	if err := writeHeader(w, "tractstack_cache_tenant_hit_ratio", "gauge", "Cache hit ratio per layer and tenant"); err != nil {
+		return err
+	}
+	for _, tenantID := range tenantIDs {
+		tenantMetrics := allTenants[tenantID]
+		layerNames := make([]string, 0, len(tenantMetrics.LayerMetrics))
+		for layerName := range tenantMetrics.LayerMetrics {
+			layerNames = append(layerNames, layerName)
+		}
+		sort.Strings(layerNames)
+
+		for _, layerName := range layerNames {
+			labels := fmt.Sprintf(`layer=%q,tenant=%q`, layerName, tenantID)
+			if err := writeSample(w, "tractstack_cache_tenant_hit_ratio", labels, tenantMetrics.LayerMetrics[layerName].HitRatio); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeEvictionMetrics emits eviction counters per layer and reason.
+func (pc *PrometheusCollector) writeEvictionMetrics(w io.Writer) error {
+	evictions := pc.monitor.GetEvictionStats()
+	layerNames := make([]string, 0, len(evictions.LayerEvictions))
+	for layerName := range evictions.LayerEvictions {
+		layerNames = append(layerNames, layerName)
+	}
+	sort.Strings(layerNames)
+
+	if err := writeHeader(w, "tractstack_cache_evictions_total", "counter", "Cache evictions per layer and reason"); err != nil {
+		return err
+	}
+	for _, layerName := range layerNames {
+		layerStats := evictions.LayerEvictions[layerName]
+		reasons := []struct {
+			reason string
+			count  int64
+		}{
+			{"manual", layerStats.ManualEvictions},
+			{"memory", layerStats.MemoryEvictions},
+			{"ttl", layerStats.TTLEvictions},
+		}
+		for _, entry := range reasons {
+			labels := fmt.Sprintf(`layer=%q,reason=%q`, layerName, entry.reason)
+			if err := writeSample(w, "tractstack_cache_evictions_total", labels, float64(entry.count)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeWarmingMetrics emits per-tenant warming durations and the overall
+// warming success rate.
+func (pc *PrometheusCollector) writeWarmingMetrics(w io.Writer) error {
+	warming := pc.monitor.GetWarmingStats()
+	tenantIDs := make([]string, 0, len(warming.TenantWarmingStats))
+	for tenantID := range warming.TenantWarmingStats {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	sort.Strings(tenantIDs)
+
+	if err := writeHeader(w, "tractstack_warming_duration_seconds", "gauge", "Moving-average cache warming duration per tenant"); err != nil {
+		return err
+	}
+	for _, tenantID := range tenantIDs {
+		if err := writeSample(w, "tractstack_warming_duration_seconds", fmt.Sprintf(`tenant=%q`, tenantID), warming.TenantWarmingStats[tenantID].AvgWarmingTime.Seconds()); err != nil {
+			return err
+		}
+	}
+
+	if err := writeHeader(w, "tractstack_warming_success_ratio", "gauge", "Fraction of warming operations that succeeded"); err != nil {
+		return err
+	}
+	return writeSample(w, "tractstack_warming_success_ratio", "", warming.WarmingSuccessRate)
+}
+
+// writeHeader writes the HELP and TYPE comment lines for a metric family.
+func writeHeader(w io.Writer, name, metricType, help string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+	return err
+}
+
+// writeSample writes a single sample line, omitting braces when unlabelled.
+func writeSample(w io.Writer, name, labels string, value float64) error {
+	var err error
+	if labels == "" {
+		_, err = fmt.Fprintf(w, "%s %g\n", name, value)
+	} else {
+		_, err = fmt.Fprintf(w, "%s{%s} %g\n", name, labels, value)
+	}
+	return err
+}
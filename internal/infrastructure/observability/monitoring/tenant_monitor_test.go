@@ -0,0 +1,43 @@
+package monitoring
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRecordCacheOperationTracksHitRatio(t *testing.T) {
+	tm := NewTenantMonitor()
+
+	// A known sequence: 3 hits and 1 miss leaves a 75% ratio.
+	tm.RecordCacheOperation("t1", true)
+	tm.RecordCacheOperation("t1", true)
+	tm.RecordCacheOperation("t1", false)
+	tm.RecordCacheOperation("t1", true)
+
+	metrics := tm.GetMetrics("t1")
+	if metrics == nil {
+		t.Fatal("expected metrics for the recorded tenant")
+	}
+	if metrics.CacheHits != 3 || metrics.CacheMisses != 1 {
+		t.Errorf("expected 3 hits and 1 miss, got %d/%d", metrics.CacheHits, metrics.CacheMisses)
+	}
+	if math.Abs(metrics.CacheHitRatio-0.75) > 0.0001 {
+		t.Errorf("expected hit ratio 0.75, got %f", metrics.CacheHitRatio)
+	}
+}
+
+func TestRecordCacheOperationIsolatesTenants(t *testing.T) {
+	tm := NewTenantMonitor()
+
+	tm.RecordCacheOperation("t1", true)
+	tm.RecordCacheOperation("t2", false)
+
+	t1 := tm.GetMetrics("t1")
+	if t1.CacheHitRatio != 1.0 {
+		t.Errorf("expected t1 ratio 1.0, got %f", t1.CacheHitRatio)
+	}
+	t2 := tm.GetMetrics("t2")
+	if t2.CacheHitRatio != 0.0 {
+		t.Errorf("expected t2 ratio 0.0, got %f", t2.CacheHitRatio)
+	}
+}
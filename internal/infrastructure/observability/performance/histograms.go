@@ -0,0 +1,200 @@
+// Package performance provides performance tracking and monitoring capabilities
+// for TractStack operations with multi-tenant support and real-time metrics.
+package performance
+
+import (
+	"sort"
+	"time"
+)
+
+// latencyBucketBounds are the upper bounds (inclusive) of each histogram
+// bucket in milliseconds. A final implicit +Inf bucket catches the rest.
+var latencyBucketBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// LatencyHistogram accumulates request durations into fixed buckets for one
+// route template and tenant. Bucketing by route template (not raw path) keeps
+// cardinality bounded.
+type LatencyHistogram struct {
+	Route    string  `json:"route"`
+	TenantID string  `json:"tenantId"`
+	Counts   []int64 `json:"counts"` // one per bucket, plus trailing +Inf bucket
+	Total    int64   `json:"total"`
+	SumMs    float64 `json:"sumMs"`
+	MinMs    float64 `json:"minMs"`
+	MaxMs    float64 `json:"maxMs"`
+}
+
+// Record adds a single observation to the histogram.
+func (h *LatencyHistogram) Record(duration time.Duration) {
+	ms := float64(duration.Microseconds()) / 1000.0
+
+	idx := sort.SearchFloat64s(latencyBucketBounds, ms)
+	h.Counts[idx]++
+	h.Total++
+	h.SumMs += ms
+	if h.Total == 1 || ms < h.MinMs {
+		h.MinMs = ms
+	}
+	if ms > h.MaxMs {
+		h.MaxMs = ms
+	}
+}
+
+// Percentile estimates the given percentile (0-100) in milliseconds by linear
+// interpolation within the containing bucket.
+func (h *LatencyHistogram) Percentile(p float64) float64 {
+	if h.Total == 0 {
+		return 0
+	}
+
+	rank := p / 100.0 * float64(h.Total)
+	var cumulative int64
+	for i, count := range h.Counts {
+		cumulative += count
+		if float64(cumulative) >= rank {
+			lower := 0.0
+			if i > 0 {
+				lower = latencyBucketBounds[i-1]
+			}
+			upper := h.MaxMs
+			if i < len(latencyBucketBounds) {
+				upper = latencyBucketBounds[i]
+			}
+			if count == 0 {
+				return upper
+			}
+			bucketRank := rank - float64(cumulative-count)
+			return lower + (upper-lower)*(bucketRank/float64(count))
+		}
+	}
+	return h.MaxMs
+}
+
+// EndpointLatencySummary is the reporting shape for one histogram.
+type EndpointLatencySummary struct {
+	Route    string  `json:"route"`
+	TenantID string  `json:"tenantId"`
+	Count    int64   `json:"count"`
+	AvgMs    float64 `json:"avgMs"`
+	MinMs    float64 `json:"minMs"`
+	MaxMs    float64 `json:"maxMs"`
+	P50Ms    float64 `json:"p50Ms"`
+	P95Ms    float64 `json:"p95Ms"`
+	P99Ms    float64 `json:"p99Ms"`
+}
+
+// RecordEndpointLatency buckets a completed HTTP request by route template
+// and tenant.
+func (t *Tracker) RecordEndpointLatency(route, tenantID string, duration time.Duration) {
+	if route == "" {
+		return
+	}
+
+	key := tenantID + "|" + route
+
+	t.histogramMu.Lock()
+	defer t.histogramMu.Unlock()
+
+	hist, exists := t.endpointHistograms[key]
+	if !exists {
+		hist = &LatencyHistogram{
+			Route:    route,
+			TenantID: tenantID,
+			Counts:   make([]int64, len(latencyBucketBounds)+1),
+		}
+		t.endpointHistograms[key] = hist
+	}
+	hist.Record(duration)
+}
+
+// GetRouteGroupHistogram merges every histogram for a tenant whose route
+// satisfies the match function into a single aggregate copy. The returned
+// histogram is detached from the tracker and safe to retain.
+func (t *Tracker) GetRouteGroupHistogram(tenantID string, match func(route string) bool) *LatencyHistogram {
+	merged := &LatencyHistogram{
+		TenantID: tenantID,
+		Counts:   make([]int64, len(latencyBucketBounds)+1),
+	}
+
+	t.histogramMu.RLock()
+	defer t.histogramMu.RUnlock()
+
+	for _, hist := range t.endpointHistograms {
+		if hist.TenantID != tenantID || !match(hist.Route) {
+			continue
+		}
+		for i, count := range hist.Counts {
+			merged.Counts[i] += count
+		}
+		if merged.Total == 0 || hist.MinMs < merged.MinMs {
+			merged.MinMs = hist.MinMs
+		}
+		if hist.MaxMs > merged.MaxMs {
+			merged.MaxMs = hist.MaxMs
+		}
+		merged.Total += hist.Total
+		merged.SumMs += hist.SumMs
+	}
+	return merged
+}
+
+// Delta returns the observations recorded since the baseline was captured, as
+// a new histogram. Both histograms must share the same bucket layout.
+func (h *LatencyHistogram) Delta(baseline *LatencyHistogram) *LatencyHistogram {
+	delta := &LatencyHistogram{
+		Route:    h.Route,
+		TenantID: h.TenantID,
+		Counts:   make([]int64, len(h.Counts)),
+		MinMs:    h.MinMs,
+		MaxMs:    h.MaxMs,
+	}
+	for i := range h.Counts {
+		diff := h.Counts[i]
+		if baseline != nil && i < len(baseline.Counts) {
+			diff -= baseline.Counts[i]
+		}
+		if diff < 0 {
+			diff = 0
+		}
+		delta.Counts[i] = diff
+		delta.Total += diff
+	}
+	delta.SumMs = h.SumMs
+	if baseline != nil {
+		delta.SumMs -= baseline.SumMs
+	}
+	return delta
+}
+
+// GetEndpointLatencies returns latency summaries for every recorded route,
+// optionally filtered by tenant (empty tenantID returns all tenants).
+func (t *Tracker) GetEndpointLatencies(tenantID string) []EndpointLatencySummary {
+	t.histogramMu.RLock()
+	defer t.histogramMu.RUnlock()
+
+	summaries := make([]EndpointLatencySummary, 0, len(t.endpointHistograms))
+	for _, hist := range t.endpointHistograms {
+		if tenantID != "" && hist.TenantID != tenantID {
+			continue
+		}
+		summaries = append(summaries, EndpointLatencySummary{
+			Route:    hist.Route,
+			TenantID: hist.TenantID,
+			Count:    hist.Total,
+			AvgMs:    hist.SumMs / float64(hist.Total),
+			MinMs:    hist.MinMs,
+			MaxMs:    hist.MaxMs,
+			P50Ms:    hist.Percentile(50),
+			P95Ms:    hist.Percentile(95),
+			P99Ms:    hist.Percentile(99),
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].TenantID != summaries[j].TenantID {
+			return summaries[i].TenantID < summaries[j].TenantID
+		}
+		return summaries[i].Route < summaries[j].Route
+	})
+	return summaries
+}
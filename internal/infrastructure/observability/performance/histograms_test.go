@@ -0,0 +1,107 @@
+package performance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRecordEndpointLatencyBucketsByRouteTemplate(t *testing.T) {
+	tracker := NewTracker(nil)
+
+	// Two requests for different concrete slugs land on the same route
+	// template, so they must accumulate in one histogram.
+	tracker.RecordEndpointLatency("/api/v1/nodes/storyfragments/:id", "t1", 4*time.Millisecond)
+	tracker.RecordEndpointLatency("/api/v1/nodes/storyfragments/:id", "t1", 40*time.Millisecond)
+	tracker.RecordEndpointLatency("/api/v1/nodes/panes/:id", "t1", 4*time.Millisecond)
+	tracker.RecordEndpointLatency("/api/v1/nodes/storyfragments/:id", "t2", 4*time.Millisecond)
+
+	summaries := tracker.GetEndpointLatencies("t1")
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 route histograms for t1, got %d", len(summaries))
+	}
+	// GetEndpointLatencies sorts by route within a tenant.
+	if summaries[0].Route != "/api/v1/nodes/panes/:id" || summaries[0].Count != 1 {
+		t.Errorf("expected 1 observation for the panes template, got %+v", summaries[0])
+	}
+	if summaries[1].Route != "/api/v1/nodes/storyfragments/:id" || summaries[1].Count != 2 {
+		t.Errorf("expected 2 observations merged under the storyfragments template, got %+v", summaries[1])
+	}
+
+	// The same route under another tenant keeps its own histogram.
+	if other := tracker.GetEndpointLatencies("t2"); len(other) != 1 || other[0].Count != 1 {
+		t.Errorf("expected an isolated t2 histogram with 1 observation, got %v", other)
+	}
+}
+
+func TestRecordEndpointLatencyPlacesObservationsInBounds(t *testing.T) {
+	tracker := NewTracker(nil)
+
+	// 4ms falls in the (0, 5] bucket, 40ms in (25, 50], 20s in +Inf.
+	tracker.RecordEndpointLatency("/route", "t1", 4*time.Millisecond)
+	tracker.RecordEndpointLatency("/route", "t1", 40*time.Millisecond)
+	tracker.RecordEndpointLatency("/route", "t1", 20*time.Second)
+
+	tracker.histogramMu.RLock()
+	hist := tracker.endpointHistograms["t1|/route"]
+	tracker.histogramMu.RUnlock()
+	if hist == nil {
+		t.Fatal("expected a histogram keyed by tenant and route")
+	}
+
+	if hist.Counts[0] != 1 {
+		t.Errorf("expected the 5ms bucket to hold 1 observation, got %d", hist.Counts[0])
+	}
+	if hist.Counts[3] != 1 {
+		t.Errorf("expected the 50ms bucket to hold 1 observation, got %d", hist.Counts[3])
+	}
+	if hist.Counts[len(hist.Counts)-1] != 1 {
+		t.Errorf("expected the +Inf bucket to hold 1 observation, got %d", hist.Counts[len(hist.Counts)-1])
+	}
+	if hist.Total != 3 {
+		t.Errorf("expected total 3, got %d", hist.Total)
+	}
+}
+
+func TestPercentileInterpolatesWithinBucket(t *testing.T) {
+	hist := &LatencyHistogram{Counts: make([]int64, len(latencyBucketBounds)+1)}
+
+	// Four observations all in the (10, 25] bucket: the p50 rank of 2 sits
+	// halfway through the bucket's count, interpolating to 10 + 15*(2/4).
+	for i := 0; i < 4; i++ {
+		hist.Record(20 * time.Millisecond)
+	}
+
+	if got, want := hist.Percentile(50), 17.5; math.Abs(got-want) > 0.001 {
+		t.Errorf("expected p50 %.3f, got %.3f", want, got)
+	}
+	if got, want := hist.Percentile(100), 25.0; math.Abs(got-want) > 0.001 {
+		t.Errorf("expected p100 at the bucket's upper bound %.3f, got %.3f", want, got)
+	}
+}
+
+func TestPercentileAcrossBuckets(t *testing.T) {
+	hist := &LatencyHistogram{Counts: make([]int64, len(latencyBucketBounds)+1)}
+
+	// Nine fast observations and one slow one: p50 stays in the first
+	// bucket, p95 lands on the slow observation's bucket.
+	for i := 0; i < 9; i++ {
+		hist.Record(2 * time.Millisecond)
+	}
+	hist.Record(400 * time.Millisecond)
+
+	if p50 := hist.Percentile(50); p50 > 5 {
+		t.Errorf("expected p50 inside the 5ms bucket, got %.3f", p50)
+	}
+	p95 := hist.Percentile(95)
+	if p95 <= 250 || p95 > 500 {
+		t.Errorf("expected p95 inside the (250, 500] bucket, got %.3f", p95)
+	}
+
+	if hist.Percentile(0) != 0 {
+		t.Errorf("expected p0 of an empty rank to be 0, got %.3f", hist.Percentile(0))
+	}
+	if empty := (&LatencyHistogram{Counts: make([]int64, len(latencyBucketBounds)+1)}); empty.Percentile(99) != 0 {
+		t.Error("expected an empty histogram to report 0")
+	}
+}
@@ -19,6 +19,9 @@ type Tracker struct {
 	mu         sync.RWMutex           // Protects concurrent access
 	started    time.Time              // When tracking started
 	config     *TrackerConfig         // Tracker configuration
+
+	endpointHistograms map[string]*LatencyHistogram // "tenant|route" -> histogram
+	histogramMu        sync.RWMutex                 // Protects endpoint histograms
 }
 
 // TrackerConfig contains configuration options for the performance tracker
@@ -91,12 +94,13 @@ func NewTracker(config *TrackerConfig) *Tracker {
 	}
 
 	tracker := &Tracker{
-		markers:    make(map[string]*Marker),
-		snapshots:  make([]*PerformanceSnapshot, 0),
-		alerts:     make([]*PerformanceAlert, 0),
-		thresholds: DefaultAlertThresholds(),
-		started:    time.Now(),
-		config:     config,
+		markers:            make(map[string]*Marker),
+		snapshots:          make([]*PerformanceSnapshot, 0),
+		alerts:             make([]*PerformanceAlert, 0),
+		thresholds:         DefaultAlertThresholds(),
+		started:            time.Now(),
+		config:             config,
+		endpointHistograms: make(map[string]*LatencyHistogram),
 	}
 
 	return tracker
@@ -33,16 +33,29 @@ func (r *BeliefRepository) FindByID(tenantID, id string) (*content.BeliefNode, e
 		return belief, nil
 	}
 
-	belief, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":belief:"+id, func() (any, error) {
+		if belief, found := r.cache.GetBelief(tenantID, id); found {
+			return belief, nil
+		}
+
+		belief, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if belief == nil {
+			return nil, nil
+		}
+
+		r.cache.SetBelief(tenantID, belief)
+		return belief, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if belief == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetBelief(tenantID, belief)
-	return belief, nil
+	return result.(*content.BeliefNode), nil
 }
 
 func (r *BeliefRepository) FindBySlug(tenantID, slug string) (*content.BeliefNode, error) {
@@ -33,16 +33,29 @@ func (r *EpinetRepository) FindByID(tenantID, id string) (*content.EpinetNode, e
 		return epinet, nil
 	}
 
-	epinet, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":epinet:"+id, func() (any, error) {
+		if epinet, found := r.cache.GetEpinet(tenantID, id); found {
+			return epinet, nil
+		}
+
+		epinet, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if epinet == nil {
+			return nil, nil
+		}
+
+		r.cache.SetEpinet(tenantID, epinet)
+		return epinet, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if epinet == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetEpinet(tenantID, epinet)
-	return epinet, nil
+	return result.(*content.EpinetNode), nil
 }
 
 // FindAll retrieves all epinets for a tenant, employing a cache-first strategy.
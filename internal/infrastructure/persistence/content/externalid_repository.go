@@ -0,0 +1,161 @@
+// Package content provides external ID mappings repository
+package content
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/interfaces"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+type ExternalIDRepository struct {
+	db     *sql.DB
+	cache  interfaces.ContentCache
+	logger *logging.ChanneledLogger
+}
+
+func NewExternalIDRepository(db *sql.DB, cache interfaces.ContentCache, logger *logging.ChanneledLogger) *ExternalIDRepository {
+	return &ExternalIDRepository{
+		db:     db,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// FindByExternalID resolves a (system, externalId) pair to a node, employing a
+// cache-first strategy against the in-memory external ID index.
+func (r *ExternalIDRepository) FindByExternalID(tenantID, system, externalID string) (*content.ExternalIDMapping, error) {
+	if mapping, found := r.cache.GetNodeByExternalID(tenantID, system, externalID); found {
+		return mapping, nil
+	}
+
+	query := `SELECT node_id, node_type, system, external_id FROM external_ids WHERE system = ? AND external_id = ?`
+
+	start := time.Now()
+	r.logger.Database().Debug("Loading external ID mapping from database", "system", system, "externalId", externalID)
+
+	row := r.db.QueryRow(query, system, externalID)
+
+	var mapping content.ExternalIDMapping
+	err := row.Scan(&mapping.NodeID, &mapping.NodeType, &mapping.System, &mapping.ExternalID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Database().Error("Failed to scan external ID mapping", "error", err.Error(), "system", system, "externalId", externalID)
+		return nil, fmt.Errorf("failed to scan external ID mapping: %w", err)
+	}
+
+	duration := time.Since(start)
+	if duration > config.SlowQueryThreshold {
+		r.logger.LogSlowQuery(query, duration, tenantID)
+	}
+
+	r.cache.SetExternalIDMapping(tenantID, &mapping)
+	return &mapping, nil
+}
+
+// FindByNodeID returns all external ID mappings for a single node.
+func (r *ExternalIDRepository) FindByNodeID(tenantID, nodeID string) ([]*content.ExternalIDMapping, error) {
+	query := `SELECT node_id, node_type, system, external_id FROM external_ids WHERE node_id = ?`
+
+	rows, err := r.db.Query(query, nodeID)
+	if err != nil {
+		r.logger.Database().Error("Failed to query external ID mappings", "error", err.Error(), "nodeId", nodeID)
+		return nil, fmt.Errorf("failed to query external ID mappings: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanMappings(rows)
+}
+
+// FindAll returns every external ID mapping for a tenant, used to warm the
+// in-memory index alongside the content cache.
+func (r *ExternalIDRepository) FindAll(tenantID string) ([]*content.ExternalIDMapping, error) {
+	query := `SELECT node_id, node_type, system, external_id FROM external_ids`
+
+	start := time.Now()
+	r.logger.Database().Debug("Loading all external ID mappings from database")
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Database().Error("Failed to query external ID mappings", "error", err.Error())
+		return nil, fmt.Errorf("failed to query external ID mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings, err := r.scanMappings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Since(start)
+	if duration > config.SlowQueryThreshold {
+		r.logger.LogSlowQuery(query, duration, tenantID)
+	}
+	return mappings, nil
+}
+
+// Upsert stores an external ID mapping for a node. Repeated imports of the
+// same (system, externalId) pair re-point the existing row rather than
+// duplicating it; a pair already claimed by a different node is an error.
+func (r *ExternalIDRepository) Upsert(tenantID string, mapping *content.ExternalIDMapping) error {
+	existing, err := r.FindByExternalID(tenantID, mapping.System, mapping.ExternalID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.NodeID != mapping.NodeID {
+		return fmt.Errorf("external ID %s/%s is already mapped to node %s", mapping.System, mapping.ExternalID, existing.NodeID)
+	}
+
+	query := `INSERT INTO external_ids (id, node_id, node_type, system, external_id) VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT(system, external_id) DO UPDATE SET node_id = excluded.node_id, node_type = excluded.node_type`
+
+	start := time.Now()
+	r.logger.Database().Debug("Executing external ID upsert", "nodeId", mapping.NodeID, "system", mapping.System)
+
+	_, err = r.db.Exec(query, security.GenerateULID(), mapping.NodeID, mapping.NodeType, mapping.System, mapping.ExternalID)
+	if err != nil {
+		r.logger.Database().Error("External ID upsert failed", "error", err.Error(), "nodeId", mapping.NodeID)
+		return fmt.Errorf("failed to upsert external ID mapping: %w", err)
+	}
+
+	duration := time.Since(start)
+	if duration > config.SlowQueryThreshold {
+		r.logger.LogSlowQuery(query, duration, tenantID)
+	}
+	r.cache.SetExternalIDMapping(tenantID, mapping)
+	return nil
+}
+
+// DeleteByNodeID removes all external ID mappings for a node, used when the
+// node itself is deleted.
+func (r *ExternalIDRepository) DeleteByNodeID(tenantID, nodeID string) error {
+	query := `DELETE FROM external_ids WHERE node_id = ?`
+
+	_, err := r.db.Exec(query, nodeID)
+	if err != nil {
+		r.logger.Database().Error("External ID delete failed", "error", err.Error(), "nodeId", nodeID)
+		return fmt.Errorf("failed to delete external ID mappings: %w", err)
+	}
+
+	r.cache.RemoveExternalIDMappings(tenantID, nodeID)
+	return nil
+}
+
+func (r *ExternalIDRepository) scanMappings(rows *sql.Rows) ([]*content.ExternalIDMapping, error) {
+	var mappings []*content.ExternalIDMapping
+	for rows.Next() {
+		var mapping content.ExternalIDMapping
+		if err := rows.Scan(&mapping.NodeID, &mapping.NodeType, &mapping.System, &mapping.ExternalID); err != nil {
+			return nil, fmt.Errorf("failed to scan external ID mapping: %w", err)
+		}
+		mappings = append(mappings, &mapping)
+	}
+	return mappings, rows.Err()
+}
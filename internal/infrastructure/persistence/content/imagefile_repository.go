@@ -32,16 +32,29 @@ func (r *ImageFileRepository) FindByID(tenantID, id string) (*content.ImageFileN
 		return imageFile, nil
 	}
 
-	imageFile, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":file:"+id, func() (any, error) {
+		if imageFile, found := r.cache.GetFile(tenantID, id); found {
+			return imageFile, nil
+		}
+
+		imageFile, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if imageFile == nil {
+			return nil, nil
+		}
+
+		r.cache.SetFile(tenantID, imageFile)
+		return imageFile, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if imageFile == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetFile(tenantID, imageFile)
-	return imageFile, nil
+	return result.(*content.ImageFileNode), nil
 }
 
 // FindAll retrieves all imagefiles for a tenant, employing a cache-first strategy.
@@ -33,16 +33,29 @@ func (r *MenuRepository) FindByID(tenantID, id string) (*content.MenuNode, error
 		return menu, nil
 	}
 
-	menu, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":menu:"+id, func() (any, error) {
+		if menu, found := r.cache.GetMenu(tenantID, id); found {
+			return menu, nil
+		}
+
+		menu, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if menu == nil {
+			return nil, nil
+		}
+
+		r.cache.SetMenu(tenantID, menu)
+		return menu, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if menu == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetMenu(tenantID, menu)
-	return menu, nil
+	return result.(*content.MenuNode), nil
 }
 
 // FindAll retrieves all menus for a tenant, employing a cache-first strategy.
@@ -34,16 +34,29 @@ func (r *PaneRepository) FindByID(tenantID, id string) (*content.PaneNode, error
 		return pane, nil
 	}
 
-	pane, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":pane:"+id, func() (any, error) {
+		if pane, found := r.cache.GetPane(tenantID, id); found {
+			return pane, nil
+		}
+
+		pane, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if pane == nil {
+			return nil, nil
+		}
+
+		r.cache.SetPane(tenantID, pane)
+		return pane, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if pane == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetPane(tenantID, pane)
-	return pane, nil
+	return result.(*content.PaneNode), nil
 }
 
 func (r *PaneRepository) FindBySlug(tenantID, slug string) (*content.PaneNode, error) {
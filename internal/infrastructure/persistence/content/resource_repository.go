@@ -33,16 +33,29 @@ func (r *ResourceRepository) FindByID(tenantID, id string) (*content.ResourceNod
 		return resource, nil
 	}
 
-	resource, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":resource:"+id, func() (any, error) {
+		if resource, found := r.cache.GetResource(tenantID, id); found {
+			return resource, nil
+		}
+
+		resource, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if resource == nil {
+			return nil, nil
+		}
+
+		r.cache.SetResource(tenantID, resource)
+		return resource, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if resource == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetResource(tenantID, resource)
-	return resource, nil
+	return result.(*content.ResourceNode), nil
 }
 
 func (r *ResourceRepository) FindBySlug(tenantID, slug string) (*content.ResourceNode, error) {
@@ -33,16 +33,29 @@ func (r *StoryFragmentRepository) FindByID(tenantID, id string) (*content.StoryF
 		return storyFragment, nil
 	}
 
-	storyFragment, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":storyfragment:"+id, func() (any, error) {
+		if storyFragment, found := r.cache.GetStoryFragment(tenantID, id); found {
+			return storyFragment, nil
+		}
+
+		storyFragment, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if storyFragment == nil {
+			return nil, nil
+		}
+
+		r.cache.SetStoryFragment(tenantID, storyFragment)
+		return storyFragment, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if storyFragment == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetStoryFragment(tenantID, storyFragment)
-	return storyFragment, nil
+	return result.(*content.StoryFragmentNode), nil
 }
 
 func (r *StoryFragmentRepository) FindBySlug(tenantID, slug string) (*content.StoryFragmentNode, error) {
@@ -32,16 +32,29 @@ func (r *TractStackRepository) FindByID(tenantID, id string) (*content.TractStac
 		return tractStack, nil
 	}
 
-	tractStack, err := r.loadFromDB(id)
+	result, err := r.cache.LoadOrShare(tenantID+":tractstack:"+id, func() (any, error) {
+		if tractStack, found := r.cache.GetTractStack(tenantID, id); found {
+			return tractStack, nil
+		}
+
+		tractStack, err := r.loadFromDB(id)
+		if err != nil {
+			return nil, err
+		}
+		if tractStack == nil {
+			return nil, nil
+		}
+
+		r.cache.SetTractStack(tenantID, tractStack)
+		return tractStack, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if tractStack == nil {
+	if result == nil {
 		return nil, nil
 	}
-
-	r.cache.SetTractStack(tenantID, tractStack)
-	return tractStack, nil
+	return result.(*content.TractStackNode), nil
 }
 
 func (r *TractStackRepository) FindBySlug(tenantID, slug string) (*content.TractStackNode, error) {
@@ -30,7 +30,7 @@ func NewSQLLeadRepository(db *database.DB, logger *logging.ChanneledLogger) *SQL
 func (r *SQLLeadRepository) FindByID(id string) (*user.Lead, error) {
 	const query = `
 		SELECT id, first_name, email, password_hash, contact_persona, 
-		       short_bio, encrypted_code, encrypted_email, created_at, changed
+		       short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		FROM leads 
 		WHERE id = ?`
 
@@ -54,7 +54,7 @@ func (r *SQLLeadRepository) FindByID(id string) (*user.Lead, error) {
 	duration := time.Since(start)
 	if duration > config.SlowQueryThreshold {
 		const query = `SELECT id, first_name, email, password_hash, contact_persona, 
-		               short_bio, encrypted_code, encrypted_email, created_at, changed
+		               short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		               FROM leads WHERE id = ?`
 		r.logger.LogSlowQuery(query, duration, "system")
 	}
@@ -65,7 +65,7 @@ func (r *SQLLeadRepository) FindByID(id string) (*user.Lead, error) {
 func (r *SQLLeadRepository) FindByEmail(email string) (*user.Lead, error) {
 	const query = `
 		SELECT id, first_name, email, password_hash, contact_persona, 
-		       short_bio, encrypted_code, encrypted_email, created_at, changed
+		       short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		FROM leads 
 		WHERE email = ?`
 
@@ -89,18 +89,70 @@ func (r *SQLLeadRepository) FindByEmail(email string) (*user.Lead, error) {
 	duration := time.Since(start)
 	if duration > config.SlowQueryThreshold {
 		const query = `SELECT id, first_name, email, password_hash, contact_persona, 
-		               short_bio, encrypted_code, encrypted_email, created_at, changed
+		               short_bio, encrypted_code, encrypted_email, status, created_at, changed
 		               FROM leads WHERE email = ?`
 		r.logger.LogSlowQuery(query, duration, "system")
 	}
 	return lead, nil
 }
 
+// FindByPersona retrieves a page of Leads filtered by contact persona along
+// with the total count of matches. An empty persona matches all leads.
+func (r *SQLLeadRepository) FindByPersona(persona string, limit, offset int) ([]*user.Lead, int, error) {
+	query := `
+		SELECT id, first_name, email, password_hash, contact_persona,
+		       short_bio, encrypted_code, encrypted_email, status, created_at, changed
+		FROM leads`
+	countQuery := `SELECT COUNT(*) FROM leads`
+	args := []any{}
+	if persona != "" {
+		query += ` WHERE contact_persona = ?`
+		countQuery += ` WHERE contact_persona = ?`
+		args = append(args, persona)
+	}
+	query += ` ORDER BY created_at DESC LIMIT ? OFFSET ?`
+
+	start := time.Now()
+	r.logger.Database().Debug("Loading leads by persona", "persona", persona, "limit", limit, "offset", offset)
+
+	var total int
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		r.logger.Database().Error("Failed to count leads by persona", "error", err.Error(), "persona", persona)
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		r.logger.Database().Error("Failed to load leads by persona", "error", err.Error(), "persona", persona)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	leads := make([]*user.Lead, 0)
+	for rows.Next() {
+		lead, err := r.scanLeadRow(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		leads = append(leads, lead)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	r.logger.Database().Info("Leads loaded by persona", "persona", persona, "count", len(leads), "total", total, "duration", time.Since(start))
+	duration := time.Since(start)
+	if duration > config.SlowQueryThreshold {
+		r.logger.LogSlowQuery(query, duration, "system")
+	}
+	return leads, total, nil
+}
+
 // Store saves a new Lead to the database.
 func (r *SQLLeadRepository) Store(lead *user.Lead) error {
 	const query = `
 		INSERT INTO leads (id, first_name, email, password_hash, contact_persona, 
-		                   short_bio, encrypted_code, encrypted_email, created_at, changed)
+		                   short_bio, encrypted_code, encrypted_email, status, created_at, changed)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	start := time.Now()
@@ -116,6 +168,7 @@ func (r *SQLLeadRepository) Store(lead *user.Lead) error {
 		lead.ShortBio,
 		lead.EncryptedCode,
 		lead.EncryptedEmail,
+		lead.LeadStatus,
 		lead.CreatedAt,
 		lead.Changed,
 	)
@@ -128,7 +181,7 @@ func (r *SQLLeadRepository) Store(lead *user.Lead) error {
 	duration := time.Since(start)
 	if duration > config.SlowQueryThreshold {
 		const query = `INSERT INTO leads (id, first_name, email, password_hash, contact_persona, 
-		               short_bio, encrypted_code, encrypted_email, created_at, changed)
+		               short_bio, encrypted_code, encrypted_email, status, created_at, changed)
 		               VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 		r.logger.LogSlowQuery(query, duration, "system")
 	}
@@ -140,7 +193,7 @@ func (r *SQLLeadRepository) Update(lead *user.Lead) error {
 	const query = `
 		UPDATE leads 
 		SET first_name = ?, email = ?, password_hash = ?, contact_persona = ?,
-		    short_bio = ?, encrypted_code = ?, encrypted_email = ?, changed = ?
+		    short_bio = ?, encrypted_code = ?, encrypted_email = ?, status = ?, changed = ?
 		WHERE id = ?`
 
 	start := time.Now()
@@ -155,6 +208,7 @@ func (r *SQLLeadRepository) Update(lead *user.Lead) error {
 		lead.ShortBio,
 		lead.EncryptedCode,
 		lead.EncryptedEmail,
+		lead.LeadStatus,
 		lead.Changed,
 		lead.ID,
 	)
@@ -168,7 +222,7 @@ func (r *SQLLeadRepository) Update(lead *user.Lead) error {
 	if duration > config.SlowQueryThreshold {
 		const query = `UPDATE leads 
 		               SET first_name = ?, email = ?, password_hash = ?, contact_persona = ?,
-		                   short_bio = ?, encrypted_code = ?, encrypted_email = ?, changed = ?
+		                   short_bio = ?, encrypted_code = ?, encrypted_email = ?, status = ?, changed = ?
 		               WHERE id = ?`
 		r.logger.LogSlowQuery(query, duration, "system")
 	}
@@ -200,10 +254,67 @@ func (r *SQLLeadRepository) ValidateCredentials(email, password string) (*user.L
 	return lead, nil
 }
 
+// scanLeadRow is a helper function to scan one row of a sql.Rows result set
+// into a Lead struct.
+func (r *SQLLeadRepository) scanLeadRow(rows *sql.Rows) (*user.Lead, error) {
+	var lead user.Lead
+	var shortBio, encryptedCode, encryptedEmail, status sql.NullString
+	var createdAtStr, changedStr string
+
+	err := rows.Scan(
+		&lead.ID,
+		&lead.FirstName,
+		&lead.Email,
+		&lead.PasswordHash,
+		&lead.ContactPersona,
+		&shortBio,
+		&encryptedCode,
+		&encryptedEmail,
+		&status,
+		&createdAtStr,
+		&changedStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if shortBio.Valid {
+		lead.ShortBio = shortBio.String
+	}
+	if encryptedCode.Valid {
+		lead.EncryptedCode = encryptedCode.String
+	}
+	if encryptedEmail.Valid {
+		lead.EncryptedEmail = encryptedEmail.String
+	}
+	lead.LeadStatus = user.LeadStatusActive
+	if status.Valid && status.String != "" {
+		lead.LeadStatus = status.String
+	}
+
+	lead.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		lead.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if changedStr != "" {
+		if changed, err := time.Parse(time.RFC3339, changedStr); err == nil {
+			lead.Changed = changed
+		} else if changed, err := time.Parse("2006-01-02 15:04:05", changedStr); err == nil {
+			lead.Changed = changed
+		}
+	}
+
+	return &lead, nil
+}
+
 // scanLead is a helper function to scan a sql.Row into a Lead struct.
 func (r *SQLLeadRepository) scanLead(row *sql.Row) (*user.Lead, error) {
 	var lead user.Lead
-	var shortBio, encryptedCode, encryptedEmail sql.NullString
+	var shortBio, encryptedCode, encryptedEmail, status sql.NullString
 	var changed sql.NullTime
 	var createdAtStr, changedStr string
 
@@ -216,6 +327,7 @@ func (r *SQLLeadRepository) scanLead(row *sql.Row) (*user.Lead, error) {
 		&shortBio,
 		&encryptedCode,
 		&encryptedEmail,
+		&status,
 		&createdAtStr,
 		&changedStr,
 	)
@@ -236,6 +348,10 @@ func (r *SQLLeadRepository) scanLead(row *sql.Row) (*user.Lead, error) {
 	if encryptedEmail.Valid {
 		lead.EncryptedEmail = encryptedEmail.String
 	}
+	lead.LeadStatus = user.LeadStatusActive
+	if status.Valid && status.String != "" {
+		lead.LeadStatus = status.String
+	}
 
 	// Parse timestamps
 	lead.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr)
@@ -119,6 +119,22 @@ func Decrypt(encrypted, key string) (string, error) {
 	return string(plaintext), nil
 }
 
+// DecryptWithFallback decrypts with the primary key, then with the previous
+// key if one is set. The fallback keeps payloads encrypted before a key
+// rotation readable during the grace window.
+func DecryptWithFallback(encrypted, key, previousKey string) (string, error) {
+	plaintext, err := Decrypt(encrypted, key)
+	if err == nil {
+		return plaintext, nil
+	}
+	if previousKey != "" {
+		if plaintext, fallbackErr := Decrypt(encrypted, previousKey); fallbackErr == nil {
+			return plaintext, nil
+		}
+	}
+	return "", err
+}
+
 // EncryptEmail encrypts an email using a shared ULID and the provided AES key
 func EncryptEmail(email, aesKey string) string {
 	sharedULID := GenerateULID()
@@ -24,6 +24,22 @@ func ValidateJWT(tokenString, jwtSecret string) (jwt.MapClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
+// ValidateJWTWithFallback validates a JWT token against the primary secret,
+// then against the previous secret if one is set. The fallback keeps tokens
+// signed before a secret rotation valid during the grace window.
+func ValidateJWTWithFallback(tokenString, jwtSecret, previousSecret string) (jwt.MapClaims, error) {
+	claims, err := ValidateJWT(tokenString, jwtSecret)
+	if err == nil {
+		return claims, nil
+	}
+	if previousSecret != "" {
+		if claims, fallbackErr := ValidateJWT(tokenString, previousSecret); fallbackErr == nil {
+			return claims, nil
+		}
+	}
+	return nil, err
+}
+
 // GetProfileFromClaims extracts a profile from JWT claims
 func GetProfileFromClaims(claims jwt.MapClaims) *user.Profile {
 	if profileData, ok := claims["profile"].(map[string]any); ok {
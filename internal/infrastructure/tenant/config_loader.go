@@ -9,37 +9,45 @@ import (
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
 // Config represents the structure of a single tenant's configuration
 type Config struct {
-	TenantID           string             `json:"tenantId"`
-	Domains            []string           `json:"domains"`
-	Status             string             `json:"status"`
-	DatabaseType       string             `json:"databaseType"`
-	TursoDatabase      string             `json:"TURSO_DATABASE_URL"`
-	TursoToken         string             `json:"TURSO_AUTH_TOKEN"`
-	AAIAPIKey          string             `json:"AAI_API_KEY"`
-	JWTSecret          string             `json:"JWT_SECRET"`
-	AESKey             string             `json:"AES_KEY"`
-	TursoEnabled       bool               `json:"TURSO_ENABLED"`
-	AdminPassword      string             `json:"ADMIN_PASSWORD,omitempty"`
-	EditorPassword     string             `json:"EDITOR_PASSWORD,omitempty"`
-	HomeSlug           string             `json:"HOME_SLUG,omitempty"`
-	TractStackHomeSlug string             `json:"TRACTSTACK_HOME_SLUG,omitempty"`
-	ActivationToken    string             `json:"ACTIVATION_TOKEN,omitempty"`
-	SQLitePath         string             `json:"-"`
-	BrandConfig        *types.BrandConfig `json:"-"`
+	TenantID                   string             `json:"tenantId"`
+	Domains                    []string           `json:"domains"`
+	Status                     string             `json:"status"`
+	DatabaseType               string             `json:"databaseType"`
+	TursoDatabase              string             `json:"TURSO_DATABASE_URL"`
+	TursoToken                 string             `json:"TURSO_AUTH_TOKEN"`
+	AAIAPIKey                  string             `json:"AAI_API_KEY"`
+	JWTSecret                  string             `json:"JWT_SECRET"`
+	AESKey                     string             `json:"AES_KEY"`
+	PreviousJWTSecret          string             `json:"JWT_SECRET_PREVIOUS,omitempty"`
+	PreviousAESKey             string             `json:"AES_KEY_PREVIOUS,omitempty"`
+	TursoEnabled               bool               `json:"TURSO_ENABLED"`
+	AdminPassword              string             `json:"ADMIN_PASSWORD,omitempty"`
+	EditorPassword             string             `json:"EDITOR_PASSWORD,omitempty"`
+	HomeSlug                   string             `json:"HOME_SLUG,omitempty"`
+	TractStackHomeSlug         string             `json:"TRACTSTACK_HOME_SLUG,omitempty"`
+	ActivationToken            string             `json:"ACTIVATION_TOKEN,omitempty"`
+	WebhookURL                 string             `json:"WEBHOOK_URL,omitempty"`
+	DisablePerfHeader          bool               `json:"DISABLE_PERF_HEADER,omitempty"`
+	MaxSessions                int                `json:"MAX_SESSIONS,omitempty"`
+	RateLimitPerMinute         int                `json:"RATE_LIMIT_PER_MINUTE,omitempty"`
+	RateLimitBurst             int                `json:"RATE_LIMIT_BURST,omitempty"`
+	RateLimitTenantPerMinute   int                `json:"RATE_LIMIT_TENANT_PER_MINUTE,omitempty"`
+	RateLimitTenantBurst       int                `json:"RATE_LIMIT_TENANT_BURST,omitempty"`
+	AnalyticsDefaultRangeHours int                `json:"ANALYTICS_DEFAULT_RANGE_HOURS,omitempty"`
+	AllowedEventVerbs          []string           `json:"ALLOWED_EVENT_VERBS,omitempty"`
+	AllowedBeliefObjects       []string           `json:"ALLOWED_BELIEF_OBJECTS,omitempty"`
+	SQLitePath                 string             `json:"-"`
+	BrandConfig                *types.BrandConfig `json:"-"`
 }
 
 // LoadTenantConfig loads configuration for a specific tenant from its env.json file.
 func LoadTenantConfig(tenantID string, logger *logging.ChanneledLogger) (*Config, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("could not find user home directory: %w", err)
-	}
-
-	configPath := filepath.Join(homeDir, "t8k-go-server", "config", tenantID, "env.json")
+	configPath := filepath.Join(config.DataDir, "config", tenantID, "env.json")
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("tenant config file not found at %s", configPath)
 	}
@@ -56,7 +64,7 @@ func LoadTenantConfig(tenantID string, logger *logging.ChanneledLogger) (*Config
 
 	// Set computed fields
 	tenantConfig.TenantID = tenantID
-	tenantConfig.SQLitePath = filepath.Join(homeDir, "t8k-go-server", "db", tenantID, "tractstack.db")
+	tenantConfig.SQLitePath = filepath.Join(config.DataDir, "db", tenantID, "tractstack.db")
 
 	// Load brand configuration
 	brandConfig, err := LoadBrandConfig(tenantID)
@@ -66,7 +74,7 @@ func LoadTenantConfig(tenantID string, logger *logging.ChanneledLogger) (*Config
 	tenantConfig.BrandConfig = brandConfig
 
 	// Ensure tailwindWhitelist.json exists
-	whitelistPath := filepath.Join(homeDir, "t8k-go-server", "config", tenantID, "tailwindWhitelist.json")
+	whitelistPath := filepath.Join(config.DataDir, "config", tenantID, "tailwindWhitelist.json")
 	if _, err := os.Stat(whitelistPath); os.IsNotExist(err) {
 		emptyWhitelist := map[string][]string{"safelist": {}}
 		whitelistData, err := json.MarshalIndent(emptyWhitelist, "", "  ")
@@ -84,12 +92,7 @@ func LoadTenantConfig(tenantID string, logger *logging.ChanneledLogger) (*Config
 
 // LoadBrandConfig loads brand configuration for a specific tenant
 func LoadBrandConfig(tenantID string) (*types.BrandConfig, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("could not find user home directory: %w", err)
-	}
-
-	brandPath := filepath.Join(homeDir, "t8k-go-server", "config", tenantID, "brand.json")
+	brandPath := filepath.Join(config.DataDir, "config", tenantID, "brand.json")
 
 	// Return defaults if file doesn't exist
 	if _, err := os.Stat(brandPath); os.IsNotExist(err) {
@@ -141,12 +144,7 @@ func LoadBrandConfig(tenantID string) (*types.BrandConfig, error) {
 
 // LoadKnownResources loads known resources configuration for a specific tenant
 func LoadKnownResources(tenantID string) (*types.KnownResourcesConfig, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("could not find user home directory: %w", err)
-	}
-
-	knownResourcesPath := filepath.Join(homeDir, "t8k-go-server", "config", tenantID, "knownResources.json")
+	knownResourcesPath := filepath.Join(config.DataDir, "config", tenantID, "knownResources.json")
 
 	// Return empty config if file doesn't exist
 	if _, err := os.Stat(knownResourcesPath); os.IsNotExist(err) {
@@ -181,12 +179,7 @@ type TenantInfo struct {
 
 // LoadTenantRegistry loads the global tenant registry
 func LoadTenantRegistry() (*TenantRegistry, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("could not find user home directory: %w", err)
-	}
-
-	registryPath := filepath.Join(homeDir, "t8k-go-server", "config", "t8k", "tenants.json")
+	registryPath := filepath.Join(config.DataDir, "config", "t8k", "tenants.json")
 
 	if _, err := os.Stat(registryPath); os.IsNotExist(err) {
 		// Create default registry with inactive default tenant
@@ -234,12 +227,7 @@ func LoadTenantRegistry() (*TenantRegistry, error) {
 
 // RegisterTenant adds a new tenant to the registry
 func RegisterTenant(tenantID string) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("could not find user home directory: %w", err)
-	}
-
-	registryPath := filepath.Join(homeDir, "t8k-go-server", "config", "t8k", "tenants.json")
+	registryPath := filepath.Join(config.DataDir, "config", "t8k", "tenants.json")
 
 	registry, err := LoadTenantRegistry()
 	if err != nil {
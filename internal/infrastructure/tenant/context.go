@@ -2,6 +2,9 @@
 package tenant
 
 import (
+	"database/sql"
+	"errors"
+
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/analytics"
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/repositories"
 	domainUser "github.com/AtRiskMedia/tractstack-go/internal/domain/user"
@@ -14,6 +17,11 @@ import (
 	persistenceUser "github.com/AtRiskMedia/tractstack-go/internal/infrastructure/persistence/user"
 )
 
+// ErrDatabaseUnavailable is returned when a tenant context holds no live
+// database connection, e.g. after a mid-request drop. Handlers map it to a
+// 503 with Retry-After so clients back off instead of retrying a 500.
+var ErrDatabaseUnavailable = errors.New("tenant database unavailable")
+
 // Context holds tenant-specific request context
 type Context struct {
 	TenantID     string
@@ -47,6 +55,16 @@ func (ctx *Context) GetDatabase() *Database {
 	return ctx.Database
 }
 
+// DB returns the tenant's live database connection, or ErrDatabaseUnavailable
+// when the context has none. Services that query the connection directly
+// should go through this wrapper rather than dereferencing Database.Conn.
+func (ctx *Context) DB() (*sql.DB, error) {
+	if ctx.Database == nil || ctx.Database.Conn == nil {
+		return nil, ErrDatabaseUnavailable
+	}
+	return ctx.Database.Conn, nil
+}
+
 // GetStatus returns the tenant status
 func (ctx *Context) GetStatus() string {
 	return ctx.Status
@@ -129,6 +147,11 @@ func (ctx *Context) ImageFileRepo() repositories.ImageFileRepository {
 	return content.NewImageFileRepository(ctx.Database.Conn, ctx.CacheManager, ctx.Logger)
 }
 
+// ExternalIDRepo returns an external ID mapping repository for this tenant
+func (ctx *Context) ExternalIDRepo() repositories.ExternalIDRepository {
+	return content.NewExternalIDRepository(ctx.Database.Conn, ctx.CacheManager, ctx.Logger)
+}
+
 // BulkRepo returns a bulk repository instance for complex operations
 func (ctx *Context) BulkRepo() bulk.BulkQueryRepository {
 	db := &database.DB{DB: ctx.Database.Conn}
@@ -0,0 +1,59 @@
+// Package tenant handles loading and providing tenant-specific configurations.
+package tenant
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// DiskUsage returns the bytes a tenant occupies on disk across its config
+// directory (including media) and database directory under config.DataDir.
+// Directories that do not exist yet contribute zero.
+func DiskUsage(tenantID string) (int64, error) {
+	var total int64
+	for _, dir := range []string{
+		filepath.Join(config.DataDir, "config", tenantID),
+		filepath.Join(config.DataDir, "db", tenantID),
+	} {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return 0, fmt.Errorf("failed to measure disk usage for tenant %s: %w", tenantID, err)
+		}
+	}
+	return total, nil
+}
+
+// CheckDiskQuota returns an error when a tenant's disk usage meets or exceeds
+// the configured allocation, so callers can refuse the write with a clear
+// message. A zero TENANT_DISK_QUOTA_MB disables enforcement.
+func CheckDiskQuota(tenantID string) error {
+	if config.TenantDiskQuotaMB <= 0 {
+		return nil
+	}
+	usage, err := DiskUsage(tenantID)
+	if err != nil {
+		return err
+	}
+	quota := int64(config.TenantDiskQuotaMB) * 1024 * 1024
+	if usage >= quota {
+		return fmt.Errorf("tenant %s has exceeded its disk quota (%d of %d bytes used)", tenantID, usage, quota)
+	}
+	return nil
+}
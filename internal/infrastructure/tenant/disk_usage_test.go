@@ -0,0 +1,82 @@
+package tenant
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+)
+
+// writeTenantFile drops size bytes under the tenant's config directory.
+func writeTenantFile(t *testing.T, dataDir, tenantID, name string, size int) {
+	t.Helper()
+	dir := filepath.Join(dataDir, "config", tenantID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create tenant dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), bytes.Repeat([]byte("x"), size), 0644); err != nil {
+		t.Fatalf("failed to write tenant file: %v", err)
+	}
+}
+
+func TestDiskUsageSumsTenantFiles(t *testing.T) {
+	origDataDir := config.DataDir
+	config.DataDir = t.TempDir()
+	defer func() { config.DataDir = origDataDir }()
+
+	writeTenantFile(t, config.DataDir, "t1", "env.json", 100)
+	writeTenantFile(t, config.DataDir, "t1", "media.bin", 400)
+	writeTenantFile(t, config.DataDir, "t2", "env.json", 9000)
+
+	usage, err := DiskUsage("t1")
+	if err != nil {
+		t.Fatalf("DiskUsage failed: %v", err)
+	}
+	if usage != 500 {
+		t.Errorf("expected 500 bytes for t1, got %d", usage)
+	}
+
+	// A tenant with no directories yet occupies zero bytes.
+	usage, err = DiskUsage("t3")
+	if err != nil {
+		t.Fatalf("DiskUsage for an absent tenant failed: %v", err)
+	}
+	if usage != 0 {
+		t.Errorf("expected 0 bytes for an absent tenant, got %d", usage)
+	}
+}
+
+func TestCheckDiskQuotaEnforcesAllocation(t *testing.T) {
+	origDataDir, origQuota := config.DataDir, config.TenantDiskQuotaMB
+	config.DataDir = t.TempDir()
+	config.TenantDiskQuotaMB = 1
+	defer func() {
+		config.DataDir, config.TenantDiskQuotaMB = origDataDir, origQuota
+	}()
+
+	writeTenantFile(t, config.DataDir, "t1", "small.bin", 1024)
+	if err := CheckDiskQuota("t1"); err != nil {
+		t.Errorf("expected a tenant under quota to pass, got %v", err)
+	}
+
+	writeTenantFile(t, config.DataDir, "t1", "large.bin", 1024*1024)
+	if err := CheckDiskQuota("t1"); err == nil {
+		t.Error("expected a tenant at quota to be refused")
+	}
+}
+
+func TestCheckDiskQuotaZeroDisablesEnforcement(t *testing.T) {
+	origDataDir, origQuota := config.DataDir, config.TenantDiskQuotaMB
+	config.DataDir = t.TempDir()
+	config.TenantDiskQuotaMB = 0
+	defer func() {
+		config.DataDir, config.TenantDiskQuotaMB = origDataDir, origQuota
+	}()
+
+	writeTenantFile(t, config.DataDir, "t1", "huge.bin", 2*1024*1024)
+	if err := CheckDiskQuota("t1"); err != nil {
+		t.Errorf("expected a zero quota to disable enforcement, got %v", err)
+	}
+}
@@ -94,6 +94,9 @@ func (m *Manager) createContext(tenantID string) (*Context, error) {
 
 	status := m.detector.GetTenantStatus(tenantID)
 
+	// Apply any per-tenant session quota override from the tenant's env.json.
+	m.cacheManager.SetSessionQuota(tenantID, config.MaxSessions)
+
 	ctx := &Context{
 		TenantID:     tenantID,
 		Config:       config,
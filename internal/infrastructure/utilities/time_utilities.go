@@ -6,71 +6,103 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 )
 
-// FormatHourKey formats a time as an hour key in YYYY-MM-DD-HH format
+// BinGranularity returns the configured analytics bin granularity, clamped to
+// the supported 15-minute, hourly, and daily modes. Anything else falls back
+// to hourly so misconfiguration never produces unparseable keys.
+func BinGranularity() time.Duration {
+	switch config.AnalyticsBinGranularity {
+	case 15 * time.Minute, time.Hour, 24 * time.Hour:
+		return config.AnalyticsBinGranularity
+	default:
+		return time.Hour
+	}
+}
+
+// truncateToBin floors a time to the start of its analytics bin.
+func truncateToBin(t time.Time) time.Time {
+	t = t.UTC()
+	switch g := BinGranularity(); g {
+	case 24 * time.Hour:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Truncate(g)
+	}
+}
+
+// FormatHourKey formats a time as a bin key. Hourly bins use the historical
+// YYYY-MM-DD-HH format; daily bins drop the hour and 15-minute bins append
+// the minute.
 func FormatHourKey(t time.Time) string {
-	return fmt.Sprintf("%d-%02d-%02d-%02d", t.Year(), t.Month(), t.Day(), t.Hour())
+	t = truncateToBin(t)
+	switch BinGranularity() {
+	case 24 * time.Hour:
+		return fmt.Sprintf("%d-%02d-%02d", t.Year(), t.Month(), t.Day())
+	case 15 * time.Minute:
+		return fmt.Sprintf("%d-%02d-%02d-%02d-%02d", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute())
+	default:
+		return fmt.Sprintf("%d-%02d-%02d-%02d", t.Year(), t.Month(), t.Day(), t.Hour())
+	}
 }
 
-// ParseHourKeyToDate parses an hour key back to a time
+// ParseHourKeyToDate parses a bin key back to a time. Daily, hourly, and
+// 15-minute key shapes are all accepted regardless of the active granularity
+// so previously cached bins remain readable after a configuration change.
 func ParseHourKeyToDate(hourKey string) (time.Time, error) {
 	parts := strings.Split(hourKey, "-")
-	if len(parts) != 4 {
+	if len(parts) < 3 || len(parts) > 5 {
 		return time.Time{}, fmt.Errorf("invalid hour key format: %s", hourKey)
 	}
 
-	year, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid year in hour key: %s", hourKey)
-	}
-
-	month, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid month in hour key: %s", hourKey)
+	fields := make([]int, len(parts))
+	names := []string{"year", "month", "day", "hour", "minute"}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s in hour key: %s", names[i], hourKey)
+		}
+		fields[i] = value
 	}
 
-	day, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day in hour key: %s", hourKey)
+	hour, minute := 0, 0
+	if len(fields) > 3 {
+		hour = fields[3]
 	}
-
-	hour, err := strconv.Atoi(parts[3])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid hour in hour key: %s", hourKey)
+	if len(fields) > 4 {
+		minute = fields[4]
 	}
 
-	return time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC), nil
+	return time.Date(fields[0], time.Month(fields[1]), fields[2], hour, minute, 0, 0, time.UTC), nil
 }
 
-// GetCurrentHourKey returns the current hour as a formatted key
+// GetCurrentHourKey returns the current bin as a formatted key
 func GetCurrentHourKey() string {
-	now := time.Now().UTC()
-	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC)
-	return FormatHourKey(currentHour)
+	return FormatHourKey(time.Now().UTC())
 }
 
-// GetHourKeysForTimeRange generates hour keys for the last N hours from now
+// GetHourKeysForTimeRange generates bin keys for the last N hours from now
 func GetHourKeysForTimeRange(hours int) []string {
 	var hourKeys []string
-	now := time.Now().UTC()
-	endHour := now.Truncate(time.Hour) // Current hour, inclusive
-	startHour := endHour.Add(-time.Duration(hours) * time.Hour)
+	granularity := BinGranularity()
+	endBin := truncateToBin(time.Now().UTC()) // Current bin, inclusive
+	startBin := truncateToBin(endBin.Add(-time.Duration(hours) * time.Hour))
 
-	for t := startHour; !t.After(endHour); t = t.Add(time.Hour) {
-		hourKey := FormatHourKey(t)
-		hourKeys = append(hourKeys, hourKey)
+	for t := startBin; !t.After(endBin); t = t.Add(granularity) {
+		hourKeys = append(hourKeys, FormatHourKey(t))
 	}
 
 	return hourKeys
 }
 
-// GetHourKeysForCustomRange generates hour keys for a custom range
+// GetHourKeysForCustomRange generates bin keys for a custom range expressed
+// as hour offsets back from now
 func GetHourKeysForCustomRange(startHour, endHour int) []string {
 	var hourKeys []string
-
-	now := time.Now().UTC()
-	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC)
+	granularity := BinGranularity()
+	currentBin := truncateToBin(time.Now().UTC())
 
 	// Ensure proper order (min to max)
 	minHour := endHour
@@ -80,43 +112,42 @@ func GetHourKeysForCustomRange(startHour, endHour int) []string {
 		maxHour = endHour
 	}
 
-	for i := maxHour; i >= minHour; i-- {
-		hourTime := currentHour.Add(-time.Duration(i) * time.Hour)
-		hourKey := FormatHourKey(hourTime)
-		hourKeys = append(hourKeys, hourKey)
+	oldest := truncateToBin(currentBin.Add(-time.Duration(maxHour) * time.Hour))
+	newest := truncateToBin(currentBin.Add(-time.Duration(minHour) * time.Hour))
+	for t := oldest; !t.After(newest); t = t.Add(granularity) {
+		hourKeys = append(hourKeys, FormatHourKey(t))
 	}
 
 	return hourKeys
 }
 
-// GetMissingHoursFromZero finds missing hours from hour 0 to first cached hour
-// Returns slice of hour keys that need to be loaded
+// GetMissingHoursFromZero finds missing bins from the current bin back over
+// the 28-day retention window, newest first.
+// Returns slice of bin keys that need to be loaded
 func GetMissingHoursFromZero() []string {
 	var missingHours []string
-	now := time.Now().UTC()
-	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC)
+	granularity := BinGranularity()
+	currentBin := truncateToBin(time.Now().UTC())
+	cutoff := currentBin.Add(-28 * 24 * time.Hour) // Max 28 days back
 
-	// Start from hour 0 and work backwards, assuming we need to fill gap
+	// Start from bin 0 and work backwards, assuming we need to fill gap
 	// This will be used by analytics code to determine what to warm
-	for i := 0; i < 672; i++ { // Max 28 days back
-		hourTime := currentHour.Add(-time.Duration(i) * time.Hour)
-		hourKey := FormatHourKey(hourTime)
-		missingHours = append(missingHours, hourKey)
+	for t := currentBin; t.After(cutoff); t = t.Add(-granularity) {
+		missingHours = append(missingHours, FormatHourKey(t))
 	}
 
 	return missingHours
 }
 
-// GetGapHourKeys calculates hour keys from 0 to a specific hour
+// GetGapHourKeys calculates bin keys from 0 back through gapSize bins
 func GetGapHourKeys(gapSize int) []string {
 	var hourKeys []string
-	now := time.Now().UTC()
-	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC)
+	granularity := BinGranularity()
+	currentBin := truncateToBin(time.Now().UTC())
 
 	for i := 0; i < gapSize; i++ {
-		hourTime := currentHour.Add(-time.Duration(i) * time.Hour)
-		hourKey := FormatHourKey(hourTime)
-		hourKeys = append(hourKeys, hourKey)
+		binTime := currentBin.Add(-time.Duration(i) * granularity)
+		hourKeys = append(hourKeys, FormatHourKey(binTime))
 	}
 
 	return hourKeys
@@ -0,0 +1,66 @@
+// Package apierr defines the machine-readable error envelope shared by API
+// handlers and middleware, so clients can switch on stable codes instead of
+// parsing message strings.
+//
+// Adoption is deliberately incremental: endpoints added since the envelope
+// landed emit it everywhere, while older handlers still return bare
+// {"error": ...} objects and migrate as they are touched. LegacyError keeps
+// the two shapes interchangeable from a client's point of view, so the
+// migration never breaks an existing consumer.
+package apierr
+
+import "github.com/gin-gonic/gin"
+
+// Stable machine-readable error codes. Add new codes here rather than
+// inventing one-off strings in handlers.
+const (
+	CodeInvalidRequest      = "INVALID_REQUEST"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeForbidden           = "FORBIDDEN"
+	CodeContentNotFound     = "CONTENT_NOT_FOUND"
+	CodeSessionNotFound     = "SESSION_NOT_FOUND"
+	CodeFingerprintNotFound = "FINGERPRINT_NOT_FOUND"
+	CodeConflict            = "CONFLICT"
+	CodeTenantRequired      = "TENANT_REQUIRED"
+	CodeTenantNotFound      = "TENANT_NOT_FOUND"
+	CodeTenantArchived      = "TENANT_ARCHIVED"
+	CodeTenantNotActivated  = "TENANT_NOT_ACTIVATED"
+	CodeDomainNotAllowed    = "DOMAIN_NOT_ALLOWED"
+	CodeIPNotAllowed        = "IP_NOT_ALLOWED"
+	CodeRateLimited         = "RATE_LIMITED"
+	CodeDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+	CodeInternal            = "INTERNAL_ERROR"
+)
+
+// ErrorResponse is the error envelope returned by the API. LegacyError
+// mirrors Message under the historical "error" key so existing clients keep
+// working while they migrate to switching on Code.
+type ErrorResponse struct {
+	Code        string         `json:"code"`
+	Message     string         `json:"message"`
+	Details     map[string]any `json:"details,omitempty"`
+	LegacyError string         `json:"error"`
+}
+
+// Write sends the structured error without aborting the handler chain.
+func Write(c *gin.Context, status int, code, message string) {
+	c.JSON(status, ErrorResponse{Code: code, Message: message, LegacyError: message})
+}
+
+// WriteDetails sends the structured error with extra machine-readable
+// context for the client.
+func WriteDetails(c *gin.Context, status int, code, message string, details map[string]any) {
+	c.JSON(status, ErrorResponse{Code: code, Message: message, Details: details, LegacyError: message})
+}
+
+// Abort sends the structured error and stops the handler chain; middleware
+// should prefer this over Write.
+func Abort(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, ErrorResponse{Code: code, Message: message, LegacyError: message})
+}
+
+// AbortWithDetails sends the structured error with extra context and stops
+// the handler chain.
+func AbortWithDetails(c *gin.Context, status int, code, message string, details map[string]any) {
+	c.AbortWithStatusJSON(status, ErrorResponse{Code: code, Message: message, Details: details, LegacyError: message})
+}
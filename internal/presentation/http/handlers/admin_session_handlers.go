@@ -0,0 +1,244 @@
+// Package handlers provides HTTP handlers for admin bulk session operations
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSessionHandlers contains HTTP handlers for admin bulk session operations
+type AdminSessionHandlers struct {
+	adminSessionService *services.AdminSessionService
+	broadcaster         messaging.Broadcaster
+	logger              *logging.ChanneledLogger
+	perfTracker         *performance.Tracker
+}
+
+// NewAdminSessionHandlers creates admin session handlers with injected dependencies
+func NewAdminSessionHandlers(adminSessionService *services.AdminSessionService, broadcaster messaging.Broadcaster, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *AdminSessionHandlers {
+	return &AdminSessionHandlers{
+		adminSessionService: adminSessionService,
+		broadcaster:         broadcaster,
+		logger:              logger,
+		perfTracker:         perfTracker,
+	}
+}
+
+// ExpireSessionsRequest represents the payload for bulk session expiry
+type ExpireSessionsRequest struct {
+	Confirm       bool   `json:"confirm"`
+	FingerprintID string `json:"fingerprintId,omitempty"`
+	LeadID        string `json:"leadId,omitempty"`
+}
+
+// ResetBeliefRequest represents the payload for a bulk belief reset
+type ResetBeliefRequest struct {
+	Confirm    bool   `json:"confirm"`
+	BeliefSlug string `json:"beliefSlug" binding:"required"`
+}
+
+// BroadcastRefreshRequest represents the payload for a storyfragment refresh broadcast
+type BroadcastRefreshRequest struct {
+	Confirm bool `json:"confirm"`
+}
+
+// SetKnownFingerprintRequest represents the payload for a forced known-status update
+type SetKnownFingerprintRequest struct {
+	FingerprintID string `json:"fingerprintId" binding:"required"`
+	IsKnown       bool   `json:"isKnown"`
+	LeadID        string `json:"leadId,omitempty"`
+}
+
+// GetKnownFingerprints handles GET /api/v1/admin/fingerprints/known
+// It lists fingerprints with a lead linkage alongside their cached
+// classification so analytics misclassifications can be diagnosed.
+func (h *AdminSessionHandlers) GetKnownFingerprints(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+
+	entries, err := h.adminSessionService.ListKnownFingerprints(tenantCtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Admin audit: known-fingerprint listing",
+		"tenantId", tenantCtx.TenantID, "count", len(entries), "clientIp", c.ClientIP(), "duration", time.Since(start))
+
+	if limit, offset, paged := parsePagination(c); paged {
+		page := entries[min(offset, len(entries)):min(offset+limit, len(entries))]
+		c.JSON(http.StatusOK, gin.H{
+			"fingerprints": page,
+			"count":        len(page),
+			"total":        len(entries),
+			"limit":        limit,
+			"offset":       offset,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"fingerprints": entries,
+		"count":        len(entries),
+	})
+}
+
+// PostKnownFingerprint handles POST /api/v1/admin/fingerprints/known
+// It force-sets a fingerprint's known status, repairing the lead linkage and
+// the sessions cache together.
+func (h *AdminSessionHandlers) PostKnownFingerprint(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+
+	var req SetKnownFingerprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		return
+	}
+
+	if err := h.adminSessionService.SetFingerprintKnown(tenantCtx, req.FingerprintID, req.IsKnown, req.LeadID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Admin audit: forced known-status update",
+		"tenantId", tenantCtx.TenantID, "fingerprintId", req.FingerprintID, "isKnown", req.IsKnown,
+		"leadId", req.LeadID, "clientIp", c.ClientIP(), "duration", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"fingerprintId": req.FingerprintID,
+		"isKnown":       req.IsKnown,
+	})
+}
+
+// PostExpireSessions handles POST /api/v1/admin/sessions/expire
+// It force-expires sessions for the tenant, optionally filtered by fingerprint or lead.
+func (h *AdminSessionHandlers) PostExpireSessions(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+
+	var req ExpireSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		return
+	}
+	if !req.Confirm {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true for bulk operations"})
+		return
+	}
+
+	expired, err := h.adminSessionService.ForceExpireSessions(tenantCtx, h.broadcaster, req.FingerprintID, req.LeadID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Admin audit: bulk session expiry",
+		"tenantId", tenantCtx.TenantID, "fingerprintId", req.FingerprintID, "leadId", req.LeadID,
+		"expired", expired, "clientIp", c.ClientIP(), "duration", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessionsExpired": expired,
+	})
+}
+
+// PostResetBelief handles POST /api/v1/admin/beliefs/reset
+// It removes a belief slug from every fingerprint — a bulk UNSET.
+func (h *AdminSessionHandlers) PostResetBelief(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+
+	var req ResetBeliefRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		return
+	}
+	if !req.Confirm {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true for bulk operations"})
+		return
+	}
+
+	affected, err := h.adminSessionService.ResetBelief(tenantCtx, h.broadcaster, req.BeliefSlug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Admin audit: bulk belief reset",
+		"tenantId", tenantCtx.TenantID, "beliefSlug", req.BeliefSlug,
+		"fingerprintsAffected", affected, "clientIp", c.ClientIP(), "duration", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"beliefSlug":           req.BeliefSlug,
+		"fingerprintsAffected": affected,
+	})
+}
+
+// PostBroadcastRefresh handles POST /api/v1/admin/storyfragments/:id/refresh
+// It pushes a refresh event for the storyfragment to all connected sessions.
+func (h *AdminSessionHandlers) PostBroadcastRefresh(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+
+	storyfragmentID := c.Param("id")
+	if storyfragmentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "storyfragment ID is required"})
+		return
+	}
+
+	var req BroadcastRefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		return
+	}
+	if !req.Confirm {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true for bulk operations"})
+		return
+	}
+
+	notified, err := h.adminSessionService.BroadcastStoryfragmentRefresh(tenantCtx, h.broadcaster, storyfragmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Admin audit: storyfragment refresh broadcast",
+		"tenantId", tenantCtx.TenantID, "storyfragmentId", storyfragmentID,
+		"sessionsNotified", notified, "clientIp", c.ClientIP(), "duration", time.Since(start))
+
+	c.JSON(http.StatusOK, gin.H{
+		"storyfragmentId":  storyfragmentID,
+		"sessionsNotified": notified,
+	})
+}
@@ -0,0 +1,118 @@
+// Package handlers provides CSV output for the analytics dashboard endpoints
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+// wantsCSV reports whether the request asked for CSV output, via either
+// ?format=csv or an Accept: text/csv header. JSON stays the default so
+// existing clients are untouched.
+func wantsCSV(c *gin.Context) bool {
+	if strings.EqualFold(c.Query("format"), "csv") {
+		return true
+	}
+	return strings.Contains(c.GetHeader("Accept"), "text/csv")
+}
+
+// writeCSV streams header and rows as a CSV attachment named for the tenant
+// and the applied hour range. encoding/csv quotes any field containing
+// commas, quotes, or newlines, so titles pass through safely.
+func writeCSV(c *gin.Context, tenantID, name string, startHour, endHour int, header []string, rows [][]string) {
+	filename := fmt.Sprintf("%s-%s-%dh-%dh.csv", tenantID, name, startHour, endHour)
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write(header)
+	for _, row := range rows {
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+// dashboardCSVRows flattens the dashboard payload: one row per hourKey per
+// line series, one row per hot-content item, and one row per summary stat.
+func dashboardCSVRows(dashboard *services.DashboardAnalytics) [][]string {
+	var rows [][]string
+
+	for _, series := range dashboard.Line {
+		for _, point := range series.Data {
+			rows = append(rows, []string{"line", series.ID, point.X, strconv.Itoa(point.Y)})
+		}
+	}
+	for _, item := range dashboard.HotContent {
+		rows = append(rows, []string{"hotContent", item.ID, "", strconv.Itoa(item.TotalEvents)})
+	}
+	rows = append(rows,
+		[]string{"stats", "daily", "", strconv.Itoa(dashboard.Stats.Daily)},
+		[]string{"stats", "weekly", "", strconv.Itoa(dashboard.Stats.Weekly)},
+		[]string{"stats", "monthly", "", strconv.Itoa(dashboard.Stats.Monthly)},
+		[]string{"stats", "dailyKnown", "", strconv.Itoa(dashboard.DailyKnown)},
+		[]string{"stats", "dailyAnonymous", "", strconv.Itoa(dashboard.DailyAnonymous)},
+		[]string{"stats", "weeklyKnown", "", strconv.Itoa(dashboard.WeeklyKnown)},
+		[]string{"stats", "weeklyAnonymous", "", strconv.Itoa(dashboard.WeeklyAnonymous)},
+		[]string{"stats", "monthlyKnown", "", strconv.Itoa(dashboard.MonthlyKnown)},
+		[]string{"stats", "monthlyAnonymous", "", strconv.Itoa(dashboard.MonthlyAnonymous)},
+	)
+
+	return rows
+}
+
+// leadMetricsCSVRows flattens lead metrics into metric/value pairs, with map
+// entries (sources, funnel stages) emitted in sorted order for stable output.
+func leadMetricsCSVRows(metrics *services.LeadMetrics) [][]string {
+	rows := [][]string{
+		{"totalLeads", strconv.Itoa(metrics.TotalLeads)},
+		{"newLeads", strconv.Itoa(metrics.NewLeads)},
+		{"conversionRate", strconv.FormatFloat(metrics.ConversionRate, 'f', -1, 64)},
+		{"firstTimeVisitors", strconv.Itoa(metrics.FirstTimeVisitors)},
+		{"returningVisitors", strconv.Itoa(metrics.ReturningVisitors)},
+	}
+
+	sourceKeys := make([]string, 0, len(metrics.LeadSources))
+	for key := range metrics.LeadSources {
+		sourceKeys = append(sourceKeys, key)
+	}
+	sort.Strings(sourceKeys)
+	for _, key := range sourceKeys {
+		rows = append(rows, []string{"leadSource." + key, strconv.Itoa(metrics.LeadSources[key])})
+	}
+
+	funnelKeys := make([]string, 0, len(metrics.ConversionFunnel))
+	for key := range metrics.ConversionFunnel {
+		funnelKeys = append(funnelKeys, key)
+	}
+	sort.Strings(funnelKeys)
+	for _, key := range funnelKeys {
+		rows = append(rows, []string{"funnel." + key, strconv.Itoa(metrics.ConversionFunnel[key])})
+	}
+
+	return rows
+}
+
+// contentSeriesCSVRows flattens a content time series into one row per
+// hourKey per verb, with the hour's unique visitor count repeated per row.
+func contentSeriesCSVRows(contentID string, series []services.ContentTimeSeriesPoint) [][]string {
+	var rows [][]string
+	for _, point := range series {
+		verbs := make([]string, 0, len(point.Events))
+		for verb := range point.Events {
+			verbs = append(verbs, verb)
+		}
+		sort.Strings(verbs)
+		for _, verb := range verbs {
+			rows = append(rows, []string{contentID, point.HourKey, verb, strconv.Itoa(point.Events[verb]), strconv.Itoa(point.UniqueVisitors)})
+		}
+	}
+	return rows
+}
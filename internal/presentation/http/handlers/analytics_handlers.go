@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
@@ -71,7 +73,7 @@ func (h *AnalyticsHandlers) HandleDashboardAnalytics(c *gin.Context) {
 	defer marker.Complete()
 	h.logger.Analytics().Debug("Received dashboard analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 	epinetIDs, err := h.getEpinetIDs(tenantCtx)
 	if err != nil || len(epinetIDs) == 0 {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get epinet IDs for analytics"})
@@ -87,7 +89,7 @@ func (h *AnalyticsHandlers) HandleDashboardAnalytics(c *gin.Context) {
 		return
 	}
 
-	dashboard, err := h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour)
+	dashboard, err := h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour, h.parseHotContentLimit(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -97,7 +99,16 @@ func (h *AnalyticsHandlers) HandleDashboardAnalytics(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for HandleDashboardAnalytics request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
-	c.JSON(http.StatusOK, gin.H{"dashboard": dashboard})
+	if wantsCSV(c) {
+		writeCSV(c, tenantCtx.TenantID, "dashboard", startHour, endHour,
+			[]string{"section", "id", "hourKey", "value"}, dashboardCSVRows(dashboard))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dashboard":    dashboard,
+		"appliedRange": gin.H{"startHour": startHour, "endHour": endHour},
+	})
 }
 
 // HandleEpinetSankey handles GET /api/v1/analytics/epinets/:id
@@ -114,7 +125,7 @@ func (h *AnalyticsHandlers) HandleEpinetSankey(c *gin.Context) {
 	h.logger.Analytics().Debug("Received epinet analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
 	epinetID := c.Param("id")
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 
 	cacheStatus := tenantCtx.CacheManager.GetRangeCacheStatus(tenantCtx.TenantID, epinetID, startHour, endHour)
 	if cacheStatus.Action != "proceed" {
@@ -133,11 +144,33 @@ func (h *AnalyticsHandlers) HandleEpinetSankey(c *gin.Context) {
 	if selectedUserID != "" {
 		selectedUserIDPtr = &selectedUserID
 	}
+	minLinkValue := 0
+	if raw := c.Query("minLinkValue"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "minLinkValue must be a non-negative integer"})
+			return
+		}
+		minLinkValue = parsed
+	}
+	var excludeGateTypes []string
+	if raw := c.Query("excludeGateTypes"); raw != "" {
+		for _, gateType := range strings.Split(raw, ",") {
+			if gateType = strings.TrimSpace(gateType); gateType != "" {
+				excludeGateTypes = append(excludeGateTypes, gateType)
+			}
+		}
+	}
+
 	filters := &services.SankeyFilters{
-		VisitorType:    visitorType,
-		SelectedUserID: selectedUserIDPtr,
-		StartHour:      &startHour,
-		EndHour:        &endHour,
+		VisitorType:      visitorType,
+		SelectedUserID:   selectedUserIDPtr,
+		StartHour:        &startHour,
+		EndHour:          &endHour,
+		Breakdown:        c.Query("breakdown") == "true",
+		MinLinkValue:     minLinkValue,
+		ExcludeGateTypes: excludeGateTypes,
+		CollapseOthers:   c.Query("collapseOthers") == "true",
 	}
 
 	epinet, err := h.epinetAnalyticsService.ComputeEpinetSankey(tenantCtx, epinetID, filters)
@@ -157,6 +190,112 @@ func (h *AnalyticsHandlers) HandleEpinetSankey(c *gin.Context) {
 		"epinet":             epinet,
 		"userCounts":         userCounts,
 		"hourlyNodeActivity": hourlyNodeActivity,
+		"appliedRange":       gin.H{"startHour": startHour, "endHour": endHour},
+	})
+}
+
+// HandleEpinetFunnel handles GET /api/v1/analytics/funnel/:id
+func (h *AnalyticsHandlers) HandleEpinetFunnel(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("epinet_funnel_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Analytics().Debug("Received epinet funnel request", "method", c.Request.Method, "path", c.Request.URL.Path)
+
+	epinetID := c.Param("id")
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
+
+	cacheStatus := tenantCtx.CacheManager.GetRangeCacheStatus(tenantCtx.TenantID, epinetID, startHour, endHour)
+	if cacheStatus.Action != "proceed" {
+		h.triggerBackgroundWarming(tenantCtx, startHour, cacheStatus)
+		c.JSON(http.StatusOK, gin.H{"funnel": gin.H{"status": "loading"}})
+		return
+	}
+
+	visitorType := c.DefaultQuery("visitorType", "all")
+	selectedUserID := c.Query("selectedUserId")
+	var selectedUserIDPtr *string
+	if selectedUserID != "" {
+		selectedUserIDPtr = &selectedUserID
+	}
+	filters := &services.SankeyFilters{
+		VisitorType:    visitorType,
+		SelectedUserID: selectedUserIDPtr,
+		StartHour:      &startHour,
+		EndHour:        &endHour,
+	}
+
+	funnel, err := h.epinetAnalyticsService.ComputeFunnel(tenantCtx, epinetID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Analytics().Info("Epinet funnel request completed", "epinetId", epinetID, "startHour", startHour, "endHour", endHour, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for HandleEpinetFunnel request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"funnel":       funnel,
+		"appliedRange": gin.H{"startHour": startHour, "endHour": endHour},
+	})
+}
+
+// HandleContentAnalytics handles GET /api/v1/analytics/content/:id
+func (h *AnalyticsHandlers) HandleContentAnalytics(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("content_analytics_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Analytics().Debug("Received content analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
+
+	contentID := c.Param("id")
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
+	epinetIDs, err := h.getEpinetIDs(tenantCtx)
+	if err != nil || len(epinetIDs) == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get epinet IDs"})
+		return
+	}
+	epinetID := epinetIDs[0]
+
+	cacheStatus := tenantCtx.CacheManager.GetRangeCacheStatus(tenantCtx.TenantID, epinetID, startHour, endHour)
+	if cacheStatus.Action != "proceed" {
+		h.triggerBackgroundWarming(tenantCtx, startHour, cacheStatus)
+		c.JSON(http.StatusOK, gin.H{"series": gin.H{"status": "loading"}})
+		return
+	}
+
+	visitorType := c.DefaultQuery("visitorType", "all")
+	series, err := h.contentAnalyticsService.GetContentTimeSeries(tenantCtx, epinetID, contentID, &startHour, &endHour, visitorType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Analytics().Info("Content analytics request completed", "contentId", contentID, "startHour", startHour, "endHour", endHour, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for HandleContentAnalytics request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	if wantsCSV(c) {
+		writeCSV(c, tenantCtx.TenantID, "content-"+contentID, startHour, endHour,
+			[]string{"contentId", "hourKey", "verb", "events", "uniqueVisitors"}, contentSeriesCSVRows(contentID, series))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contentId":    contentID,
+		"series":       series,
+		"appliedRange": gin.H{"startHour": startHour, "endHour": endHour},
 	})
 }
 
@@ -173,7 +312,7 @@ func (h *AnalyticsHandlers) HandleStoryfragmentAnalytics(c *gin.Context) {
 	defer marker.Complete()
 	h.logger.Analytics().Debug("Received storyfragment analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 	epinetIDs, err := h.getEpinetIDs(tenantCtx)
 	if err != nil || len(epinetIDs) == 0 {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get epinet IDs"})
@@ -214,7 +353,7 @@ func (h *AnalyticsHandlers) HandleLeadMetrics(c *gin.Context) {
 	defer marker.Complete()
 	h.logger.Analytics().Debug("Received lead analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 	epinetIDs, err := h.getEpinetIDs(tenantCtx)
 	if err != nil || len(epinetIDs) == 0 {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get epinet IDs"})
@@ -239,6 +378,12 @@ func (h *AnalyticsHandlers) HandleLeadMetrics(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for HandleLeadMetrics request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if wantsCSV(c) {
+		writeCSV(c, tenantCtx.TenantID, "leads", startHour, endHour,
+			[]string{"metric", "value"}, leadMetricsCSVRows(leadMetrics))
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"leads": leadMetrics})
 }
 
@@ -255,7 +400,7 @@ func (h *AnalyticsHandlers) HandleAllAnalytics(c *gin.Context) {
 	defer marker.Complete()
 	h.logger.Analytics().Debug("Received all analytics request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 	epinetIDs, err := h.getEpinetIDs(tenantCtx)
 	if err != nil || len(epinetIDs) == 0 {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get epinet IDs"})
@@ -289,7 +434,7 @@ func (h *AnalyticsHandlers) HandleAllAnalytics(c *gin.Context) {
 	go func() {
 		defer wg.Done()
 		var err error
-		dashboard, err = h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour)
+		dashboard, err = h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour, h.parseHotContentLimit(c))
 		if err != nil {
 			errChan <- fmt.Errorf("dashboard error: %w", err)
 		}
@@ -313,7 +458,7 @@ func (h *AnalyticsHandlers) HandleAllAnalytics(c *gin.Context) {
 		if selectedUserID != "" {
 			selectedUserIDPtr = &selectedUserID
 		}
-		filters := &services.SankeyFilters{VisitorType: visitorType, SelectedUserID: selectedUserIDPtr, StartHour: &startHour, EndHour: &endHour}
+		filters := &services.SankeyFilters{VisitorType: visitorType, SelectedUserID: selectedUserIDPtr, StartHour: &startHour, EndHour: &endHour, Breakdown: c.Query("breakdown") == "true"}
 		epinet, err = h.epinetAnalyticsService.ComputeEpinetSankey(tenantCtx, epinetID, filters)
 		if err != nil {
 			errChan <- fmt.Errorf("epinet sankey error: %w", err)
@@ -358,17 +503,36 @@ func (h *AnalyticsHandlers) HandleAllAnalytics(c *gin.Context) {
 		"epinet":             epinet,
 		"userCounts":         userCounts,
 		"hourlyNodeActivity": hourlyNodeActivity,
+		"appliedRange":       gin.H{"startHour": startHour, "endHour": endHour},
 	})
 }
 
 // --- Helper Methods ---
 
-func (h *AnalyticsHandlers) parseTimeRange(c *gin.Context) (int, int) {
-	startHour, _ := strconv.Atoi(c.DefaultQuery("startHour", "168"))
+// parseTimeRange resolves the analytics window in hours-ago. When the client
+// omits startHour the tenant's configured default range applies, falling back
+// to the global default; explicit query params always win.
+func (h *AnalyticsHandlers) parseTimeRange(c *gin.Context, tenantCtx *tenant.Context) (int, int) {
+	defaultStart := config.AnalyticsDefaultRangeHours
+	if tenantCtx.Config != nil && tenantCtx.Config.AnalyticsDefaultRangeHours > 0 {
+		defaultStart = tenantCtx.Config.AnalyticsDefaultRangeHours
+	}
+	startHour, _ := strconv.Atoi(c.DefaultQuery("startHour", strconv.Itoa(defaultStart)))
 	endHour, _ := strconv.Atoi(c.DefaultQuery("endHour", "0"))
 	return startHour, endHour
 }
 
+func (h *AnalyticsHandlers) parseHotContentLimit(c *gin.Context) int {
+	limit, err := strconv.Atoi(c.DefaultQuery("hotContentLimit", strconv.Itoa(services.DefaultHotContentLimit)))
+	if err != nil || limit < 1 {
+		return services.DefaultHotContentLimit
+	}
+	if limit > services.MaxHotContentLimit {
+		return services.MaxHotContentLimit
+	}
+	return limit
+}
+
 func (h *AnalyticsHandlers) getEpinetIDs(tenantCtx *tenant.Context) ([]string, error) {
 	epinetRepo := tenantCtx.EpinetRepo()
 	epinets, err := epinetRepo.FindAll(tenantCtx.TenantID)
@@ -429,7 +593,7 @@ func (h *AnalyticsHandlers) HandleContentSummary(c *gin.Context) {
 	h.logger.Analytics().Debug("Received content summary request", "method", c.Request.Method, "path", c.Request.URL.Path)
 
 	// Parse time range same as HandleAllAnalytics
-	startHour, endHour := h.parseTimeRange(c)
+	startHour, endHour := h.parseTimeRange(c, tenantCtx)
 
 	// Get epinet IDs same as HandleAllAnalytics
 	epinetIDs, err := h.getEpinetIDs(tenantCtx)
@@ -449,7 +613,7 @@ func (h *AnalyticsHandlers) HandleContentSummary(c *gin.Context) {
 	}
 
 	// Compute dashboard same as HandleAllAnalytics
-	dashboard, err := h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour)
+	dashboard, err := h.dashboardAnalyticsService.ComputeDashboard(tenantCtx, startHour, endHour, h.parseHotContentLimit(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
+	"github.com/gin-gonic/gin"
+)
+
+// newAPIErrContext builds a gin context carrying a tenant context and a JSON
+// request body, mirroring what the tenant middleware provides in production.
+func newAPIErrContext(t *testing.T, method, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, "/", strings.NewReader(body))
+	if body != "" {
+		c.Request.Header.Set("Content-Type", "application/json")
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	c.Set("tenant", &tenant.Context{TenantID: "t1", CacheManager: cacheManager})
+	return c, w
+}
+
+func newAPIErrVisitHandlers(t *testing.T) *VisitHandlers {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	perfTracker := performance.NewTracker(nil)
+	return &VisitHandlers{
+		sessionService: services.NewSessionService(nil, logger, nil, perfTracker),
+		authService:    services.NewAuthService(logger, perfTracker, nil, nil),
+		logger:         logger,
+		perfTracker:    perfTracker,
+	}
+}
+
+// decodeErrorEnvelope asserts the response parses as the apierr envelope and
+// returns it for code assertions.
+func decodeErrorEnvelope(t *testing.T, w *httptest.ResponseRecorder) apierr.ErrorResponse {
+	t.Helper()
+	var envelope apierr.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("response is not an apierr envelope: %v (body %s)", err, w.Body.String())
+	}
+	if envelope.LegacyError == "" {
+		t.Error("expected the legacy \"error\" key to be populated for old clients")
+	}
+	return envelope
+}
+
+func TestPostHeartbeatUnknownSessionReturnsSessionNotFound(t *testing.T) {
+	h := newAPIErrVisitHandlers(t)
+	c, w := newAPIErrContext(t, http.MethodPost, `{"sessionId":"no-such-session"}`)
+
+	h.PostHeartbeat(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if envelope := decodeErrorEnvelope(t, w); envelope.Code != apierr.CodeSessionNotFound {
+		t.Errorf("expected code %s, got %s", apierr.CodeSessionNotFound, envelope.Code)
+	}
+}
+
+func TestPostMergeFingerprintsWithoutAuthReturnsForbidden(t *testing.T) {
+	h := newAPIErrVisitHandlers(t)
+	c, w := newAPIErrContext(t, http.MethodPost, `{"primaryFingerprintId":"fp-1","secondaryFingerprintId":"fp-2"}`)
+
+	h.PostMergeFingerprints(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+	if envelope := decodeErrorEnvelope(t, w); envelope.Code != apierr.CodeForbidden {
+		t.Errorf("expected code %s, got %s", apierr.CodeForbidden, envelope.Code)
+	}
+}
+
+func TestPostVisitMalformedBodyReturnsInvalidRequest(t *testing.T) {
+	h := newAPIErrVisitHandlers(t)
+	c, w := newAPIErrContext(t, http.MethodPost, `{not json`)
+
+	h.PostVisit(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if envelope := decodeErrorEnvelope(t, w); envelope.Code != apierr.CodeInvalidRequest {
+		t.Errorf("expected code %s, got %s", apierr.CodeInvalidRequest, envelope.Code)
+	}
+}
+
+func TestPostStateBatchWithoutSessionReturnsInvalidRequest(t *testing.T) {
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	h := &StateHandlers{logger: logger, perfTracker: performance.NewTracker(nil)}
+
+	c, w := newAPIErrContext(t, http.MethodPost, `{"events":[]}`)
+	h.PostStateBatch(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if envelope := decodeErrorEnvelope(t, w); envelope.Code != apierr.CodeInvalidRequest {
+		t.Errorf("expected code %s, got %s", apierr.CodeInvalidRequest, envelope.Code)
+	}
+}
+
+func TestGetProfileExportWithoutAuthReturnsForbidden(t *testing.T) {
+	h := newAPIErrVisitHandlers(t)
+	c, w := newAPIErrContext(t, http.MethodGet, "")
+	c.Request.URL.RawQuery = "fingerprintId=fp-1"
+
+	h.GetProfileExport(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+	if envelope := decodeErrorEnvelope(t, w); envelope.Code != apierr.CodeForbidden {
+		t.Errorf("expected code %s, got %s", apierr.CodeForbidden, envelope.Code)
+	}
+}
@@ -0,0 +1,73 @@
+// Package handlers provides HTTP handlers for the authentication audit log
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAuditLogLines = 100
+	maxAuditLogLines     = 1000
+)
+
+// AuditLogHandlers contains HTTP handlers for reading the audit log
+type AuditLogHandlers struct {
+	auditLogger *logging.AuditLogger
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewAuditLogHandlers creates audit log handlers with injected dependencies
+func NewAuditLogHandlers(auditLogger *logging.AuditLogger, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *AuditLogHandlers {
+	return &AuditLogHandlers{
+		auditLogger: auditLogger,
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// GetAuditLog handles GET /api/v1/admin/audit-log
+// It returns the last N authentication events, oldest first. The lines
+// query parameter caps the window (default 100, maximum 1000).
+func (h *AuditLogHandlers) GetAuditLog(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("get_audit_log", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	lines := defaultAuditLogLines
+	if linesStr := c.Query("lines"); linesStr != "" {
+		parsed, err := strconv.Atoi(linesStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "lines must be a positive integer"})
+			return
+		}
+		lines = parsed
+	}
+	if lines > maxAuditLogLines {
+		lines = maxAuditLogLines
+	}
+
+	events, err := h.auditLogger.Tail(lines)
+	if err != nil {
+		h.logger.Auth().Error("Failed to read audit log", "tenantId", tenantCtx.TenantID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read audit log"})
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"count":  len(events),
+	})
+}
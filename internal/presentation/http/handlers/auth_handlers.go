@@ -8,6 +8,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -131,6 +132,100 @@ func (h *AuthHandlers) PostLogin(c *gin.Context) {
 	})
 }
 
+// PostRegister handles POST /api/v1/auth/register - starts the magic-link
+// email verification flow for a new lead
+func (h *AuthHandlers) PostRegister(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("post_register_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Auth().Debug("Received register request", "method", c.Request.Method, "path", c.Request.URL.Path, "tenantId", tenantCtx.TenantID)
+
+	var registerReq struct {
+		Email     string `json:"email" binding:"required"`
+		FirstName string `json:"firstName" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&registerReq); err != nil {
+		h.logger.Auth().Error("Register request JSON binding failed", "tenantId", tenantCtx.TenantID, "error", err.Error())
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	result, err := h.authService.RegisterLead(registerReq.FirstName, registerReq.Email, tenantCtx)
+	if err != nil {
+		h.logger.Auth().Error("Lead registration failed", "tenantId", tenantCtx.TenantID, "error", err.Error(), "duration", time.Since(start))
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "Registration failed")
+		return
+	}
+
+	if !result.Success {
+		h.logger.Auth().Warn("Lead registration rejected", "tenantId", tenantCtx.TenantID, "error", result.Error, "duration", time.Since(start))
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusConflict, apierr.CodeConflict, result.Error)
+		return
+	}
+
+	h.logger.Auth().Info("Lead registration accepted", "tenantId", tenantCtx.TenantID, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for PostRegister request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	c.JSON(http.StatusOK, gin.H{"status": "pending"})
+}
+
+// PostVerify handles POST /api/v1/auth/verify - completes the magic-link
+// flow by checking the emailed code and activating the lead
+func (h *AuthHandlers) PostVerify(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("post_verify_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Auth().Debug("Received verify request", "method", c.Request.Method, "path", c.Request.URL.Path, "tenantId", tenantCtx.TenantID)
+
+	var verifyReq struct {
+		Email string `json:"email" binding:"required"`
+		Code  string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&verifyReq); err != nil {
+		h.logger.Auth().Error("Verify request JSON binding failed", "tenantId", tenantCtx.TenantID, "error", err.Error())
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "Invalid request format")
+		return
+	}
+
+	result, err := h.authService.VerifyLead(verifyReq.Email, verifyReq.Code, tenantCtx)
+	if err != nil {
+		h.logger.Auth().Error("Lead verification failed", "tenantId", tenantCtx.TenantID, "error", err.Error(), "duration", time.Since(start))
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "Verification failed")
+		return
+	}
+
+	if !result.Success {
+		h.logger.Auth().Warn("Lead verification rejected", "tenantId", tenantCtx.TenantID, "error", result.Error, "duration", time.Since(start))
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusUnauthorized, apierr.CodeUnauthorized, result.Error)
+		return
+	}
+
+	h.logger.Auth().Info("Lead verification succeeded", "tenantId", tenantCtx.TenantID, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for PostVerify request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	c.JSON(http.StatusOK, gin.H{"status": "active"})
+}
+
 // PostLogout handles POST /api/v1/auth/logout - clears authentication cookies
 func (h *AuthHandlers) PostLogout(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
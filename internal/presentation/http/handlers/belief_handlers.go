@@ -9,6 +9,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -56,6 +57,17 @@ func (h *BeliefHandlers) GetAllBeliefIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllBeliefIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(beliefIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"beliefIds": page,
+			"count":     len(page),
+			"total":     len(beliefIDs),
+			"limit":     limit,
+			"offset":    offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"beliefIds": beliefIDs,
 		"count":     len(beliefIDs),
@@ -126,7 +138,7 @@ func (h *BeliefHandlers) GetBeliefByID(c *gin.Context) {
 	}
 
 	if beliefNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "belief not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "belief not found")
 		return
 	}
 
@@ -134,6 +146,11 @@ func (h *BeliefHandlers) GetBeliefByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetBeliefByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "beliefId", beliefID)
 
+	if writeETag(c, nodeETag(tenantCtx, beliefNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, beliefNode)
 }
 
@@ -162,7 +179,7 @@ func (h *BeliefHandlers) GetBeliefBySlug(c *gin.Context) {
 	}
 
 	if beliefNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "belief not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "belief not found")
 		return
 	}
 
@@ -170,6 +187,11 @@ func (h *BeliefHandlers) GetBeliefBySlug(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetBeliefBySlug request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
 
+	if writeETag(c, nodeETag(tenantCtx, beliefNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, beliefNode)
 }
 
@@ -0,0 +1,248 @@
+// Package handlers provides HTTP handlers for cache statistics endpoints
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/monitoring"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// CacheStatsHandlers contains HTTP handlers for cache statistics
+type CacheStatsHandlers struct {
+	cacheMonitor   *monitoring.CachePerformanceMonitor
+	promCollector  *monitoring.PrometheusCollector
+	warmingService *services.WarmingService
+	logger         *logging.ChanneledLogger
+	perfTracker    *performance.Tracker
+}
+
+// NewCacheStatsHandlers creates cache stats handlers with injected dependencies
+func NewCacheStatsHandlers(cacheMonitor *monitoring.CachePerformanceMonitor, warmingService *services.WarmingService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *CacheStatsHandlers {
+	return &CacheStatsHandlers{
+		cacheMonitor:   cacheMonitor,
+		promCollector:  monitoring.NewPrometheusCollector(cacheMonitor),
+		warmingService: warmingService,
+		logger:         logger,
+		perfTracker:    perfTracker,
+	}
+}
+
+// GetWarmProgress handles GET /api/v1/cache/warm-progress
+// Streams cache warming progress over SSE: one JSON event per tenant warm
+// start, completion, or failure. The subscription is torn down when the
+// client disconnects.
+func (h *CacheStatsHandlers) GetWarmProgress(c *gin.Context) {
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+
+	subID, events := h.warmingService.SubscribeWarmProgress()
+	defer h.warmingService.UnsubscribeWarmProgress(subID)
+
+	fmt.Fprintf(c.Writer, ": connection established\n\n")
+	c.Writer.Flush()
+
+	clientGone := c.Request.Context().Done()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Cache().Error("Failed to encode warm progress event", "error", err)
+				return true
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			return true
+		case <-clientGone:
+			return false
+		}
+	})
+}
+
+// GetPrometheusMetrics handles GET /metrics
+// Serves cache hit ratios, eviction counters, operation latencies, and
+// warming durations in the Prometheus text exposition format. The route is
+// registered outside the tenant middleware because scrapers send no tenant
+// headers; tenant IDs appear as metric labels instead.
+func (h *CacheStatsHandlers) GetPrometheusMetrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := h.promCollector.WriteMetrics(c.Writer); err != nil {
+		h.logger.System().Error("Failed to write Prometheus metrics", "error", err)
+	}
+}
+
+// GetCacheStats handles GET /api/v1/cache/stats
+// Returns item counts, estimated memory, hit ratios, and eviction counts for
+// the requesting tenant's caches, plus the monitor's detailed health report
+// and overall metrics. With ?layer= it returns a single layer's metrics;
+// with ?tenant= it returns another tenant's metrics (the route is admin-only).
+func (h *CacheStatsHandlers) GetCacheStats(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("cache_stats_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if layerName := c.Query("layer"); layerName != "" {
+		metrics := h.cacheMonitor.GetLayerMetrics(layerName)
+		if metrics == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown cache layer: " + layerName})
+			return
+		}
+		marker.SetSuccess(true)
+		c.JSON(http.StatusOK, metrics)
+		return
+	}
+
+	if tenantID := c.Query("tenant"); tenantID != "" {
+		metrics := h.cacheMonitor.GetTenantMetrics(tenantID)
+		if metrics == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no cache metrics recorded for tenant: " + tenantID})
+			return
+		}
+		marker.SetSuccess(true)
+		c.JSON(http.StatusOK, metrics)
+		return
+	}
+
+	stats := tenantCtx.CacheManager.GetDetailedStats(tenantCtx.TenantID)
+
+	h.logger.Cache().Debug("Cache stats request completed", "tenantId", tenantCtx.TenantID, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats":        stats,
+		"healthReport": h.cacheMonitor.GetDetailedHealthReport(),
+		"overall":      h.cacheMonitor.GetOverallMetrics(),
+	})
+}
+
+// GetAllCacheStats handles GET /api/v1/cache/stats/all
+// Aggregates detailed cache stats across every tenant the cache manager has
+// seen since startup.
+func (h *CacheStatsHandlers) GetAllCacheStats(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("cache_stats_all_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	allStats := tenantCtx.CacheManager.GetAllDetailedStats()
+
+	h.logger.Cache().Debug("All-tenant cache stats request completed", "tenantId", tenantCtx.TenantID, "tenants", len(allStats), "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"generatedAt": time.Now().UTC(),
+		"tenants":     allStats,
+	})
+}
+
+// InvalidateTenantCache handles DELETE /api/v1/cache/tenant
+// Blows away the requesting tenant's entire cache (content, user state, HTML
+// chunks, and analytics) without a restart, for content migrations. With
+// ?tenant= and multi-tenant mode enabled, another tenant's cache can be
+// cleared instead. The response reports each store's item counts and
+// estimated bytes as they stood before clearing; repeat calls are harmless
+// and simply report zeros.
+func (h *CacheStatsHandlers) InvalidateTenantCache(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("cache_invalidate_tenant_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	targetTenantID := tenantCtx.TenantID
+	if tenantID := c.Query("tenant"); tenantID != "" && tenantID != targetTenantID {
+		if !config.EnableMultiTenant {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "clearing another tenant's cache requires multi-tenant mode"})
+			return
+		}
+		targetTenantID = tenantID
+	}
+
+	cleared := tenantCtx.CacheManager.GetDetailedStats(targetTenantID)
+	tenantCtx.CacheManager.InvalidateTenant(targetTenantID)
+
+	h.logger.Cache().Info("Tenant cache invalidated via API", "tenantId", targetTenantID, "requestedBy", tenantCtx.TenantID)
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId": targetTenantID,
+		"cleared":  cleared,
+	})
+}
+
+// GetCacheMetrics handles GET /api/v1/admin/cache/metrics
+// Returns the monitor's overall metrics, per-layer health report, and
+// eviction stats as one JSON document.
+func (h *CacheStatsHandlers) GetCacheMetrics(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("cache_metrics_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{
+		"overall":      h.cacheMonitor.GetOverallMetrics(),
+		"healthReport": h.cacheMonitor.GetDetailedHealthReport(),
+		"evictions":    h.cacheMonitor.GetEvictionStats(),
+	})
+}
+
+// GetTenantCacheMetrics handles GET /api/v1/admin/cache/metrics/:tenantId
+// Tenant admins can only inspect their own tenant's numbers; the path param
+// must match the tenant resolved by the tenant context middleware.
+func (h *CacheStatsHandlers) GetTenantCacheMetrics(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("tenant_cache_metrics_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	tenantID := c.Param("tenantId")
+	if tenantID != tenantCtx.TenantID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "cache metrics are only available for your own tenant"})
+		return
+	}
+
+	metrics := h.cacheMonitor.GetTenantMetrics(tenantID)
+	if metrics == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no cache metrics recorded for tenant: " + tenantID})
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, metrics)
+}
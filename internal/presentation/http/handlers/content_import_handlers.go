@@ -0,0 +1,78 @@
+// Package handlers provides HTTP handlers for bulk content import endpoints
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentImportHandlers contains HTTP handlers for bulk content import
+type ContentImportHandlers struct {
+	contentImportService *services.ContentImportService
+	logger               *logging.ChanneledLogger
+	perfTracker          *performance.Tracker
+}
+
+// NewContentImportHandlers creates content import handlers with injected dependencies
+func NewContentImportHandlers(contentImportService *services.ContentImportService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *ContentImportHandlers {
+	return &ContentImportHandlers{
+		contentImportService: contentImportService,
+		logger:               logger,
+		perfTracker:          perfTracker,
+	}
+}
+
+// PostContentImport handles POST /api/v1/admin/content/import
+// With ?dryRun=true it validates the payload and returns the per-node plan
+// without writing; otherwise it applies the same plan.
+func (h *ContentImportHandlers) PostContentImport(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("content_import_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	var payload services.ImportPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid import payload: " + err.Error()})
+		return
+	}
+
+	dryRun := c.Query("dryRun") == "true"
+
+	var plan []services.ImportPlanEntry
+	var err error
+	if dryRun {
+		plan, err = h.contentImportService.PlanImport(tenantCtx, &payload)
+	} else {
+		plan, err = h.contentImportService.ApplyImport(tenantCtx, &payload)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, entry := range plan {
+		counts[entry.Action]++
+	}
+
+	h.logger.Content().Info("Content import request completed", "tenantId", tenantCtx.TenantID, "dryRun", dryRun, "nodes", len(plan), "created", counts[services.ImportActionCreate], "overwritten", counts[services.ImportActionOverwrite], "skipped", counts[services.ImportActionSkip], "rejected", counts[services.ImportActionReject], "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"dryRun": dryRun,
+		"plan":   plan,
+		"counts": counts,
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// newContentMapFixture wires content map handlers to a cache manager holding
+// a seeded full content map, mirroring a warmed production tenant.
+func newContentMapFixture(t *testing.T) (*ContentMapHandlers, *manager.Manager) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	cacheManager.SetFullContentMap("t1", []types.FullContentMapItem{
+		{ID: "ts-1", Title: "Main", Slug: "main", Type: "TractStack"},
+		{ID: "pane-1", Title: "Hero", Slug: "hero", Type: "Pane"},
+	})
+
+	perfTracker := performance.NewTracker(nil)
+	handlers := NewContentMapHandlers(services.NewContentMapService(logger, perfTracker), nil, logger, perfTracker)
+	return handlers, cacheManager
+}
+
+func getContentMap(t *testing.T, handlers *ContentMapHandlers, cacheManager *manager.Manager, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/content/full-map", nil)
+	if ifNoneMatch != "" {
+		c.Request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	c.Set("tenant", &tenant.Context{TenantID: "t1", CacheManager: cacheManager})
+	handlers.GetContentMap(c)
+	// Flush gin's lazily-written status so 304 responses reach the recorder.
+	c.Writer.WriteHeaderNow()
+	return w
+}
+
+func TestGetContentMapServes200ThenConditional304(t *testing.T) {
+	handlers, cacheManager := newContentMapFixture(t)
+
+	// First fetch: full payload with a validator attached.
+	first := getContentMap(t, handlers, cacheManager, "")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected the first fetch to return 200, got %d", first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected the first fetch to carry an ETag")
+	}
+	var payload struct {
+		Data struct {
+			Data        []json.RawMessage `json:"data"`
+			LastUpdated int64             `json:"lastUpdated"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(first.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode the content map payload: %v", err)
+	}
+	if len(payload.Data.Data) != 2 {
+		t.Errorf("expected 2 content map items, got %d", len(payload.Data.Data))
+	}
+
+	// Replay with the validator: unchanged map short-circuits to 304.
+	second := getContentMap(t, handlers, cacheManager, etag)
+	if second.Code != http.StatusNotModified {
+		t.Fatalf("expected a matching If-None-Match to return 304, got %d", second.Code)
+	}
+	if second.Body.Len() != 0 {
+		t.Errorf("expected the 304 response to have an empty body, got %d bytes", second.Body.Len())
+	}
+}
+
+func TestGetContentMapStaleValidatorGetsFreshPayload(t *testing.T) {
+	handlers, cacheManager := newContentMapFixture(t)
+
+	first := getContentMap(t, handlers, cacheManager, "")
+	staleETag := first.Header().Get("ETag")
+
+	// A content rebuild bumps the stored ETag, so the old validator misses.
+	cacheManager.SetFullContentMap("t1", []types.FullContentMapItem{
+		{ID: "ts-1", Title: "Renamed", Slug: "main", Type: "TractStack"},
+	})
+
+	refetch := getContentMap(t, handlers, cacheManager, staleETag)
+	if refetch.Code != http.StatusOK {
+		t.Fatalf("expected a stale validator to return 200, got %d", refetch.Code)
+	}
+	if fresh := refetch.Header().Get("ETag"); fresh == "" || fresh == staleETag {
+		t.Errorf("expected a fresh ETag after the rebuild, got %q (stale %q)", fresh, staleETag)
+	}
+}
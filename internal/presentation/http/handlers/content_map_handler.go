@@ -3,31 +3,76 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
 // ContentMapHandlers contains all content map-related HTTP handlers
 type ContentMapHandlers struct {
 	contentMapService *services.ContentMapService
+	externalIDService *services.ExternalIDService
 	logger            *logging.ChanneledLogger
 	perfTracker       *performance.Tracker
 }
 
 // NewContentMapHandlers creates content map handlers with injected dependencies
-func NewContentMapHandlers(contentMapService *services.ContentMapService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *ContentMapHandlers {
+func NewContentMapHandlers(contentMapService *services.ContentMapService, externalIDService *services.ExternalIDService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *ContentMapHandlers {
 	return &ContentMapHandlers{
 		contentMapService: contentMapService,
+		externalIDService: externalIDService,
 		logger:            logger,
 		perfTracker:       perfTracker,
 	}
 }
 
+// SearchNodes handles GET /api/v1/nodes/search
+func (h *ContentMapHandlers) SearchNodes(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("search_nodes_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received node search request", "method", c.Request.Method, "path", c.Request.URL.Path)
+
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q query parameter is required"})
+		return
+	}
+
+	var nodeTypes []string
+	if typeParam := c.Query("type"); typeParam != "" {
+		nodeTypes = strings.Split(typeParam, ",")
+	}
+
+	results, err := h.contentMapService.Search(tenantCtx, query, nodeTypes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if results == nil {
+		results = []services.SearchResult{}
+	}
+
+	h.logger.Content().Info("Node search request completed", "query", query, "count", len(results), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for SearchNodes request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
 func (h *ContentMapHandlers) GetContentMap(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
 	if !exists {
@@ -54,14 +99,69 @@ func (h *ContentMapHandlers) GetContentMap(c *gin.Context) {
 		return
 	}
 
+	// Prefer the content-hash ETag stored alongside the cached map; fall back
+	// to the timestamp-derived tag when the map was served without the cache.
+	etag := tenantCtx.CacheManager.GetContentMapETag(tenantCtx.TenantID)
+	if etag == "" {
+		etag = contentMapETag(response.LastUpdated)
+	}
+	if writeETag(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	h.logger.Content().Info("Get content map request completed", "itemCount", len(response.Data), "duration", time.Since(start))
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetContentMap request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": gin.H{
-			"data":        response.Data,
-			"lastUpdated": response.LastUpdated,
-		},
-	})
+	data := gin.H{
+		"data":        response.Data,
+		"lastUpdated": response.LastUpdated,
+	}
+
+	// Optional paging over the sorted map; without limit/offset the full
+	// map is returned as before.
+	if c.Query("limit") != "" || c.Query("offset") != "" {
+		limit := config.ContentMapMaxPageSize
+		if limitStr := c.Query("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil || parsed < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+				return
+			}
+			limit = parsed
+		}
+		if limit > config.ContentMapMaxPageSize {
+			limit = config.ContentMapMaxPageSize
+		}
+
+		offset := 0
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			parsed, err := strconv.Atoi(offsetStr)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			offset = parsed
+		}
+
+		total := len(response.Data)
+		page := response.Data[min(offset, total):min(offset+limit, total)]
+		data["data"] = page
+		data["total"] = total
+		data["limit"] = limit
+		data["offset"] = offset
+	}
+
+	// Optionally include external system ID mappings (nodeId -> {system: externalId}).
+	if c.Query("includeExternalIds") == "true" {
+		externalIDs, err := h.externalIDService.GetAllMappingsByNode(tenantCtx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		data["externalIds"] = externalIDs
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": data})
 }
@@ -15,22 +15,42 @@ import (
 
 // DatabaseHandlers contains all database-related HTTP handlers
 type DatabaseHandlers struct {
-	dbService     *services.DBService
-	logger        *logging.ChanneledLogger
-	perfTracker   *performance.Tracker
-	tenantManager *tenant.Manager
+	dbService      *services.DBService
+	logger         *logging.ChanneledLogger
+	perfTracker    *performance.Tracker
+	tenantManager  *tenant.Manager
+	warmingService *services.WarmingService
 }
 
 // NewDBHandlers creates database handlers with injected dependencies
-func NewDBHandlers(dbService *services.DBService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker, tenantManager *tenant.Manager) *DatabaseHandlers {
+func NewDBHandlers(dbService *services.DBService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker, tenantManager *tenant.Manager, warmingService *services.WarmingService) *DatabaseHandlers {
 	return &DatabaseHandlers{
-		dbService:     dbService,
-		logger:        logger,
-		perfTracker:   perfTracker,
-		tenantManager: tenantManager,
+		dbService:      dbService,
+		logger:         logger,
+		perfTracker:    perfTracker,
+		tenantManager:  tenantManager,
+		warmingService: warmingService,
 	}
 }
 
+// GetReadiness handles GET /api/v1/health/ready - reports 503 until startup
+// cache warming has completed so load balancers can hold traffic while cold.
+func (h *DatabaseHandlers) GetReadiness(c *gin.Context) {
+	if h.warmingService != nil && !h.warmingService.IsWarmComplete() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":    "warming",
+			"ready":     false,
+			"timestamp": time.Now().UTC().Unix(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "ready",
+		"ready":     true,
+		"timestamp": time.Now().UTC().Unix(),
+	})
+}
+
 // GetConnectionStats handles GET /api/v1/db/stats - database connection statistics
 func (h *DatabaseHandlers) GetConnectionStats(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
@@ -209,10 +229,11 @@ func (h *DatabaseHandlers) GetGeneralHealth(c *gin.Context) {
 	if exists && tenantCtx.Status == "active" {
 		// Return healthy status in exact legacy format
 		c.JSON(http.StatusOK, gin.H{
-			"status":    "ok",
-			"healthy":   true,
-			"timestamp": time.Now().UTC().Unix(),
-			"tenantId":  tenantCtx.TenantID,
+			"status":     "ok",
+			"healthy":    true,
+			"timestamp":  time.Now().UTC().Unix(),
+			"tenantId":   tenantCtx.TenantID,
+			"warmStatus": h.warmingService.GetTenantWarmStatus(tenantCtx.TenantID),
 		})
 		return
 	}
@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -57,6 +59,17 @@ func (h *EpinetHandlers) GetAllEpinetIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllEpinetIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(epinetIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"epinetIds": page,
+			"count":     len(page),
+			"total":     len(epinetIDs),
+			"limit":     limit,
+			"offset":    offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"epinetIds": epinetIDs,
 		"count":     len(epinetIDs),
@@ -129,7 +142,7 @@ func (h *EpinetHandlers) GetEpinetByID(c *gin.Context) {
 	}
 
 	if epinetNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "epinet not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "epinet not found")
 		return
 	}
 
@@ -137,6 +150,11 @@ func (h *EpinetHandlers) GetEpinetByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetEpinetByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "epinetId", epinetID)
 
+	if writeETag(c, nodeETag(tenantCtx, epinetNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, epinetNode)
 }
 
@@ -159,6 +177,10 @@ func (h *EpinetHandlers) CreateEpinet(c *gin.Context) {
 	}
 
 	if err := h.epinetService.Create(tenantCtx, &epinet); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -199,6 +221,10 @@ func (h *EpinetHandlers) UpdateEpinet(c *gin.Context) {
 	epinet.ID = epinetID
 
 	if err := h.epinetService.Update(tenantCtx, &epinet); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -245,3 +271,75 @@ func (h *EpinetHandlers) DeleteEpinet(c *gin.Context) {
 		"epinetId": epinetID,
 	})
 }
+
+// ExportEpinet returns an epinet's full configuration as portable JSON
+func (h *EpinetHandlers) ExportEpinet(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("export_epinet_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received export epinet request", "method", c.Request.Method, "path", c.Request.URL.Path, "epinetId", c.Param("id"))
+
+	epinetID := c.Param("id")
+	if epinetID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "epinet ID is required"})
+		return
+	}
+
+	export, err := h.epinetService.Export(tenantCtx, epinetID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if export == nil {
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "epinet not found")
+		return
+	}
+
+	h.logger.Content().Info("Export epinet request completed", "epinetId", epinetID, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for ExportEpinet request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "epinetId", epinetID)
+
+	c.JSON(http.StatusOK, export)
+}
+
+// ImportEpinet creates a new epinet from a portable export payload
+func (h *EpinetHandlers) ImportEpinet(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("import_epinet_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received import epinet request", "method", c.Request.Method, "path", c.Request.URL.Path)
+
+	var export services.EpinetExport
+	if err := c.ShouldBindJSON(&export); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	epinet, err := h.epinetService.Import(tenantCtx, &export)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Content().Info("Import epinet request completed", "epinetId", epinet.ID, "title", epinet.Title, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for ImportEpinet request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "epinetId", epinet.ID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "epinet imported successfully",
+		"epinetId": epinet.ID,
+	})
+}
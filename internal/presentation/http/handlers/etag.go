@@ -0,0 +1,51 @@
+// Package handlers provides shared ETag helpers for content node endpoints
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// nodeETag derives a stable ETag for a cached content node from its ID and
+// the tenant content cache clock. Every SetPane/SetStoryFragment/etc. call
+// bumps that clock, so any content write changes the ETag automatically.
+func nodeETag(tenantCtx *tenant.Context, nodeID string) string {
+	version := tenantCtx.CacheManager.GetContentVersion(tenantCtx.TenantID)
+	sum := sha256.Sum256([]byte(nodeID + ":" + strconv.FormatInt(version.UnixNano(), 10)))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// contentMapETag derives the full content map ETag from its lastUpdated
+// timestamp, which SetFullContentMap bumps on every rebuild.
+func contentMapETag(lastUpdated int64) string {
+	return `"cmap-` + strconv.FormatInt(lastUpdated, 10) + `"`
+}
+
+// writeETag sets the ETag response header and reports whether the client's
+// If-None-Match already matches, in which case the caller should reply 304.
+// The header may carry a comma-separated candidate list, weak validators
+// (W/"..."), or "*", all of which compare against the current ETag.
+func writeETag(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newETagContext(t *testing.T, ifNoneMatch string) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	if ifNoneMatch != "" {
+		c.Request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	return c, w
+}
+
+func TestWriteETagSetsHeaderAndMissesWithoutIfNoneMatch(t *testing.T) {
+	c, w := newETagContext(t, "")
+
+	if writeETag(c, `"abc123"`) {
+		t.Error("expected no match when the client sent no If-None-Match")
+	}
+	if got := w.Header().Get("ETag"); got != `"abc123"` {
+		t.Errorf("expected ETag header %q, got %q", `"abc123"`, got)
+	}
+}
+
+func TestWriteETagMatchesForConditionalRequests(t *testing.T) {
+	cases := []struct {
+		name        string
+		ifNoneMatch string
+		match       bool
+	}{
+		{"exact match", `"abc123"`, true},
+		{"mismatch", `"def456"`, false},
+		{"candidate list", `"def456", "abc123"`, true},
+		{"weak validator", `W/"abc123"`, true},
+		{"wildcard", "*", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newETagContext(t, tc.ifNoneMatch)
+			if got := writeETag(c, `"abc123"`); got != tc.match {
+				t.Errorf("writeETag with If-None-Match %q = %v, expected %v (should yield 304: %v)", tc.ifNoneMatch, got, tc.match, tc.match)
+			}
+		})
+	}
+}
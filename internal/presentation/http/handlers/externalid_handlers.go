@@ -0,0 +1,70 @@
+// Package handlers provides HTTP handlers for external ID lookup endpoints
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// ExternalIDHandlers contains HTTP handlers for resolving external system IDs
+type ExternalIDHandlers struct {
+	externalIDService *services.ExternalIDService
+	logger            *logging.ChanneledLogger
+	perfTracker       *performance.Tracker
+}
+
+// NewExternalIDHandlers creates external ID handlers with injected dependencies
+func NewExternalIDHandlers(externalIDService *services.ExternalIDService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *ExternalIDHandlers {
+	return &ExternalIDHandlers{
+		externalIDService: externalIDService,
+		logger:            logger,
+		perfTracker:       perfTracker,
+	}
+}
+
+// LookupNode handles GET /api/v1/nodes/lookup?system=...&externalId=...
+// It resolves an external system's ID to our node ID and type.
+func (h *ExternalIDHandlers) LookupNode(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("lookup_external_id_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	system := c.Query("system")
+	externalID := c.Query("externalId")
+	if system == "" || externalID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "system and externalId query parameters are required"})
+		return
+	}
+
+	mapping, err := h.externalIDService.LookupNode(tenantCtx, system, externalID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if mapping == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no node found for external ID"})
+		return
+	}
+
+	h.logger.Content().Info("External ID lookup request completed", "system", system, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":         mapping.NodeID,
+		"type":       mapping.NodeType,
+		"system":     mapping.System,
+		"externalId": mapping.ExternalID,
+	})
+}
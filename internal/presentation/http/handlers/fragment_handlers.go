@@ -28,6 +28,28 @@ func NewFragmentHandlers(fragmentService *services.FragmentService, logger *logg
 	}
 }
 
+// GetPaneRenderFailures handles GET /api/v1/admin/panes/render-failures
+// Lists the panes currently serving the fallback placeholder because their
+// node tree failed to render, with error details and failure counts.
+func (h *FragmentHandlers) GetPaneRenderFailures(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("pane_render_failures_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	failures := tenantCtx.CacheManager.GetPaneRenderFailures(tenantCtx.TenantID)
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{
+		"failures": failures,
+		"count":    len(failures),
+	})
+}
+
 // PreviewFromPayloadRequest represents the request body for preview generation
 type PreviewFromPayloadRequest struct {
 	Panes []PreviewPaneData `json:"panes"`
@@ -3,7 +3,6 @@ package handlers
 
 import (
 	"net/http"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -13,7 +12,10 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/media"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 	"github.com/oklog/ulid/v2"
 )
@@ -72,6 +74,17 @@ func (h *ImageFileHandlers) GetAllFileIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllFileIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(fileIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"fileIds": page,
+			"count":   len(page),
+			"total":   len(fileIDs),
+			"limit":   limit,
+			"offset":  offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"fileIds": fileIDs,
 		"count":   len(fileIDs),
@@ -142,7 +155,7 @@ func (h *ImageFileHandlers) GetFileByID(c *gin.Context) {
 	}
 
 	if fileNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "file not found")
 		return
 	}
 
@@ -150,6 +163,11 @@ func (h *ImageFileHandlers) GetFileByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetFileByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "fileId", fileID)
 
+	if writeETag(c, nodeETag(tenantCtx, fileNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, fileNode)
 }
 
@@ -180,11 +198,16 @@ func (h *ImageFileHandlers) CreateFile(c *gin.Context) {
 		return
 	}
 
+	if err := tenant.CheckDiskQuota(tenantCtx.TenantID); err != nil {
+		c.JSON(http.StatusInsufficientStorage, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Generate ULID for fileId
 	fileID := ulid.Make().String()
 
 	// Get tenant's media path and create ImageProcessor
-	mediaPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantCtx.TenantID, "media")
+	mediaPath := filepath.Join(config.DataDir, "config", tenantCtx.TenantID, "media")
 	processor := media.NewImageProcessor(mediaPath)
 
 	// Process the image and generate responsive versions
@@ -342,6 +365,11 @@ func (h *ImageFileHandlers) UploadOGImage(c *gin.Context) {
 		return
 	}
 
+	if err := tenant.CheckDiskQuota(tenantCtx.TenantID); err != nil {
+		c.JSON(http.StatusInsufficientStorage, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Extract nodeID from filename (everything before first dash)
 	nodeID := req.Filename
 	if dashIndex := strings.Index(req.Filename, "-"); dashIndex != -1 {
@@ -349,7 +377,7 @@ func (h *ImageFileHandlers) UploadOGImage(c *gin.Context) {
 	}
 
 	// Get tenant's media path and create ImageProcessor
-	mediaPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantCtx.TenantID, "media")
+	mediaPath := filepath.Join(config.DataDir, "config", tenantCtx.TenantID, "media")
 	processor := media.NewImageProcessor(mediaPath)
 
 	// Process the image and generate thumbnails
@@ -411,7 +439,7 @@ func (h *ImageFileHandlers) DeleteOGImage(c *gin.Context) {
 	}
 
 	// Get tenant's media path and create ImageProcessor
-	mediaPath := filepath.Join(os.Getenv("HOME"), "t8k-go-server", "config", tenantCtx.TenantID, "media")
+	mediaPath := filepath.Join(config.DataDir, "config", tenantCtx.TenantID, "media")
 	processor := media.NewImageProcessor(mediaPath)
 
 	// Delete the image and its thumbnails
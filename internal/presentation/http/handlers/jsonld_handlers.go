@@ -0,0 +1,65 @@
+// Package handlers provides HTTP handlers for JSON-LD structured data endpoints
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// JSONLDHandlers contains HTTP handlers for structured data generation
+type JSONLDHandlers struct {
+	jsonLDService *services.JSONLDService
+	logger        *logging.ChanneledLogger
+	perfTracker   *performance.Tracker
+}
+
+// NewJSONLDHandlers creates JSON-LD handlers with injected dependencies
+func NewJSONLDHandlers(jsonLDService *services.JSONLDService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *JSONLDHandlers {
+	return &JSONLDHandlers{
+		jsonLDService: jsonLDService,
+		logger:        logger,
+		perfTracker:   perfTracker,
+	}
+}
+
+// GetStoryFragmentJSONLD handles GET /api/v1/nodes/storyfragments/slug/:slug/jsonld
+// It returns the combined schema.org graph for the storyfragment.
+func (h *JSONLDHandlers) GetStoryFragmentJSONLD(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_storyfragment_jsonld_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	slug := c.Param("slug")
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slug parameter is required"})
+		return
+	}
+
+	document, err := h.jsonLDService.GetStoryFragmentJSONLD(tenantCtx, slug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if document == nil {
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "storyfragment not found")
+		return
+	}
+
+	h.logger.Content().Info("Get storyfragment JSON-LD request completed", "slug", slug, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.Data(http.StatusOK, "application/ld+json", document)
+}
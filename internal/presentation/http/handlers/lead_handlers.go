@@ -0,0 +1,127 @@
+// Package handlers provides HTTP handlers for lead query endpoints
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLeadPageSize = 50
+	maxLeadPageSize     = 500
+)
+
+// LeadHandlers contains HTTP handlers for lead query operations
+type LeadHandlers struct {
+	sessionService *services.SessionService
+	logger         *logging.ChanneledLogger
+	perfTracker    *performance.Tracker
+}
+
+// NewLeadHandlers creates lead handlers with injected dependencies
+func NewLeadHandlers(sessionService *services.SessionService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *LeadHandlers {
+	return &LeadHandlers{
+		sessionService: sessionService,
+		logger:         logger,
+		perfTracker:    perfTracker,
+	}
+}
+
+// GetLeads handles GET /api/v1/admin/leads?persona=&limit=&offset=
+// It returns a page of leads optionally filtered by contact persona.
+func (h *LeadHandlers) GetLeads(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_leads_by_persona_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	persona := c.Query("persona")
+
+	limit := defaultLeadPageSize
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+	if limit > maxLeadPageSize {
+		limit = maxLeadPageSize
+	}
+
+	offset := 0
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = parsed
+	}
+
+	leads, total, err := h.sessionService.GetLeadsByPersona(persona, limit, offset, tenantCtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Content().Info("Get leads request completed", "tenantId", tenantCtx.TenantID, "persona", persona, "count", len(leads), "total", total, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"leads":      leads,
+		"totalCount": total,
+		"limit":      limit,
+		"offset":     offset,
+		"persona":    persona,
+	})
+}
+
+// PostConsolidateFingerprints handles POST /api/v1/admin/leads/:id/consolidate-fingerprints
+// It merges held beliefs from all of a lead's fingerprints into the most
+// recently active one so personalization is consistent across devices.
+func (h *LeadHandlers) PostConsolidateFingerprints(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("consolidate_lead_fingerprints_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	leadID := c.Param("id")
+	if leadID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lead id is required"})
+		return
+	}
+
+	canonicalID, copied, err := h.sessionService.ConsolidateLeadFingerprints(leadID, tenantCtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Auth().Info("Consolidate lead fingerprints request completed", "tenantId", tenantCtx.TenantID, "leadId", leadID, "canonicalFingerprintId", canonicalID, "beliefsCopied", copied, "duration", time.Since(start))
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"leadId":                 leadID,
+		"canonicalFingerprintId": canonicalID,
+		"beliefsCopied":          copied,
+	})
+}
@@ -0,0 +1,50 @@
+// Package handlers provides HTTP handlers for maintenance scheduler inspection
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/maintenance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandlers contains HTTP handlers for maintenance job status
+type MaintenanceHandlers struct {
+	scheduler   *maintenance.Scheduler
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewMaintenanceHandlers creates maintenance handlers with injected dependencies
+func NewMaintenanceHandlers(scheduler *maintenance.Scheduler, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *MaintenanceHandlers {
+	return &MaintenanceHandlers{
+		scheduler:   scheduler,
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// GetMaintenanceJobs handles GET /api/v1/admin/maintenance/jobs
+// It returns every registered maintenance job with its configured interval,
+// enabled state, and per-tenant last-run status.
+func (h *MaintenanceHandlers) GetMaintenanceJobs(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("get_maintenance_jobs", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	jobs := h.scheduler.Status()
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -71,6 +72,17 @@ func (h *MenuHandlers) GetAllMenuIDs(c *gin.Context) {
 
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllMenuIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(menuIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"menuIds": page,
+			"count":   len(page),
+			"total":   len(menuIDs),
+			"limit":   limit,
+			"offset":  offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"menuIds": menuIDs,
 		"count":   len(menuIDs),
@@ -142,13 +154,18 @@ func (h *MenuHandlers) GetMenuByID(c *gin.Context) {
 	}
 
 	if menuNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "menu not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "menu not found")
 		return
 	}
 
 	h.logger.Content().Info("Get menu by ID request completed", "menuId", menuID, "found", menuNode != nil, "duration", time.Since(start))
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetMenuByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "menuId", menuID)
+	if writeETag(c, nodeETag(tenantCtx, menuNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, menuNode)
 }
 
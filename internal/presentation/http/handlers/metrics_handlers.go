@@ -0,0 +1,42 @@
+// Package handlers provides HTTP handlers for observability metrics endpoints
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandlers contains HTTP handlers for exposing observability metrics
+type MetricsHandlers struct {
+	perfTracker *performance.Tracker
+	logger      *logging.ChanneledLogger
+}
+
+// NewMetricsHandlers creates metrics handlers with injected dependencies
+func NewMetricsHandlers(perfTracker *performance.Tracker, logger *logging.ChanneledLogger) *MetricsHandlers {
+	return &MetricsHandlers{
+		perfTracker: perfTracker,
+		logger:      logger,
+	}
+}
+
+// GetEndpointLatencies handles GET /api/v1/admin/metrics/endpoints
+// It returns per-route latency distributions (p50/p95/p99) for the tenant.
+func (h *MetricsHandlers) GetEndpointLatencies(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	summaries := h.perfTracker.GetEndpointLatencies(tenantCtx.TenantID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId":  tenantCtx.TenantID,
+		"endpoints": summaries,
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -82,7 +83,8 @@ func (h *MultiTenantHandlers) HandleActivateTenant(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ActivateTenant(req.Token); err != nil {
+	tenantID, err := h.service.ActivateTenant(req.Token)
+	if err != nil {
 		marker.SetError(err)
 		h.logger.System().Error("Tenant activation failed", "error", err)
 		c.JSON(http.StatusConflict, gin.H{"error": "Tenant activation failed", "details": err.Error()})
@@ -90,7 +92,36 @@ func (h *MultiTenantHandlers) HandleActivateTenant(c *gin.Context) {
 	}
 
 	marker.SetSuccess(true)
-	c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Tenant activated successfully."})
+	c.JSON(http.StatusOK, gin.H{
+		"status":     "ok",
+		"message":    "Tenant activated successfully.",
+		"tenantId":   tenantID,
+		"warmStatus": h.service.GetTenantWarmStatus(tenantID),
+	})
+}
+
+// HandleDeactivateTenant handles POST /api/v1/tenant/deactivate
+func (h *MultiTenantHandlers) HandleDeactivateTenant(c *gin.Context) {
+	marker := h.perfTracker.StartOperation("handler_deactivate_tenant", "unknown")
+	defer marker.Complete()
+
+	var req services.DeactivationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		marker.SetError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	marker.TenantID = req.TenantID
+
+	if err := h.service.DeactivateTenant(req); err != nil {
+		marker.SetError(err)
+		h.logger.System().Error("Tenant deactivation failed", "error", err, "tenantId", req.TenantID)
+		c.JSON(http.StatusConflict, gin.H{"error": "Tenant deactivation failed", "details": err.Error()})
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Tenant archived successfully."})
 }
 
 // HandleGetCapacity handles GET /api/v1/tenant/capacity
@@ -109,6 +140,53 @@ func (h *MultiTenantHandlers) HandleGetCapacity(c *gin.Context) {
 	c.JSON(http.StatusOK, capacity)
 }
 
+// HandleRotateSecrets handles POST /api/v1/admin/tenant/rotate-secrets
+func (h *MultiTenantHandlers) HandleRotateSecrets(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	marker := h.perfTracker.StartOperation("handler_rotate_tenant_secrets", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	if err := h.service.RotateTenantSecrets(tenantCtx.TenantID); err != nil {
+		marker.SetError(err)
+		h.logger.System().Error("Tenant secret rotation failed", "error", err, "tenantId", tenantCtx.TenantID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Secret rotation failed", "details": err.Error()})
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "Secrets rotated. Previous secrets remain valid until rotation is finalized.",
+	})
+}
+
+// HandleFinalizeSecretRotation handles POST /api/v1/admin/tenant/rotate-secrets/finalize
+func (h *MultiTenantHandlers) HandleFinalizeSecretRotation(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	marker := h.perfTracker.StartOperation("handler_finalize_secret_rotation", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	if err := h.service.FinalizeSecretRotation(tenantCtx.TenantID); err != nil {
+		marker.SetError(err)
+		h.logger.System().Error("Tenant secret rotation finalize failed", "error", err, "tenantId", tenantCtx.TenantID)
+		c.JSON(http.StatusConflict, gin.H{"error": "Secret rotation finalize failed", "details": err.Error()})
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "Secret rotation finalized. Previous secrets dropped."})
+}
+
 // HandleSetupInitialize handles POST /api/v1/setup/initialize
 func (h *MultiTenantHandlers) HandleSetupInitialize(c *gin.Context) {
 	marker := h.perfTracker.StartOperation("handler_setup_initialize", "default")
@@ -161,7 +239,7 @@ func (h *MultiTenantHandlers) HandleSetupInitialize(c *gin.Context) {
 	}
 
 	// Immediately activate (creates database schema, sets status to "active")
-	if err := h.service.ActivateTenant(activationToken); err != nil {
+	if _, err := h.service.ActivateTenant(activationToken); err != nil {
 		marker.SetError(err)
 		h.logger.System().Error("Setup activation failed", "error", err)
 		c.JSON(http.StatusConflict, gin.H{"error": "Activation failed", "details": err.Error()})
@@ -0,0 +1,47 @@
+// Package handlers provides shared pagination helpers for the bulk node list endpoints.
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultListPageSize applies when ?offset is given without ?limit.
+	defaultListPageSize = 100
+	// maxListPageSize caps ?limit so a single page stays bounded.
+	maxListPageSize = 1000
+)
+
+// parsePagination reads ?limit=N&offset=M for the bulk ID list endpoints.
+// paged is false when neither param is present, so callers can preserve the
+// historical return-everything behavior for existing clients.
+func parsePagination(c *gin.Context) (limit, offset int, paged bool) {
+	limitStr := c.Query("limit")
+	offsetStr := c.Query("offset")
+	if limitStr == "" && offsetStr == "" {
+		return 0, 0, false
+	}
+
+	limit = defaultListPageSize
+	if limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = min(parsed, maxListPageSize)
+		}
+	}
+	if offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed > 0 {
+			offset = parsed
+		}
+	}
+	return limit, offset, true
+}
+
+// paginateIDs returns the window of ids selected by limit/offset.
+func paginateIDs(ids []string, limit, offset int) []string {
+	if offset >= len(ids) {
+		return []string{}
+	}
+	return ids[offset:min(offset+limit, len(ids))]
+}
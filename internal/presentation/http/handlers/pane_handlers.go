@@ -2,6 +2,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -26,19 +29,26 @@ type PaneIDsRequest struct {
 	PaneIDs []string `json:"paneIds" binding:"required"`
 }
 
+// PaneSlugsRequest represents the request body for bulk slug resolution
+type PaneSlugsRequest struct {
+	Slugs []string `json:"slugs" binding:"required"`
+}
+
 // PaneHandlers contains all pane-related HTTP handlers
 type PaneHandlers struct {
-	paneService *services.PaneService
-	logger      *logging.ChanneledLogger
-	perfTracker *performance.Tracker
+	paneService       *services.PaneService
+	contentMapService *services.ContentMapService
+	logger            *logging.ChanneledLogger
+	perfTracker       *performance.Tracker
 }
 
 // NewPaneHandlers creates pane handlers with injected dependencies
-func NewPaneHandlers(paneService *services.PaneService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *PaneHandlers {
+func NewPaneHandlers(paneService *services.PaneService, contentMapService *services.ContentMapService, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *PaneHandlers {
 	return &PaneHandlers{
-		paneService: paneService,
-		logger:      logger,
-		perfTracker: perfTracker,
+		paneService:       paneService,
+		contentMapService: contentMapService,
+		logger:            logger,
+		perfTracker:       perfTracker,
 	}
 }
 
@@ -63,6 +73,17 @@ func (h *PaneHandlers) GetAllPaneIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllPaneIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(paneIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"paneIds": page,
+			"count":   len(page),
+			"total":   len(paneIDs),
+			"limit":   limit,
+			"offset":  offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"paneIds": paneIDs,
 		"count":   len(paneIDs),
@@ -108,6 +129,58 @@ func (h *PaneHandlers) GetPanesByIDs(c *gin.Context) {
 	})
 }
 
+// GetPanesBySlugs resolves a batch of slugs to panes in one round trip using
+// cache-first pattern; unknown slugs map to null
+func (h *PaneHandlers) GetPanesBySlugs(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_panes_by_slugs_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received get panes by slugs request", "method", c.Request.Method, "path", c.Request.URL.Path)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	var req PaneSlugsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	if len(req.Slugs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slugs array cannot be empty"})
+		return
+	}
+
+	if len(req.Slugs) > maxSlugBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("slugs array exceeds maximum batch size of %d", maxSlugBatchSize)})
+		return
+	}
+
+	panes, err := h.paneService.GetBySlugs(tenantCtx, req.Slugs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	foundCount := 0
+	for _, pane := range panes {
+		if pane != nil {
+			foundCount++
+		}
+	}
+
+	h.logger.Content().Info("Get panes by slugs request completed", "requestedCount", len(req.Slugs), "foundCount", foundCount, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for GetPanesBySlugs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "requestedCount", len(req.Slugs))
+
+	c.JSON(http.StatusOK, gin.H{
+		"panes": panes,
+		"count": foundCount,
+	})
+}
+
 // GetPaneByID returns a specific pane by ID using cache-first pattern
 func (h *PaneHandlers) GetPaneByID(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
@@ -133,7 +206,7 @@ func (h *PaneHandlers) GetPaneByID(c *gin.Context) {
 	}
 
 	if paneNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "pane not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "pane not found")
 		return
 	}
 
@@ -141,6 +214,11 @@ func (h *PaneHandlers) GetPaneByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetPaneByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "paneId", paneID)
 
+	if writeETag(c, nodeETag(tenantCtx, paneNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, paneNode)
 }
 
@@ -169,7 +247,7 @@ func (h *PaneHandlers) GetPaneBySlug(c *gin.Context) {
 	}
 
 	if paneNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "pane not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "pane not found")
 		return
 	}
 
@@ -177,6 +255,11 @@ func (h *PaneHandlers) GetPaneBySlug(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetPaneBySlug request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
 
+	if writeETag(c, nodeETag(tenantCtx, paneNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, paneNode)
 }
 
@@ -300,6 +383,10 @@ func (h *PaneHandlers) DeletePane(c *gin.Context) {
 	}
 
 	if err := h.paneService.Delete(tenantCtx, paneID); err != nil {
+		if errors.Is(err, services.ErrPaneStillReferenced) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -341,7 +428,7 @@ func (h *PaneHandlers) GetPaneTemplate(c *gin.Context) {
 	}
 
 	if templatePayload == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "pane not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "pane not found")
 		return
 	}
 
@@ -382,6 +469,10 @@ func (h *PaneHandlers) BulkUpdateFilePaneRelationships(c *gin.Context) {
 	}
 
 	if err := h.paneService.BulkUpdateFilePaneRelationships(tenantCtx, relationships); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -472,3 +563,46 @@ func (h *PaneHandlers) GetContextPaneFullPayload(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// GetPaneDependents returns the storyfragments referencing a pane plus the
+// number of currently cached HTML chunk variants, so editors can judge the
+// blast radius of a change before making it
+func (h *PaneHandlers) GetPaneDependents(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_pane_dependents_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received get pane dependents request", "method", c.Request.Method, "path", c.Request.URL.Path, "paneId", c.Param("id"))
+
+	paneID := c.Param("id")
+	if paneID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pane ID is required"})
+		return
+	}
+
+	dependents, err := h.contentMapService.GetPaneDependents(tenantCtx, paneID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if dependents == nil {
+		dependents = []string{}
+	}
+
+	chunkVariants := len(tenantCtx.CacheManager.GetChunksByPaneID(tenantCtx.TenantID, paneID))
+
+	h.logger.Content().Info("Get pane dependents request completed", "paneId", paneID, "dependentCount", len(dependents), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for GetPaneDependents request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "paneId", paneID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"paneId":                     paneID,
+		"referencedByStoryFragments": dependents,
+		"htmlChunkVariants":          chunkVariants,
+	})
+}
@@ -0,0 +1,43 @@
+// Package handlers provides HTTP handlers for performance snapshot endpoints
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// PerfHandlers contains HTTP handlers for performance snapshot endpoints
+type PerfHandlers struct {
+	perfSnapshotService *services.PerfSnapshotService
+	logger              *logging.ChanneledLogger
+}
+
+// NewPerfHandlers creates perf handlers with injected dependencies
+func NewPerfHandlers(perfSnapshotService *services.PerfSnapshotService, logger *logging.ChanneledLogger) *PerfHandlers {
+	return &PerfHandlers{
+		perfSnapshotService: perfSnapshotService,
+		logger:              logger,
+	}
+}
+
+// GetPerfSnapshot handles GET /api/v1/perf/snapshot
+// It returns the tenant's current latency and cache-hit snapshot for the
+// frontend's initial load decision.
+func (h *PerfHandlers) GetPerfSnapshot(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	if tenantCtx.Config != nil && tenantCtx.Config.DisablePerfHeader {
+		c.JSON(http.StatusNotFound, gin.H{"error": "performance snapshot disabled for this tenant"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.perfSnapshotService.GetSnapshot(tenantCtx))
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/gin-gonic/gin"
+)
+
+func newReadinessHandlers(t *testing.T, warmingService *services.WarmingService) *DatabaseHandlers {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+	return NewDBHandlers(nil, logger, performance.NewTracker(nil), nil, warmingService)
+}
+
+func getReadiness(t *testing.T, handlers *DatabaseHandlers) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/health/ready", nil)
+	handlers.GetReadiness(c)
+	return w
+}
+
+func TestGetReadinessGatesOnWarmCompletion(t *testing.T) {
+	warmingService := services.NewWarmingService(nil, performance.NewTracker(nil), nil, nil)
+	handlers := newReadinessHandlers(t, warmingService)
+
+	// Requests that land before warming finishes — the WAIT_FOR_WARM=false
+	// mode, where the listener binds immediately — see 503 so load balancers
+	// hold traffic.
+	cold := getReadiness(t, handlers)
+	if cold.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 before warming completes, got %d", cold.Code)
+	}
+	var body struct {
+		Status string `json:"status"`
+		Ready  bool   `json:"ready"`
+	}
+	if err := json.Unmarshal(cold.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode readiness body: %v", err)
+	}
+	if body.Ready || body.Status != "warming" {
+		t.Errorf("expected a not-ready warming body, got %+v", body)
+	}
+
+	// Once warming completes the same endpoint flips to ready.
+	warmingService.MarkWarmComplete()
+	warm := getReadiness(t, handlers)
+	if warm.Code != http.StatusOK {
+		t.Fatalf("expected 200 after warming completes, got %d", warm.Code)
+	}
+	if err := json.Unmarshal(warm.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode readiness body: %v", err)
+	}
+	if !body.Ready || body.Status != "ready" {
+		t.Errorf("expected a ready body, got %+v", body)
+	}
+}
+
+func TestGetReadinessWithoutWarmingServiceIsReady(t *testing.T) {
+	// In the WAIT_FOR_WARM=true mode the listener only binds after warmCaches
+	// returns, so a handler with no warming service to consult reports ready.
+	handlers := newReadinessHandlers(t, nil)
+	if w := getReadiness(t, handlers); w.Code != http.StatusOK {
+		t.Errorf("expected 200 without a warming service, got %d", w.Code)
+	}
+}
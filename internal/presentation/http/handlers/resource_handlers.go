@@ -3,12 +3,15 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -18,8 +21,18 @@ type ResourceIDsRequest struct {
 	ResourceIDs []string `json:"resourceIds,omitempty"`
 	Categories  []string `json:"categories,omitempty"`
 	Slugs       []string `json:"slugs,omitempty"`
+
+	// Paged alternative to explicit IDs: fetch one page of a category
+	// (sorted by ID) instead of POSTing the full ID list back.
+	Category string `json:"category,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+	Offset   int    `json:"offset,omitempty"`
 }
 
+// defaultResourcePageSize is the page size used when a paged resource request
+// does not specify a limit.
+const defaultResourcePageSize = 100
+
 // ResourceHandlers contains all resource-related HTTP handlers
 type ResourceHandlers struct {
 	resourceService *services.ResourceService
@@ -48,6 +61,78 @@ func (h *ResourceHandlers) GetAllResourceIDs(c *gin.Context) {
 		return
 	}
 
+	// Option filter mode: repeatable ?filter=key:value returns the full
+	// resource nodes whose options payload matches every filter.
+	if filterParams := c.QueryArray("filter"); len(filterParams) > 0 {
+		filters := make(map[string]string, len(filterParams))
+		for _, filterParam := range filterParams {
+			key, value, found := strings.Cut(filterParam, ":")
+			if !found || key == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "filter must be in key:value form", "filter": filterParam})
+				return
+			}
+			filters[key] = value
+		}
+
+		resources, err := h.resourceService.GetByOptionFilters(tenantCtx, filters)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		h.logger.Content().Info("Get resources by option filters request completed", "filterCount", len(filters), "count", len(resources), "duration", time.Since(start))
+		marker.SetSuccess(true)
+		h.logger.Perf().Info("Performance for GetAllResourceIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+		c.JSON(http.StatusOK, gin.H{
+			"resources": resources,
+			"count":     len(resources),
+		})
+		return
+	}
+
+	// Paged mode: any of category/slugPrefix/limit/offset switches to a
+	// stable sorted page with total and nextOffset for iteration.
+	if c.Query("category") != "" || c.Query("slugPrefix") != "" || c.Query("limit") != "" || c.Query("offset") != "" {
+		limit := defaultResourcePageSize
+		if limitStr := c.Query("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil || parsed < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+				return
+			}
+			limit = parsed
+		}
+		offset := 0
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			parsed, err := strconv.Atoi(offsetStr)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			offset = parsed
+		}
+
+		page, total, err := h.resourceService.GetIDsPage(tenantCtx, c.Query("category"), c.Query("slugPrefix"), limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		h.logger.Content().Info("Get resource ID page request completed", "pageSize", len(page), "total", total, "duration", time.Since(start))
+		marker.SetSuccess(true)
+		h.logger.Perf().Info("Performance for GetAllResourceIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+		c.JSON(http.StatusOK, gin.H{
+			"resourceIds": page,
+			"count":       len(page),
+			"total":       total,
+			"offset":      offset,
+			"nextOffset":  nextPageOffset(offset, len(page), total),
+		})
+		return
+	}
+
 	resourceIDs, err := h.resourceService.GetAllIDs(tenantCtx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -64,6 +149,16 @@ func (h *ResourceHandlers) GetAllResourceIDs(c *gin.Context) {
 	})
 }
 
+// nextPageOffset returns the offset for the following page, or nil when the
+// page reached the end of the set.
+func nextPageOffset(offset, pageLen, total int) any {
+	next := offset + pageLen
+	if pageLen == 0 || next >= total {
+		return nil
+	}
+	return next
+}
+
 // GetResourcesByIDs returns multiple resources by IDs/filters using cache-first pattern
 func (h *ResourceHandlers) GetResourcesByIDs(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
@@ -82,6 +177,38 @@ func (h *ResourceHandlers) GetResourcesByIDs(c *gin.Context) {
 		return
 	}
 
+	// Paged category mode: one page of the category's resources, with total
+	// and nextOffset so clients can iterate without the full ID list.
+	if req.Category != "" && len(req.ResourceIDs) == 0 && len(req.Categories) == 0 && len(req.Slugs) == 0 {
+		limit := req.Limit
+		if limit <= 0 {
+			limit = defaultResourcePageSize
+		}
+		pageIDs, total, err := h.resourceService.GetIDsPage(tenantCtx, req.Category, "", limit, req.Offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		resources, err := h.resourceService.GetByIDs(tenantCtx, pageIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		h.logger.Content().Info("Get resources by category page completed", "category", req.Category, "pageSize", len(resources), "total", total, "duration", time.Since(start))
+		marker.SetSuccess(true)
+		h.logger.Perf().Info("Performance for GetResourcesByIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+		c.JSON(http.StatusOK, gin.H{
+			"resources":  resources,
+			"count":      len(resources),
+			"total":      total,
+			"offset":     req.Offset,
+			"nextOffset": nextPageOffset(req.Offset, len(resources), total),
+		})
+		return
+	}
+
 	// Handle different request patterns
 	var resources []*content.ResourceNode
 	var err error
@@ -90,7 +217,7 @@ func (h *ResourceHandlers) GetResourcesByIDs(c *gin.Context) {
 		// Multi-filter request
 		resources, err = h.resourceService.GetByFilters(tenantCtx, req.ResourceIDs, req.Categories, req.Slugs)
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one filter (resourceIds, categories, or slugs) must be provided"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one filter (resourceIds, categories, slugs, or category) must be provided"})
 		return
 	}
 
@@ -134,7 +261,7 @@ func (h *ResourceHandlers) GetResourceByID(c *gin.Context) {
 	}
 
 	if resourceNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "resource not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "resource not found")
 		return
 	}
 
@@ -142,6 +269,11 @@ func (h *ResourceHandlers) GetResourceByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetResourceByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "resourceId", resourceID)
 
+	if writeETag(c, nodeETag(tenantCtx, resourceNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, resourceNode)
 }
 
@@ -170,7 +302,7 @@ func (h *ResourceHandlers) GetResourceBySlug(c *gin.Context) {
 	}
 
 	if resourceNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "resource not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "resource not found")
 		return
 	}
 
@@ -178,6 +310,11 @@ func (h *ResourceHandlers) GetResourceBySlug(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetResourceBySlug request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
 
+	if writeETag(c, nodeETag(tenantCtx, resourceNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, resourceNode)
 }
 
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+func newSSEFixture(t *testing.T) (*VisitHandlers, *tenant.Context) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	handlers := NewVisitHandlers(nil, nil, messaging.NewSSEBroadcaster(logger), logger, performance.NewTracker(nil))
+	return handlers, tenantCtx
+}
+
+func TestGetSSESendsKeepaliveWithinInterval(t *testing.T) {
+	handlers, tenantCtx := newSSEFixture(t)
+
+	origInterval := config.SSEHeartbeatIntervalSeconds
+	config.SSEHeartbeatIntervalSeconds = 1
+	t.Cleanup(func() { config.SSEHeartbeatIntervalSeconds = origInterval })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/visit/sse?sessionId=sess-keepalive&storyfragmentId=sf-1", nil).WithContext(ctx)
+	c.Set("tenant", tenantCtx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlers.GetSSE(c)
+	}()
+
+	// Hold the stream open past one heartbeat interval, then hang up.
+	time.Sleep(1500 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetSSE did not return after the client context was cancelled")
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "event: connected\n") {
+		t.Errorf("expected the initial connected event, got %q", body)
+	}
+	if !strings.Contains(body, ": keepalive\n\n") {
+		t.Errorf("expected a comment-style keepalive frame within the heartbeat interval, got %q", body)
+	}
+	if w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Errorf("expected the text/event-stream content type, got %q", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestGetSSEDeliversBroadcastFrames(t *testing.T) {
+	handlers, tenantCtx := newSSEFixture(t)
+
+	origInterval := config.SSEHeartbeatIntervalSeconds
+	config.SSEHeartbeatIntervalSeconds = 1
+	t.Cleanup(func() { config.SSEHeartbeatIntervalSeconds = origInterval })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/visit/sse?sessionId=sess-broadcast&storyfragmentId=sf-1", nil).WithContext(ctx)
+	c.Set("tenant", tenantCtx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handlers.GetSSE(c)
+	}()
+
+	// Give the handler time to subscribe before broadcasting to the session.
+	time.Sleep(100 * time.Millisecond)
+	handlers.broadcaster.BroadcastToSpecificSession("t1", "sess-broadcast", "sf-1", []string{"pane-1"}, nil)
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetSSE did not return after the client context was cancelled")
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "event: panes_updated\n") {
+		t.Errorf("expected the broadcast panes_updated frame, got %q", body)
+	}
+	if !strings.Contains(body, "pane-1") {
+		t.Errorf("expected the broadcast payload to name the updated pane, got %q", body)
+	}
+}
+
+func TestGetSSERequiresSessionAndStoryfragment(t *testing.T) {
+	handlers, tenantCtx := newSSEFixture(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/visit/sse?storyfragmentId=sf-1", nil)
+	c.Set("tenant", tenantCtx)
+	handlers.GetSSE(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a session ID, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/visit/sse?sessionId=sess-1", nil)
+	c.Set("tenant", tenantCtx)
+	handlers.GetSSE(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a storyfragment ID, got %d", w.Code)
+	}
+}
@@ -2,6 +2,8 @@
 package handlers
 
 import (
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,7 +13,10 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
@@ -38,6 +43,32 @@ func NewStateHandlers(
 	}
 }
 
+// allowFingerprintState enforces the per-fingerprint token bucket on state
+// mutations, writing a 429 with Retry-After when the bucket is empty. Known
+// (lead-linked) fingerprints bypass the limit, as do sessions that have not
+// resolved to a fingerprint yet - those are rejected downstream anyway.
+func (h *StateHandlers) allowFingerprintState(c *gin.Context, tenantCtx *tenant.Context, sessionID string) bool {
+	session, found := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID)
+	if !found || session.FingerprintID == "" {
+		return true
+	}
+	if tenantCtx.CacheManager.IsKnownFingerprint(tenantCtx.TenantID, session.FingerprintID) {
+		return true
+	}
+
+	allowed, retryAfter := tenantCtx.CacheManager.AllowFingerprintRequest(
+		tenantCtx.TenantID, session.FingerprintID, config.StateRateLimitPerMinute, config.StateRateLimitBurst)
+	if !allowed {
+		h.logger.System().Warn("Fingerprint state rate limit exceeded",
+			"tenantId", tenantCtx.TenantID,
+			"fingerprintId", session.FingerprintID,
+			"sessionId", sessionID)
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		apierr.Write(c, http.StatusTooManyRequests, apierr.CodeRateLimited, "rate limit exceeded")
+	}
+	return allowed
+}
+
 // PostState handles POST /api/v1/state - processes widget state updates and belief events with SSE broadcasting
 func (h *StateHandlers) PostState(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
@@ -55,6 +86,10 @@ func (h *StateHandlers) PostState(c *gin.Context) {
 		return
 	}
 
+	if !h.allowFingerprintState(c, tenantCtx, sessionID) {
+		return
+	}
+
 	storyFragmentID := c.GetHeader("X-StoryFragment-ID")
 	h.logger.System().Debug("🚨 UNSET DEBUG: Headers received",
 		"sessionId", sessionID,
@@ -107,6 +142,16 @@ func (h *StateHandlers) PostState(c *gin.Context) {
 			"storyFragmentId", storyFragmentID,
 			"eventCount", len(eventList))
 	}
+	if rejected := h.eventProcessor.DisallowedEvents(tenantCtx, eventList); len(rejected) > 0 {
+		h.logger.System().Warn("Rejected events failing ingestion allowlist",
+			"tenantId", tenantCtx.TenantID,
+			"sessionId", sessionID,
+			"rejected", strings.Join(rejected, ", "))
+		marker.SetSuccess(false)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Event not accepted", "rejected": rejected})
+		return
+	}
+
 	h.logger.System().Debug("🚨 UNSET DEBUG: Calling ProcessEventsWithSSE",
 		"sessionId", sessionID,
 		"storyFragmentId", storyFragmentID,
@@ -125,6 +170,94 @@ func (h *StateHandlers) PostState(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok", "tenantId": tenantCtx.TenantID})
 }
 
+// StateBatchEvent is one event in a batched state request
+type StateBatchEvent struct {
+	ID     string `json:"id" binding:"required"`
+	Type   string `json:"type" binding:"required"`
+	Verb   string `json:"verb"`
+	Object string `json:"object,omitempty"`
+}
+
+// StateBatchRequest represents the request body for batched state updates
+type StateBatchRequest struct {
+	Events     []StateBatchEvent `json:"events" binding:"required"`
+	PaneID     string            `json:"paneId,omitempty"`
+	GotoPaneID string            `json:"gotoPaneID,omitempty"`
+}
+
+// maxEventBatchSize caps how many events one batch state request may carry
+const maxEventBatchSize = 100
+
+// PostStateBatch handles POST /api/v1/state/batch - processes an array of
+// events in one request. Each event is validated and applied independently
+// (partial success allowed); belief changes across the batch produce a single
+// SSE broadcast. The response carries a per-event result array with indexes.
+func (h *StateHandlers) PostStateBatch(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("post_state_batch_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	sessionID := c.GetHeader("X-TractStack-Session-ID")
+	if sessionID == "" {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "Session ID required")
+		return
+	}
+
+	if !h.allowFingerprintState(c, tenantCtx, sessionID) {
+		return
+	}
+
+	storyFragmentID := c.GetHeader("X-StoryFragment-ID")
+
+	var req StateBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierr.WriteDetails(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	if len(req.Events) == 0 {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "events array cannot be empty")
+		return
+	}
+
+	if len(req.Events) > maxEventBatchSize {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, fmt.Sprintf("events array exceeds maximum batch size of %d", maxEventBatchSize))
+		return
+	}
+
+	eventList := make([]events.Event, len(req.Events))
+	for i, e := range req.Events {
+		eventList[i] = events.Event{
+			ID:     e.ID,
+			Type:   e.Type,
+			Verb:   e.Verb,
+			Object: e.Object,
+		}
+	}
+
+	results := h.eventProcessor.ProcessEventBatchWithSSE(tenantCtx, sessionID, storyFragmentID, eventList, req.PaneID, req.GotoPaneID, h.broadcaster)
+
+	failed := 0
+	for _, result := range results {
+		if !result.Success {
+			failed++
+		}
+	}
+
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for PostStateBatch request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "eventCount", len(eventList), "failedCount", failed)
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":  results,
+		"tenantId": tenantCtx.TenantID,
+	})
+}
+
 // convertRequestToEvent converts form data into a domain event.
 func convertRequestToEvent(req *struct {
 	BeliefID     string `form:"beliefId"`
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/types"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// newStateRateLimitFixture builds a state handler, tenant context, and cached
+// session for one fingerprint so allowFingerprintState can be driven directly.
+func newStateRateLimitFixture(t *testing.T, fingerprintID string) (*StateHandlers, *tenant.Context) {
+	t.Helper()
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	cacheManager.SetSession("t1", &types.SessionData{
+		SessionID:     "session-1",
+		FingerprintID: fingerprintID,
+		LastActivity:  time.Now().UTC(),
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+	})
+
+	h := &StateHandlers{logger: logger}
+	tenantCtx := &tenant.Context{TenantID: "t1", CacheManager: cacheManager}
+	return h, tenantCtx
+}
+
+func TestAllowFingerprintStateReturns429PastLimit(t *testing.T) {
+	origPerMinute, origBurst := config.StateRateLimitPerMinute, config.StateRateLimitBurst
+	config.StateRateLimitPerMinute = 60
+	config.StateRateLimitBurst = 2
+	defer func() {
+		config.StateRateLimitPerMinute, config.StateRateLimitBurst = origPerMinute, origBurst
+	}()
+
+	h, tenantCtx := newStateRateLimitFixture(t, "fp-1")
+	gin.SetMode(gin.TestMode)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		if !h.allowFingerprintState(c, tenantCtx, "session-1") {
+			t.Fatalf("request %d within burst: expected the fingerprint to be allowed", i+1)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if h.allowFingerprintState(c, tenantCtx, "session-1") {
+		t.Fatal("expected the fingerprint to be rate limited past its burst")
+	}
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on 429")
+	}
+}
+
+func TestAllowFingerprintStateBypassesKnownFingerprints(t *testing.T) {
+	origPerMinute, origBurst := config.StateRateLimitPerMinute, config.StateRateLimitBurst
+	config.StateRateLimitPerMinute = 60
+	config.StateRateLimitBurst = 1
+	defer func() {
+		config.StateRateLimitPerMinute, config.StateRateLimitBurst = origPerMinute, origBurst
+	}()
+
+	h, tenantCtx := newStateRateLimitFixture(t, "fp-known")
+	tenantCtx.CacheManager.SetKnownFingerprint("t1", "fp-known", true)
+	gin.SetMode(gin.TestMode)
+
+	for i := 0; i < 10; i++ {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		if !h.allowFingerprintState(c, tenantCtx, "session-1") {
+			t.Fatalf("request %d: expected a known fingerprint to bypass the limit", i+1)
+		}
+	}
+}
+
+func TestAllowFingerprintStateSkipsUnresolvedSessions(t *testing.T) {
+	h, tenantCtx := newStateRateLimitFixture(t, "fp-1")
+	gin.SetMode(gin.TestMode)
+
+	// Sessions that haven't resolved to a fingerprint are handled (and
+	// rejected) downstream; the limiter must not block them here.
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	if !h.allowFingerprintState(c, tenantCtx, "unknown-session") {
+		t.Error("expected an unresolved session to pass through the limiter")
+	}
+}
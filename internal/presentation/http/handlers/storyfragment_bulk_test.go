@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/database"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newBulkPayloadFixture seeds two storyfragments that share a tractstack, a
+// menu, and one pane, so the bulk endpoint has something to deduplicate.
+func newBulkPayloadFixture(t *testing.T) (*StoryFragmentHandlers, *tenant.Context) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := logging.DefaultLoggerConfig()
+	cfg.OutputToFile = false
+	cfg.OutputToConsole = false
+	logger, err := logging.NewChanneledLogger(cfg)
+	if err != nil {
+		t.Fatalf("failed to create test logger: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.NewTableCreator().CreateSchema(db); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	seedStatements := []string{
+		`INSERT INTO tractstacks (id, title, slug) VALUES ('ts-1', 'Main', 'main')`,
+		`INSERT INTO menus (id, title, theme, options_payload) VALUES ('menu-1', 'Main Menu', 'default', '[]')`,
+		`INSERT INTO panes (id, title, slug, options_payload, pane_type) VALUES ('pane-1', 'Hero', 'hero', '{"nodes":[{"id":"n-hero"}]}', 'standard')`,
+		`INSERT INTO panes (id, title, slug, options_payload, pane_type) VALUES ('pane-shared', 'Footer', 'footer', '{"nodes":[{"id":"n-footer"}]}', 'standard')`,
+		`INSERT INTO storyfragments (id, title, slug, tractstack_id, menu_id) VALUES ('sf-1', 'Page One', 'page-one', 'ts-1', 'menu-1')`,
+		`INSERT INTO storyfragments (id, title, slug, tractstack_id, menu_id) VALUES ('sf-2', 'Page Two', 'page-two', 'ts-1', 'menu-1')`,
+		`INSERT INTO storyfragment_panes (id, storyfragment_id, pane_id, weight) VALUES ('sp-1', 'sf-1', 'pane-1', 1)`,
+		`INSERT INTO storyfragment_panes (id, storyfragment_id, pane_id, weight) VALUES ('sp-2', 'sf-1', 'pane-shared', 2)`,
+		`INSERT INTO storyfragment_panes (id, storyfragment_id, pane_id, weight) VALUES ('sp-3', 'sf-2', 'pane-shared', 1)`,
+	}
+	for _, statement := range seedStatements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to seed content: %v", err)
+		}
+	}
+
+	cacheManager := manager.NewManager(nil)
+	cacheManager.InitializeTenant("t1")
+	tenantCtx := &tenant.Context{
+		TenantID:     "t1",
+		Config:       &tenant.Config{TenantID: "t1"},
+		Database:     &tenant.Database{Conn: db, TenantID: "t1"},
+		CacheManager: cacheManager,
+		Logger:       logger,
+	}
+	perfTracker := performance.NewTracker(nil)
+	storyFragmentService := services.NewStoryFragmentService(logger, perfTracker, services.NewContentMapService(logger, perfTracker), nil)
+	return NewStoryFragmentHandlers(storyFragmentService, nil, logger, perfTracker), tenantCtx
+}
+
+func postFullPayloads(t *testing.T, handlers *StoryFragmentHandlers, tenantCtx *tenant.Context, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/nodes/storyfragments/full-payloads", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("tenant", tenantCtx)
+	handlers.GetStoryFragmentFullPayloadsBySlugs(c)
+	return w
+}
+
+func TestGetFullPayloadsDeduplicatesSharedNodes(t *testing.T) {
+	handlers, tenantCtx := newBulkPayloadFixture(t)
+
+	// page-one is requested twice; the duplicate must not double its payload.
+	w := postFullPayloads(t, handlers, tenantCtx, `{"slugs":["page-one","page-two","page-one"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		StoryfragmentNodes []struct {
+			ID string `json:"id"`
+		} `json:"storyfragmentNodes"`
+		PaneNodes []struct {
+			ID             string         `json:"id"`
+			OptionsPayload map[string]any `json:"optionsPayload"`
+		} `json:"paneNodes"`
+		ChildNodes      []map[string]any `json:"childNodes"`
+		TractstackNodes []struct {
+			ID string `json:"id"`
+		} `json:"tractstackNodes"`
+		MenuNodes []struct {
+			ID string `json:"id"`
+		} `json:"menuNodes"`
+		Missing []string `json:"missing"`
+		Count   int      `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Count != 2 || len(resp.StoryfragmentNodes) != 2 {
+		t.Errorf("expected 2 storyfragments for 2 unique slugs, got count=%d nodes=%d", resp.Count, len(resp.StoryfragmentNodes))
+	}
+	if len(resp.TractstackNodes) != 1 {
+		t.Errorf("expected the shared tractstack once, got %d", len(resp.TractstackNodes))
+	}
+	if len(resp.MenuNodes) != 1 {
+		t.Errorf("expected the shared menu once, got %d", len(resp.MenuNodes))
+	}
+	if len(resp.PaneNodes) != 2 {
+		t.Errorf("expected pane-1 and a single copy of the shared pane, got %d panes", len(resp.PaneNodes))
+	}
+	if len(resp.Missing) != 0 {
+		t.Errorf("expected no missing slugs, got %v", resp.Missing)
+	}
+
+	// Child nodes are hoisted out of each pane exactly once and the panes ship
+	// without their embedded node trees.
+	if len(resp.ChildNodes) != 2 {
+		t.Errorf("expected one hoisted child node per unique pane, got %d", len(resp.ChildNodes))
+	}
+	for _, pane := range resp.PaneNodes {
+		if _, exists := pane.OptionsPayload["nodes"]; exists {
+			t.Errorf("expected pane %s to ship without embedded nodes", pane.ID)
+		}
+	}
+}
+
+func TestGetFullPayloadsReportsMissingSlugs(t *testing.T) {
+	handlers, tenantCtx := newBulkPayloadFixture(t)
+
+	w := postFullPayloads(t, handlers, tenantCtx, `{"slugs":["page-one","ghost","also-missing"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with partial results, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Missing []string `json:"missing"`
+		Count   int      `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Count != 1 {
+		t.Errorf("expected 1 resolved storyfragment, got %d", resp.Count)
+	}
+	if len(resp.Missing) != 2 || resp.Missing[0] != "ghost" || resp.Missing[1] != "also-missing" {
+		t.Errorf("expected the unknown slugs reported in request order, got %v", resp.Missing)
+	}
+}
+
+func TestGetFullPayloadsRejectsEmptyBatch(t *testing.T) {
+	handlers, tenantCtx := newBulkPayloadFixture(t)
+
+	if w := postFullPayloads(t, handlers, tenantCtx, `{"slugs":[]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty slug batch, got %d", w.Code)
+	}
+}
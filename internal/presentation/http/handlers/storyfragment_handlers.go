@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/media"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -21,6 +23,14 @@ type StoryFragmentIDsRequest struct {
 	StoryFragmentIDs []string `json:"storyFragmentIds" binding:"required"`
 }
 
+// StoryFragmentSlugsRequest represents the request body for bulk slug resolution
+type StoryFragmentSlugsRequest struct {
+	Slugs []string `json:"slugs" binding:"required"`
+}
+
+// maxSlugBatchSize caps how many slugs one bulk resolution request may carry
+const maxSlugBatchSize = 200
+
 // StoryFragmentHandlers contains all storyfragment-related HTTP handlers
 type StoryFragmentHandlers struct {
 	storyFragmentService *services.StoryFragmentService
@@ -61,6 +71,17 @@ func (h *StoryFragmentHandlers) GetAllStoryFragmentIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllStoryFragmentIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(storyFragmentIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"storyFragmentIds": page,
+			"count":            len(page),
+			"total":            len(storyFragmentIDs),
+			"limit":            limit,
+			"offset":           offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"storyFragmentIds": storyFragmentIDs,
 		"count":            len(storyFragmentIDs),
@@ -131,7 +152,7 @@ func (h *StoryFragmentHandlers) GetStoryFragmentByID(c *gin.Context) {
 	}
 
 	if storyFragmentNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "storyfragment not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "storyfragment not found")
 		return
 	}
 
@@ -139,6 +160,11 @@ func (h *StoryFragmentHandlers) GetStoryFragmentByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetStoryFragmentByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "storyFragmentId", storyFragmentID)
 
+	if writeETag(c, nodeETag(tenantCtx, storyFragmentNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, storyFragmentNode)
 }
 
@@ -167,7 +193,7 @@ func (h *StoryFragmentHandlers) GetStoryFragmentBySlug(c *gin.Context) {
 	}
 
 	if storyFragmentNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "storyfragment not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "storyfragment not found")
 		return
 	}
 
@@ -181,9 +207,179 @@ func (h *StoryFragmentHandlers) GetStoryFragmentBySlug(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetStoryFragmentBySlug request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
 
+	if writeETag(c, nodeETag(tenantCtx, storyFragmentNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, storyFragmentNode)
 }
 
+// GetStoryFragmentsBySlugs resolves a batch of slugs to storyfragments in one
+// round trip using cache-first pattern; unknown slugs map to null
+func (h *StoryFragmentHandlers) GetStoryFragmentsBySlugs(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_storyfragments_by_slugs_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received get story fragments by slugs request", "method", c.Request.Method, "path", c.Request.URL.Path)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	var req StoryFragmentSlugsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	if len(req.Slugs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slugs array cannot be empty"})
+		return
+	}
+
+	if len(req.Slugs) > maxSlugBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("slugs array exceeds maximum batch size of %d", maxSlugBatchSize)})
+		return
+	}
+
+	storyFragments, err := h.storyFragmentService.GetBySlugs(tenantCtx, req.Slugs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	foundCount := 0
+	for _, storyFragment := range storyFragments {
+		if storyFragment != nil {
+			foundCount++
+		}
+	}
+
+	h.logger.Content().Info("Get story fragments by slugs request completed", "requestedCount", len(req.Slugs), "foundCount", foundCount, "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for GetStoryFragmentsBySlugs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "requestedCount", len(req.Slugs))
+
+	c.JSON(http.StatusOK, gin.H{
+		"storyFragments": storyFragments,
+		"count":          foundCount,
+	})
+}
+
+// GetStoryFragmentFullPayloadsBySlugs assembles full editorial payloads for a
+// batch of slugs in one round trip, deduplicating tractstacks, menus, and
+// panes shared across storyfragments; unknown slugs are reported in "missing"
+func (h *StoryFragmentHandlers) GetStoryFragmentFullPayloadsBySlugs(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	start := time.Now()
+	marker := h.perfTracker.StartOperation("get_storyfragment_full_payloads_request", tenantCtx.TenantID)
+	defer marker.Complete()
+	h.logger.Content().Debug("Received get story fragment full payloads request", "method", c.Request.Method, "path", c.Request.URL.Path)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	var req StoryFragmentSlugsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	if len(req.Slugs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slugs array cannot be empty"})
+		return
+	}
+
+	if len(req.Slugs) > maxSlugBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("slugs array exceeds maximum batch size of %d", maxSlugBatchSize)})
+		return
+	}
+
+	storyfragmentNodes := make([]*content.StoryFragmentNode, 0, len(req.Slugs))
+	tractstackNodes := make([]*content.TractStackNode, 0)
+	menuNodes := make([]*content.MenuNode, 0)
+	paneNodes := make([]*content.PaneNode, 0)
+	allChildNodes := make([]any, 0)
+	missing := make([]string, 0)
+
+	seenSlugs := make(map[string]bool)
+	seenTractStacks := make(map[string]bool)
+	seenMenus := make(map[string]bool)
+	seenPanes := make(map[string]bool)
+
+	for _, slug := range req.Slugs {
+		if seenSlugs[slug] {
+			continue
+		}
+		seenSlugs[slug] = true
+
+		fullPayload, err := h.storyFragmentService.GetFullPayloadBySlug(tenantCtx, slug)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if fullPayload == nil || fullPayload.StoryFragment == nil {
+			missing = append(missing, slug)
+			continue
+		}
+
+		storyfragmentNodes = append(storyfragmentNodes, fullPayload.StoryFragment)
+
+		if fullPayload.TractStack != nil && !seenTractStacks[fullPayload.TractStack.ID] {
+			seenTractStacks[fullPayload.TractStack.ID] = true
+			tractstackNodes = append(tractstackNodes, fullPayload.TractStack)
+		}
+		if fullPayload.Menu != nil && !seenMenus[fullPayload.Menu.ID] {
+			seenMenus[fullPayload.Menu.ID] = true
+			menuNodes = append(menuNodes, fullPayload.Menu)
+		}
+
+		for _, pane := range fullPayload.Panes {
+			if pane == nil || seenPanes[pane.ID] {
+				continue
+			}
+			seenPanes[pane.ID] = true
+
+			// Extract child nodes from this pane's OptionsPayload
+			if pane.OptionsPayload != nil {
+				if nodes, exists := pane.OptionsPayload["nodes"]; exists {
+					if nodesArray, ok := nodes.([]any); ok {
+						allChildNodes = append(allChildNodes, nodesArray...)
+					}
+				}
+			}
+
+			// Create cleaned pane (without embedded nodes)
+			cleanedPane := *pane
+			cleanedPane.OptionsPayload = make(map[string]any)
+			if pane.OptionsPayload != nil {
+				for k, v := range pane.OptionsPayload {
+					if k != "nodes" {
+						cleanedPane.OptionsPayload[k] = v
+					}
+				}
+			}
+			paneNodes = append(paneNodes, &cleanedPane)
+		}
+	}
+
+	h.logger.Content().Info("Get story fragment full payloads request completed", "requestedCount", len(req.Slugs), "foundCount", len(storyfragmentNodes), "missingCount", len(missing), "duration", time.Since(start))
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for GetStoryFragmentFullPayloadsBySlugs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "requestedCount", len(req.Slugs))
+
+	c.JSON(http.StatusOK, gin.H{
+		"storyfragmentNodes": storyfragmentNodes,
+		"paneNodes":          paneNodes,
+		"childNodes":         allChildNodes,
+		"tractstackNodes":    tractstackNodes,
+		"menuNodes":          menuNodes,
+		"missing":            missing,
+		"count":              len(storyfragmentNodes),
+	})
+}
+
 // GetStoryFragmentFullPayloadBySlug returns a storyfragment with full editorial payload
 func (h *StoryFragmentHandlers) GetStoryFragmentFullPayloadBySlug(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
@@ -209,7 +405,7 @@ func (h *StoryFragmentHandlers) GetStoryFragmentFullPayloadBySlug(c *gin.Context
 	}
 
 	if fullPayload == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "storyfragment not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "storyfragment not found")
 		return
 	}
 
@@ -299,6 +495,11 @@ func (h *StoryFragmentHandlers) GetHomeStoryFragment(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetHomeStoryFragment request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if writeETag(c, nodeETag(tenantCtx, storyFragmentNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, storyFragmentNode)
 }
 
@@ -325,6 +526,10 @@ func (h *StoryFragmentHandlers) CreateStoryFragment(c *gin.Context) {
 		"paneIDs", sf.PaneIDs)
 
 	if err := h.storyFragmentService.Create(tenantCtx, &sf); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -366,6 +571,10 @@ func (h *StoryFragmentHandlers) UpdateStoryFragment(c *gin.Context) {
 	sf.ID = storyFragmentID
 
 	if err := h.storyFragmentService.Update(tenantCtx, &sf); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -445,7 +654,7 @@ func (h *StoryFragmentHandlers) GetStoryFragmentPersonalizedPayloadBySlug(c *gin
 	}
 
 	if storyFragment == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "storyfragment not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "storyfragment not found")
 		return
 	}
 
@@ -581,6 +790,10 @@ func (h *StoryFragmentHandlers) UpdateStoryFragmentComplete(c *gin.Context) {
 
 	// Update the storyfragment
 	if err := h.storyFragmentService.UpdateComplete(tenantCtx, &payload); err != nil {
+		if errors.Is(err, services.ErrMissingReferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/domain/entities/content"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -57,6 +58,17 @@ func (h *TractStackHandlers) GetAllTractStackIDs(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetAllTractStackIDs request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
 
+	if limit, offset, paged := parsePagination(c); paged {
+		page := paginateIDs(tractStackIDs, limit, offset)
+		c.JSON(http.StatusOK, gin.H{
+			"tractStackIds": page,
+			"count":         len(page),
+			"total":         len(tractStackIDs),
+			"limit":         limit,
+			"offset":        offset,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"tractStackIds": tractStackIDs,
 		"count":         len(tractStackIDs),
@@ -129,7 +141,7 @@ func (h *TractStackHandlers) GetTractStackByID(c *gin.Context) {
 	}
 
 	if tractStackNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "tractstack not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "tractstack not found")
 		return
 	}
 
@@ -137,6 +149,11 @@ func (h *TractStackHandlers) GetTractStackByID(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetTractStackByID request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "tractStackId", tractStackID)
 
+	if writeETag(c, nodeETag(tenantCtx, tractStackNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, tractStackNode)
 }
 
@@ -166,7 +183,7 @@ func (h *TractStackHandlers) GetTractStackBySlug(c *gin.Context) {
 	}
 
 	if tractStackNode == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "tractstack not found"})
+		apierr.Write(c, http.StatusNotFound, apierr.CodeContentNotFound, "tractstack not found")
 		return
 	}
 
@@ -174,6 +191,11 @@ func (h *TractStackHandlers) GetTractStackBySlug(c *gin.Context) {
 	marker.SetSuccess(true)
 	h.logger.Perf().Info("Performance for GetTractStackBySlug request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true, "slug", slug)
 
+	if writeETag(c, nodeETag(tenantCtx, tractStackNode.ID)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	c.JSON(http.StatusOK, tractStackNode)
 }
 
@@ -3,8 +3,11 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/security"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
 	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
@@ -100,7 +104,7 @@ func (sc *safeSSEConnection) SafeClose() bool {
 func (h *VisitHandlers) PostVisit(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
 	if !exists {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
 		return
 	}
 
@@ -113,7 +117,7 @@ func (h *VisitHandlers) PostVisit(c *gin.Context) {
 	if c.Request.ContentLength > 0 {
 		if err := c.ShouldBindJSON(&req); err != nil {
 			h.logger.Auth().Error("Visit request JSON binding failed", "tenantId", tenantCtx.TenantID, "error", err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format"})
+			apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "invalid request format")
 			return
 		}
 	}
@@ -138,7 +142,7 @@ func (h *VisitHandlers) PostVisit(c *gin.Context) {
 		"hasCloneRequest", req.TractStackSessionID != nil,
 		"hasConsent", req.Consent != nil)
 
-	result := h.sessionService.ProcessVisitRequest(&req, storyfragmentID, tenantCtx)
+	result := h.sessionService.ProcessVisitRequest(&req, storyfragmentID, c.ClientIP(), c.GetHeader("Idempotency-Key"), tenantCtx)
 
 	if !result.Success {
 		h.logger.Auth().Error("Visit processing failed",
@@ -148,16 +152,20 @@ func (h *VisitHandlers) PostVisit(c *gin.Context) {
 		marker.SetSuccess(false)
 		h.logger.Perf().Info("Performance for PostVisit request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", false)
 
-		status := http.StatusInternalServerError
+		status, code := http.StatusInternalServerError, apierr.CodeInternal
 		switch result.Error {
 		case "session ID required":
-			status = http.StatusBadRequest
+			status, code = http.StatusBadRequest, apierr.CodeInvalidRequest
 		case "invalid credentials":
-			status = http.StatusUnauthorized
+			status, code = http.StatusUnauthorized, apierr.CodeUnauthorized
 		case "failed to decrypt email", "failed to decrypt code":
-			status = http.StatusBadRequest
+			status, code = http.StatusBadRequest, apierr.CodeInvalidRequest
+		case "database unavailable":
+			// Let clients back off during a DB blip instead of hammering a 500.
+			status, code = http.StatusServiceUnavailable, apierr.CodeDatabaseUnavailable
+			c.Header("Retry-After", "30")
 		}
-		c.JSON(status, gin.H{"error": result.Error, "success": false})
+		apierr.Write(c, status, code, result.Error)
 		return
 	}
 
@@ -176,6 +184,176 @@ func (h *VisitHandlers) PostVisit(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// PostHeartbeat handles POST /api/v1/auth/heartbeat - extends a live
+// session's expiry so long-reading pages don't expire mid-visit
+func (h *VisitHandlers) PostHeartbeat(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("post_heartbeat_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	var req struct {
+		SessionID string `json:"sessionId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "sessionId required")
+		return
+	}
+
+	if !h.sessionService.ExtendSession(req.SessionID, tenantCtx) {
+		h.logger.Auth().Debug("Heartbeat for unknown or expired session", "tenantId", tenantCtx.TenantID, "sessionId", req.SessionID)
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusNotFound, apierr.CodeSessionNotFound, "session not found")
+		return
+	}
+
+	marker.SetSuccess(true)
+	c.Status(http.StatusNoContent)
+}
+
+// PostMergeFingerprints handles POST /api/v1/auth/merge-fingerprints -
+// consolidates a secondary fingerprint's history into a primary one so a
+// user's cross-device events end up under a single fingerprint
+func (h *VisitHandlers) PostMergeFingerprints(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("post_merge_fingerprints_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	var req struct {
+		PrimaryFingerprintID   string `json:"primaryFingerprintId" binding:"required"`
+		SecondaryFingerprintID string `json:"secondaryFingerprintId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "primaryFingerprintId and secondaryFingerprintId are required")
+		return
+	}
+
+	// A merge rewrites history, so it follows the profile-export rule:
+	// an admin token authorizes any merge, otherwise the caller's session
+	// must own the secondary fingerprint being folded away.
+	authorized := false
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		authorized = h.authService.ValidateAdminToken(authHeader, tenantCtx)
+	}
+	if !authorized {
+		if sessionID := c.GetHeader("X-TractStack-Session-ID"); sessionID != "" {
+			if session, ok := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID); ok && session.FingerprintID == req.SecondaryFingerprintID {
+				authorized = true
+			}
+		}
+	}
+	if !authorized {
+		h.logger.Auth().Warn("Unauthorized fingerprint merge attempt", "tenantId", tenantCtx.TenantID, "secondaryFingerprintId", req.SecondaryFingerprintID, "clientIp", c.ClientIP())
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusForbidden, apierr.CodeForbidden, "merge not authorized")
+		return
+	}
+
+	visitCount, actionCount, err := h.sessionService.MergeFingerprints(req.PrimaryFingerprintID, req.SecondaryFingerprintID, c.ClientIP(), tenantCtx)
+	if err != nil {
+		marker.SetSuccess(false)
+		switch {
+		case errors.Is(err, tenant.ErrDatabaseUnavailable):
+			c.Header("Retry-After", "30")
+			apierr.Write(c, http.StatusServiceUnavailable, apierr.CodeDatabaseUnavailable, "database unavailable")
+		case strings.Contains(err.Error(), "not found"):
+			apierr.Write(c, http.StatusNotFound, apierr.CodeFingerprintNotFound, err.Error())
+		default:
+			apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, err.Error())
+		}
+		return
+	}
+
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for PostMergeFingerprints request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"mergedVisitCount":  visitCount,
+		"mergedActionCount": actionCount,
+	})
+}
+
+// GetProfileExport handles GET /api/v1/auth/profile/export - streams every
+// row associated with a fingerprint as one JSON document for GDPR data
+// requests. Callers must either present an admin token or a session ID that
+// belongs to the fingerprint, and each fingerprint is limited to one export
+// per hour.
+func (h *VisitHandlers) GetProfileExport(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, "tenant context not found")
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("get_profile_export_request", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	fingerprintID := c.Query("fingerprintId")
+	if fingerprintID == "" {
+		apierr.Write(c, http.StatusBadRequest, apierr.CodeInvalidRequest, "fingerprintId is required")
+		return
+	}
+
+	authorized := false
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		authorized = h.authService.ValidateAdminToken(authHeader, tenantCtx)
+	}
+	if !authorized {
+		if sessionID := c.GetHeader("X-TractStack-Session-ID"); sessionID != "" {
+			if session, ok := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID); ok && session.FingerprintID == fingerprintID {
+				authorized = true
+			}
+		}
+	}
+	if !authorized {
+		h.logger.Auth().Warn("Unauthorized data export attempt", "tenantId", tenantCtx.TenantID, "fingerprintId", fingerprintID, "clientIp", c.ClientIP())
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusForbidden, apierr.CodeForbidden, "export not authorized")
+		return
+	}
+
+	allowed, retryAfter := tenantCtx.CacheManager.AllowFingerprintExport(tenantCtx.TenantID, fingerprintID)
+	if !allowed {
+		c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		marker.SetSuccess(false)
+		apierr.Write(c, http.StatusTooManyRequests, apierr.CodeRateLimited, "export limited to one per fingerprint per hour")
+		return
+	}
+
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	if err := h.sessionService.ExportFingerprintData(fingerprintID, c.ClientIP(), tenantCtx, c.Writer); err != nil {
+		marker.SetSuccess(false)
+		if c.Writer.Written() {
+			// The document is already partially on the wire; all we can do
+			// is log the truncation.
+			h.logger.System().Error("Data export aborted mid-stream", "error", err, "tenantId", tenantCtx.TenantID, "fingerprintId", fingerprintID)
+			return
+		}
+		switch {
+		case errors.Is(err, tenant.ErrDatabaseUnavailable):
+			c.Header("Retry-After", "30")
+			apierr.Write(c, http.StatusServiceUnavailable, apierr.CodeDatabaseUnavailable, "database unavailable")
+		case strings.Contains(err.Error(), "not found"):
+			apierr.Write(c, http.StatusNotFound, apierr.CodeFingerprintNotFound, err.Error())
+		default:
+			apierr.Write(c, http.StatusInternalServerError, apierr.CodeInternal, err.Error())
+		}
+		return
+	}
+
+	marker.SetSuccess(true)
+	h.logger.Perf().Info("Performance for GetProfileExport request", "duration", marker.Duration, "tenantId", tenantCtx.TenantID, "success", true)
+}
+
 func (h *VisitHandlers) GetSSE(c *gin.Context) {
 	tenantCtx, exists := middleware.GetTenantContext(c)
 	if !exists {
@@ -255,6 +433,23 @@ func (h *VisitHandlers) GetSSE(c *gin.Context) {
 			"error", err.Error())
 		return
 	}
+
+	// Replay events broadcast while the client was disconnected; browsers
+	// resend the last seen id: field as Last-Event-ID on reconnect.
+	if lastEventIDHeader := c.GetHeader("Last-Event-ID"); lastEventIDHeader != "" {
+		if lastEventID, err := strconv.ParseUint(lastEventIDHeader, 10, 64); err == nil {
+			missed := h.broadcaster.ReplaySessionEventsSince(tenantCtx.TenantID, sessionID, lastEventID)
+			for _, message := range missed {
+				if _, err := c.Writer.WriteString(message); err != nil {
+					h.logger.SSE().Error("SSE replay write failed", "tenantId", tenantCtx.TenantID, "sessionId", sessionID, "error", err.Error())
+					return
+				}
+			}
+			if len(missed) > 0 {
+				h.logger.SSE().Info("Replayed missed SSE events on reconnect", "tenantId", tenantCtx.TenantID, "sessionId", sessionID, "replayed", len(missed))
+			}
+		}
+	}
 	c.Writer.Flush()
 
 	ticker := time.NewTicker(time.Duration(config.SSEHeartbeatIntervalSeconds) * time.Second)
@@ -289,10 +484,38 @@ func (h *VisitHandlers) GetSSE(c *gin.Context) {
 			}
 			c.Writer.Flush()
 
+			if strings.HasSuffix(message, messaging.ShutdownMessage) {
+				h.logger.SSE().Info("SSE connection closing for server shutdown",
+					"tenantId", tenantCtx.TenantID,
+					"sessionId", sessionID)
+				return
+			}
+
 		case <-ticker.C:
-			heartbeat := fmt.Sprintf("event: heartbeat\ndata: {\"timestamp\":%d,\"sessionId\":\"%s\",\"tenantId\":\"%s\"}\n\n", time.Now().UTC().Unix(), sessionID, tenantCtx.TenantID)
-			if _, err := c.Writer.WriteString(heartbeat); err != nil {
-				h.logger.SSE().Error("SSE heartbeat failed",
+			// Close subscriptions whose session has gone idle (backgrounded
+			// tab) so abandoned connections don't hold goroutines and
+			// channels until the hard connection timeout. Clients reconnect
+			// on their next interaction.
+			if config.SSEInactivityTimeoutMinutes > 0 {
+				idleTimeout := time.Duration(config.SSEInactivityTimeoutMinutes) * time.Minute
+				if sessionData, found := tenantCtx.CacheManager.GetSession(tenantCtx.TenantID, sessionID); found && time.Since(sessionData.LastActivity) > idleTimeout {
+					idleMessage := fmt.Sprintf("event: idle_timeout\ndata: {\"sessionId\":\"%s\",\"idleMinutes\":%d}\n\n", sessionID, config.SSEInactivityTimeoutMinutes)
+					if _, err := c.Writer.WriteString(idleMessage); err == nil {
+						c.Writer.Flush()
+					}
+					h.logger.SSE().Info("SSE connection closing for session inactivity",
+						"tenantId", tenantCtx.TenantID,
+						"sessionId", sessionID,
+						"lastActivity", sessionData.LastActivity,
+						"idleTimeout", idleTimeout)
+					return
+				}
+			}
+
+			// Comment-style frame: keeps intermediate proxies from dropping
+			// the idle connection without EventSource dispatching an event.
+			if _, err := c.Writer.WriteString(": keepalive\n\n"); err != nil {
+				h.logger.SSE().Error("SSE keepalive failed",
 					"tenantId", tenantCtx.TenantID,
 					"sessionId", sessionID,
 					"error", err.Error())
@@ -327,7 +550,7 @@ func (h *VisitHandlers) PostProfile(c *gin.Context) {
 	}
 
 	if req.EncryptedEmail != nil && req.EncryptedCode != nil {
-		result := h.handleProfileValidation(&req, tenantCtx)
+		result := h.handleProfileValidation(&req, c.ClientIP(), tenantCtx)
 		if !result.Success {
 			c.JSON(http.StatusUnauthorized, result)
 		} else {
@@ -342,7 +565,7 @@ func (h *VisitHandlers) PostProfile(c *gin.Context) {
 	}
 
 	if req.IsUpdate {
-		result := h.handleProfileUpdate(&req, tenantCtx)
+		result := h.handleProfileUpdate(&req, c.ClientIP(), tenantCtx)
 		if !result.Success {
 			c.JSON(http.StatusUnauthorized, result)
 		} else {
@@ -364,8 +587,13 @@ func (h *VisitHandlers) PostProfile(c *gin.Context) {
 			return
 		}
 
-		sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, result.Profile, *req.SessionID)
+		sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, result.Profile, *req.SessionID, c.ClientIP())
 		if err != nil {
+			if errors.Is(err, tenant.ErrDatabaseUnavailable) {
+				c.Header("Retry-After", "30")
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "database unavailable"})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to handle profile session"})
 			return
 		}
@@ -388,7 +616,7 @@ func (h *VisitHandlers) validateEncryptedCredentials(encryptedEmail, encryptedCo
 	return h.authService.ValidateEncryptedCredentials(encryptedEmail, encryptedCode, tenantCtx)
 }
 
-func (h *VisitHandlers) handleProfileValidation(req *ProfileRequest, tenantCtx *tenant.Context) *ProfileResponse {
+func (h *VisitHandlers) handleProfileValidation(req *ProfileRequest, clientIP string, tenantCtx *tenant.Context) *ProfileResponse {
 	profile := h.validateEncryptedCredentials(*req.EncryptedEmail, *req.EncryptedCode, tenantCtx)
 	if profile == nil {
 		return &ProfileResponse{
@@ -397,7 +625,7 @@ func (h *VisitHandlers) handleProfileValidation(req *ProfileRequest, tenantCtx *
 		}
 	}
 
-	sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, profile, *req.SessionID)
+	sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, profile, *req.SessionID, clientIP)
 	if err != nil {
 		return &ProfileResponse{
 			Success: false,
@@ -435,8 +663,8 @@ func (h *VisitHandlers) handleProfileValidation(req *ProfileRequest, tenantCtx *
 	}
 }
 
-func (h *VisitHandlers) handleProfileUpdate(req *ProfileRequest, tenantCtx *tenant.Context) *ProfileResponse {
-	lead, err := h.sessionService.ValidateLeadCredentials(req.Email, req.Codeword, tenantCtx)
+func (h *VisitHandlers) handleProfileUpdate(req *ProfileRequest, clientIP string, tenantCtx *tenant.Context) *ProfileResponse {
+	lead, err := h.sessionService.ValidateLeadCredentials(req.Email, req.Codeword, clientIP, tenantCtx)
 	if err != nil {
 		return &ProfileResponse{
 			Success: false,
@@ -452,7 +680,7 @@ func (h *VisitHandlers) handleProfileUpdate(req *ProfileRequest, tenantCtx *tena
 		ShortBio:       lead.ShortBio,
 	}
 
-	sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, profile, *req.SessionID)
+	sessionResponse, err := h.sessionService.HandleProfileSession(tenantCtx, profile, *req.SessionID, clientIP)
 	if err != nil {
 		return &ProfileResponse{
 			Success: false,
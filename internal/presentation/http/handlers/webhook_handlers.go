@@ -0,0 +1,84 @@
+// Package handlers provides HTTP handlers for webhook dead-letter endpoints
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/messaging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/logging"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandlers contains HTTP handlers for webhook dead-letter management
+type WebhookHandlers struct {
+	dispatcher  *messaging.WebhookDispatcher
+	logger      *logging.ChanneledLogger
+	perfTracker *performance.Tracker
+}
+
+// NewWebhookHandlers creates webhook handlers with injected dependencies
+func NewWebhookHandlers(dispatcher *messaging.WebhookDispatcher, logger *logging.ChanneledLogger, perfTracker *performance.Tracker) *WebhookHandlers {
+	return &WebhookHandlers{
+		dispatcher:  dispatcher,
+		logger:      logger,
+		perfTracker: perfTracker,
+	}
+}
+
+// GetDeadLetterWebhooks handles GET /api/v1/admin/webhooks/dead-letter
+// Returns the requesting tenant's dead-letter buffer, oldest first.
+func (h *WebhookHandlers) GetDeadLetterWebhooks(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("webhook_dead_letter_list", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	events := h.dispatcher.DeadLetters(tenantCtx.TenantID)
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId": tenantCtx.TenantID,
+		"count":    len(events),
+		"events":   events,
+	})
+}
+
+// PostReplayWebhook handles POST /api/v1/admin/webhooks/dead-letter/:id/replay
+// Removes the event from the dead-letter buffer and re-queues it for delivery
+// with a fresh retry budget.
+func (h *WebhookHandlers) PostReplayWebhook(c *gin.Context) {
+	tenantCtx, exists := middleware.GetTenantContext(c)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "tenant context not found"})
+		return
+	}
+
+	eventID := c.Param("id")
+	if eventID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event ID is required"})
+		return
+	}
+
+	marker := h.perfTracker.StartOperation("webhook_dead_letter_replay", tenantCtx.TenantID)
+	defer marker.Complete()
+
+	if err := h.dispatcher.Replay(tenantCtx.TenantID, eventID); err != nil {
+		marker.SetError(err)
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.System().Info("Dead-letter webhook re-queued", "tenantId", tenantCtx.TenantID, "eventId", eventID)
+	marker.SetSuccess(true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "queued",
+		"eventId": eventID,
+	})
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// gzipWriterPool recycles gzip writers so per-request compression doesn't pay
+// the allocation cost of a fresh deflate state on every response.
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		w, err := gzip.NewWriterLevel(io.Discard, config.CompressionLevel)
+		if err != nil {
+			w = gzip.NewWriter(io.Discard)
+		}
+		return w
+	},
+}
+
+// gzipResponseWriter routes response body writes through a gzip writer while
+// delegating everything else to the underlying gin writer. Event-stream
+// responses are detected from the Content-Type the handler sets and passed
+// through uncompressed, since SSE endpoints aren't reliably identifiable by
+// path alone.
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	gz          *gzip.Writer
+	wroteBody   bool
+	checkedType bool
+	passthrough bool
+}
+
+// detectEventStream switches to passthrough before any bytes go out when the
+// handler declared an SSE response; once headers are written the encoding is
+// committed and the check is skipped.
+func (w *gzipResponseWriter) detectEventStream() {
+	if w.checkedType {
+		return
+	}
+	w.checkedType = true
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") && !w.Written() {
+		w.passthrough = true
+		w.Header().Del("Content-Encoding")
+		w.Header().Del("Vary")
+	}
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	w.detectEventStream()
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	w.wroteBody = true
+	return w.gz.Write(data)
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush compresses any buffered bytes before flushing the underlying writer,
+// so streaming handlers that slip past the event-stream check still deliver
+// events immediately instead of stalling in the gzip buffer.
+func (w *gzipResponseWriter) Flush() {
+	w.detectEventStream()
+	if !w.passthrough && w.wroteBody {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// CompressionMiddleware gzips response bodies for clients that advertise gzip
+// support, at the configured COMPRESSION_LEVEL. Responses with a
+// text/event-stream Content-Type are passed through uncompressed so SSE
+// flushing keeps working, and empty-bodied responses are left untouched.
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		gz := gzipWriterPool.Get().(*gzip.Writer)
+		gz.Reset(c.Writer)
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer, gz: gz}
+		c.Header("Content-Encoding", "gzip")
+		c.Header("Vary", "Accept-Encoding")
+		c.Writer = writer
+
+		defer func() {
+			if writer.wroteBody {
+				gz.Close()
+			} else {
+				// Nothing ran through the gzip writer (204s, aborted
+				// requests, event streams); drop the encoding headers when
+				// still possible instead of emitting an empty gzip frame.
+				gz.Reset(io.Discard)
+				gz.Close()
+				if !writer.passthrough && !writer.Written() {
+					c.Writer.Header().Del("Content-Encoding")
+					c.Writer.Header().Del("Vary")
+				}
+			}
+			gzipWriterPool.Put(gz)
+		}()
+
+		c.Next()
+	}
+}
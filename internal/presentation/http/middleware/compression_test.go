@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+func newCompressionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CompressionMiddleware())
+	r.GET("/json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": strings.Repeat("tractstack ", 100)})
+	})
+	r.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Writer.WriteString("data: ping\n\n")
+		c.Writer.Flush()
+	})
+	return r
+}
+
+func TestCompressionMiddlewareGzipsForAcceptingClients(t *testing.T) {
+	r := newCompressionRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/json", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected Content-Encoding gzip, got %q", got)
+	}
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("response body is not valid gzip: %v", err)
+	}
+	defer gz.Close()
+	body, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to decompress response body: %v", err)
+	}
+	if !strings.Contains(string(body), "tractstack") {
+		t.Errorf("decompressed body missing expected payload: %q", body)
+	}
+}
+
+func TestCompressionMiddlewareSkipsNonAcceptingClients(t *testing.T) {
+	r := newCompressionRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding without Accept-Encoding gzip, got %q", got)
+	}
+	if !strings.Contains(w.Body.String(), "tractstack") {
+		t.Errorf("expected plain body, got %q", w.Body.String())
+	}
+}
+
+func TestCompressionMiddlewarePassesEventStreamsThrough(t *testing.T) {
+	r := newCompressionRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected SSE response to stay uncompressed, got Content-Encoding %q", got)
+	}
+	if w.Body.String() != "data: ping\n\n" {
+		t.Errorf("expected plain SSE payload, got %q", w.Body.String())
+	}
+}
+
+func TestCompressionMiddlewareDropsHeadersOnEmptyBody(t *testing.T) {
+	r := newCompressionRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/empty", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding on empty body, got %q", got)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %d bytes", w.Body.Len())
+	}
+}
+
+func TestCompressionUsesConfiguredLevel(t *testing.T) {
+	orig := config.CompressionLevel
+	defer func() { config.CompressionLevel = orig }()
+
+	payload := bytes.Repeat([]byte("tractstack "), 200)
+	compress := func(level int) int {
+		config.CompressionLevel = level
+		gz := gzipWriterPool.New().(*gzip.Writer)
+		var buf bytes.Buffer
+		gz.Reset(&buf)
+		if _, err := gz.Write(payload); err != nil {
+			t.Fatalf("gzip write failed at level %d: %v", level, err)
+		}
+		gz.Close()
+		return buf.Len()
+	}
+
+	none := compress(gzip.NoCompression)
+	best := compress(gzip.BestCompression)
+	if best >= none {
+		t.Errorf("expected BestCompression output (%d bytes) smaller than NoCompression output (%d bytes); configured level not applied", best, none)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,10 +23,12 @@ func DomainValidationMiddleware(tenantManager *tenant.Manager) gin.HandlerFunc {
 		origin := c.GetHeader("Origin")
 		host := c.Request.Host
 
-		// Allow localhost and IPv6 development origins
-		if strings.HasPrefix(host, "localhost:") ||
-			strings.HasPrefix(host, "127.0.0.1:") ||
-			strings.HasPrefix(host, "[::1]:") {
+		// Allow localhost and IPv6 development origins unless strict mode
+		// requires every origin to match the tenant's allowed domains
+		if !config.StrictDomainValidation &&
+			(strings.HasPrefix(host, "localhost:") ||
+				strings.HasPrefix(host, "127.0.0.1:") ||
+				strings.HasPrefix(host, "[::1]:")) {
 			c.Next()
 			return
 		}
@@ -32,8 +36,7 @@ func DomainValidationMiddleware(tenantManager *tenant.Manager) gin.HandlerFunc {
 		// Get tenant context
 		tenantCtx, exists := GetTenantContext(c)
 		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "tenant context required"})
-			c.Abort()
+			apierr.Abort(c, http.StatusForbidden, apierr.CodeTenantRequired, "tenant context required")
 			return
 		}
 
@@ -49,8 +52,7 @@ func DomainValidationMiddleware(tenantManager *tenant.Manager) gin.HandlerFunc {
 
 		// Validate domain against tenant's allowed domains
 		if !tenantManager.GetDetector().ValidateDomain(tenantCtx.TenantID, domain) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "domain not allowed for tenant"})
-			c.Abort()
+			apierr.AbortWithDetails(c, http.StatusForbidden, apierr.CodeDomainNotAllowed, "domain not allowed for tenant", map[string]any{"domain": domain})
 			return
 		}
 
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
+	"github.com/gin-gonic/gin"
+)
+
+// EndpointLatencyMiddleware records request durations into per-route, per-tenant
+// latency histograms on the performance tracker. Durations are keyed by the
+// route template (c.FullPath), not the raw URL, to keep cardinality bounded.
+func EndpointLatencyMiddleware(perfTracker *performance.Tracker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			// Unmatched routes share a single bucket.
+			route = "unmatched"
+		}
+
+		tenantID := "unknown"
+		if tenantCtx, exists := GetTenantContext(c); exists {
+			tenantID = tenantCtx.TenantID
+		}
+
+		perfTracker.RecordEndpointLatency(route, tenantID, time.Since(start))
+	}
+}
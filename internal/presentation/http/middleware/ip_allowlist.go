@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// IPAllowlistMiddleware restricts a route group to the CIDR ranges configured
+// in ADMIN_IP_ALLOWLIST as defense in depth for admin surfaces. The client IP
+// is taken from gin's ClientIP, which applies trusted-proxy resolution. An
+// empty allowlist disables the restriction.
+func IPAllowlistMiddleware() gin.HandlerFunc {
+	networks := make([]*net.IPNet, 0, len(config.AdminIPAllowlist))
+	for _, cidr := range config.AdminIPAllowlist {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			// A bare IP is accepted as a /32 (or /128) for convenience.
+			if ip := net.ParseIP(cidr); ip != nil {
+				bits := 32
+				if ip.To4() == nil {
+					bits = 128
+				}
+				network = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+			} else {
+				log.Printf("Ignoring invalid ADMIN_IP_ALLOWLIST entry: %s", cidr)
+				continue
+			}
+		}
+		networks = append(networks, network)
+	}
+
+	if len(networks) == 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		clientIP := net.ParseIP(c.ClientIP())
+		if clientIP != nil {
+			for _, network := range networks {
+				if network.Contains(clientIP) {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		apierr.Abort(c, http.StatusForbidden, apierr.CodeIPNotAllowed, "access denied from this IP address")
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+func newAllowlistRouter(t *testing.T, allowlist []string) *gin.Engine {
+	t.Helper()
+	orig := config.AdminIPAllowlist
+	config.AdminIPAllowlist = allowlist
+	t.Cleanup(func() { config.AdminIPAllowlist = orig })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(IPAllowlistMiddleware())
+	r.GET("/admin", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return r
+}
+
+func doAllowlistRequest(r *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIPAllowlistAllowsClientsInsideCIDR(t *testing.T) {
+	r := newAllowlistRouter(t, []string{"10.0.0.0/24"})
+
+	if w := doAllowlistRequest(r, "10.0.0.42:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected an in-range client to pass, got %d", w.Code)
+	}
+}
+
+func TestIPAllowlistDeniesClientsOutsideCIDR(t *testing.T) {
+	r := newAllowlistRouter(t, []string{"10.0.0.0/24"})
+
+	w := doAllowlistRequest(r, "192.168.1.5:1234")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected an out-of-range client to get 403, got %d", w.Code)
+	}
+}
+
+func TestIPAllowlistAcceptsBareIPEntries(t *testing.T) {
+	r := newAllowlistRouter(t, []string{"203.0.113.7"})
+
+	if w := doAllowlistRequest(r, "203.0.113.7:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected the exact bare-IP entry to pass, got %d", w.Code)
+	}
+	if w := doAllowlistRequest(r, "203.0.113.8:1234"); w.Code != http.StatusForbidden {
+		t.Errorf("expected a neighboring IP to get 403 under a /32 entry, got %d", w.Code)
+	}
+}
+
+func TestIPAllowlistEmptyListDisablesRestriction(t *testing.T) {
+	r := newAllowlistRouter(t, nil)
+
+	if w := doAllowlistRequest(r, "198.51.100.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected any client to pass with an empty allowlist, got %d", w.Code)
+	}
+}
+
+func TestIPAllowlistIgnoresInvalidEntries(t *testing.T) {
+	// An invalid entry is skipped, leaving only the valid range active.
+	r := newAllowlistRouter(t, []string{"not-a-cidr", "10.0.0.0/24"})
+
+	if w := doAllowlistRequest(r, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected the valid range to still apply, got %d", w.Code)
+	}
+	if w := doAllowlistRequest(r, "172.16.0.1:1234"); w.Code != http.StatusForbidden {
+		t.Errorf("expected clients outside the valid range to get 403, got %d", w.Code)
+	}
+}
@@ -0,0 +1,35 @@
+// Package middleware provides HTTP middleware for the presentation layer.
+package middleware
+
+import (
+	"github.com/AtRiskMedia/tractstack-go/internal/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+// PerfHeaderName is the opt-in request and response header carrying the
+// tenant's coarse latency and cache-hit snapshot.
+const PerfHeaderName = "X-TractStack-Perf"
+
+// PerfHeaderMiddleware attaches the precomputed performance snapshot to
+// responses when the client opts in by sending the header on its request.
+// The snapshot is tenant-scoped and the header can be disabled per tenant
+// via DISABLE_PERF_HEADER in the tenant's env.json.
+func PerfHeaderMiddleware(perfSnapshotService *services.PerfSnapshotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(PerfHeaderName) == "" {
+			c.Next()
+			return
+		}
+
+		tenantCtx, exists := GetTenantContext(c)
+		if !exists || tenantCtx.Config == nil || tenantCtx.Config.DisablePerfHeader {
+			c.Next()
+			return
+		}
+
+		if header := perfSnapshotService.GetSnapshotHeader(tenantCtx); header != "" {
+			c.Header(PerfHeaderName, header)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/caching/manager"
+	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitRouter(tenantCfg *tenant.Config) (*gin.Engine, *manager.Manager) {
+	gin.SetMode(gin.TestMode)
+	cacheManager := manager.NewManager(nil)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("tenant", &tenant.Context{
+			TenantID:     "t1",
+			Config:       tenantCfg,
+			CacheManager: cacheManager,
+		})
+	})
+	r.Use(RateLimitMiddleware(cacheManager))
+	r.GET("/state", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return r, cacheManager
+}
+
+func doRateLimitRequest(r *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/state", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddlewareReturns429PastBurst(t *testing.T) {
+	r, _ := newRateLimitRouter(&tenant.Config{
+		RateLimitPerMinute: 60,
+		RateLimitBurst:     2,
+	})
+
+	for i := 0; i < 2; i++ {
+		if w := doRateLimitRequest(r, "10.0.0.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d within burst: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	w := doRateLimitRequest(r, "10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 past burst, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on 429")
+	}
+
+	// A different client IP has its own bucket and is not affected.
+	if w := doRateLimitRequest(r, "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected a fresh client IP to pass, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddlewareTenantBucketHasOwnBurst(t *testing.T) {
+	// Many distinct clients, each well inside their per-IP burst and the
+	// tenant per-minute rate, must not trip the aggregate bucket just
+	// because their combined count exceeds the small per-IP burst.
+	r, _ := newRateLimitRouter(&tenant.Config{
+		RateLimitPerMinute:       60,
+		RateLimitBurst:           2,
+		RateLimitTenantPerMinute: 600,
+	})
+
+	for i := 0; i < 20; i++ {
+		addr := "10.0.1." + string(rune('0'+i%10)) + ":1234"
+		if w := doRateLimitRequest(r, addr); w.Code != http.StatusOK {
+			t.Fatalf("request %d from a distinct in-limit client tripped the tenant bucket: %d", i+1, w.Code)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareTenantCapReturns429(t *testing.T) {
+	r, _ := newRateLimitRouter(&tenant.Config{
+		RateLimitPerMinute:       600,
+		RateLimitBurst:           100,
+		RateLimitTenantPerMinute: 60,
+		RateLimitTenantBurst:     3,
+	})
+
+	var saw429 bool
+	for i := 0; i < 5; i++ {
+		addr := "10.0.2." + string(rune('1'+i)) + ":1234"
+		if w := doRateLimitRequest(r, addr); w.Code == http.StatusTooManyRequests {
+			saw429 = true
+			if w.Header().Get("Retry-After") == "" {
+				t.Error("expected a Retry-After header on tenant-cap 429")
+			}
+			break
+		}
+	}
+	if !saw429 {
+		t.Error("expected the tenant-wide cap to return 429 once its burst is spent")
+	}
+}
+
+func TestRateLimitMiddlewareDisabledWithZeroRate(t *testing.T) {
+	origPerMinute := config.RateLimitPerMinute
+	config.RateLimitPerMinute = 0
+	defer func() { config.RateLimitPerMinute = origPerMinute }()
+
+	r, _ := newRateLimitRouter(&tenant.Config{})
+
+	// A non-positive per-minute rate disables limiting entirely.
+	for i := 0; i < 50; i++ {
+		if w := doRateLimitRequest(r, "10.0.3.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: limiter ran despite a zero per-minute rate: %d", i+1, w.Code)
+		}
+	}
+}
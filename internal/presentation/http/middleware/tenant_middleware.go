@@ -8,6 +8,7 @@ import (
 
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/observability/performance"
 	"github.com/AtRiskMedia/tractstack-go/internal/infrastructure/tenant"
+	"github.com/AtRiskMedia/tractstack-go/internal/presentation/http/apierr"
 	"github.com/gin-gonic/gin"
 )
 
@@ -36,8 +37,18 @@ func TenantMiddleware(tenantManager *tenant.Manager, perfTracker *performance.Tr
 			logger.Tenant().Warn(errMsg, "path", c.Request.URL.Path)
 			marker.SetSuccess(false)
 			marker.SetError(fmt.Errorf(errMsg))
-			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
-			c.Abort()
+			apierr.Abort(c, http.StatusBadRequest, apierr.CodeTenantRequired, errMsg)
+			return
+		}
+
+		// Archived tenants keep their config on disk but must not serve;
+		// reject before a context (and database handle) can be recreated.
+		if tenantManager.GetDetector().GetTenantStatus(tenantID) == "archived" {
+			errMsg := fmt.Sprintf("tenant '%s' has been archived", tenantID)
+			logger.Tenant().Warn(errMsg, "path", c.Request.URL.Path)
+			marker.SetSuccess(false)
+			marker.SetError(fmt.Errorf(errMsg))
+			apierr.Abort(c, http.StatusGone, apierr.CodeTenantArchived, "tenant archived")
 			return
 		}
 
@@ -60,8 +71,13 @@ func TenantMiddleware(tenantManager *tenant.Manager, perfTracker *performance.Tr
 			logger.Tenant().Error(errMsg, "error", err, "tenantId", tenantID)
 			marker.SetSuccess(false)
 			marker.SetError(err)
-			c.JSON(http.StatusNotFound, gin.H{"error": "tenant not found"})
-			c.Abort()
+			// Inactive tenants exist on disk but have not been activated;
+			// clients can act on that distinction.
+			if tenantManager.GetDetector().GetTenantStatus(tenantID) == "inactive" {
+				apierr.Abort(c, http.StatusServiceUnavailable, apierr.CodeTenantNotActivated, "tenant not activated")
+				return
+			}
+			apierr.Abort(c, http.StatusNotFound, apierr.CodeTenantNotFound, "tenant not found")
 			return
 		}
 
@@ -14,6 +14,7 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 	r := gin.Default()
 
 	r.Use(middleware.CORSMiddleware())
+	r.Use(middleware.CompressionMiddleware())
 
 	// Serve static SysOp dashboard files from the /sysop URL.
 	r.Static("/sysop", "web/sysop")
@@ -21,14 +22,16 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 
 	// Initialize handlers
 	menuHandlers := handlers.NewMenuHandlers(container.MenuService, container.Logger, container.PerfTracker)
-	paneHandlers := handlers.NewPaneHandlers(container.PaneService, container.Logger, container.PerfTracker)
+	paneHandlers := handlers.NewPaneHandlers(container.PaneService, container.ContentMapService, container.Logger, container.PerfTracker)
 	resourceHandlers := handlers.NewResourceHandlers(container.ResourceService, container.Logger, container.PerfTracker)
 	storyFragmentHandlers := handlers.NewStoryFragmentHandlers(container.StoryFragmentService, container.FragmentService, container.Logger, container.PerfTracker)
 	tractStackHandlers := handlers.NewTractStackHandlers(container.TractStackService, container.Logger, container.PerfTracker)
 	beliefHandlers := handlers.NewBeliefHandlers(container.BeliefService, container.Logger, container.PerfTracker)
 	imageFileHandlers := handlers.NewImageFileHandlers(container.ImageFileService, container.Logger, container.PerfTracker)
 	epinetHandlers := handlers.NewEpinetHandlers(container.EpinetService, container.Logger, container.PerfTracker)
-	contentMapHandlers := handlers.NewContentMapHandlers(container.ContentMapService, container.Logger, container.PerfTracker)
+	contentMapHandlers := handlers.NewContentMapHandlers(container.ContentMapService, container.ExternalIDService, container.Logger, container.PerfTracker)
+	externalIDHandlers := handlers.NewExternalIDHandlers(container.ExternalIDService, container.Logger, container.PerfTracker)
+	jsonLDHandlers := handlers.NewJSONLDHandlers(container.JSONLDService, container.Logger, container.PerfTracker)
 	orphanHandlers := handlers.NewOrphanAnalysisHandlers(container.OrphanAnalysisService, container.Logger, container.PerfTracker)
 	configHandlers := handlers.NewConfigHandlers(container.ConfigService, container.Logger, container.PerfTracker)
 	fragmentHandlers := handlers.NewFragmentHandlers(container.FragmentService, container.Logger, container.PerfTracker)
@@ -46,11 +49,20 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 	authHandlers := handlers.NewAuthHandlers(container.AuthService, container.Logger, container.PerfTracker)
 	visitHandlers := handlers.NewVisitHandlers(container.SessionService, container.AuthService, container.Broadcaster, container.Logger, container.PerfTracker)
 	stateHandlers := handlers.NewStateHandlers(container.EventProcessingService, container.Broadcaster, container.Logger, container.PerfTracker)
-	dbHandlers := handlers.NewDBHandlers(container.DBService, container.Logger, container.PerfTracker, container.TenantManager)
+	dbHandlers := handlers.NewDBHandlers(container.DBService, container.Logger, container.PerfTracker, container.TenantManager, container.WarmingService)
 	sysopHandlers := handlers.NewSysOpHandlers(container)
 	multiTenantHandlers := handlers.NewMultiTenantHandlers(container.MultiTenantService, container.Logger, container.PerfTracker)
 	aaiHandlers := handlers.NewAAIHandlers(container.Logger, container.PerfTracker)
 	tailwindHandlers := handlers.NewTailwindHandlers(container.TailwindService, container.Logger, container.PerfTracker)
+	metricsHandlers := handlers.NewMetricsHandlers(container.PerfTracker, container.Logger)
+	adminSessionHandlers := handlers.NewAdminSessionHandlers(container.AdminSessionService, container.Broadcaster, container.Logger, container.PerfTracker)
+	auditLogHandlers := handlers.NewAuditLogHandlers(container.AuditLogger, container.Logger, container.PerfTracker)
+	contentImportHandlers := handlers.NewContentImportHandlers(container.ContentImportService, container.Logger, container.PerfTracker)
+	cacheStatsHandlers := handlers.NewCacheStatsHandlers(container.CacheMonitor, container.WarmingService, container.Logger, container.PerfTracker)
+	webhookHandlers := handlers.NewWebhookHandlers(container.WebhookDispatcher, container.Logger, container.PerfTracker)
+	perfHandlers := handlers.NewPerfHandlers(container.PerfSnapshotService, container.Logger)
+	leadHandlers := handlers.NewLeadHandlers(container.SessionService, container.Logger, container.PerfTracker)
+	maintenanceHandlers := handlers.NewMaintenanceHandlers(container.MaintenanceScheduler, container.Logger, container.PerfTracker)
 
 	sysopAPI := r.Group("/api/sysop")
 	{
@@ -72,12 +84,17 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 	}
 	r.GET("/sysop-logs/stream", sysopHandlers.StreamLogs)
 
+	// Prometheus scrape endpoint (no tenant context; tenants appear as labels)
+	r.GET("/metrics", cacheStatsHandlers.GetPrometheusMetrics)
+
 	// Multi-tenant provisioning routes (conditional)
 	if config.EnableMultiTenant {
 		tenantAPI := r.Group("/api/v1/tenant")
+		tenantAPI.Use(middleware.IPAllowlistMiddleware())
 		{
 			tenantAPI.POST("/provision", multiTenantHandlers.HandleProvisionTenant)
 			tenantAPI.POST("/activation", multiTenantHandlers.HandleActivateTenant)
+			tenantAPI.POST("/deactivate", multiTenantHandlers.HandleDeactivateTenant)
 			tenantAPI.GET("/capacity", multiTenantHandlers.HandleGetCapacity)
 		}
 	}
@@ -91,6 +108,7 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 	api := r.Group("/api/v1")
 	api.Use(middleware.TenantMiddleware(container.TenantManager, container.PerfTracker))
 	api.Use(middleware.DomainValidationMiddleware(container.TenantManager))
+	api.Use(middleware.EndpointLatencyMiddleware(container.PerfTracker))
 	{
 		// Config endpoints
 		configGroup := api.Group("/config")
@@ -99,6 +117,7 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 			configGroup.GET("/brand", configHandlers.GetBrandConfig)
 
 			// Protected config endpoints
+			configGroup.Use(middleware.IPAllowlistMiddleware())
 			configGroup.Use(authHandlers.AuthMiddleware())
 			configGroup.PUT("/brand", configHandlers.UpdateBrandConfig)
 			configGroup.GET("/advanced", configHandlers.GetAdvancedConfig)
@@ -113,13 +132,24 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 			tailwindGroup.POST("/update", tailwindHandlers.UpdateTailwindCSS)
 		}
 
+		// Unauthenticated visit/state endpoints share a per-tenant,
+		// per-client-IP token bucket so one tenant's traffic spike cannot
+		// starve the rest of the process.
+		rateLimit := middleware.RateLimitMiddleware(container.CacheManager)
+
 		// Authentication and system routes
 		auth := api.Group("/auth")
+		auth.Use(rateLimit)
 		{
 			auth.POST("/visit", visitHandlers.PostVisit)
+			auth.POST("/heartbeat", visitHandlers.PostHeartbeat)
+			auth.POST("/merge-fingerprints", visitHandlers.PostMergeFingerprints)
 			auth.GET("/sse", visitHandlers.GetSSE)
 			auth.GET("/profile/decode", authHandlers.GetDecodeProfile)
+			auth.GET("/profile/export", visitHandlers.GetProfileExport)
 			auth.POST("/profile", visitHandlers.PostProfile)
+			auth.POST("/register", authHandlers.PostRegister)
+			auth.POST("/verify", authHandlers.PostVerify)
 			auth.POST("/login", authHandlers.PostLogin)
 			auth.POST("/logout", authHandlers.PostLogout)
 			auth.GET("/status", authHandlers.GetAuthStatus)
@@ -127,11 +157,18 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 		}
 
 		// State management (separate from auth)
-		api.POST("/state", stateHandlers.PostState)
+		api.POST("/state", rateLimit, stateHandlers.PostState)
+		api.POST("/state/batch", rateLimit, stateHandlers.PostStateBatch)
 
 		// General health endpoint
 		api.GET("/health", dbHandlers.GetGeneralHealth)
 
+		// Readiness gate; not-ready until startup cache warming completes
+		api.GET("/health/ready", dbHandlers.GetReadiness)
+
+		// Performance snapshot for frontend adaptive loading
+		api.GET("/perf/snapshot", perfHandlers.GetPerfSnapshot)
+
 		// Analytics endpoints
 		analytics := api.Group("/analytics")
 		if !config.ExposeAnalytics {
@@ -140,7 +177,9 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 		{
 			analytics.GET("/dashboard", analyticsHandlers.HandleDashboardAnalytics)
 			analytics.GET("/content-summary", analyticsHandlers.HandleContentSummary)
+			analytics.GET("/content/:id", analyticsHandlers.HandleContentAnalytics)
 			analytics.GET("/epinet/:id", analyticsHandlers.HandleEpinetSankey)
+			analytics.GET("/funnel/:id", analyticsHandlers.HandleEpinetFunnel)
 			analytics.GET("/storyfragments", analyticsHandlers.HandleStoryfragmentAnalytics)
 			analytics.GET("/leads", analyticsHandlers.HandleLeadMetrics)
 			analytics.GET("/all", analyticsHandlers.HandleAllAnalytics)
@@ -149,17 +188,45 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 		// Content endpoints
 		api.GET("/content/full-map", contentMapHandlers.GetContentMap)
 
+		// Cache statistics (admin token required)
+		api.GET("/cache/stats", authHandlers.AdminOnlyMiddleware(), cacheStatsHandlers.GetCacheStats)
+		api.GET("/cache/stats/all", authHandlers.AdminOnlyMiddleware(), cacheStatsHandlers.GetAllCacheStats)
+		api.DELETE("/cache/tenant", authHandlers.AdminOnlyMiddleware(), cacheStatsHandlers.InvalidateTenantCache)
+		api.GET("/cache/warm-progress", authHandlers.AdminOnlyMiddleware(), cacheStatsHandlers.GetWarmProgress)
+
 		// Admin endpoints
 		admin := api.Group("/admin")
+		admin.Use(middleware.IPAllowlistMiddleware())
 		admin.Use(authHandlers.AuthMiddleware())
 		{
 			admin.GET("/orphan-analysis", orphanHandlers.GetOrphanAnalysis)
+			admin.GET("/leads", leadHandlers.GetLeads)
 			admin.GET("/leads/download", analyticsHandlers.HandleLeadsDownload)
+			admin.GET("/metrics/endpoints", metricsHandlers.GetEndpointLatencies)
+			admin.GET("/cache/metrics", cacheStatsHandlers.GetCacheMetrics)
+			admin.GET("/cache/metrics/:tenantId", cacheStatsHandlers.GetTenantCacheMetrics)
+			admin.GET("/panes/render-failures", fragmentHandlers.GetPaneRenderFailures)
+			admin.GET("/webhooks/dead-letter", webhookHandlers.GetDeadLetterWebhooks)
+			admin.GET("/audit-log", authHandlers.AdminOnlyMiddleware(), auditLogHandlers.GetAuditLog)
+			admin.GET("/fingerprints/known", adminSessionHandlers.GetKnownFingerprints)
+			admin.GET("/maintenance/jobs", maintenanceHandlers.GetMaintenanceJobs)
+			admin.POST("/fingerprints/known", authHandlers.AdminOnlyMiddleware(), adminSessionHandlers.PostKnownFingerprint)
+			admin.POST("/webhooks/dead-letter/:id/replay", authHandlers.AdminOnlyMiddleware(), webhookHandlers.PostReplayWebhook)
 			api.POST("/aai/askLemur", authHandlers.AuthMiddleware(), aaiHandlers.PostAskLemur)
+
+			// Bulk operations require the admin role and an explicit confirm flag
+			admin.POST("/content/import", authHandlers.AdminOnlyMiddleware(), contentImportHandlers.PostContentImport)
+			admin.POST("/leads/:id/consolidate-fingerprints", authHandlers.AdminOnlyMiddleware(), leadHandlers.PostConsolidateFingerprints)
+			admin.POST("/sessions/expire", authHandlers.AdminOnlyMiddleware(), adminSessionHandlers.PostExpireSessions)
+			admin.POST("/beliefs/reset", authHandlers.AdminOnlyMiddleware(), adminSessionHandlers.PostResetBelief)
+			admin.POST("/storyfragments/:id/refresh", authHandlers.AdminOnlyMiddleware(), adminSessionHandlers.PostBroadcastRefresh)
+			admin.POST("/tenant/rotate-secrets", authHandlers.AdminOnlyMiddleware(), multiTenantHandlers.HandleRotateSecrets)
+			admin.POST("/tenant/rotate-secrets/finalize", authHandlers.AdminOnlyMiddleware(), multiTenantHandlers.HandleFinalizeSecretRotation)
 		}
 
 		// Fragment rendering endpoints
 		fragments := api.Group("/fragments")
+		fragments.Use(middleware.PerfHeaderMiddleware(container.PerfSnapshotService))
 		{
 			fragments.GET("/panes/:id", fragmentHandlers.GetPaneFragment)
 			fragments.GET("/panes/:id/static", fragmentHandlers.GetPaneFragmentStatic)
@@ -170,6 +237,10 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 		// Content nodes - ALL PUBLIC for API access
 		nodes := api.Group("/nodes")
 		{
+			// External ID resolution
+			nodes.GET("/lookup", externalIDHandlers.LookupNode)
+			nodes.GET("/search", contentMapHandlers.SearchNodes)
+
 			// Menu endpoints
 			nodes.GET("/menus", menuHandlers.GetAllMenuIDs)
 			nodes.POST("/menus", menuHandlers.GetMenusByIDs)
@@ -183,7 +254,9 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 			nodes.POST("/panes", paneHandlers.GetPanesByIDs)
 			nodes.GET("/panes/:id", paneHandlers.GetPaneByID)
 			nodes.GET("/panes/:id/template", paneHandlers.GetPaneTemplate)
+			nodes.GET("/panes/:id/dependents", paneHandlers.GetPaneDependents)
 			nodes.GET("/panes/slug/:slug", paneHandlers.GetPaneBySlug)
+			nodes.POST("/panes/slugs", paneHandlers.GetPanesBySlugs)
 			nodes.GET("/panes/context", paneHandlers.GetContextPanes)
 			nodes.POST("/panes/create", paneHandlers.CreatePane)
 			nodes.PUT("/panes/:id", paneHandlers.UpdatePane)
@@ -201,11 +274,15 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 			nodes.DELETE("/resources/:id", resourceHandlers.DeleteResource)
 
 			// Story fragment endpoints
+			perfHeader := middleware.PerfHeaderMiddleware(container.PerfSnapshotService)
 			nodes.GET("/storyfragments", storyFragmentHandlers.GetAllStoryFragmentIDs)
-			nodes.GET("/storyfragments/slug/:slug/full-payload", storyFragmentHandlers.GetStoryFragmentFullPayloadBySlug)
-			nodes.GET("/storyfragments/slug/:slug/personalized-payload", storyFragmentHandlers.GetStoryFragmentPersonalizedPayloadBySlug)
-			nodes.GET("/storyfragments/home/personalized-payload", storyFragmentHandlers.GetStoryFragmentPersonalizedPayloadBySlug)
+			nodes.GET("/storyfragments/slug/:slug/full-payload", perfHeader, storyFragmentHandlers.GetStoryFragmentFullPayloadBySlug)
+			nodes.GET("/storyfragments/slug/:slug/jsonld", jsonLDHandlers.GetStoryFragmentJSONLD)
+			nodes.GET("/storyfragments/slug/:slug/personalized-payload", perfHeader, storyFragmentHandlers.GetStoryFragmentPersonalizedPayloadBySlug)
+			nodes.GET("/storyfragments/home/personalized-payload", perfHeader, storyFragmentHandlers.GetStoryFragmentPersonalizedPayloadBySlug)
 			nodes.POST("/storyfragments", storyFragmentHandlers.GetStoryFragmentsByIDs)
+			nodes.POST("/storyfragments/slugs", storyFragmentHandlers.GetStoryFragmentsBySlugs)
+			nodes.POST("/storyfragments/full-payloads", perfHeader, storyFragmentHandlers.GetStoryFragmentFullPayloadsBySlugs)
 			nodes.GET("/storyfragments/:id", storyFragmentHandlers.GetStoryFragmentByID)
 			nodes.GET("/storyfragments/slug/:slug", storyFragmentHandlers.GetStoryFragmentBySlug)
 			nodes.GET("/storyfragments/home", storyFragmentHandlers.GetHomeStoryFragment)
@@ -248,7 +325,9 @@ func SetupRoutes(container *container.Container) *gin.Engine {
 			nodes.GET("/epinets", epinetHandlers.GetAllEpinetIDs)
 			nodes.POST("/epinets", epinetHandlers.GetEpinetsByIDs)
 			nodes.GET("/epinets/:id", epinetHandlers.GetEpinetByID)
+			nodes.GET("/epinets/:id/export", epinetHandlers.ExportEpinet)
 			nodes.POST("/epinets/create", epinetHandlers.CreateEpinet)
+			nodes.POST("/epinets/import", epinetHandlers.ImportEpinet)
 			nodes.PUT("/epinets/:id", epinetHandlers.UpdateEpinet)
 			nodes.DELETE("/epinets/:id", epinetHandlers.DeleteEpinet)
 		}
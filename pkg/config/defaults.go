@@ -3,8 +3,10 @@ package config
 
 import (
 	"bufio"
+	"compress/gzip"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -91,6 +93,30 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// clampCompressionLevel validates a configured gzip level, rejecting values
+// outside gzip's accepted range in favor of the library default.
+func clampCompressionLevel(level int) int {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		log.Printf("Config override rejected: COMPRESSION_LEVEL=%d outside gzip range [%d, %d], using default", level, gzip.HuffmanOnly, gzip.BestCompression)
+		return gzip.DefaultCompression
+	}
+	return level
+}
+
+func splitAndTrim(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
 var (
 	// Server Configuration
 	Port               string
@@ -102,9 +128,17 @@ var (
 	EnableMultiTenant bool
 
 	// Cache Configuration
-	MaxTenants           int
-	MaxMemoryMB          int
-	MaxSessionsPerTenant int
+	MaxTenants            int
+	MaxMemoryMB           int
+	MaxSessionsPerTenant  int
+	MaxSessionMemoryBytes int
+	WarmingConcurrency    int
+	EpinetWarmConcurrency int
+	PaneRenderConcurrency int
+	WaitForWarm           bool
+	EagerContentMapWarm   bool
+	PaneFallbackRender    bool
+	PaneFallbackTTL       time.Duration
 
 	// Database Pool
 	DBMaxOpenConns           int
@@ -119,23 +153,62 @@ var (
 	SSEConnectionTimeoutMinutes int
 	SSEHeartbeatIntervalSeconds int
 	SSEInactivityTimeoutMinutes int
+	SSEShutdownRetryMs          int
+
+	// Rate limiting defaults for unauthenticated state/visit endpoints;
+	// tenants can override per-tenant in their env.json.
+	RateLimitPerMinute       int
+	RateLimitBurst           int
+	RateLimitTenantPerMinute int
+	RateLimitTenantBurst     int
+	RateLimitIdleTTL         time.Duration
+	// Per-fingerprint limit on belief-state mutations; known (lead-linked)
+	// fingerprints bypass it. Zero disables it.
+	StateRateLimitPerMinute int
+	StateRateLimitBurst     int
 
 	// TTL Configuration
-	ContentCacheTTL time.Duration
-	UserStateTTL    time.Duration
+	ContentCacheTTL   time.Duration
+	UserStateTTL      time.Duration
+	IdempotencyKeyTTL time.Duration
+	// PendingLeadTTL is how long an unverified pending lead stays claimable;
+	// past it the verification code stops working and the purge job removes
+	// the row. Zero disables expiry.
+	PendingLeadTTL  time.Duration
 	HTMLChunkTTL    time.Duration
 	AnalyticsBinTTL time.Duration
-	CurrentHourTTL  time.Duration
-	LeadMetricsTTL  time.Duration
-	DashboardTTL    time.Duration
+	// AnalyticsBinGranularity is the bucket size for analytics bins;
+	// supported values are 15m, 1h (default), and 24h.
+	AnalyticsBinGranularity time.Duration
+	CurrentHourTTL          time.Duration
+	LeadMetricsTTL          time.Duration
+	DashboardTTL            time.Duration
 
 	// Cleanup Intervals
-	CleanupInterval           time.Duration
-	TenantTimeout             time.Duration
-	SSECleanupInterval        time.Duration
-	DBPoolCleanupInterval     time.Duration
-	RepositoryCleanupInterval time.Duration
-	RepositoryCleanupVerbose  bool
+	CleanupInterval            time.Duration
+	CurrentHourRefreshInterval time.Duration
+	TenantTimeout              time.Duration
+	SSECleanupInterval         time.Duration
+	DBPoolCleanupInterval      time.Duration
+	RepositoryCleanupInterval  time.Duration
+	RepositoryCleanupVerbose   bool
+
+	// Maintenance Scheduler
+	MaintenanceTickInterval            time.Duration
+	MaintenanceBinPurgeInterval        time.Duration
+	MaintenanceAnonFingerprintInterval time.Duration
+	MaintenanceAnonFingerprintMaxAge   time.Duration
+	MaintenancePendingLeadInterval     time.Duration
+
+	// Shutdown Phase Timeouts
+	ShutdownServerTimeout   time.Duration
+	ShutdownSSEDrainTimeout time.Duration
+	ShutdownFlushTimeout    time.Duration
+	ShutdownPersistTimeout  time.Duration
+	ShutdownDBCloseTimeout  time.Duration
+
+	// Cache Snapshot Persistence
+	SnapshotSaveInterval time.Duration
 
 	// Logging Configuration
 	LogVerbosity string
@@ -143,8 +216,55 @@ var (
 	// SysOp Configuration
 	SysopPassword string
 
+	// Admin Access Configuration
+	AdminIPAllowlist []string
+
+	// Domain Validation Configuration
+	StrictDomainValidation bool
+
+	// Belief Evaluation Configuration
+	BeliefEvalComplexityThreshold int
+
+	// Performance Snapshot Configuration
+	PerfSnapshotInterval time.Duration
+	PerfSnapshotWindow   time.Duration
+
+	// Content Map Configuration
+	ContentMapMaxPageSize int
+
+	// HTML Chunk Cache Configuration
+	MaxHTMLChunksPerTenant   int
+	MaxHTMLChunkMBPerTenant  int
+	HTMLCompressionThreshold int
+	CompressionLevel         int
+
+	// Per-Content-Type Cache TTL Configuration
+	TractStackCacheTTL    time.Duration
+	StoryFragmentCacheTTL time.Duration
+	PaneCacheTTL          time.Duration
+	MenuCacheTTL          time.Duration
+	ResourceCacheTTL      time.Duration
+	BeliefCacheTTL        time.Duration
+	EpinetCacheTTL        time.Duration
+	FileCacheTTL          time.Duration
+
 	// Analytics Configuration
-	ExposeAnalytics bool
+	ExposeAnalytics            bool
+	AnalyticsSampleRate        int
+	AnalyticsDefaultRangeHours int
+
+	// Storage Configuration
+	DataDir           string
+	TenantDiskQuotaMB int
+
+	// Webhook Delivery Configuration
+	WebhookMaxAttempts     int
+	WebhookRetryBackoff    time.Duration
+	WebhookTimeout         time.Duration
+	WebhookDeadLetterLimit int
+
+	// Session Backend Configuration
+	RedisURL string
 )
 
 func init() {
@@ -163,6 +283,28 @@ func init() {
 	MaxTenants = getEnvInt("MAX_TENANTS", 5)
 	MaxMemoryMB = getEnvInt("MAX_MEMORY_MB", 512)
 	MaxSessionsPerTenant = getEnvInt("MAX_SESSIONS_PER_TENANT", 5000)
+	// Soft cap on a tenant's estimated session memory; zero disables it.
+	MaxSessionMemoryBytes = getEnvInt("MAX_SESSION_MEMORY_BYTES", 0)
+	// How many tenants are cache-warmed in parallel during cold start.
+	WarmingConcurrency = getEnvInt("WARMING_CONCURRENCY", 4)
+	// How many epinets have their hourly bins built in parallel within one
+	// analytics warming batch.
+	EpinetWarmConcurrency = getEnvInt("EPINET_WARM_CONCURRENCY", 4)
+	// How many panes a single request renders in parallel; 1 is sequential.
+	PaneRenderConcurrency = getEnvInt("PANE_RENDER_CONCURRENCY", 4)
+	// Whether startup blocks on cache warming before the listener binds; when
+	// false the server accepts traffic immediately and the readiness endpoint
+	// reports not-ready until warming finishes.
+	WaitForWarm = getEnvBool("WAIT_FOR_WARM", true)
+	// Whether tenant warming builds the full content map eagerly; when false
+	// the build is deferred to the first request that needs it (singleflight
+	// keeps concurrent first requests from building it more than once).
+	EagerContentMapWarm = getEnvBool("EAGER_CONTENT_MAP_WARM", true)
+	// Whether a pane whose node tree fails to render serves a minimal
+	// placeholder (cached briefly) instead of failing the whole page.
+	PaneFallbackRender = getEnvBool("PANE_FALLBACK_RENDER", true)
+	// How long a fallback placeholder stays cached before a re-render retry.
+	PaneFallbackTTL = getEnvDuration("PANE_FALLBACK_TTL", time.Minute)
 
 	// Database Pool
 	DBMaxOpenConns = getEnvInt("DB_MAX_OPEN_CONNS", 0)
@@ -175,32 +317,150 @@ func init() {
 	MaxSessionsPerClient = getEnvInt("MAX_SESSIONS_PER_CLIENT", 10000)
 	MaxSessionConnections = getEnvInt("MAX_SESSION_CONNECTIONS", 3)
 	SSEConnectionTimeoutMinutes = getEnvInt("SSE_CONNECTION_TIMEOUT_MINUTES", 30)
-	SSEHeartbeatIntervalSeconds = getEnvInt("SSE_HEARTBEAT_INTERVAL_SECONDS", 30)
+	SSEHeartbeatIntervalSeconds = getEnvInt("SSE_HEARTBEAT_INTERVAL_SECONDS", 20)
 	SSEInactivityTimeoutMinutes = getEnvInt("SSE_INACTIVITY_TIMEOUT_MINUTES", 5)
+	SSEShutdownRetryMs = getEnvInt("SSE_SHUTDOWN_RETRY_MS", 3000)
+	RateLimitPerMinute = getEnvInt("RATE_LIMIT_PER_MINUTE", 120)
+	RateLimitBurst = getEnvInt("RATE_LIMIT_BURST", 30)
+	// Aggregate cap across all of a tenant's clients; the per-IP limit alone
+	// cannot stop a flood arriving from many addresses. Zero disables it.
+	RateLimitTenantPerMinute = getEnvInt("RATE_LIMIT_TENANT_PER_MINUTE", 0)
+	// Burst for the aggregate bucket; zero scales it from the tenant
+	// per-minute rate so many in-limit clients don't trip it together.
+	RateLimitTenantBurst = getEnvInt("RATE_LIMIT_TENANT_BURST", 0)
+	RateLimitIdleTTL = getEnvDuration("RATE_LIMIT_IDLE_TTL", 10*time.Minute)
+	StateRateLimitPerMinute = getEnvInt("STATE_RATE_LIMIT_PER_MINUTE", 60)
+	StateRateLimitBurst = getEnvInt("STATE_RATE_LIMIT_BURST", 20)
 
 	// TTL Configuration
 	ContentCacheTTL = time.Duration(getEnvInt("CONTENT_CACHE_TTL_HOURS", 24)) * time.Hour
 	UserStateTTL = time.Duration(getEnvInt("USER_STATE_TTL_HOURS", 168)) * time.Hour
+	// How long a replayed Idempotency-Key returns the stored result instead
+	// of re-running the request's side effects.
+	IdempotencyKeyTTL = getEnvDuration("IDEMPOTENCY_KEY_TTL", 10*time.Minute)
+	PendingLeadTTL = getEnvDuration("PENDING_LEAD_TTL", 24*time.Hour)
 	HTMLChunkTTL = time.Duration(getEnvInt("HTML_CHUNK_TTL_HOURS", 1)) * time.Hour
 	AnalyticsBinTTL = time.Duration(getEnvInt("ANALYTICS_BIN_TTL_DAYS", 28)) * 24 * time.Hour
+	// Finer bins (15m) suit real-time dashboards; coarser (24h) save memory.
+	AnalyticsBinGranularity = getEnvDuration("ANALYTICS_BIN_GRANULARITY", time.Hour)
 	CurrentHourTTL = time.Duration(getEnvInt("CURRENT_HOUR_TTL_MINUTES", 5)) * time.Minute
 	LeadMetricsTTL = time.Duration(getEnvInt("LEAD_METRICS_TTL_MINUTES", 5)) * time.Minute
 	DashboardTTL = time.Duration(getEnvInt("DASHBOARD_TTL_MINUTES", 10)) * time.Minute
 
 	// Cleanup Intervals
 	CleanupInterval = time.Duration(getEnvInt("CACHE_CLEANUP_INTERVAL_MINUTES", 30)) * time.Minute
+	// How often the background refresher re-warms the current-hour analytics
+	// bin for active tenants; zero disables it.
+	CurrentHourRefreshInterval = time.Duration(getEnvInt("CURRENT_HOUR_REFRESH_INTERVAL_MINUTES", 5)) * time.Minute
 	TenantTimeout = time.Duration(getEnvInt("TENANT_TIMEOUT_HOURS", 4)) * time.Hour
 	SSECleanupInterval = time.Duration(getEnvInt("SSE_CLEANUP_INTERVAL_MINUTES", 5)) * time.Minute
 	DBPoolCleanupInterval = time.Duration(getEnvInt("DB_POOL_CLEANUP_INTERVAL_MINUTES", 5)) * time.Minute
 	RepositoryCleanupInterval = time.Duration(getEnvInt("REPOSITORY_CLEANUP_INTERVAL", 30)) * time.Minute
 	RepositoryCleanupVerbose = getEnvString("REPOSITORY_CLEANUP_VERBOSE", "true") == "false"
 
+	// Maintenance Scheduler. The tick is how often due jobs are checked;
+	// each job interval can be tuned per deployment, and a zero interval
+	// disables that job entirely.
+	MaintenanceTickInterval = getEnvDuration("MAINTENANCE_TICK_INTERVAL", time.Minute)
+	MaintenanceBinPurgeInterval = getEnvDuration("MAINTENANCE_BIN_PURGE_INTERVAL", time.Hour)
+	MaintenanceAnonFingerprintInterval = getEnvDuration("MAINTENANCE_ANON_FINGERPRINT_INTERVAL", 24*time.Hour)
+	MaintenanceAnonFingerprintMaxAge = getEnvDuration("MAINTENANCE_ANON_FINGERPRINT_MAX_AGE", 90*24*time.Hour)
+	MaintenancePendingLeadInterval = getEnvDuration("MAINTENANCE_PENDING_LEAD_INTERVAL", time.Hour)
+
+	// Shutdown Phase Timeouts
+	ShutdownServerTimeout = getEnvDuration("SHUTDOWN_SERVER_TIMEOUT", 10*time.Second)
+	ShutdownSSEDrainTimeout = getEnvDuration("SHUTDOWN_SSE_DRAIN_TIMEOUT", 5*time.Second)
+	ShutdownFlushTimeout = getEnvDuration("SHUTDOWN_FLUSH_TIMEOUT", 5*time.Second)
+	ShutdownPersistTimeout = getEnvDuration("SHUTDOWN_PERSIST_TIMEOUT", 10*time.Second)
+	ShutdownDBCloseTimeout = getEnvDuration("SHUTDOWN_DB_CLOSE_TIMEOUT", 5*time.Second)
+
+	// How often cache snapshots are written to disk while running, so a crash
+	// loses at most one interval of warmed analytics. Zero disables periodic
+	// saves; graceful shutdown still persists.
+	SnapshotSaveInterval = getEnvDuration("CACHE_SNAPSHOT_INTERVAL", 15*time.Minute)
+
 	// Logging Configuration
 	LogVerbosity = getEnvString("LOG_VERBOSITY", "WARN")
 
 	// SysOp Configuration
 	SysopPassword = getEnvString("SYSOP_PASSWORD", "storykeep")
 
+	// Admin Access Configuration
+	// Comma-separated CIDR ranges; empty means no IP restriction.
+	AdminIPAllowlist = splitAndTrim(getEnvString("ADMIN_IP_ALLOWLIST", ""))
+
+	// Domain Validation Configuration
+	// When enabled, localhost origins are validated like any other domain.
+	StrictDomainValidation = getEnvBool("STRICT_DOMAIN_VALIDATION", false)
+
+	// Belief Evaluation Configuration
+	// Storyfragments whose total per-render belief comparisons exceed this
+	// are flagged in the logs so editors know the page is getting expensive.
+	BeliefEvalComplexityThreshold = getEnvInt("BELIEF_EVAL_COMPLEXITY_THRESHOLD", 250)
+
+	// Performance Snapshot Configuration
+	PerfSnapshotInterval = getEnvDuration("PERF_SNAPSHOT_INTERVAL", 5*time.Second)
+	PerfSnapshotWindow = getEnvDuration("PERF_SNAPSHOT_WINDOW", 5*time.Minute)
+
+	// Content Map Configuration
+	// Caps the page size clients can request on the full content map endpoint.
+	ContentMapMaxPageSize = getEnvInt("CONTENT_MAP_MAX_PAGE_SIZE", 1000)
+
+	// HTML Chunk Cache Configuration
+	// Per-tenant chunk count and total size caps; least-recently-used chunks
+	// are evicted once a tenant exceeds either. Zero disables that limit.
+	MaxHTMLChunksPerTenant = getEnvInt("MAX_HTML_CHUNKS_PER_TENANT", 5000)
+	MaxHTMLChunkMBPerTenant = getEnvInt("MAX_HTML_CHUNK_MB_PER_TENANT", 64)
+	// Chunks larger than this many bytes are gzip-compressed in memory;
+	// zero disables compression.
+	HTMLCompressionThreshold = getEnvInt("HTML_COMPRESSION_THRESHOLD_BYTES", 4096)
+	// Gzip level shared by precompressed HTML chunks and on-the-fly
+	// response compression; precompressed bodies can afford a higher level
+	// while per-request compression should favor speed. Levels outside
+	// gzip's range are rejected in favor of the library default.
+	CompressionLevel = clampCompressionLevel(getEnvInt("COMPRESSION_LEVEL", gzip.DefaultCompression))
+
+	// Per-Content-Type Cache TTL Configuration
+	// Each type falls back to the global ContentCacheTTL so the existing
+	// CONTENT_CACHE_TTL_HOURS override still covers every type.
+	TractStackCacheTTL = getEnvDuration("TRACTSTACK_CACHE_TTL", ContentCacheTTL)
+	StoryFragmentCacheTTL = getEnvDuration("STORYFRAGMENT_CACHE_TTL", ContentCacheTTL)
+	PaneCacheTTL = getEnvDuration("PANE_CACHE_TTL", ContentCacheTTL)
+	MenuCacheTTL = getEnvDuration("MENU_CACHE_TTL", ContentCacheTTL)
+	ResourceCacheTTL = getEnvDuration("RESOURCE_CACHE_TTL", ContentCacheTTL)
+	BeliefCacheTTL = getEnvDuration("BELIEF_CACHE_TTL", ContentCacheTTL)
+	EpinetCacheTTL = getEnvDuration("EPINET_CACHE_TTL", ContentCacheTTL)
+	FileCacheTTL = getEnvDuration("FILE_CACHE_TTL", ContentCacheTTL)
+
 	// Analytics Configuration
 	ExposeAnalytics = getEnvBool("EXPOSE_ANALYTICS", false)
+	// Ingestion sampling keeps 1 in N action events, decided per fingerprint
+	// so a visitor's journey stays coherent. 1 (the default) keeps everything.
+	AnalyticsSampleRate = getEnvInt("ANALYTICS_SAMPLE_RATE", 1)
+	// Window applied when an analytics request omits startHour; tenants can
+	// override it in their env.json. 168 hours preserves the last-7-days default.
+	AnalyticsDefaultRangeHours = getEnvInt("ANALYTICS_DEFAULT_RANGE_HOURS", 168)
+
+	// Storage Configuration
+	// Base directory for tenant configs, databases, media, and logs. The
+	// default preserves the historical ~/t8k-go-server layout; containers
+	// can point DATA_DIR at a mounted volume instead.
+	DataDir = getEnvString("DATA_DIR", filepath.Join(os.Getenv("HOME"), "t8k-go-server"))
+	// Per-tenant disk allocation in megabytes; zero disables the quota.
+	TenantDiskQuotaMB = getEnvInt("TENANT_DISK_QUOTA_MB", 0)
+
+	// Webhook Delivery Configuration
+	// Failed deliveries retry with exponential backoff (base doubles each
+	// attempt) until the attempt budget is spent, then move to the tenant's
+	// dead-letter buffer for manual replay.
+	WebhookMaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 5)
+	WebhookRetryBackoff = getEnvDuration("WEBHOOK_RETRY_BACKOFF", 2*time.Second)
+	WebhookTimeout = getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	WebhookDeadLetterLimit = getEnvInt("WEBHOOK_DEAD_LETTER_LIMIT", 100)
+
+	// Session Backend Configuration
+	// When set (e.g. redis://:password@localhost:6379), session state is kept
+	// in Redis so multiple instances behind a load balancer share sessions.
+	// Empty keeps sessions in per-process memory.
+	RedisURL = getEnvString("REDIS_URL", "")
 }
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"compress/gzip"
+	"testing"
+)
+
+func TestClampCompressionLevelAcceptsValidLevels(t *testing.T) {
+	for level := gzip.HuffmanOnly; level <= gzip.BestCompression; level++ {
+		if got := clampCompressionLevel(level); got != level {
+			t.Errorf("clampCompressionLevel(%d) = %d, expected the level to pass through", level, got)
+		}
+	}
+}
+
+func TestClampCompressionLevelRejectsOutOfRangeLevels(t *testing.T) {
+	for _, level := range []int{gzip.HuffmanOnly - 1, gzip.BestCompression + 1, 100, -100} {
+		if got := clampCompressionLevel(level); got != gzip.DefaultCompression {
+			t.Errorf("clampCompressionLevel(%d) = %d, expected fallback to gzip.DefaultCompression", level, got)
+		}
+	}
+}